@@ -4,7 +4,6 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -14,9 +13,7 @@ import (
 	"github.com/korotovsky/slack-mcp-server/pkg/oauth"
 	"github.com/korotovsky/slack-mcp-server/pkg/provider"
 	"github.com/korotovsky/slack-mcp-server/pkg/server"
-	"github.com/mattn/go-isatty"
 	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
 )
 
 var defaultSseHost = "127.0.0.1"
@@ -28,7 +25,11 @@ func main() {
 	flag.StringVar(&transport, "transport", "stdio", "Transport type (stdio, sse or http)")
 	flag.Parse()
 
-	logger, err := newLogger(transport)
+	logConfig, err := server.LoggerConfigFromEnv(transport)
+	if err != nil {
+		panic(err)
+	}
+	logger, err := server.NewLogger(logConfig)
 	if err != nil {
 		panic(err)
 	}
@@ -42,6 +43,14 @@ func main() {
 		)
 	}
 
+	toolFlags, err := server.ParseToolFlags(os.Getenv("SLACK_MCP_TOOLS"))
+	if err != nil {
+		logger.Fatal("error in SLACK_MCP_TOOLS",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
+
 	// Check if OAuth mode is enabled
 	oauthEnabled := os.Getenv("SLACK_MCP_OAUTH_ENABLED") == "true"
 
@@ -56,30 +65,90 @@ func main() {
 		// Validate OAuth configuration
 		clientID := os.Getenv("SLACK_MCP_OAUTH_CLIENT_ID")
 		clientSecret := os.Getenv("SLACK_MCP_OAUTH_CLIENT_SECRET")
+		clientSecretFile := os.Getenv("SLACK_MCP_OAUTH_CLIENT_SECRET_FILE")
 		redirectURI := os.Getenv("SLACK_MCP_OAUTH_REDIRECT_URI")
 
-		if clientID == "" || clientSecret == "" || redirectURI == "" {
+		if clientID == "" || (clientSecret == "" && clientSecretFile == "") || redirectURI == "" {
 			logger.Fatal("OAuth enabled but missing required credentials",
 				zap.String("context", "console"),
 				zap.Bool("has_client_id", clientID != ""),
-				zap.Bool("has_client_secret", clientSecret != ""),
+				zap.Bool("has_client_secret", clientSecret != "" || clientSecretFile != ""),
 				zap.Bool("has_redirect_uri", redirectURI != ""),
 			)
 		}
 
+		normalizedURI, err := oauth.NormalizeRedirectURI(redirectURI)
+		if err != nil {
+			logger.Fatal("Invalid SLACK_MCP_OAUTH_REDIRECT_URI",
+				zap.String("context", "console"),
+				zap.Error(err),
+			)
+		}
+		if normalizedURI != redirectURI {
+			logger.Info("Normalized OAuth redirect URI",
+				zap.String("context", "console"),
+				zap.String("redirect_uri", normalizedURI),
+			)
+			redirectURI = normalizedURI
+		}
+		if !strings.HasPrefix(redirectURI, "https://") {
+			logger.Warn("OAuth redirect URI is not HTTPS, Slack only accepts HTTPS redirect URLs",
+				zap.String("context", "console"),
+				zap.String("redirect_uri", redirectURI),
+			)
+		}
+
 		// Create OAuth components
-		tokenStorage := oauth.NewMemoryStorage()
-		oauthManager := oauth.NewManager(clientID, clientSecret, redirectURI, tokenStorage)
+		strictTokens := os.Getenv("SLACK_MCP_OAUTH_STRICT_TOKEN_PREFIX") == "true"
+		tokenStorage := oauth.NewValidatingStorage(oauth.NewMemoryStorage(), strictTokens, logger)
+		var oauthManager *oauth.Manager
+		if clientSecretFile != "" {
+			// read lazily so the secret can rotate without a restart
+			oauthManager = oauth.NewManagerWithCredentials(clientID, oauth.FileCredentials{Path: clientSecretFile},
+				oauth.DefaultCredentialsCacheTTL, redirectURI, tokenStorage)
+		} else {
+			oauthManager = oauth.NewManager(clientID, clientSecret, redirectURI, tokenStorage)
+		}
+
+		authorizeLimits, err := server.AuthorizeLimitConfigFromEnv()
+		if err != nil {
+			logger.Fatal("error in OAuth authorize limits configuration",
+				zap.String("context", "console"),
+				zap.Error(err),
+			)
+		}
+
+		// Share OAuth states through Redis when running multiple instances
+		var stateStore oauth.StateStore
+		if redisURL := os.Getenv("SLACK_MCP_OAUTH_REDIS_URL"); redisURL != "" {
+			redisStore, err := oauth.NewRedisStateStore(redisURL)
+			if err != nil {
+				logger.Fatal("Failed to configure Redis state store",
+					zap.String("context", "console"),
+					zap.Error(err),
+				)
+			}
+			if err := redisStore.Ping(context.Background()); err != nil {
+				logger.Fatal("Failed to connect to Redis state store",
+					zap.String("context", "console"),
+					zap.Error(err),
+				)
+			}
+			stateStore = redisStore
+			logger.Info("Using Redis OAuth state store", zap.String("context", "console"))
+		} else {
+			stateStore = oauth.NewMemoryStateStoreWithLimit(authorizeLimits.MaxStates)
+		}
 
 		// Create OAuth handler for HTTP endpoints
-		oauthHandler = server.NewOAuthHandler(oauthManager, logger)
+		oauthHandler = server.NewOAuthHandler(oauthManager, stateStore, authorizeLimits, nil, logger)
 
 		// Create handlers with OAuth support
 		conversationsHandler := handler.NewConversationsHandlerWithOAuth(tokenStorage, logger)
 		channelsHandler := handler.NewChannelsHandlerWithOAuth(tokenStorage, logger)
 
 		// Create MCP server with OAuth middleware
-		s = server.NewMCPServerWithOAuth(conversationsHandler, channelsHandler, oauthManager, logger)
+		s = server.NewMCPServerWithOAuth(conversationsHandler, channelsHandler, oauthManager, toolFlags, logger)
 
 		logger.Info("OAuth server initialized",
 			zap.String("context", "console"),
@@ -90,7 +159,7 @@ func main() {
 		logger.Info("Legacy mode enabled", zap.String("context", "console"))
 
 		p := provider.New(transport, logger)
-		s = server.NewMCPServer(p, logger)
+		s = server.NewMCPServer(p, toolFlags, logger)
 
 		go func() {
 			var once sync.Once
@@ -123,11 +192,22 @@ func main() {
 		if oauthEnabled && oauthHandler != nil {
 			// OAuth mode: use combined handler
 			handler := s.ServeSSEWithOAuth(":"+port, oauthHandler)
+			httpConfig, err := server.HTTPServerConfigFromEnv()
+			if err != nil {
+				logger.Fatal("error in HTTP server configuration",
+					zap.String("context", "console"),
+					zap.Error(err),
+				)
+			}
+			scheme := "http"
+			if httpConfig.TLSEnabled() {
+				scheme = "https"
+			}
 
 			logger.Info("OAuth endpoints enabled",
 				zap.String("context", "console"),
-				zap.String("authorize_url", fmt.Sprintf("http://%s/oauth/authorize", addr)),
-				zap.String("callback_url", fmt.Sprintf("http://%s/oauth/callback", addr)),
+				zap.String("authorize_url", fmt.Sprintf("%s://%s/oauth/authorize", scheme, addr)),
+				zap.String("callback_url", fmt.Sprintf("%s://%s/oauth/callback", scheme, addr)),
 			)
 
 			logger.Info(
@@ -137,7 +217,7 @@ func main() {
 				zap.String("port", port),
 			)
 
-			if err := http.ListenAndServe(addr, handler); err != nil {
+			if err := server.ListenAndServe(server.NewHTTPServer(addr, handler, httpConfig), httpConfig); err != nil {
 				logger.Fatal("Server error",
 					zap.String("context", "console"),
 					zap.Error(err),
@@ -182,11 +262,22 @@ func main() {
 		if oauthEnabled && oauthHandler != nil {
 			// OAuth mode: use combined handler
 			handler := s.ServeHTTPWithOAuth(":"+port, oauthHandler)
+			httpConfig, err := server.HTTPServerConfigFromEnv()
+			if err != nil {
+				logger.Fatal("error in HTTP server configuration",
+					zap.String("context", "console"),
+					zap.Error(err),
+				)
+			}
+			scheme := "http"
+			if httpConfig.TLSEnabled() {
+				scheme = "https"
+			}
 
 			logger.Info("OAuth endpoints enabled",
 				zap.String("context", "console"),
-				zap.String("authorize_url", fmt.Sprintf("http://%s/oauth/authorize", addr)),
-				zap.String("callback_url", fmt.Sprintf("http://%s/oauth/callback", addr)),
+				zap.String("authorize_url", fmt.Sprintf("%s://%s/oauth/authorize", scheme, addr)),
+				zap.String("callback_url", fmt.Sprintf("%s://%s/oauth/callback", scheme, addr)),
 			)
 
 			logger.Info(
@@ -196,7 +287,7 @@ func main() {
 				zap.String("port", port),
 			)
 
-			if err := http.ListenAndServe(addr, handler); err != nil {
+			if err := server.ListenAndServe(server.NewHTTPServer(addr, handler, httpConfig), httpConfig); err != nil {
 				logger.Fatal("Server error",
 					zap.String("context", "console"),
 					zap.Error(err),
@@ -237,6 +328,13 @@ func main() {
 
 func newUsersWatcher(p *provider.ApiProvider, once *sync.Once, logger *zap.Logger) func() {
 	return func() {
+		if p.UsersWarmConfig().Lazy {
+			logger.Info("Users collection is cached on first use, as SLACK_MCP_USERS_WARM is lazy",
+				zap.String("context", "console"),
+			)
+			return
+		}
+
 		logger.Info("Caching users collection...",
 			zap.String("context", "console"),
 		)
@@ -255,6 +353,7 @@ func newUsersWatcher(p *provider.ApiProvider, once *sync.Once, logger *zap.Logge
 				zap.Error(err),
 			)
 		}
+		p.StartUsersRefresh(context.Background())
 
 		ready, _ := p.IsReady()
 		if ready {
@@ -326,125 +425,3 @@ func validateToolConfig(config string) error {
 
 	return nil
 }
-
-func newLogger(transport string) (*zap.Logger, error) {
-	atomicLevel := zap.NewAtomicLevelAt(zap.InfoLevel)
-	if envLevel := os.Getenv("SLACK_MCP_LOG_LEVEL"); envLevel != "" {
-		if err := atomicLevel.UnmarshalText([]byte(envLevel)); err != nil {
-			fmt.Printf("Invalid log level '%s': %v, using 'info'\n", envLevel, err)
-		}
-	}
-
-	useJSON := shouldUseJSONFormat()
-	useColors := shouldUseColors() && !useJSON
-
-	outputPath := "stdout"
-	if transport == "stdio" {
-		outputPath = "stderr"
-	}
-
-	var config zap.Config
-
-	if useJSON {
-		config = zap.Config{
-			Level:            atomicLevel,
-			Development:      false,
-			Encoding:         "json",
-			OutputPaths:      []string{outputPath},
-			ErrorOutputPaths: []string{"stderr"},
-			EncoderConfig: zapcore.EncoderConfig{
-				TimeKey:       "timestamp",
-				LevelKey:      "level",
-				NameKey:       "logger",
-				MessageKey:    "message",
-				StacktraceKey: "stacktrace",
-				EncodeLevel:   zapcore.LowercaseLevelEncoder,
-				EncodeTime:    zapcore.RFC3339TimeEncoder,
-				EncodeCaller:  zapcore.ShortCallerEncoder,
-			},
-		}
-	} else {
-		config = zap.Config{
-			Level:            atomicLevel,
-			Development:      true,
-			Encoding:         "console",
-			OutputPaths:      []string{outputPath},
-			ErrorOutputPaths: []string{"stderr"},
-			EncoderConfig: zapcore.EncoderConfig{
-				TimeKey:          "timestamp",
-				LevelKey:         "level",
-				NameKey:          "logger",
-				MessageKey:       "msg",
-				StacktraceKey:    "stacktrace",
-				EncodeLevel:      getConsoleLevelEncoder(useColors),
-				EncodeTime:       zapcore.ISO8601TimeEncoder,
-				EncodeCaller:     zapcore.ShortCallerEncoder,
-				ConsoleSeparator: " | ",
-			},
-		}
-	}
-
-	logger, err := config.Build(zap.AddCaller())
-	if err != nil {
-		return nil, err
-	}
-
-	logger = logger.With(zap.String("app", "slack-mcp-server"))
-
-	return logger, err
-}
-
-// shouldUseJSONFormat determines if JSON format should be used
-func shouldUseJSONFormat() bool {
-	if format := os.Getenv("SLACK_MCP_LOG_FORMAT"); format != "" {
-		return strings.ToLower(format) == "json"
-	}
-
-	if env := os.Getenv("ENVIRONMENT"); env != "" {
-		switch strings.ToLower(env) {
-		case "production", "prod", "staging":
-			return true
-		case "development", "dev", "local":
-			return false
-		}
-	}
-
-	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" ||
-		os.Getenv("DOCKER_CONTAINER") != "" ||
-		os.Getenv("container") != "" {
-		return true
-	}
-
-	if !isatty.IsTerminal(os.Stdout.Fd()) {
-		return true
-	}
-
-	return false
-}
-
-func shouldUseColors() bool {
-	if colorEnv := os.Getenv("SLACK_MCP_LOG_COLOR"); colorEnv != "" {
-		return colorEnv == "true" || colorEnv == "1"
-	}
-
-	if os.Getenv("NO_COLOR") != "" {
-		return false
-	}
-
-	if os.Getenv("FORCE_COLOR") != "" {
-		return true
-	}
-
-	if env := os.Getenv("ENVIRONMENT"); env == "development" || env == "dev" {
-		return isatty.IsTerminal(os.Stdout.Fd())
-	}
-
-	return isatty.IsTerminal(os.Stdout.Fd())
-}
-
-func getConsoleLevelEncoder(useColors bool) zapcore.LevelEncoder {
-	if useColors {
-		return zapcore.CapitalColorLevelEncoder
-	}
-	return zapcore.CapitalLevelEncoder
-}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// defaultReadMethods are the Slack methods slack_api_read may call unless
+// SLACK_MCP_API_READ_METHODS replaces them
+var defaultReadMethods = []string{
+	"bookmarks.list",
+	"bots.info",
+	"conversations.history",
+	"conversations.info",
+	"conversations.list",
+	"conversations.members",
+	"conversations.replies",
+	"emoji.list",
+	"files.info",
+	"pins.list",
+	"reactions.get",
+	"team.info",
+	"usergroups.list",
+	"usergroups.users.list",
+	"users.getPresence",
+	"users.info",
+	"users.list",
+	"users.lookupByEmail",
+	"users.profile.get",
+}
+
+var (
+	methodNameRe = regexp.MustCompile(`^[a-z]+(\.[a-zA-Z]+)+$`)
+	slackTokenRe = regexp.MustCompile(`xox[a-z]-[A-Za-z0-9-]+`)
+)
+
+// readVerbs are the prefixes of the last segment of read-only Slack methods,
+// e.g. users.info, conversations.history or users.getPresence
+var readVerbs = []string{"info", "list", "history", "replies", "members", "get", "lookup"}
+
+// isReadMethod reports whether a Slack method name looks like a read-only method
+func isReadMethod(method string) bool {
+	if !methodNameRe.MatchString(method) {
+		return false
+	}
+	last := method[strings.LastIndex(method, ".")+1:]
+	for _, verb := range readVerbs {
+		if strings.HasPrefix(last, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+// getReadMethods reads the slack_api_read allowlist from SLACK_MCP_API_READ_METHODS,
+// dropping methods that are not read-only so that the allowlist can never
+// open up write or destructive calls
+func getReadMethods(logger *zap.Logger) map[string]bool {
+	methods := defaultReadMethods
+	if v := os.Getenv("SLACK_MCP_API_READ_METHODS"); v != "" {
+		methods = strings.Split(v, ",")
+	}
+
+	allowed := make(map[string]bool)
+	for _, method := range methods {
+		method = strings.TrimSpace(method)
+		if method == "" {
+			continue
+		}
+		if !isReadMethod(method) {
+			logger.Warn("Ignoring method in SLACK_MCP_API_READ_METHODS, only read methods can be allowed",
+				zap.String("method", method),
+			)
+			continue
+		}
+		allowed[method] = true
+	}
+	return allowed
+}
+
+// redactTokens masks Slack tokens in text returned to the client
+func redactTokens(s string) string {
+	return slackTokenRe.ReplaceAllStringFunc(s, func(token string) string {
+		return token[:5] + "REDACTED"
+	})
+}
+
+// SlackAPIReadHandler calls an allowlisted Slack read method and returns its raw JSON response
+func (ch *ConversationsHandler) SlackAPIReadHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("SlackAPIReadHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	method := strings.TrimSpace(request.GetString("method", ""))
+	if method == "" {
+		return nil, errors.New("method must be a string")
+	}
+	if !ch.readMethods[method] {
+		allowed := make([]string, 0, len(ch.readMethods))
+		for m := range ch.readMethods {
+			allowed = append(allowed, m)
+		}
+		sort.Strings(allowed)
+		return nil, fmt.Errorf("method %q is not allowed, allowed methods: %s", method, strings.Join(allowed, ", "))
+	}
+
+	params, err := methodParams(request.GetArguments()["params"])
+	if err != nil {
+		return nil, err
+	}
+
+	var body json.RawMessage
+	if ch.oauthEnabled {
+		userCtx, ok := auth.FromContext(ctx)
+		if !ok {
+			return nil, fmt.Errorf("user context not found")
+		}
+		client := &http.Client{Timeout: 30 * time.Second, Transport: oauthHTTPClient.Transport}
+		body, err = provider.CallMethod(ctx, client, "https://slack.com/api/", userCtx.AccessToken, method, params)
+	} else {
+		body, err = ch.apiProvider.Slack().CallMethodContext(ctx, method, params)
+	}
+	if err != nil {
+		ch.logger.Error("Slack method call failed", zap.String("method", method), zap.Error(err))
+		return nil, errors.New(redactTokens(err.Error()))
+	}
+
+	var status struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &status); err == nil && !status.OK && status.Error != "" {
+		return nil, fmt.Errorf("slack error: %s: %s", method, status.Error)
+	}
+
+	return mcp.NewToolResultText(redactTokens(string(body))), nil
+}
+
+// methodParams turns the params object of slack_api_read into form values,
+// accepting it as an object or as a JSON string
+func methodParams(raw any) (url.Values, error) {
+	values := url.Values{}
+	if raw == nil {
+		return values, nil
+	}
+
+	obj, ok := raw.(map[string]any)
+	if !ok {
+		s, isString := raw.(string)
+		if !isString {
+			return nil, errors.New("params must be a JSON object")
+		}
+		if strings.TrimSpace(s) == "" {
+			return values, nil
+		}
+		if err := json.Unmarshal([]byte(s), &obj); err != nil {
+			return nil, fmt.Errorf("params must be a JSON object: %w", err)
+		}
+	}
+
+	for key, v := range obj {
+		if key == "token" {
+			return nil, errors.New("params must not contain a token, the server's token is used")
+		}
+		switch val := v.(type) {
+		case string:
+			values.Set(key, val)
+		case bool:
+			values.Set(key, strconv.FormatBool(val))
+		case float64:
+			values.Set(key, strconv.FormatFloat(val, 'f', -1, 64))
+		case nil:
+		default:
+			// Slack accepts nested values such as arrays as JSON strings
+			b, err := json.Marshal(val)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %q: %w", key, err)
+			}
+			values.Set(key, string(b))
+		}
+	}
+	return values, nil
+}
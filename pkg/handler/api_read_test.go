@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitIsReadMethod(t *testing.T) {
+	for _, method := range []string{"users.info", "conversations.history", "users.getPresence", "users.profile.get", "users.lookupByEmail"} {
+		assert.True(t, isReadMethod(method), method)
+	}
+	for _, method := range []string{"chat.postMessage", "conversations.archive", "files.delete", "auth.revoke", "users.info/../chat.postMessage", "users", ""} {
+		assert.False(t, isReadMethod(method), method)
+	}
+}
+
+func TestUnitGetReadMethods(t *testing.T) {
+	t.Setenv("SLACK_MCP_API_READ_METHODS", "")
+	assert.True(t, getReadMethods(zap.NewNop())["users.info"])
+
+	t.Setenv("SLACK_MCP_API_READ_METHODS", "team.info, chat.delete,users.info")
+	assert.Equal(t, map[string]bool{"team.info": true, "users.info": true}, getReadMethods(zap.NewNop()), "write methods are never allowed")
+}
+
+func TestUnitSlackAPIRead(t *testing.T) {
+	t.Setenv("SLACK_MCP_API_READ_METHODS", "")
+
+	api := &fakeSlackAPI{call: func(method string, params url.Values) (json.RawMessage, error) {
+		assert.Equal(t, "bookmarks.list", method)
+		assert.Equal(t, url.Values{"channel_id": {"C1"}, "limit": {"10"}, "types": {`["link"]`}}, params)
+		return json.RawMessage(`{"ok":true,"bookmarks":[{"link":"https://example.com/?t=xoxb-123-abc"}]}`), nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.SlackAPIReadHandler(context.Background(), newToolRequest(map[string]any{
+		"method": "bookmarks.list",
+		"params": map[string]any{"channel_id": "C1", "limit": float64(10), "types": []any{"link"}},
+	}))
+	require.NoError(t, err)
+
+	out := toolResultText(t, res)
+	assert.Contains(t, out, `"bookmarks"`)
+	assert.NotContains(t, out, "xoxb-123-abc", "tokens are redacted")
+	assert.Contains(t, out, "xoxb-REDACTED")
+}
+
+func TestUnitSlackAPIReadRejects(t *testing.T) {
+	t.Setenv("SLACK_MCP_API_READ_METHODS", "")
+
+	api := &fakeSlackAPI{call: func(method string, params url.Values) (json.RawMessage, error) {
+		t.Fatalf("Slack must not be called for %s", method)
+		return nil, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	for name, args := range map[string]map[string]any{
+		"write method":    {"method": "chat.postMessage"},
+		"unlisted read":   {"method": "files.list"},
+		"method case":     {"method": "Users.info"},
+		"token in params": {"method": "users.info", "params": map[string]any{"token": "xoxp-1"}},
+		"params array":    {"method": "users.info", "params": []any{"U1"}},
+	} {
+		_, err := ch.SlackAPIReadHandler(context.Background(), newToolRequest(args))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestUnitSlackAPIReadErrors(t *testing.T) {
+	t.Setenv("SLACK_MCP_API_READ_METHODS", "")
+
+	api := &fakeSlackAPI{call: func(method string, params url.Values) (json.RawMessage, error) {
+		if params.Get("user") == "U404" {
+			return json.RawMessage(`{"ok":false,"error":"user_not_found"}`), nil
+		}
+		return nil, errors.New(`Post "https://slack.com/api/users.info?token=xoxc-secret": timeout`)
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	_, err := ch.SlackAPIReadHandler(context.Background(), newToolRequest(map[string]any{
+		"method": "users.info",
+		"params": `{"user": "U404"}`,
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "user_not_found")
+
+	_, err = ch.SlackAPIReadHandler(context.Background(), newToolRequest(map[string]any{
+		"method": "users.info",
+		"params": map[string]any{"user": "U1"},
+	}))
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "xoxc-secret")
+}
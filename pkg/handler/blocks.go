@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/slack-go/slack"
+)
+
+// maxMessageBlocks is the number of blocks Slack accepts in one message
+const maxMessageBlocks = 50
+
+// parseBlocksParam parses the blocks parameter of the posting tools, a JSON
+// array of Block Kit blocks or an object with a blocks array as exported by
+// the Block Kit Builder. Blocks of unknown types are rejected before posting.
+func parseBlocksParam(raw string) ([]slack.Block, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	var blocks slack.Blocks
+	if strings.HasPrefix(raw, "{") {
+		var payload struct {
+			Blocks *slack.Blocks `json:"blocks"`
+		}
+		if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+			return nil, fmt.Errorf("blocks must be a JSON array of Block Kit blocks: %w", err)
+		}
+		if payload.Blocks == nil {
+			return nil, errors.New("blocks must be a JSON array of Block Kit blocks or an object with a blocks array")
+		}
+		blocks = *payload.Blocks
+	} else if err := json.Unmarshal([]byte(raw), &blocks); err != nil {
+		return nil, fmt.Errorf("blocks must be a JSON array of Block Kit blocks: %w", err)
+	}
+
+	if len(blocks.BlockSet) == 0 {
+		return nil, errors.New("blocks must contain at least one block")
+	}
+	if len(blocks.BlockSet) > maxMessageBlocks {
+		return nil, fmt.Errorf("blocks must contain at most %d blocks, got %d", maxMessageBlocks, len(blocks.BlockSet))
+	}
+	for i, block := range blocks.BlockSet {
+		if unknown, ok := block.(*slack.UnknownBlock); ok {
+			return nil, fmt.Errorf("block %d has unknown type %q", i, unknown.Type)
+		}
+	}
+	return blocks.BlockSet, nil
+}
+
+// blocksBroadcast returns the first broadcast in the parsed blocks, either as
+// markup in a text object, e.g. <!channel>, or as a rich_text broadcast
+// element, or "" when the blocks notify no whole channel. Blocks are checked
+// once parsed, so JSON escapes such as \u003c!channel\u003e are decoded.
+func blocksBroadcast(blocks []slack.Block) string {
+	for _, block := range blocks {
+		var texts []*slack.TextBlockObject
+		switch b := block.(type) {
+		case *slack.SectionBlock:
+			texts = append(texts, b.Text)
+			texts = append(texts, b.Fields...)
+		case *slack.HeaderBlock:
+			texts = append(texts, b.Text)
+		case *slack.ContextBlock:
+			for _, element := range b.ContextElements.Elements {
+				if t, ok := element.(*slack.TextBlockObject); ok {
+					texts = append(texts, t)
+				}
+			}
+		case *slack.ImageBlock:
+			texts = append(texts, b.Title)
+		case *slack.VideoBlock:
+			texts = append(texts, b.Title, b.Description)
+		case *slack.MarkdownBlock:
+			if markup := text.BroadcastMarkup(b.Text); markup != "" {
+				return markup
+			}
+		case *slack.RichTextBlock:
+			if markup := richTextBroadcast(b.Elements); markup != "" {
+				return markup
+			}
+		}
+		for _, t := range texts {
+			if t == nil {
+				continue
+			}
+			if markup := text.BroadcastMarkup(t.Text); markup != "" {
+				return markup
+			}
+		}
+	}
+	return ""
+}
+
+// richTextBroadcast returns the first broadcast in rich_text elements,
+// walking sections, quotes, preformatted text and nested lists
+func richTextBroadcast(elements []slack.RichTextElement) string {
+	for _, element := range elements {
+		var sectionElements []slack.RichTextSectionElement
+		switch e := element.(type) {
+		case *slack.RichTextSection:
+			sectionElements = e.Elements
+		case *slack.RichTextQuote:
+			sectionElements = e.Elements
+		case *slack.RichTextPreformatted:
+			sectionElements = e.Elements
+		case *slack.RichTextList:
+			if markup := richTextBroadcast(e.Elements); markup != "" {
+				return markup
+			}
+		}
+		for _, sectionElement := range sectionElements {
+			switch se := sectionElement.(type) {
+			case *slack.RichTextSectionBroadcastElement:
+				return "<!" + se.Range + ">"
+			case *slack.RichTextSectionTextElement:
+				if markup := text.BroadcastMarkup(se.Text); markup != "" {
+					return markup
+				}
+			}
+		}
+	}
+	return ""
+}
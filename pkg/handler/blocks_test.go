@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitParseBlocksParam(t *testing.T) {
+	section := `{"type":"section","text":{"type":"mrkdwn","text":"*Deploy* finished"},"fields":[{"type":"mrkdwn","text":"Env: prod"}]}`
+	actions := `{"type":"actions","elements":[{"type":"button","text":{"type":"plain_text","text":"Logs"},"url":"https://example.com/logs"}]}`
+
+	blocks, err := parseBlocksParam("[" + section + "," + actions + "]")
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	assert.IsType(t, &slack.SectionBlock{}, blocks[0])
+	assert.IsType(t, &slack.ActionBlock{}, blocks[1])
+
+	// the Block Kit Builder exports an object
+	blocks, err = parseBlocksParam(`{"blocks":[` + section + `]}`)
+	require.NoError(t, err)
+	assert.Len(t, blocks, 1)
+
+	blocks, err = parseBlocksParam("  ")
+	require.NoError(t, err)
+	assert.Nil(t, blocks, "blocks are optional")
+
+	tooMany := "[" + strings.TrimSuffix(strings.Repeat(`{"type":"divider"},`, maxMessageBlocks+1), ",") + "]"
+	for _, raw := range []string{
+		"not json",
+		"[]",
+		`{"text":"no blocks"}`,
+		`[{"type":"fancy_new_block"}]`,
+		`[{"text":"no type"}]`,
+		tooMany,
+	} {
+		_, err := parseBlocksParam(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestUnitConversationsAddMessageBlocks(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "true")
+
+	var values url.Values
+	api := &fakeSlackAPI{
+		post: func(channel string, options ...slack.MsgOption) (string, string, error) {
+			var err error
+			_, values, err = slack.UnsafeApplyMsgOptions("token", channel, "https://slack.com/api/", options...)
+			require.NoError(t, err)
+			return channel, "1700000000.000200", nil
+		},
+		history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+			return &slack.GetConversationHistoryResponse{}, nil
+		},
+	}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	post := func(args map[string]any) error {
+		values = nil
+		args["channel_id"] = "C1234567890"
+		_, err := ch.ConversationsAddMessageHandler(context.Background(), newToolRequest(args))
+		return err
+	}
+
+	blocks := `[{"type":"section","text":{"type":"mrkdwn","text":"Deploy finished"}}]`
+	require.NoError(t, post(map[string]any{"blocks": blocks, "payload": "Deploy finished"}))
+	var sent []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(values.Get("blocks")), &sent))
+	require.Len(t, sent, 1)
+	assert.Equal(t, "section", sent[0]["type"])
+	assert.Equal(t, "Deploy finished", values.Get("text"), "payload is the notification fallback")
+
+	require.NoError(t, post(map[string]any{"blocks": blocks}), "payload is optional with blocks")
+	assert.True(t, values.Has("blocks"))
+	assert.False(t, values.Has("text"))
+
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_UNFURLING", "example.com")
+	linked := `[{"type":"section","text":{"type":"mrkdwn","text":"see https://evil.test/x"}}]`
+	require.NoError(t, post(map[string]any{"blocks": linked}))
+	assert.Equal(t, "false", values.Get("unfurl_links"), "links in blocks are checked against the unfurling policy")
+
+	err := post(map[string]any{"blocks": `[{"type":"fancy_new_block"}]`, "payload": "hi"})
+	require.Error(t, err)
+	assert.Nil(t, values, "nothing is posted")
+
+	err = post(map[string]any{})
+	assert.Error(t, err, "payload or blocks is required")
+}
+
+func TestUnitBlocksBroadcast(t *testing.T) {
+	for raw, want := range map[string]string{
+		`[{"type":"section","text":{"type":"mrkdwn","text":"<!channel> deploy"}}]`:                                                                                                      "<!channel>",
+		`[{"type":"section","fields":[{"type":"mrkdwn","text":"Env: prod"},{"type":"mrkdwn","text":"<!here|here>"}]}]`:                                                                  "<!here|here>",
+		`[{"type":"context","elements":[{"type":"mrkdwn","text":"cc <!everyone>"}]}]`:                                                                                                   "<!everyone>",
+		`[{"type":"markdown","text":"<!here> deploy"}]`:                                                                                                                                 "<!here>",
+		`[{"type":"rich_text","elements":[{"type":"rich_text_section","elements":[{"type":"broadcast","range":"channel"}]}]}]`:                                                          "<!channel>",
+		`[{"type":"rich_text","elements":[{"type":"rich_text_quote","elements":[{"type":"text","text":"<!here>"}]}]}]`:                                                                  "<!here>",
+		`[{"type":"rich_text","elements":[{"type":"rich_text_list","style":"bullet","elements":[{"type":"rich_text_section","elements":[{"type":"broadcast","range":"everyone"}]}]}]}]`: "<!everyone>",
+		`[{"type":"section","text":{"type":"mrkdwn","text":"Deploy finished"}},{"type":"divider"}]`:                                                                                     "",
+		`[{"type":"rich_text","elements":[{"type":"rich_text_section","elements":[{"type":"user","user_id":"U1"}]}]}]`:                                                                  "",
+	} {
+		blocks, err := parseBlocksParam(raw)
+		require.NoError(t, err, raw)
+		assert.Equal(t, want, blocksBroadcast(blocks), raw)
+	}
+}
+
+func TestUnitPostingToolsBroadcastBlocks(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "true")
+
+	var sent []string
+	record := func(channel string, options ...slack.MsgOption) {
+		_, values, err := slack.UnsafeApplyMsgOptions("token", channel, "https://slack.com/api/", options...)
+		require.NoError(t, err)
+		sent = append(sent, values.Get("blocks"))
+	}
+	api := &fakeSlackAPI{
+		post: func(channel string, options ...slack.MsgOption) (string, string, error) {
+			record(channel, options...)
+			return channel, "1700000000.000200", nil
+		},
+		ephemeral: func(channel, userID string, options ...slack.MsgOption) (string, error) {
+			record(channel, options...)
+			return "1700000000.000500", nil
+		},
+		schedule: func(channel, postAt string, options ...slack.MsgOption) (string, string, error) {
+			record(channel, options...)
+			return channel, "Q1298393284", nil
+		},
+		history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+			return &slack.GetConversationHistoryResponse{}, nil
+		},
+	}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	postAt := strconv.FormatInt(time.Now().Add(48*time.Hour).Unix(), 10)
+	tools := map[string]func(blocks string) error{
+		"post": func(blocks string) error {
+			_, err := ch.ConversationsAddMessageHandler(context.Background(), newToolRequest(map[string]any{
+				"channel_id": "C1234567890",
+				"blocks":     blocks,
+			}))
+			return err
+		},
+		"ephemeral": func(blocks string) error {
+			_, err := ch.ConversationsPostEphemeralHandler(context.Background(), newToolRequest(map[string]any{
+				"channel_id": "C1234567890",
+				"user_id":    "U0ALICE",
+				"blocks":     blocks,
+			}))
+			return err
+		},
+		"schedule": func(blocks string) error {
+			_, err := ch.ConversationsScheduleMessageHandler(context.Background(), newToolRequest(map[string]any{
+				"channel_id": "C1234567890",
+				"blocks":     blocks,
+				"post_at":    postAt,
+			}))
+			return err
+		},
+	}
+	escaped := `[{"type":"section","text":{"type":"mrkdwn","text":"\u003c!channel\u003e deploy"}}]`
+	richText := `[{"type":"rich_text","elements":[{"type":"rich_text_section","elements":[{"type":"broadcast","range":"channel"},{"type":"text","text":" deploy"}]}]}]`
+
+	for name, send := range tools {
+		for _, blocks := range []string{escaped, richText} {
+			sent = nil
+			err := send(blocks)
+			require.Error(t, err, "%s: %s", name, blocks)
+			assert.Contains(t, err.Error(), "SLACK_MCP_ADD_MESSAGE_BROADCAST", name)
+			assert.Empty(t, sent, "nothing is sent by %s", name)
+		}
+	}
+
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_BROADCAST", "true")
+	for name, send := range tools {
+		for _, blocks := range []string{escaped, richText} {
+			sent = nil
+			require.NoError(t, send(blocks), "%s: %s", name, blocks)
+			assert.Len(t, sent, 1, name)
+		}
+	}
+}
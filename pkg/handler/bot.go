@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+type BotInfo struct {
+	BotUserID string `json:"botUserID"`
+	UserName  string `json:"userName"`
+	RealName  string `json:"realName"`
+	TeamID    string `json:"teamID"`
+	Scopes    string `json:"scopes"`
+}
+
+// botName is the name of a bot user, looked up once per team and bot
+type botName struct {
+	userName string
+	realName string
+}
+
+// BotInfoHandler returns the identity and scopes of the bot token (OAuth mode)
+func (ch *ConversationsHandler) BotInfoHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("BotInfoHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	if !ch.oauthEnabled {
+		return mcp.NewToolResultText("No bot token available: bot identity is only known in OAuth mode."), nil
+	}
+
+	userCtx, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user context not found")
+	}
+	if userCtx.BotToken == "" {
+		return mcp.NewToolResultText("No bot token available: the Slack app was installed without bot scopes, so all actions run as the authenticated user."), nil
+	}
+
+	// ID and scopes come from the stored OAuth response, only the name needs a
+	// lookup, which is cached as the OAuth response does not carry it
+	info := BotInfo{
+		BotUserID: userCtx.BotUserID,
+		UserName:  userCtx.BotUserID,
+		RealName:  userCtx.BotUserID,
+		TeamID:    userCtx.TeamID,
+		Scopes:    strings.Join(userCtx.BotScopes, ","),
+	}
+	if info.Scopes == "" {
+		info.Scopes = "unknown"
+	}
+
+	key := userCtx.TeamID + "/" + userCtx.BotUserID
+	if name, ok := ch.botNames.Load(key); ok {
+		info.UserName = name.(botName).userName
+		info.RealName = name.(botName).realName
+	} else if userCtx.BotUserID != "" {
+		client, err := ch.getBotSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		user, err := client.GetUserInfoContext(ctx, userCtx.BotUserID)
+		if err != nil {
+			ch.logger.Warn("Failed to resolve bot user name", zap.Error(err))
+		} else {
+			info.UserName = user.Name
+			info.RealName = user.RealName
+			ch.botNames.Store(key, botName{userName: user.Name, realName: user.RealName})
+		}
+	}
+
+	infos := []BotInfo{info}
+	csvBytes, err := gocsv.MarshalBytes(&infos)
+	if err != nil {
+		ch.logger.Error("Failed to marshal bot info to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitBotInfoFromUserContext(t *testing.T) {
+	ch := NewConversationsHandlerWithOAuth(nil, zap.NewNop())
+	ctx := auth.WithUserContext(context.Background(), &auth.UserContext{
+		UserID:    "U1",
+		TeamID:    "T1",
+		BotToken:  "xoxb-test",
+		BotScopes: []string{"chat:write", "channels:read"},
+	})
+
+	res, err := ch.BotInfoHandler(ctx, newToolRequest(nil))
+	require.NoError(t, err)
+
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"T1"}, csvColumn(t, out, "TeamID"))
+	assert.Equal(t, []string{"chat:write,channels:read"}, csvColumn(t, out, "Scopes"))
+}
+
+func TestUnitBotInfoWithoutBotToken(t *testing.T) {
+	ch := NewConversationsHandlerWithOAuth(nil, zap.NewNop())
+	ctx := auth.WithUserContext(context.Background(), &auth.UserContext{
+		UserID: "U1",
+		TeamID: "T1",
+	})
+
+	res, err := ch.BotInfoHandler(ctx, newToolRequest(nil))
+	require.NoError(t, err)
+	assert.Contains(t, toolResultText(t, res), "No bot token available")
+}
+
+// usersInfoTransport answers users.info for the bot user and counts the calls
+type usersInfoTransport struct {
+	calls int
+}
+
+func (t *usersInfoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"ok":true,"user":{"id":"B1","name":"deploybot","real_name":"Deploy Bot"}}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestUnitBotInfoCachesBotName(t *testing.T) {
+	rt := &usersInfoTransport{}
+	orig := oauthHTTPClient.Transport
+	oauthHTTPClient.Transport = rt
+	t.Cleanup(func() { oauthHTTPClient.Transport = orig })
+
+	ch := NewConversationsHandlerWithOAuth(nil, zap.NewNop())
+	ctx := auth.WithUserContext(context.Background(), &auth.UserContext{
+		UserID:    "U1",
+		TeamID:    "T1",
+		BotToken:  "xoxb-test",
+		BotUserID: "B1",
+	})
+
+	for i := 0; i < 3; i++ {
+		res, err := ch.BotInfoHandler(ctx, newToolRequest(nil))
+		require.NoError(t, err)
+		out := toolResultText(t, res)
+		assert.Equal(t, []string{"deploybot"}, csvColumn(t, out, "UserName"))
+		assert.Equal(t, []string{"Deploy Bot"}, csvColumn(t, out, "RealName"))
+	}
+	assert.Equal(t, 1, rt.calls, "the bot name is looked up once per team and bot")
+}
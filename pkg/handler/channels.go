@@ -4,19 +4,23 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"os"
+	"path"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/gocarina/gocsv"
 	"github.com/korotovsky/slack-mcp-server/pkg/oauth"
 	"github.com/korotovsky/slack-mcp-server/pkg/provider"
 	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
-	"github.com/korotovsky/slack-mcp-server/pkg/text"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/slack-go/slack"
 	"go.uber.org/zap"
 )
 
+const defaultMaxChannelTypes = 4
+
 type Channel struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
@@ -27,10 +31,12 @@ type Channel struct {
 }
 
 type ChannelsHandler struct {
-	apiProvider  *provider.ApiProvider  // Legacy mode
-	tokenStorage oauth.TokenStorage     // OAuth mode
+	apiProvider  *provider.ApiProvider       // Legacy mode
+	tokenStorage oauth.TokenStorage          // OAuth mode
+	teamChannels *provider.TeamChannelsCache // OAuth mode
 	oauthEnabled bool
 	validTypes   map[string]bool
+	maxTypes     int
 	logger       *zap.Logger
 }
 
@@ -45,6 +51,7 @@ func NewChannelsHandler(apiProvider *provider.ApiProvider, logger *zap.Logger) *
 		apiProvider:  apiProvider,
 		oauthEnabled: false,
 		validTypes:   validTypes,
+		maxTypes:     getMaxChannelTypes(logger),
 		logger:       logger,
 	}
 }
@@ -58,12 +65,32 @@ func NewChannelsHandlerWithOAuth(tokenStorage oauth.TokenStorage, logger *zap.Lo
 
 	return &ChannelsHandler{
 		tokenStorage: tokenStorage,
+		teamChannels: provider.NewTeamChannelsCacheFromEnv(logger),
 		oauthEnabled: true,
 		validTypes:   validTypes,
+		maxTypes:     getMaxChannelTypes(logger),
 		logger:       logger,
 	}
 }
 
+// getMaxChannelTypes reads the per-call cap on distinct channel types from
+// SLACK_MCP_MAX_CHANNEL_TYPES, falling back to the default on invalid values
+func getMaxChannelTypes(logger *zap.Logger) int {
+	v := os.Getenv("SLACK_MCP_MAX_CHANNEL_TYPES")
+	if v == "" {
+		return defaultMaxChannelTypes
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		logger.Warn("Invalid SLACK_MCP_MAX_CHANNEL_TYPES, using default",
+			zap.String("value", v),
+			zap.Int("default", defaultMaxChannelTypes),
+		)
+		return defaultMaxChannelTypes
+	}
+	return n
+}
+
 // getSlackClient creates a Slack client for the current request (OAuth mode)
 func (ch *ChannelsHandler) getSlackClient(ctx context.Context) (*slack.Client, error) {
 	if !ch.oauthEnabled {
@@ -76,7 +103,7 @@ func (ch *ChannelsHandler) getSlackClient(ctx context.Context) (*slack.Client, e
 	}
 
 	// Use token directly from context (already validated by middleware)
-	return slack.New(userCtx.AccessToken), nil
+	return slack.New(userCtx.AccessToken, slack.OptionHTTPClient(oauthHTTPClient)), nil
 }
 
 func (ch *ChannelsHandler) ChannelsResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
@@ -95,28 +122,23 @@ func (ch *ChannelsHandler) ChannelsResource(ctx context.Context, request mcp.Rea
 		return nil, err
 	}
 
-	ar, err := ch.apiProvider.Slack().AuthTest()
+	ws, err := ch.apiProvider.Workspace(ctx)
 	if err != nil {
 		ch.logger.Error("Auth test failed", zap.Error(err))
 		return nil, err
 	}
 
-	ws, err := text.Workspace(ar.URL)
-	if err != nil {
-		ch.logger.Error("Failed to parse workspace from URL",
-			zap.String("url", ar.URL),
-			zap.Error(err),
-		)
-		return nil, fmt.Errorf("failed to parse workspace from URL: %v", err)
-	}
-
 	channels := ch.apiProvider.ProvideChannelsMaps().Channels
 	ch.logger.Debug("Retrieved channels from provider", zap.Int("count", len(channels)))
 
 	for _, channel := range channels {
+		// the directory lists active channels, archived ones are in the cache for lookups
+		if channel.IsArchived {
+			continue
+		}
 		channelList = append(channelList, Channel{
 			ID:          channel.ID,
-			Name:        channel.Name,
+			Name:        normalizeChannelName(channel),
 			Topic:       channel.Topic,
 			Purpose:     channel.Purpose,
 			MemberCount: channel.MemberCount,
@@ -139,7 +161,9 @@ func (ch *ChannelsHandler) ChannelsResource(ctx context.Context, request mcp.Rea
 }
 
 func (ch *ChannelsHandler) ChannelsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ch.logger.Debug("ChannelsHandler called")
+	ch.logger.Debug("ChannelsHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+	)
 
 	// In OAuth mode, we don't have apiProvider - fetch directly
 	if ch.oauthEnabled {
@@ -155,30 +179,21 @@ func (ch *ChannelsHandler) ChannelsHandler(ctx context.Context, request mcp.Call
 	types := request.GetString("channel_types", provider.PubChanType)
 	cursor := request.GetString("cursor", "")
 	limit := request.GetInt("limit", 0)
+	query := request.GetString("query", "")
+	includeArchived := request.GetBool("include_archived", false)
 
 	ch.logger.Debug("Request parameters",
 		zap.String("sort", sortType),
 		zap.String("channel_types", types),
 		zap.String("cursor", cursor),
 		zap.Int("limit", limit),
+		zap.String("query", query),
+		zap.Bool("include_archived", includeArchived),
 	)
 
-	// MCP Inspector v0.14.0 has issues with Slice type
-	// introspection, so some type simplification makes sense here
-	channelTypes := []string{}
-	for _, t := range strings.Split(types, ",") {
-		t = strings.TrimSpace(t)
-		if ch.validTypes[t] {
-			channelTypes = append(channelTypes, t)
-		} else if t != "" {
-			ch.logger.Warn("Invalid channel type ignored", zap.String("type", t))
-		}
-	}
-
-	if len(channelTypes) == 0 {
-		ch.logger.Debug("No valid channel types provided, using defaults")
-		channelTypes = append(channelTypes, provider.PubChanType)
-		channelTypes = append(channelTypes, provider.PrivateChanType)
+	channelTypes, err := ch.parseChannelTypes(types)
+	if err != nil {
+		return nil, err
 	}
 
 	ch.logger.Debug("Validated channel types", zap.Strings("types", channelTypes))
@@ -200,15 +215,28 @@ func (ch *ChannelsHandler) ChannelsHandler(ctx context.Context, request mcp.Call
 	allChannels := ch.apiProvider.ProvideChannelsMaps().Channels
 	ch.logger.Debug("Total channels available", zap.Int("count", len(allChannels)))
 
-	channels := filterChannelsByTypes(allChannels, channelTypes)
+	// helpers log with the request ID so their lines correlate with this call
+	reqLogger := ch.logger.With(zap.String("request_id", auth.RequestIDFromContext(ctx)))
+
+	channels := filterChannelsByTypes(allChannels, channelTypes, reqLogger)
 	ch.logger.Debug("Channels after filtering by type", zap.Int("count", len(channels)))
 
+	// filter before paginating, so that cursors page through the matches only
+	if !includeArchived {
+		channels = withoutArchived(channels)
+	}
+	channels, err = filterChannelsByQuery(channels, query)
+	if err != nil {
+		return nil, err
+	}
+
 	var chans []provider.Channel
 
 	chans, nextcur = paginateChannels(
 		channels,
 		cursor,
 		limit,
+		reqLogger,
 	)
 
 	ch.logger.Debug("Pagination results",
@@ -219,7 +247,7 @@ func (ch *ChannelsHandler) ChannelsHandler(ctx context.Context, request mcp.Call
 	for _, channel := range chans {
 		channelList = append(channelList, Channel{
 			ID:          channel.ID,
-			Name:        channel.Name,
+			Name:        normalizeChannelName(channel),
 			Topic:       channel.Topic,
 			Purpose:     channel.Purpose,
 			MemberCount: channel.MemberCount,
@@ -229,9 +257,7 @@ func (ch *ChannelsHandler) ChannelsHandler(ctx context.Context, request mcp.Call
 	switch sortType {
 	case "popularity":
 		ch.logger.Debug("Sorting channels by popularity (member count)")
-		sort.Slice(channelList, func(i, j int) bool {
-			return channelList[i].MemberCount > channelList[j].MemberCount
-		})
+		sortChannelsByPopularity(channelList)
 	default:
 		ch.logger.Debug("No sorting applied", zap.String("sort_type", sortType))
 	}
@@ -250,9 +276,58 @@ func (ch *ChannelsHandler) ChannelsHandler(ctx context.Context, request mcp.Call
 	return mcp.NewToolResultText(string(csvBytes)), nil
 }
 
-func filterChannelsByTypes(channels map[string]provider.Channel, types []string) []provider.Channel {
-	logger := zap.L()
+// parseChannelTypes validates and de-duplicates the comma-separated channel_types
+// parameter, rejecting calls with more distinct types than allowed. In OAuth mode
+// every type is a separate Slack call, so the cap bounds the per-request load.
+func (ch *ChannelsHandler) parseChannelTypes(types string) ([]string, error) {
+	// MCP Inspector v0.14.0 has issues with Slice type
+	// introspection, so some type simplification makes sense here
+	channelTypes := []string{}
+	seen := make(map[string]bool)
+	for _, t := range strings.Split(types, ",") {
+		t = strings.TrimSpace(t)
+		if ch.validTypes[t] {
+			if !seen[t] {
+				seen[t] = true
+				channelTypes = append(channelTypes, t)
+			}
+		} else if t != "" {
+			ch.logger.Warn("Invalid channel type ignored", zap.String("type", t))
+		}
+	}
+
+	if len(channelTypes) > ch.maxTypes {
+		ch.logger.Warn("Too many channel types requested",
+			zap.Strings("types", channelTypes),
+			zap.Int("max", ch.maxTypes),
+		)
+		return nil, fmt.Errorf("too many channel types requested: %d, at most %d distinct channel types are allowed per call (SLACK_MCP_MAX_CHANNEL_TYPES)", len(channelTypes), ch.maxTypes)
+	}
+
+	if len(channelTypes) == 0 {
+		ch.logger.Debug("No valid channel types provided, using defaults")
+		channelTypes = append(channelTypes, provider.PubChanType)
+		channelTypes = append(channelTypes, provider.PrivateChanType)
+		if len(channelTypes) > ch.maxTypes {
+			channelTypes = channelTypes[:ch.maxTypes]
+		}
+	}
+
+	return channelTypes, nil
+}
+
+// normalizeChannelName applies the same naming rule in legacy and OAuth modes:
+// public and private channels are prefixed with #, IMs and MPIMs with @
+// as used for #channel and @user_dm lookups.
+func normalizeChannelName(channel provider.Channel) string {
+	name := strings.TrimLeft(channel.Name, "#@")
+	if channel.IsIM || channel.IsMpIM {
+		return "@" + name
+	}
+	return "#" + name
+}
 
+func filterChannelsByTypes(channels map[string]provider.Channel, types []string, logger *zap.Logger) []provider.Channel {
 	var result []provider.Channel
 	typeSet := make(map[string]bool)
 
@@ -260,45 +335,103 @@ func filterChannelsByTypes(channels map[string]provider.Channel, types []string)
 		typeSet[t] = true
 	}
 
-	publicCount := 0
-	privateCount := 0
-	imCount := 0
-	mpimCount := 0
-
+	counts := make(map[string]int)
 	for _, ch := range channels {
-		if typeSet["public_channel"] && !ch.IsPrivate && !ch.IsIM && !ch.IsMpIM {
+		t := channelType(ch)
+		if typeSet[t] {
 			result = append(result, ch)
-			publicCount++
-		}
-		if typeSet["private_channel"] && ch.IsPrivate && !ch.IsIM && !ch.IsMpIM {
-			result = append(result, ch)
-			privateCount++
-		}
-		if typeSet["im"] && ch.IsIM {
-			result = append(result, ch)
-			imCount++
-		}
-		if typeSet["mpim"] && ch.IsMpIM {
-			result = append(result, ch)
-			mpimCount++
+			counts[t]++
 		}
 	}
 
 	logger.Debug("Channel filtering complete",
 		zap.Int("total_input", len(channels)),
 		zap.Int("total_output", len(result)),
-		zap.Int("public_channels", publicCount),
-		zap.Int("private_channels", privateCount),
-		zap.Int("ims", imCount),
-		zap.Int("mpims", mpimCount),
+		zap.Int("public_channels", counts[provider.PubChanType]),
+		zap.Int("private_channels", counts[provider.PrivateChanType]),
+		zap.Int("ims", counts["im"]),
+		zap.Int("mpims", counts["mpim"]),
 	)
 
 	return result
 }
 
-func paginateChannels(channels []provider.Channel, cursor string, limit int) ([]provider.Channel, string) {
-	logger := zap.L()
+// withoutArchived drops archived channels, which the channel caches hold so
+// that they can be looked up by name
+func withoutArchived(channels []provider.Channel) []provider.Channel {
+	var result []provider.Channel
+	for _, c := range channels {
+		if !c.IsArchived {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// filterChannelsByQuery keeps the channels whose name, topic or purpose match
+// the query, case-insensitively. A query with *, ? or [ is a glob matched
+// against each field as a whole, e.g. incident-*, anything else a substring.
+// A leading # or @ of the query is ignored, as channel names are matched
+// without it.
+func filterChannelsByQuery(channels []provider.Channel, query string) ([]provider.Channel, error) {
+	query = strings.ToLower(strings.TrimLeft(strings.TrimSpace(query), "#@"))
+	if query == "" {
+		return channels, nil
+	}
+	glob := strings.ContainsAny(query, "*?[")
+	if glob {
+		if _, err := path.Match(query, ""); err != nil {
+			return nil, fmt.Errorf("invalid query %q: %w", query, err)
+		}
+	}
+
+	var result []provider.Channel
+	for _, c := range channels {
+		for _, field := range []string{strings.TrimLeft(c.Name, "#@"), c.Topic, c.Purpose} {
+			field = strings.ToLower(field)
+			var ok bool
+			if glob {
+				ok, _ = path.Match(query, field)
+			} else {
+				ok = strings.Contains(field, query)
+			}
+			if ok {
+				result = append(result, c)
+				break
+			}
+		}
+	}
+	return result, nil
+}
 
+// channelType returns the conversation type of a channel as used by the
+// channel_types parameters: im, mpim, private_channel or public_channel
+func channelType(ch provider.Channel) string {
+	switch {
+	case ch.IsIM:
+		return "im"
+	case ch.IsMpIM:
+		return "mpim"
+	case ch.IsPrivate:
+		return provider.PrivateChanType
+	default:
+		return provider.PubChanType
+	}
+}
+
+// sortChannelsByPopularity sorts channels by member count, most members first.
+// Channels with the same member count are ordered by ID, so that repeated and
+// paginated listings return them in the same order.
+func sortChannelsByPopularity(channels []Channel) {
+	sort.SliceStable(channels, func(i, j int) bool {
+		if channels[i].MemberCount != channels[j].MemberCount {
+			return channels[i].MemberCount > channels[j].MemberCount
+		}
+		return channels[i].ID < channels[j].ID
+	})
+}
+
+func paginateChannels(channels []provider.Channel, cursor string, limit int, logger *zap.Logger) ([]provider.Channel, string) {
 	sort.Slice(channels, func(i, j int) bool {
 		return channels[i].ID < channels[j].ID
 	})
@@ -364,47 +497,53 @@ func (ch *ChannelsHandler) channelsHandlerOAuth(ctx context.Context, request mcp
 
 	types := request.GetString("channel_types", "public_channel")
 	limit := request.GetInt("limit", 100)
+	query := request.GetString("query", "")
+	includeArchived := request.GetBool("include_archived", false)
 
 	ch.logger.Debug("OAuth mode: fetching channels",
 		zap.String("types", types),
 		zap.Int("limit", limit),
+		zap.String("query", query),
+		zap.Bool("include_archived", includeArchived),
 	)
 
-	// Parse channel types
-	channelTypes := []string{}
-	for _, t := range strings.Split(types, ",") {
-		t = strings.TrimSpace(t)
-		if ch.validTypes[t] {
-			channelTypes = append(channelTypes, t)
-		}
+	channelTypes, err := ch.parseChannelTypes(types)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(channelTypes) == 0 {
-		channelTypes = []string{"public_channel", "private_channel"}
+	userCtx, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("authentication error: user context not found")
 	}
 
-	// Fetch channels from Slack API
+	// Serve from the per-team cache, refreshing with this user's token on expiry
 	var allChannels []Channel
 	for _, chanType := range channelTypes {
-		params := &slack.GetConversationsParameters{
-			Types:           []string{chanType},
-			Limit:           limit,
-			ExcludeArchived: true,
-		}
-
-		channels, _, err := client.GetConversations(params)
+		channels, err := ch.teamChannels.Load(ctx, client, userCtx.TeamID, userCtx.UserID, chanType)
 		if err != nil {
 			ch.logger.Error("Failed to get conversations", zap.Error(err))
 			return nil, fmt.Errorf("failed to get channels: %w", err)
 		}
 
+		if !includeArchived {
+			channels = withoutArchived(channels)
+		}
+		channels, err = filterChannelsByQuery(channels, query)
+		if err != nil {
+			return nil, err
+		}
+		if len(channels) > limit {
+			channels = channels[:limit]
+		}
+
 		for _, c := range channels {
 			allChannels = append(allChannels, Channel{
 				ID:          c.ID,
-				Name:        "#" + c.Name,
-				Topic:       c.Topic.Value,
-				Purpose:     c.Purpose.Value,
-				MemberCount: c.NumMembers,
+				Name:        normalizeChannelName(c),
+				Topic:       c.Topic,
+				Purpose:     c.Purpose,
+				MemberCount: c.MemberCount,
 			})
 		}
 	}
@@ -412,9 +551,7 @@ func (ch *ChannelsHandler) channelsHandlerOAuth(ctx context.Context, request mcp
 	// Sort by popularity if requested
 	sortType := request.GetString("sort", "")
 	if sortType == "popularity" {
-		sort.Slice(allChannels, func(i, j int) bool {
-			return allChannels[i].MemberCount > allChannels[j].MemberCount
-		})
+		sortChannelsByPopularity(allChannels)
 	}
 
 	// Marshal to CSV
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+type ChannelArchiveState struct {
+	ChannelID string `json:"channelID"`
+	Archived  bool   `json:"archived"`
+	Changed   bool   `json:"changed"` // false when the channel already was in the requested state
+}
+
+// channelsArchiveAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type channelsArchiveAPI interface {
+	ArchiveConversationContext(ctx context.Context, channelID string) error
+	UnArchiveConversationContext(ctx context.Context, channelID string) error
+}
+
+// ChannelsArchiveHandler archives a channel
+func (ch *ChannelsHandler) ChannelsArchiveHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsArchiveHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+	return ch.setChannelArchived(ctx, request, true)
+}
+
+// ChannelsUnarchiveHandler unarchives a channel
+func (ch *ChannelsHandler) ChannelsUnarchiveHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsUnarchiveHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+	return ch.setChannelArchived(ctx, request, false)
+}
+
+// setChannelArchived archives or unarchives a channel, treating a channel
+// already in the requested state as a success
+func (ch *ChannelsHandler) setChannelArchived(ctx context.Context, request mcp.CallToolRequest, archive bool) (*mcp.CallToolResult, error) {
+	tool := "channels_archive"
+	if !archive {
+		tool = "channels_unarchive"
+	}
+	if err := channelsWriteEnabled(tool); err != nil {
+		return nil, err
+	}
+
+	channel := strings.TrimSpace(request.GetString("channel_id", ""))
+	if channel == "" {
+		return nil, errors.New("channel_id must be a string")
+	}
+
+	var api channelsArchiveAPI
+	if ch.oauthEnabled {
+		if strings.HasPrefix(channel, "#") {
+			return nil, fmt.Errorf("in OAuth mode, please use channel ID (C...) instead of name (%s)", channel)
+		}
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			ch.logger.Error("Failed to get Slack client", zap.Error(err))
+			return nil, fmt.Errorf("authentication error: %w", err)
+		}
+		api = client
+	} else {
+		if strings.HasPrefix(channel, "#") {
+			channelsMaps := ch.apiProvider.ProvideChannelsMaps()
+			chn, ok := channelsMaps.ChannelsInv[channel]
+			if !ok {
+				return nil, fmt.Errorf("channel %q not found", channel)
+			}
+			channel = channelsMaps.Channels[chn].ID
+		}
+		api = ch.apiProvider.Slack()
+	}
+
+	var err error
+	if archive {
+		err = api.ArchiveConversationContext(ctx, channel)
+	} else {
+		err = api.UnArchiveConversationContext(ctx, channel)
+	}
+
+	changed := true
+	switch {
+	case err == nil:
+	case archive && isSlackError(err, "already_archived"), !archive && isSlackError(err, "not_archived"):
+		changed = false
+	case isSlackError(err, "cant_archive_general"):
+		return nil, fmt.Errorf("channel %s is the workspace's general channel, which every member belongs to and which cannot be archived: %w", channel, err)
+	case isMissingScope(err):
+		return nil, fmt.Errorf("archiving channels requires the channels:write scope (groups:write for private channels), add it to the Slack app and reinstall it: %w", err)
+	default:
+		if archive {
+			ch.logger.Error("Slack ArchiveConversationContext failed", zap.Error(err))
+		} else {
+			ch.logger.Error("Slack UnArchiveConversationContext failed", zap.Error(err))
+		}
+		return nil, err
+	}
+
+	if ch.oauthEnabled {
+		if userCtx, ok := auth.FromContext(ctx); ok {
+			ch.teamChannels.SetArchived(userCtx.TeamID, userCtx.UserID, channel, archive)
+		}
+	} else {
+		ch.apiProvider.SetChannelArchived(channel, archive)
+	}
+
+	states := []ChannelArchiveState{{
+		ChannelID: channel,
+		Archived:  archive,
+		Changed:   changed,
+	}}
+	csvBytes, err := gocsv.MarshalBytes(&states)
+	if err != nil {
+		ch.logger.Error("Failed to marshal archive state to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
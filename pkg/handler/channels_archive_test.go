@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitChannelsArchive(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "true")
+
+	archived := map[string]bool{}
+	api := &fakeSlackAPI{archive: func(channelID string, archive bool) error {
+		if archive && archived[channelID] {
+			return slack.SlackErrorResponse{Err: "already_archived"}
+		}
+		if !archive && !archived[channelID] {
+			return slack.SlackErrorResponse{Err: "not_archived"}
+		}
+		archived[channelID] = archive
+		return nil
+	}}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	maps := p.ProvideChannelsMaps()
+	maps.Channels["C1"] = provider.Channel{ID: "C1", Name: "#old-project"}
+	maps.ChannelsInv["#old-project"] = "C1"
+	ch := NewChannelsHandler(p, zap.NewNop())
+
+	res, err := ch.ChannelsArchiveHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "#old-project"}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"C1"}, csvColumn(t, out, "ChannelID"))
+	assert.Equal(t, []string{"true"}, csvColumn(t, out, "Changed"))
+	assert.True(t, p.ProvideChannelsMaps().Channels["C1"].IsArchived, "cached flag is updated")
+
+	// archiving twice is not an error
+	res, err = ch.ChannelsArchiveHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"false"}, csvColumn(t, toolResultText(t, res), "Changed"))
+
+	res, err = ch.ChannelsUnarchiveHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1"}))
+	require.NoError(t, err)
+	out = toolResultText(t, res)
+	assert.Equal(t, []string{"false"}, csvColumn(t, out, "Archived"))
+	assert.Equal(t, []string{"true"}, csvColumn(t, out, "Changed"))
+	assert.False(t, p.ProvideChannelsMaps().Channels["C1"].IsArchived)
+
+	res, err = ch.ChannelsUnarchiveHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"false"}, csvColumn(t, toolResultText(t, res), "Changed"))
+}
+
+func TestUnitChannelsArchiveErrors(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "true")
+
+	for code, want := range map[string]string{
+		"cant_archive_general": "cannot be archived",
+		"missing_scope":        "channels:write",
+		"channel_not_found":    "channel_not_found",
+	} {
+		api := &fakeSlackAPI{archive: func(channelID string, archive bool) error {
+			return slack.SlackErrorResponse{Err: code}
+		}}
+		ch := NewChannelsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+		_, err := ch.ChannelsArchiveHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1"}))
+		require.Error(t, err, code)
+		assert.Contains(t, err.Error(), want)
+	}
+}
+
+func TestUnitChannelsArchiveDisabledByDefault(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "")
+
+	api := &fakeSlackAPI{archive: func(channelID string, archive bool) error {
+		t.Fatal("Slack must not be called")
+		return nil
+	}}
+	ch := NewChannelsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	_, err := ch.ChannelsArchiveHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SLACK_MCP_CHANNELS_WRITE_TOOL")
+}
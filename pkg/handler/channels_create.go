@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// ChannelCreated is a channel created by channels_create. A Note reports the
+// initial settings that could not be applied, the channel exists regardless.
+type ChannelCreated struct {
+	ChannelID string `json:"channelID"`
+	Name      string `json:"name"`
+	IsPrivate bool   `json:"isPrivate"`
+	Topic     string `json:"topic"`
+	Purpose   string `json:"purpose"`
+	Invited   string `json:"invited"` // comma-separated user IDs
+	Note      string `json:"note"`
+}
+
+// channelsCreateAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type channelsCreateAPI interface {
+	CreateConversationContext(ctx context.Context, params slack.CreateConversationParams) (*slack.Channel, error)
+	SetTopicOfConversationContext(ctx context.Context, channelID, topic string) (*slack.Channel, error)
+	SetPurposeOfConversationContext(ctx context.Context, channelID, purpose string) (*slack.Channel, error)
+	InviteUsersToConversationContext(ctx context.Context, channelID string, users ...string) (*slack.Channel, error)
+}
+
+// channelsWriteEnabled fails unless SLACK_MCP_CHANNELS_WRITE_TOOL enables the
+// tools creating, changing, archiving and muting channels
+func channelsWriteEnabled(tool string) error {
+	if !envEnabled("SLACK_MCP_CHANNELS_WRITE_TOOL") {
+		return fmt.Errorf("by default, the %s tool is disabled to guard Slack workspaces against accidental changes. "+
+			"To enable it, set the SLACK_MCP_CHANNELS_WRITE_TOOL environment variable to true", tool)
+	}
+	return nil
+}
+
+// ChannelsCreateHandler creates a channel with conversations.create, then sets
+// its topic and purpose and invites users. The new channel is added to the
+// channels cache so that it can be used by name right away.
+func (ch *ChannelsHandler) ChannelsCreateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsCreateHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	if err := channelsWriteEnabled("channels_create"); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(request.GetString("name", "")) == "" {
+		return nil, errors.New("name must be a string")
+	}
+	name, err := text.ValidateChannelName(request.GetString("name", ""))
+	if err != nil {
+		return nil, err
+	}
+	isPrivate := request.GetBool("is_private", false)
+	topic := strings.TrimSpace(request.GetString("topic", ""))
+	purpose := strings.TrimSpace(request.GetString("purpose", ""))
+
+	var (
+		api   channelsCreateAPI
+		users *provider.UsersCache
+	)
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			ch.logger.Error("Failed to get Slack client", zap.Error(err))
+			return nil, fmt.Errorf("authentication error: %w", err)
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+		users = ch.apiProvider.ProvideUsersMap()
+	}
+
+	var invitees []string
+	if raw := request.GetString("invitees", ""); strings.TrimSpace(raw) != "" {
+		ids, err := parseUserIDList(raw, users)
+		if err != nil {
+			return nil, err
+		}
+		invitees = ids
+	}
+
+	channel, err := api.CreateConversationContext(ctx, slack.CreateConversationParams{ChannelName: name, IsPrivate: isPrivate})
+	if err != nil {
+		switch {
+		case isSlackError(err, "name_taken"):
+			return nil, fmt.Errorf("a channel named %q already exists, archived channels keep their name too: %w", name, err)
+		case isSlackError(err, "invalid_name_required"), isSlackError(err, "invalid_name_specials"),
+			isSlackError(err, "invalid_name_punctuation"), isSlackError(err, "invalid_name_maxlength"), isSlackError(err, "invalid_name"):
+			return nil, fmt.Errorf("invalid channel name %q: names must be lowercase, at most 80 characters, with letters, numbers, hyphens and underscores only: %w", name, err)
+		case isSlackError(err, "restricted_action"):
+			return nil, fmt.Errorf("the workspace does not allow this user to create channels: %w", err)
+		case isMissingScope(err):
+			return nil, fmt.Errorf("creating channels requires the channels:write scope (groups:write for private channels), add it to the Slack app and reinstall it: %w", err)
+		}
+		ch.logger.Error("Slack CreateConversationContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	// the channel exists from here on, failed settings are reported instead of failing the call
+	var notes []string
+	if topic != "" {
+		if _, err := api.SetTopicOfConversationContext(ctx, channel.ID, topic); err != nil {
+			ch.logger.Warn("Slack SetTopicOfConversationContext failed", zap.String("channel", channel.ID), zap.Error(err))
+			notes = append(notes, "topic not set: "+err.Error())
+		} else {
+			channel.Topic.Value = topic
+		}
+	}
+	if purpose != "" {
+		if _, err := api.SetPurposeOfConversationContext(ctx, channel.ID, purpose); err != nil {
+			ch.logger.Warn("Slack SetPurposeOfConversationContext failed", zap.String("channel", channel.ID), zap.Error(err))
+			notes = append(notes, "purpose not set: "+err.Error())
+		} else {
+			channel.Purpose.Value = purpose
+		}
+	}
+	var invited []string
+	if len(invitees) > 0 {
+		if _, err := api.InviteUsersToConversationContext(ctx, channel.ID, invitees...); err != nil {
+			ch.logger.Warn("Slack InviteUsersToConversationContext failed", zap.String("channel", channel.ID), zap.Error(err))
+			notes = append(notes, "users not invited: "+err.Error())
+		} else {
+			invited = invitees
+		}
+	}
+
+	if ch.oauthEnabled {
+		if userCtx, ok := auth.FromContext(ctx); ok {
+			ch.teamChannels.Add(userCtx.TeamID, userCtx.UserID, *channel)
+		}
+	} else {
+		ch.apiProvider.AddChannel(*channel)
+	}
+
+	rows := []ChannelCreated{{
+		ChannelID: channel.ID,
+		Name:      "#" + channel.Name,
+		IsPrivate: channel.IsPrivate,
+		Topic:     channel.Topic.Value,
+		Purpose:   channel.Purpose.Value,
+		Invited:   strings.Join(invited, ","),
+		Note:      strings.Join(notes, "; "),
+	}}
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal created channel to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
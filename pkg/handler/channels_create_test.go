@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newCreatedChannel(id, name string, private bool) *slack.Channel {
+	return &slack.Channel{GroupConversation: slack.GroupConversation{
+		Name:         name,
+		Conversation: slack.Conversation{ID: id, NameNormalized: name, IsPrivate: private},
+	}}
+}
+
+func TestUnitChannelsCreate(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "true")
+
+	var (
+		created        slack.CreateConversationParams
+		topic, purpose string
+		invited        []string
+	)
+	api := &fakeSlackAPI{
+		create: func(params slack.CreateConversationParams) (*slack.Channel, error) {
+			created = params
+			return newCreatedChannel("G1", params.ChannelName, params.IsPrivate), nil
+		},
+		topic: func(channelID, value string) (*slack.Channel, error) {
+			topic = value
+			return newCreatedChannel(channelID, "incident-42", true), nil
+		},
+		purpose: func(channelID, value string) (*slack.Channel, error) {
+			purpose = value
+			return newCreatedChannel(channelID, "incident-42", true), nil
+		},
+		invite: func(channelID string, users ...string) (*slack.Channel, error) {
+			invited = users
+			return newCreatedChannel(channelID, "incident-42", true), nil
+		},
+	}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	p.ProvideUsersMap().UsersInv["bob"] = "U2"
+	ch := NewChannelsHandler(p, zap.NewNop())
+
+	res, err := ch.ChannelsCreateHandler(context.Background(), newToolRequest(map[string]any{
+		"name":       "#incident-42",
+		"is_private": true,
+		"topic":      "Status: investigating",
+		"purpose":    "Checkout outage",
+		"invitees":   "U1, @bob, U1",
+	}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, slack.CreateConversationParams{ChannelName: "incident-42", IsPrivate: true}, created)
+	assert.Equal(t, "Status: investigating", topic)
+	assert.Equal(t, "Checkout outage", purpose)
+	assert.Equal(t, []string{"U1", "U2"}, invited)
+	assert.Equal(t, []string{"G1"}, csvColumn(t, out, "ChannelID"))
+	assert.Equal(t, []string{"#incident-42"}, csvColumn(t, out, "Name"))
+	assert.Equal(t, []string{"Status: investigating"}, csvColumn(t, out, "Topic"))
+	assert.Equal(t, []string{"U1,U2"}, csvColumn(t, out, "Invited"))
+	assert.Equal(t, []string{""}, csvColumn(t, out, "Note"))
+
+	maps := p.ProvideChannelsMaps()
+	assert.Equal(t, "G1", maps.ChannelsInv["#incident-42"], "the channel can be used by name right away")
+	assert.True(t, maps.Channels["G1"].IsPrivate)
+	assert.Equal(t, "Checkout outage", maps.Channels["G1"].Purpose)
+}
+
+func TestUnitChannelsCreatePartialFailure(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "true")
+
+	api := &fakeSlackAPI{
+		create: func(params slack.CreateConversationParams) (*slack.Channel, error) {
+			return newCreatedChannel("C1", params.ChannelName, false), nil
+		},
+		invite: func(channelID string, users ...string) (*slack.Channel, error) {
+			return nil, slack.SlackErrorResponse{Err: "cant_invite_self"}
+		},
+	}
+	ch := NewChannelsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.ChannelsCreateHandler(context.Background(), newToolRequest(map[string]any{"name": "launch", "invitees": "U1"}))
+	require.NoError(t, err, "the channel exists even though the invite failed")
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"C1"}, csvColumn(t, out, "ChannelID"))
+	assert.Equal(t, []string{""}, csvColumn(t, out, "Invited"))
+	assert.Equal(t, []string{"users not invited: cant_invite_self"}, csvColumn(t, out, "Note"))
+}
+
+func TestUnitChannelsCreateErrors(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "true")
+
+	for code, want := range map[string]string{
+		"name_taken":            "already exists",
+		"invalid_name_specials": "invalid channel name",
+		"missing_scope":         "channels:write",
+		"restricted_action":     "does not allow",
+	} {
+		api := &fakeSlackAPI{create: func(params slack.CreateConversationParams) (*slack.Channel, error) {
+			return nil, slack.SlackErrorResponse{Err: code}
+		}}
+		ch := NewChannelsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+		_, err := ch.ChannelsCreateHandler(context.Background(), newToolRequest(map[string]any{"name": "launch"}))
+		require.Error(t, err, code)
+		assert.Contains(t, err.Error(), want)
+	}
+
+	ch := NewChannelsHandler(provider.NewWithClient("stdio", &fakeSlackAPI{}, zap.NewNop()), zap.NewNop())
+	_, err := ch.ChannelsCreateHandler(context.Background(), newToolRequest(map[string]any{"name": " "}))
+	assert.Error(t, err)
+	_, err = ch.ChannelsCreateHandler(context.Background(), newToolRequest(map[string]any{"name": "launch", "invitees": "@nobody"}))
+	assert.Error(t, err, "invitees are resolved before the channel is created")
+	_, err = ch.ChannelsCreateHandler(context.Background(), newToolRequest(map[string]any{"name": "Launch Plan"}))
+	assert.Error(t, err, "names are validated before the channel is created")
+}
+
+func TestUnitChannelsCreateDisabledByDefault(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "")
+
+	created := false
+	api := &fakeSlackAPI{create: func(params slack.CreateConversationParams) (*slack.Channel, error) {
+		created = true
+		return newCreatedChannel("C1", params.ChannelName, false), nil
+	}}
+	ch := NewChannelsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	_, err := ch.ChannelsCreateHandler(context.Background(), newToolRequest(map[string]any{"name": "launch"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SLACK_MCP_CHANNELS_WRITE_TOOL")
+	assert.False(t, created)
+}
@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/limiter"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultExportInfoCalls = 100
+	maxExportInfoCalls     = 500
+)
+
+type ChannelExport struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Topic        string `json:"topic"`
+	Purpose      string `json:"purpose"`
+	MemberCount  int    `json:"memberCount"`
+	Archived     bool   `json:"archived"`
+	LastActivity string `json:"lastActivity"`
+}
+
+// channelsExportAPI is the Slack API needed to export channels,
+// satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type channelsExportAPI interface {
+	provider.ConversationsLister
+	GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error)
+}
+
+// exportFilter holds the optional filters of channels_export, all of which must match
+type exportFilter struct {
+	minMembers    int
+	maxMembers    int
+	archived      string // exclude, include or only
+	activeSince   time.Time
+	inactiveSince time.Time
+}
+
+func (f exportFilter) needsActivity() bool {
+	return !f.activeSince.IsZero() || !f.inactiveSince.IsZero()
+}
+
+// ChannelsExportHandler returns channels matching all given filters as CSV
+func (ch *ChannelsHandler) ChannelsExportHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsExportHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	channelTypes, err := ch.parseChannelTypes(request.GetString("channel_types", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	filter := exportFilter{
+		minMembers: request.GetInt("min_members", 0),
+		maxMembers: request.GetInt("max_members", 0),
+		archived:   request.GetString("archived", "exclude"),
+	}
+	if filter.minMembers < 0 || filter.maxMembers < 0 {
+		return nil, fmt.Errorf("min_members and max_members must not be negative")
+	}
+	switch filter.archived {
+	case "exclude", "include", "only":
+	default:
+		return nil, fmt.Errorf("archived must be one of exclude, include or only, got %q", filter.archived)
+	}
+	if v := request.GetString("active_within", ""); v != "" {
+		if filter.activeSince, err = parseRecency(v); err != nil {
+			return nil, err
+		}
+	}
+	if v := request.GetString("inactive_for", ""); v != "" {
+		if filter.inactiveSince, err = parseRecency(v); err != nil {
+			return nil, err
+		}
+	}
+
+	maxInfoCalls := request.GetInt("max_info_calls", defaultExportInfoCalls)
+	if maxInfoCalls < 1 || maxInfoCalls > maxExportInfoCalls {
+		return nil, fmt.Errorf("max_info_calls must be an integer between 1 and %d", maxExportInfoCalls)
+	}
+	maxTextLen, err := parseMaxTextLen(request)
+	if err != nil {
+		return nil, err
+	}
+	withActivity := filter.needsActivity() || request.GetBool("include_activity", false)
+
+	var api channelsExportAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			ch.logger.Error("Failed to get Slack client", zap.Error(err))
+			return nil, fmt.Errorf("authentication error: %w", err)
+		}
+		api = client
+	} else {
+		if ready, err := ch.apiProvider.IsReady(); !ready {
+			ch.logger.Error("API provider not ready", zap.Error(err))
+			return nil, err
+		}
+		api = ch.apiProvider.Slack()
+	}
+
+	candidates, err := ch.exportCandidates(ctx, api, channelTypes, filter.archived != "exclude")
+	if err != nil {
+		ch.logger.Error("Failed to get channels", zap.Error(err))
+		return nil, fmt.Errorf("failed to get channels: %w", err)
+	}
+
+	channels := filterExportChannels(candidates, filter)
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].ID < channels[j].ID
+	})
+
+	ch.logger.Debug("Channels after filtering",
+		zap.Int("candidates", len(candidates)),
+		zap.Int("matched", len(channels)),
+	)
+
+	lastActivity := make(map[string]time.Time)
+	if withActivity {
+		// every channel costs one conversations.info call
+		if len(channels) > maxInfoCalls {
+			return nil, fmt.Errorf("%d channels match the other filters, but activity lookup is capped at %d conversations.info calls (max_info_calls): narrow the filters or raise max_info_calls", len(channels), maxInfoCalls)
+		}
+
+		rl := limiter.Tier3.Limiter()
+		for _, c := range channels {
+			if err := rl.Wait(ctx); err != nil {
+				return nil, err
+			}
+			info, err := api.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{ChannelID: c.ID})
+			if err != nil {
+				ch.logger.Warn("Failed to get channel info",
+					zap.String("channel", c.ID),
+					zap.Error(err),
+				)
+				continue
+			}
+			if info.Latest == nil {
+				continue
+			}
+			if ts, err := parseSlackTimestamp(info.Latest.Timestamp); err == nil {
+				lastActivity[c.ID] = ts
+			}
+		}
+	}
+
+	exports := []ChannelExport{}
+	for _, c := range channels {
+		ts, known := lastActivity[c.ID]
+		if !matchesActivity(ts, known, filter) {
+			continue
+		}
+
+		export := ChannelExport{
+			ID:          c.ID,
+			Name:        normalizeChannelName(c),
+			Topic:       text.TruncateText(c.Topic, maxTextLen),
+			Purpose:     text.TruncateText(c.Purpose, maxTextLen),
+			MemberCount: c.MemberCount,
+			Archived:    c.IsArchived,
+		}
+		if known {
+			export.LastActivity = ts.UTC().Format(time.RFC3339)
+		}
+		exports = append(exports, export)
+	}
+
+	csvBytes, err := gocsv.MarshalBytes(&exports)
+	if err != nil {
+		ch.logger.Error("Failed to marshal channels to CSV", zap.Error(err))
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// exportCandidates returns channels of the given types from the channel cache,
+// the per-team cache in OAuth mode, dropping archived ones unless asked for
+func (ch *ChannelsHandler) exportCandidates(ctx context.Context, api channelsExportAPI, channelTypes []string, includeArchived bool) ([]provider.Channel, error) {
+	var result []provider.Channel
+	if ch.oauthEnabled {
+		userCtx, ok := auth.FromContext(ctx)
+		if !ok {
+			return nil, fmt.Errorf("authentication error: user context not found")
+		}
+		for _, chanType := range channelTypes {
+			channels, err := ch.teamChannels.Load(ctx, api, userCtx.TeamID, userCtx.UserID, chanType)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, channels...)
+		}
+	} else {
+		result = filterChannelsByTypes(ch.apiProvider.ProvideChannelsMaps().Channels, channelTypes,
+			ch.logger.With(zap.String("request_id", auth.RequestIDFromContext(ctx))))
+	}
+
+	if !includeArchived {
+		result = withoutArchived(result)
+	}
+	return result, nil
+}
+
+// filterExportChannels applies the member count and archived state filters
+func filterExportChannels(channels []provider.Channel, f exportFilter) []provider.Channel {
+	var result []provider.Channel
+	for _, c := range channels {
+		if f.minMembers > 0 && c.MemberCount < f.minMembers {
+			continue
+		}
+		if f.maxMembers > 0 && c.MemberCount > f.maxMembers {
+			continue
+		}
+		if f.archived == "exclude" && c.IsArchived {
+			continue
+		}
+		if f.archived == "only" && !c.IsArchived {
+			continue
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+// matchesActivity applies the recency filters. A channel whose last activity is
+// unknown matches neither active_within nor inactive_for.
+func matchesActivity(last time.Time, known bool, f exportFilter) bool {
+	if !f.needsActivity() {
+		return true
+	}
+	if !known {
+		return false
+	}
+	if !f.activeSince.IsZero() && last.Before(f.activeSince) {
+		return false
+	}
+	if !f.inactiveSince.IsZero() && !last.Before(f.inactiveSince) {
+		return false
+	}
+	return true
+}
+
+// parseRecency turns an expression like 7d, 2w or 3m into the point in time
+// that far in the past
+func parseRecency(expr string) (time.Time, error) {
+	if len(expr) < 2 {
+		return time.Time{}, fmt.Errorf("invalid recency %q: must be a positive integer followed by 'd', 'w', or 'm'", expr)
+	}
+	n, err := strconv.Atoi(expr[:len(expr)-1])
+	if err != nil || n <= 0 {
+		return time.Time{}, fmt.Errorf("invalid recency %q: must be a positive integer followed by 'd', 'w', or 'm'", expr)
+	}
+
+	now := time.Now()
+	switch expr[len(expr)-1] {
+	case 'd':
+		return now.AddDate(0, 0, -n), nil
+	case 'w':
+		return now.AddDate(0, 0, -n*7), nil
+	case 'm':
+		return now.AddDate(0, -n, 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("invalid recency %q: must end in 'd', 'w', or 'm'", expr)
+	}
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func exportIDs(channels []provider.Channel) []string {
+	var ids []string
+	for _, c := range channels {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
+
+func TestUnitFilterExportChannels(t *testing.T) {
+	channels := []provider.Channel{
+		{ID: "C1", MemberCount: 2},
+		{ID: "C2", MemberCount: 10},
+		{ID: "C3", MemberCount: 50, IsArchived: true},
+		{ID: "C4", MemberCount: 3, IsArchived: true},
+	}
+
+	tests := []struct {
+		name   string
+		filter exportFilter
+		want   []string
+	}{
+		{"no filters", exportFilter{archived: "exclude"}, []string{"C1", "C2"}},
+		{"include archived", exportFilter{archived: "include"}, []string{"C1", "C2", "C3", "C4"}},
+		{"only archived", exportFilter{archived: "only"}, []string{"C3", "C4"}},
+		{"min members", exportFilter{archived: "include", minMembers: 10}, []string{"C2", "C3"}},
+		{"max members", exportFilter{archived: "include", maxMembers: 3}, []string{"C1", "C4"}},
+		{"all combined", exportFilter{archived: "only", minMembers: 3, maxMembers: 10}, []string{"C4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exportIDs(filterExportChannels(channels, tt.filter)))
+		})
+	}
+}
+
+func TestUnitMatchesActivity(t *testing.T) {
+	now := time.Now()
+	week := now.AddDate(0, 0, -7)
+
+	assert.True(t, matchesActivity(time.Time{}, false, exportFilter{}), "no activity filter")
+
+	active := exportFilter{activeSince: week}
+	assert.True(t, matchesActivity(now.Add(-time.Hour), true, active))
+	assert.False(t, matchesActivity(now.AddDate(0, 0, -30), true, active))
+	assert.False(t, matchesActivity(time.Time{}, false, active), "unknown activity")
+
+	inactive := exportFilter{inactiveSince: week}
+	assert.True(t, matchesActivity(now.AddDate(0, 0, -30), true, inactive))
+	assert.False(t, matchesActivity(now.Add(-time.Hour), true, inactive))
+	assert.False(t, matchesActivity(time.Time{}, false, inactive), "unknown activity")
+
+	both := exportFilter{activeSince: now.AddDate(0, 0, -30), inactiveSince: week}
+	assert.True(t, matchesActivity(now.AddDate(0, 0, -14), true, both))
+	assert.False(t, matchesActivity(now.AddDate(0, 0, -60), true, both))
+}
+
+func TestUnitParseRecency(t *testing.T) {
+	ts, err := parseRecency("2w")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -14), ts, time.Minute)
+
+	for _, expr := range []string{"", "d", "0d", "-1d", "5y", "abc"} {
+		_, err := parseRecency(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestUnitExportCandidatesArchived(t *testing.T) {
+	p := provider.NewWithClient("stdio", &fakeSlackAPI{}, zap.NewNop())
+	maps := p.ProvideChannelsMaps()
+	maps.Channels["C1"] = provider.Channel{ID: "C1", Name: "#general"}
+	maps.Channels["C2"] = provider.Channel{ID: "C2", Name: "#old-project", IsArchived: true}
+	ch := NewChannelsHandler(p, zap.NewNop())
+
+	chans, err := ch.exportCandidates(context.Background(), nil, []string{provider.PubChanType}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C1"}, exportIDs(chans))
+
+	chans, err = ch.exportCandidates(context.Background(), nil, []string{provider.PubChanType}, true)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"C1", "C2"}, exportIDs(chans), "archived channels come from the cache too")
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// ChannelInfo is the full detail of a single channel as returned by channels_info
+type ChannelInfo struct {
+	ChannelID   string `json:"channelID"`
+	Name        string `json:"name"`
+	Created     string `json:"created"`
+	Creator     string `json:"creator"`
+	CreatorName string `json:"creatorName"`
+	IsPrivate   bool   `json:"isPrivate"`
+	IsArchived  bool   `json:"isArchived"`
+	IsGeneral   bool   `json:"isGeneral"`
+	IsShared    bool   `json:"isShared"`
+	IsExtShared bool   `json:"isExtShared"`
+	MemberCount int    `json:"memberCount"`
+	Topic       string `json:"topic"`
+	Purpose     string `json:"purpose"`
+	LatestTs    string `json:"latestTs"`
+}
+
+// ChannelsInfoHandler returns the detail of one channel from conversations.info,
+// where channels_list only lists name, topic, purpose and member count
+func (ch *ChannelsHandler) ChannelsInfoHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsInfoHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	input := strings.TrimSpace(request.GetString("channel_id", ""))
+	if input == "" {
+		return nil, errors.New("channel_id must be a string")
+	}
+	channelID, err := ch.resolveChannelID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		api   conversationInfoAPI
+		users map[string]slack.User
+	)
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			ch.logger.Error("Failed to get Slack client", zap.Error(err))
+			return nil, fmt.Errorf("authentication error: %w", err)
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+		users = ch.apiProvider.ProvideUsersMap().Users
+	}
+
+	info, err := api.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
+		ChannelID:         channelID,
+		IncludeNumMembers: true,
+	})
+	if err != nil {
+		switch {
+		case isSlackError(err, "channel_not_found"):
+			// Slack does not tell apart missing channels from private ones the token is not in
+			return nil, fmt.Errorf("channel %s not found or not accessible: private channels can only be looked up by their members: %w", channelID, err)
+		case isMissingScope(err):
+			return nil, fmt.Errorf("channels_info requires the channels:read scope (groups:read, im:read and mpim:read for private channels and DMs): %w", err)
+		}
+		ch.logger.Error("Slack GetConversationInfoContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	count := info.NumMembers
+	if info.IsIM {
+		count = 2
+	}
+	// keep the channel cache in sync with the fresh count
+	if ch.oauthEnabled {
+		if userCtx, ok := auth.FromContext(ctx); ok {
+			ch.teamChannels.SetMemberCount(userCtx.TeamID, userCtx.UserID, info.ID, count)
+		}
+	} else {
+		ch.apiProvider.SetChannelMemberCount(info.ID, count)
+	}
+
+	name := info.Name
+	if info.IsIM {
+		name = info.User
+	}
+	row := ChannelInfo{
+		ChannelID:   info.ID,
+		Name:        normalizeChannelName(provider.Channel{Name: name, IsIM: info.IsIM, IsMpIM: info.IsMpIM}),
+		Creator:     info.Creator,
+		IsPrivate:   info.IsPrivate,
+		IsArchived:  info.IsArchived,
+		IsGeneral:   info.IsGeneral,
+		IsShared:    info.IsShared,
+		IsExtShared: info.IsExtShared,
+		MemberCount: count,
+		Topic:       info.Topic.Value,
+		Purpose:     info.Purpose.Value,
+	}
+	if info.Created > 0 {
+		row.Created = info.Created.Time().UTC().Format(time.RFC3339)
+	}
+	if u, ok := users[info.Creator]; ok {
+		row.CreatorName = u.Name
+	}
+	if info.Latest != nil {
+		row.LatestTs = info.Latest.Timestamp
+	}
+
+	rows := []ChannelInfo{row}
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal channel info to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
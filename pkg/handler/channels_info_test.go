@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitChannelsInfo(t *testing.T) {
+	api := &fakeSlackAPI{info: func(input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+		assert.Equal(t, "C1", input.ChannelID)
+		assert.True(t, input.IncludeNumMembers)
+		var c slack.Channel
+		c.ID = "C1"
+		c.Name = "general"
+		c.Created = slack.JSONTime(1700000000)
+		c.Creator = "U1"
+		c.IsGeneral = true
+		c.IsExtShared = true
+		c.NumMembers = 42
+		c.Topic.Value = "Company news"
+		c.Purpose.Value = "Everyone"
+		c.Latest = &slack.Message{Msg: slack.Msg{Timestamp: "1700000100.000200"}}
+		return &c, nil
+	}}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	maps := p.ProvideChannelsMaps()
+	maps.Channels["C1"] = provider.Channel{ID: "C1", Name: "#general", MemberCount: 10}
+	maps.ChannelsInv["#general"] = "C1"
+	p.ProvideUsersMap().Users["U1"] = slack.User{ID: "U1", Name: "alice"}
+	ch := NewChannelsHandler(p, zap.NewNop())
+
+	res, err := ch.ChannelsInfoHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "#general"}))
+	require.NoError(t, err)
+
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"#general"}, csvColumn(t, out, "Name"))
+	assert.Equal(t, []string{"2023-11-14T22:13:20Z"}, csvColumn(t, out, "Created"))
+	assert.Equal(t, []string{"alice"}, csvColumn(t, out, "CreatorName"))
+	assert.Equal(t, []string{"true"}, csvColumn(t, out, "IsGeneral"))
+	assert.Equal(t, []string{"true"}, csvColumn(t, out, "IsExtShared"))
+	assert.Equal(t, []string{"42"}, csvColumn(t, out, "MemberCount"))
+	assert.Equal(t, []string{"Company news"}, csvColumn(t, out, "Topic"))
+	assert.Equal(t, []string{"1700000100.000200"}, csvColumn(t, out, "LatestTs"))
+	assert.Equal(t, 42, p.ProvideChannelsMaps().Channels["C1"].MemberCount, "cached count is updated")
+}
+
+func TestUnitChannelsInfoNotAccessible(t *testing.T) {
+	api := &fakeSlackAPI{info: func(input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+		return nil, slack.SlackErrorResponse{Err: "channel_not_found"}
+	}}
+	ch := NewChannelsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	_, err := ch.ChannelsInfoHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C2"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "private channels can only be looked up by their members")
+}
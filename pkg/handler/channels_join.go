@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type ChannelMembership struct {
+	ChannelID string `json:"channelID"`
+	Member    bool   `json:"member"`
+	Changed   bool   `json:"changed"` // false when the user already was in the requested state
+}
+
+// channelsJoinAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type channelsJoinAPI interface {
+	JoinConversationContext(ctx context.Context, channelID string) (*slack.Channel, string, []string, error)
+	LeaveConversationContext(ctx context.Context, channelID string) (bool, error)
+}
+
+// ChannelsJoinHandler joins a public channel, e.g. to read or post into a
+// channel that fails with not_in_channel
+func (ch *ChannelsHandler) ChannelsJoinHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsJoinHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+	return ch.setChannelMember(ctx, request, true)
+}
+
+// ChannelsLeaveHandler leaves a channel
+func (ch *ChannelsHandler) ChannelsLeaveHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsLeaveHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+	return ch.setChannelMember(ctx, request, false)
+}
+
+// setChannelMember joins or leaves a channel as the authenticated user,
+// treating a user already in the requested state as a success
+func (ch *ChannelsHandler) setChannelMember(ctx context.Context, request mcp.CallToolRequest, join bool) (*mcp.CallToolResult, error) {
+	tool := "channels_join"
+	if !join {
+		tool = "channels_leave"
+	}
+	if err := channelsWriteEnabled(tool); err != nil {
+		return nil, err
+	}
+
+	input := strings.TrimSpace(request.GetString("channel_id", ""))
+	if input == "" {
+		return nil, errors.New("channel_id must be a string")
+	}
+	channelID, err := ch.resolveChannelID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var api channelsJoinAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			ch.logger.Error("Failed to get Slack client", zap.Error(err))
+			return nil, fmt.Errorf("authentication error: %w", err)
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	changed := true
+	if join {
+		channel, warning, _, err := api.JoinConversationContext(ctx, channelID)
+		switch {
+		case err == nil:
+			changed = warning != "already_in_channel"
+			ch.cacheJoinedChannel(ctx, channel)
+		case isSlackError(err, "is_archived"):
+			return nil, fmt.Errorf("channel %s is archived, unarchive it before joining: %w", channelID, err)
+		case isSlackError(err, "method_not_supported_for_channel_type"):
+			return nil, fmt.Errorf("channel %s is not a public channel, private channels and DMs can only be joined by invitation: %w", channelID, err)
+		case isMissingScope(err):
+			return nil, fmt.Errorf("joining channels requires the channels:join scope for bot tokens or channels:write for user tokens, add it to the Slack app and reinstall it: %w", err)
+		default:
+			ch.logger.Error("Slack JoinConversationContext failed", zap.Error(err))
+			return nil, err
+		}
+	} else {
+		notInChannel, err := api.LeaveConversationContext(ctx, channelID)
+		switch {
+		case err == nil:
+			changed = !notInChannel
+		case isSlackError(err, "not_in_channel"):
+			changed = false
+		case isSlackError(err, "cant_leave_general"):
+			return nil, fmt.Errorf("channel %s is the workspace's general channel, which every member belongs to and which cannot be left: %w", channelID, err)
+		case isMissingScope(err):
+			return nil, fmt.Errorf("leaving channels requires the channels:write scope (groups:write for private channels), add it to the Slack app and reinstall it: %w", err)
+		default:
+			ch.logger.Error("Slack LeaveConversationContext failed", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	memberships := []ChannelMembership{{
+		ChannelID: channelID,
+		Member:    join,
+		Changed:   changed,
+	}}
+	csvBytes, err := gocsv.MarshalBytes(&memberships)
+	if err != nil {
+		ch.logger.Error("Failed to marshal channel membership to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// cacheJoinedChannel adds a joined channel to the channels cache when it is
+// missing there, e.g. when it was created after the last sync
+func (ch *ChannelsHandler) cacheJoinedChannel(ctx context.Context, channel *slack.Channel) {
+	if channel == nil || channel.ID == "" {
+		return
+	}
+	if ch.oauthEnabled {
+		if userCtx, ok := auth.FromContext(ctx); ok {
+			ch.teamChannels.Add(userCtx.TeamID, userCtx.UserID, *channel)
+		}
+		return
+	}
+	if _, ok := ch.apiProvider.ProvideChannelsMaps().Channels[channel.ID]; !ok {
+		ch.apiProvider.AddChannel(*channel)
+	}
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitChannelsJoinLeave(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "true")
+
+	member := map[string]bool{}
+	api := &fakeSlackAPI{
+		join: func(channelID string) (*slack.Channel, string, error) {
+			warning := ""
+			if member[channelID] {
+				warning = "already_in_channel"
+			}
+			member[channelID] = true
+			return newCreatedChannel(channelID, "deploys", false), warning, nil
+		},
+		leave: func(channelID string) (bool, error) {
+			if !member[channelID] {
+				return true, nil
+			}
+			member[channelID] = false
+			return false, nil
+		},
+	}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	maps := p.ProvideChannelsMaps()
+	maps.Channels["C1"] = provider.Channel{ID: "C1", Name: "#general"}
+	maps.ChannelsInv["#general"] = "C1"
+	ch := NewChannelsHandler(p, zap.NewNop())
+
+	res, err := ch.ChannelsJoinHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "#general"}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"C1"}, csvColumn(t, out, "ChannelID"))
+	assert.Equal(t, []string{"true"}, csvColumn(t, out, "Member"))
+	assert.Equal(t, []string{"true"}, csvColumn(t, out, "Changed"))
+	assert.Equal(t, "#general", p.ProvideChannelsMaps().Channels["C1"].Name, "cached channels are kept")
+
+	// joining twice is not an error
+	res, err = ch.ChannelsJoinHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"false"}, csvColumn(t, toolResultText(t, res), "Changed"))
+
+	res, err = ch.ChannelsJoinHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C2"}))
+	require.NoError(t, err)
+	assert.Equal(t, "C2", p.ProvideChannelsMaps().ChannelsInv["#deploys"], "joined channels missing from the cache are added")
+
+	res, err = ch.ChannelsLeaveHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1"}))
+	require.NoError(t, err)
+	out = toolResultText(t, res)
+	assert.Equal(t, []string{"false"}, csvColumn(t, out, "Member"))
+	assert.Equal(t, []string{"true"}, csvColumn(t, out, "Changed"))
+
+	res, err = ch.ChannelsLeaveHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"false"}, csvColumn(t, toolResultText(t, res), "Changed"))
+}
+
+func TestUnitChannelsJoinLeaveErrors(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "true")
+
+	for code, want := range map[string]string{
+		"is_archived":                           "archived",
+		"method_not_supported_for_channel_type": "not a public channel",
+		"missing_scope":                         "channels:join",
+		"channel_not_found":                     "channel_not_found",
+	} {
+		api := &fakeSlackAPI{join: func(channelID string) (*slack.Channel, string, error) {
+			return nil, "", slack.SlackErrorResponse{Err: code}
+		}}
+		ch := NewChannelsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+		_, err := ch.ChannelsJoinHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1"}))
+		require.Error(t, err, code)
+		assert.Contains(t, err.Error(), want)
+	}
+
+	api := &fakeSlackAPI{leave: func(channelID string) (bool, error) {
+		return false, slack.SlackErrorResponse{Err: "cant_leave_general"}
+	}}
+	ch := NewChannelsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+	_, err := ch.ChannelsLeaveHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be left")
+
+	_, err = ch.ChannelsJoinHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "#unknown"}))
+	assert.Error(t, err)
+}
+
+func TestUnitChannelsJoinDisabledByDefault(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "")
+
+	joined := false
+	api := &fakeSlackAPI{join: func(channelID string) (*slack.Channel, string, error) {
+		joined = true
+		return nil, "", nil
+	}}
+	ch := NewChannelsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	_, err := ch.ChannelsJoinHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "channels_join")
+	_, err = ch.ChannelsLeaveHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "channels_leave")
+	assert.False(t, joined)
+}
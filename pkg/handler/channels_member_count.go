@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type ChannelMemberCount struct {
+	ChannelID   string `json:"channelID"`
+	Name        string `json:"name"`
+	MemberCount int    `json:"memberCount"`
+}
+
+// ChannelMemberCountHandler returns the member count of a channel from
+// conversations.info, without paging through its members
+func (ch *ChannelsHandler) ChannelMemberCountHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelMemberCountHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	channel := strings.TrimSpace(request.GetString("channel_id", ""))
+	if channel == "" {
+		return nil, errors.New("channel_id must be a string")
+	}
+
+	var api channelsExportAPI
+	if ch.oauthEnabled {
+		if strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@") {
+			return nil, fmt.Errorf("in OAuth mode, please use channel ID (C...) instead of name (%s)", channel)
+		}
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			ch.logger.Error("Failed to get Slack client", zap.Error(err))
+			return nil, fmt.Errorf("authentication error: %w", err)
+		}
+		api = client
+	} else {
+		if strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@") {
+			channelsMaps := ch.apiProvider.ProvideChannelsMaps()
+			chn, ok := channelsMaps.ChannelsInv[channel]
+			if !ok {
+				return nil, fmt.Errorf("channel %q not found", channel)
+			}
+			channel = channelsMaps.Channels[chn].ID
+		}
+		api = ch.apiProvider.Slack()
+	}
+
+	info, err := api.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
+		ChannelID:         channel,
+		IncludeNumMembers: true,
+	})
+	if err != nil {
+		ch.logger.Error("Slack GetConversationInfoContext failed", zap.Error(err))
+		var slackErr slack.SlackErrorResponse
+		if errors.As(err, &slackErr) && slackErr.Err == "channel_not_found" {
+			// Slack does not tell apart missing channels from private ones the token is not in
+			return nil, fmt.Errorf("channel %s not found or not accessible: private channels can only be counted by their members: %w", channel, err)
+		}
+		if isMissingScope(err) {
+			return nil, fmt.Errorf("channels_member_count requires the channels:read scope (groups:read for private channels): %w", err)
+		}
+		return nil, err
+	}
+
+	count := info.NumMembers
+	if info.IsIM {
+		count = 2
+	}
+
+	// keep the channel cache in sync with the fresh count
+	if ch.oauthEnabled {
+		if userCtx, ok := auth.FromContext(ctx); ok {
+			ch.teamChannels.SetMemberCount(userCtx.TeamID, userCtx.UserID, info.ID, count)
+		}
+	} else {
+		ch.apiProvider.SetChannelMemberCount(info.ID, count)
+	}
+
+	name := info.Name
+	if info.IsIM {
+		name = info.User
+	}
+
+	counts := []ChannelMemberCount{{
+		ChannelID:   info.ID,
+		Name:        normalizeChannelName(provider.Channel{Name: name, IsIM: info.IsIM, IsMpIM: info.IsMpIM}),
+		MemberCount: count,
+	}}
+	csvBytes, err := gocsv.MarshalBytes(&counts)
+	if err != nil {
+		ch.logger.Error("Failed to marshal member count to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitChannelMemberCount(t *testing.T) {
+	api := &fakeSlackAPI{info: func(input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+		assert.Equal(t, "C1", input.ChannelID)
+		assert.True(t, input.IncludeNumMembers)
+		var c slack.Channel
+		c.ID = "C1"
+		c.Name = "general"
+		c.NumMembers = 4200
+		return &c, nil
+	}}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	maps := p.ProvideChannelsMaps()
+	maps.Channels["C1"] = provider.Channel{ID: "C1", Name: "#general", MemberCount: 10}
+	maps.ChannelsInv["#general"] = "C1"
+	ch := NewChannelsHandler(p, zap.NewNop())
+
+	res, err := ch.ChannelMemberCountHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "#general"}))
+	require.NoError(t, err)
+
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"#general"}, csvColumn(t, out, "Name"))
+	assert.Equal(t, []string{"4200"}, csvColumn(t, out, "MemberCount"))
+	assert.Equal(t, 4200, p.ProvideChannelsMaps().Channels["C1"].MemberCount, "cached count is updated")
+}
+
+func TestUnitChannelMemberCountNotAccessible(t *testing.T) {
+	api := &fakeSlackAPI{info: func(input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+		return nil, slack.SlackErrorResponse{Err: "channel_not_found"}
+	}}
+	ch := NewChannelsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	_, err := ch.ChannelMemberCountHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C2"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "private channels can only be counted by their members")
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultChannelMembersLimit = 100
+	maxChannelMembersLimit     = 1000
+)
+
+// ChannelMember is a member of a channel. Names are only filled with
+// resolve_users, the last row of a page carries the cursor of the next page.
+type ChannelMember struct {
+	UserID   string `json:"userID"`
+	UserName string `json:"userName"`
+	RealName string `json:"realName"`
+	Cursor   string `json:"cursor"`
+}
+
+// channelMembersAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type channelMembersAPI interface {
+	GetUsersInConversationContext(ctx context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error)
+}
+
+// ChannelsMembersHandler lists the members of a channel with
+// conversations.members, a page at a time
+func (ch *ChannelsHandler) ChannelsMembersHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsMembersHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	input := strings.TrimSpace(request.GetString("channel_id", ""))
+	if input == "" {
+		return nil, errors.New("channel_id must be a string")
+	}
+	limit := request.GetInt("limit", defaultChannelMembersLimit)
+	if limit < 1 || limit > maxChannelMembersLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d, got %d", maxChannelMembersLimit, limit)
+	}
+	resolve := request.GetBool("resolve_users", false)
+	channelID, err := ch.resolveChannelID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var api channelMembersAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			ch.logger.Error("Failed to get Slack client", zap.Error(err))
+			return nil, fmt.Errorf("authentication error: %w", err)
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	members, nextCursor, err := api.GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
+		ChannelID: channelID,
+		Cursor:    request.GetString("cursor", ""),
+		Limit:     limit,
+	})
+	if err != nil {
+		switch {
+		case isSlackError(err, "channel_not_found"):
+			// Slack does not tell apart missing channels from private ones the token is not in
+			return nil, fmt.Errorf("channel %s not found or not accessible: private channels can only be listed by their members: %w", channelID, err)
+		case isSlackError(err, "invalid_cursor"):
+			return nil, fmt.Errorf("invalid cursor, pass the cursor of the last row of the previous page: %w", err)
+		case isMissingScope(err):
+			return nil, fmt.Errorf("channels_members requires the channels:read scope (groups:read, im:read and mpim:read for private channels and DMs): %w", err)
+		}
+		ch.logger.Error("Slack GetUsersInConversationContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	usersMap := map[string]slack.User{}
+	if resolve && !ch.oauthEnabled {
+		ch.apiProvider.ResolveUsers(members)
+		usersMap = ch.apiProvider.ProvideUsersMap().Users
+	}
+	rows := make([]ChannelMember, 0, len(members))
+	for _, id := range members {
+		row := ChannelMember{UserID: id}
+		if u, ok := usersMap[id]; ok {
+			row.UserName = u.Name
+			row.RealName = u.RealName
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) > 0 {
+		rows[len(rows)-1].Cursor = nextCursor
+	}
+
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal channel members to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
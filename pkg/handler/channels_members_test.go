@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitChannelsMembers(t *testing.T) {
+	var calls []slack.GetUsersInConversationParameters
+	api := &fakeSlackAPI{inChannel: func(params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+		calls = append(calls, *params)
+		if params.Cursor == "" {
+			return []string{"U1", "U2"}, "dXNlcjpVMw==", nil
+		}
+		return []string{"U3"}, "", nil
+	}}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	p.ProvideUsersMap().Users["U1"] = slack.User{ID: "U1", Name: "alice", RealName: "Alice Doe"}
+	p.ProvideUsersMap().Users["U2"] = slack.User{ID: "U2", Name: "bob", RealName: "Bob Roe"}
+	maps := p.ProvideChannelsMaps()
+	maps.Channels["C1"] = provider.Channel{ID: "C1", Name: "#ops"}
+	maps.ChannelsInv["#ops"] = "C1"
+	ch := NewChannelsHandler(p, zap.NewNop())
+
+	res, err := ch.ChannelsMembersHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "#ops", "limit": 2}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, slack.GetUsersInConversationParameters{ChannelID: "C1", Limit: 2}, calls[0])
+	assert.Equal(t, []string{"U1", "U2"}, csvColumn(t, out, "UserID"))
+	assert.Equal(t, []string{"", ""}, csvColumn(t, out, "UserName"), "names are resolved on request only")
+	assert.Equal(t, []string{"", "dXNlcjpVMw=="}, csvColumn(t, out, "Cursor"))
+
+	res, err = ch.ChannelsMembersHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1", "resolve_users": true}))
+	require.NoError(t, err)
+	out = toolResultText(t, res)
+	assert.Equal(t, []string{"alice", "bob"}, csvColumn(t, out, "UserName"))
+	assert.Equal(t, []string{"Alice Doe", "Bob Roe"}, csvColumn(t, out, "RealName"))
+
+	res, err = ch.ChannelsMembersHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1", "cursor": "dXNlcjpVMw=="}))
+	require.NoError(t, err)
+	out = toolResultText(t, res)
+	assert.Equal(t, "dXNlcjpVMw==", calls[2].Cursor)
+	assert.Equal(t, []string{"U3"}, csvColumn(t, out, "UserID"))
+	assert.Equal(t, []string{""}, csvColumn(t, out, "Cursor"), "the last page has no cursor")
+}
+
+func TestUnitChannelsMembersErrors(t *testing.T) {
+	for code, want := range map[string]string{
+		"channel_not_found": "not accessible",
+		"invalid_cursor":    "invalid cursor",
+		"missing_scope":     "channels:read",
+	} {
+		api := &fakeSlackAPI{inChannel: func(params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+			return nil, "", slack.SlackErrorResponse{Err: code}
+		}}
+		ch := NewChannelsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+		_, err := ch.ChannelsMembersHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1"}))
+		require.Error(t, err, code)
+		assert.Contains(t, err.Error(), want)
+	}
+
+	ch := NewChannelsHandler(provider.NewWithClient("stdio", &fakeSlackAPI{}, zap.NewNop()), zap.NewNop())
+	_, err := ch.ChannelsMembersHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1", "limit": 1001}))
+	assert.Error(t, err)
+	_, err = ch.ChannelsMembersHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "#unknown"}))
+	assert.Error(t, err)
+}
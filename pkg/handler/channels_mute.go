@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// setNotificationsMethod is the Slack method behind the channel notification
+// preferences of the Slack clients. It is not part of the public Web API and
+// no OAuth scope unlocks it, so it only works with user tokens that Slack
+// allows to call it, such as browser session tokens.
+const setNotificationsMethod = "users.prefs.setNotifications"
+
+// unavailableMethodErrors are the Slack errors returned when a method is not
+// available to the token at all, as opposed to failures of the call
+var unavailableMethodErrors = map[string]bool{
+	"unknown_method":         true,
+	"not_allowed_token_type": true,
+	"missing_scope":          true,
+	"method_deprecated":      true,
+}
+
+type ChannelNotificationPref struct {
+	ChannelID string `json:"channelID"`
+	Muted     bool   `json:"muted"`
+}
+
+// ChannelsSetMutedHandler mutes or unmutes a channel for the authenticated user
+func (ch *ChannelsHandler) ChannelsSetMutedHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsSetMutedHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	if err := channelsWriteEnabled("channels_set_muted"); err != nil {
+		return nil, err
+	}
+
+	channel := strings.TrimSpace(request.GetString("channel_id", ""))
+	if channel == "" {
+		return nil, errors.New("channel_id must be a string")
+	}
+	args := request.GetArguments()
+	if _, ok := args["mute"].(bool); !ok {
+		return nil, errors.New("mute must be a boolean")
+	}
+	mute := request.GetBool("mute", false)
+
+	params := url.Values{}
+	params.Set("channel_id", channel)
+	params.Set("name", "muted")
+	params.Set("value", strconv.FormatBool(mute))
+	params.Set("global", "false")
+
+	var (
+		body json.RawMessage
+		err  error
+	)
+	if ch.oauthEnabled {
+		if strings.HasPrefix(channel, "#") {
+			return nil, fmt.Errorf("in OAuth mode, please use channel ID (C...) instead of name (%s)", channel)
+		}
+		// notification preferences belong to the user, never use the bot token here
+		userCtx, ok := auth.FromContext(ctx)
+		if !ok {
+			return nil, fmt.Errorf("user context not found")
+		}
+		client := &http.Client{Timeout: 30 * time.Second, Transport: oauthHTTPClient.Transport}
+		body, err = provider.CallMethod(ctx, client, "https://slack.com/api/", userCtx.AccessToken, setNotificationsMethod, params)
+	} else {
+		if strings.HasPrefix(channel, "#") {
+			channelsMaps := ch.apiProvider.ProvideChannelsMaps()
+			chn, ok := channelsMaps.ChannelsInv[channel]
+			if !ok {
+				return nil, fmt.Errorf("channel %q not found", channel)
+			}
+			channel = channelsMaps.Channels[chn].ID
+			params.Set("channel_id", channel)
+		}
+		body, err = ch.apiProvider.Slack().CallMethodContext(ctx, setNotificationsMethod, params)
+	}
+	if err != nil {
+		ch.logger.Error("Slack method call failed", zap.String("method", setNotificationsMethod), zap.Error(err))
+		return nil, errors.New(redactTokens(err.Error()))
+	}
+
+	var status struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, fmt.Errorf("failed to parse %s response: %w", setNotificationsMethod, err)
+	}
+	if !status.OK {
+		if unavailableMethodErrors[status.Error] {
+			// not a failure of the server, the token just cannot do this
+			return mcp.NewToolResultError(fmt.Sprintf("channel notification preferences cannot be changed with this token (%s). "+
+				"Slack only allows user tokens of its own clients, such as browser session tokens (xoxc/xoxd), to change them, "+
+				"mute the channel from the Slack app instead", status.Error)), nil
+		}
+		if status.Error == "channel_not_found" {
+			return nil, fmt.Errorf("channel %s not found", channel)
+		}
+		return nil, fmt.Errorf("slack error: %s: %s", setNotificationsMethod, status.Error)
+	}
+
+	prefs := []ChannelNotificationPref{{
+		ChannelID: channel,
+		Muted:     mute,
+	}}
+	csvBytes, err := gocsv.MarshalBytes(&prefs)
+	if err != nil {
+		ch.logger.Error("Failed to marshal notification preference to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitChannelsSetMuted(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "true")
+	var got url.Values
+	api := &fakeSlackAPI{call: func(method string, params url.Values) (json.RawMessage, error) {
+		assert.Equal(t, "users.prefs.setNotifications", method)
+		got = params
+		return json.RawMessage(`{"ok":true}`), nil
+	}}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	maps := p.ProvideChannelsMaps()
+	maps.Channels["C1"] = provider.Channel{ID: "C1", Name: "#noisy"}
+	maps.ChannelsInv["#noisy"] = "C1"
+	ch := NewChannelsHandler(p, zap.NewNop())
+
+	res, err := ch.ChannelsSetMutedHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "#noisy", "mute": true}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"C1"}, csvColumn(t, out, "ChannelID"))
+	assert.Equal(t, []string{"true"}, csvColumn(t, out, "Muted"))
+	assert.Equal(t, "C1", got.Get("channel_id"))
+	assert.Equal(t, "muted", got.Get("name"))
+	assert.Equal(t, "true", got.Get("value"))
+
+	res, err = ch.ChannelsSetMutedHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1", "mute": false}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"false"}, csvColumn(t, toolResultText(t, res), "Muted"))
+	assert.Equal(t, "false", got.Get("value"))
+}
+
+func TestUnitChannelsSetMutedErrors(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "true")
+	reply := `{"ok":false,"error":"not_allowed_token_type"}`
+	api := &fakeSlackAPI{call: func(string, url.Values) (json.RawMessage, error) {
+		return json.RawMessage(reply), nil
+	}}
+	ch := NewChannelsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	// a token that cannot change preferences gets a message, not a failure
+	res, err := ch.ChannelsSetMutedHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1", "mute": true}))
+	require.NoError(t, err)
+	assert.True(t, res.IsError)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "not_allowed_token_type")
+
+	reply = `{"ok":false,"error":"channel_not_found"}`
+	_, err = ch.ChannelsSetMutedHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C404", "mute": true}))
+	assert.ErrorContains(t, err, "channel C404 not found")
+
+	_, err = ch.ChannelsSetMutedHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1"}))
+	assert.ErrorContains(t, err, "mute must be a boolean")
+}
+
+func TestUnitChannelsSetMutedDisabled(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "")
+	called := false
+	api := &fakeSlackAPI{call: func(string, url.Values) (json.RawMessage, error) {
+		called = true
+		return json.RawMessage(`{"ok":true}`), nil
+	}}
+	ch := NewChannelsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	_, err := ch.ChannelsSetMutedHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1", "mute": true}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SLACK_MCP_CHANNELS_WRITE_TOOL")
+	assert.False(t, called)
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type ChannelRenamed struct {
+	ChannelID string `json:"channelID"`
+	Name      string `json:"name"`
+	IsPrivate bool   `json:"isPrivate"`
+}
+
+// channelsRenameAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type channelsRenameAPI interface {
+	RenameConversationContext(ctx context.Context, channelID, channelName string) (*slack.Channel, error)
+}
+
+// ChannelsRenameHandler renames a channel with conversations.rename. The name
+// is checked against Slack's rules first and the channel is renamed in the
+// channels cache so that the new name can be used right away.
+func (ch *ChannelsHandler) ChannelsRenameHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsRenameHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	if err := channelsWriteEnabled("channels_rename"); err != nil {
+		return nil, err
+	}
+
+	input := strings.TrimSpace(request.GetString("channel_id", ""))
+	if input == "" {
+		return nil, errors.New("channel_id must be a string")
+	}
+	if strings.TrimSpace(request.GetString("name", "")) == "" {
+		return nil, errors.New("name must be a string")
+	}
+	name, err := text.ValidateChannelName(request.GetString("name", ""))
+	if err != nil {
+		return nil, err
+	}
+	channelID, err := ch.resolveChannelID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var api channelsRenameAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			ch.logger.Error("Failed to get Slack client", zap.Error(err))
+			return nil, fmt.Errorf("authentication error: %w", err)
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	channel, err := api.RenameConversationContext(ctx, channelID, name)
+	if err != nil {
+		switch {
+		case isSlackError(err, "name_taken"):
+			return nil, fmt.Errorf("a channel named %q already exists, archived channels keep their name too: %w", name, err)
+		case isSlackError(err, "invalid_name_required"), isSlackError(err, "invalid_name_specials"),
+			isSlackError(err, "invalid_name_punctuation"), isSlackError(err, "invalid_name_maxlength"), isSlackError(err, "invalid_name"):
+			return nil, fmt.Errorf("invalid channel name %q: names must be lowercase, at most 80 characters, with letters, numbers, hyphens and underscores only: %w", name, err)
+		case isSlackError(err, "channel_not_found"):
+			return nil, fmt.Errorf("channel %s not found or not accessible: %w", channelID, err)
+		case isSlackError(err, "is_archived"):
+			return nil, fmt.Errorf("channel %s is archived, unarchive it before renaming it: %w", channelID, err)
+		case isSlackError(err, "not_in_channel"):
+			return nil, fmt.Errorf("only members can rename channel %s, join it first: %w", channelID, err)
+		case isSlackError(err, "not_authorized"), isSlackError(err, "restricted_action"):
+			return nil, fmt.Errorf("the workspace only allows the creator of channel %s or admins to rename it: %w", channelID, err)
+		case isMissingScope(err):
+			return nil, fmt.Errorf("renaming channels requires the channels:write scope (groups:write for private channels), add it to the Slack app and reinstall it: %w", err)
+		}
+		ch.logger.Error("Slack RenameConversationContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	row := ChannelRenamed{ChannelID: channelID, Name: "#" + name}
+	if channel != nil {
+		if channel.Name != "" {
+			name = channel.Name
+			row.Name = "#" + channel.Name
+		}
+		row.IsPrivate = channel.IsPrivate
+	}
+
+	if ch.oauthEnabled {
+		if userCtx, ok := auth.FromContext(ctx); ok {
+			ch.teamChannels.Rename(userCtx.TeamID, userCtx.UserID, channelID, name)
+		}
+	} else {
+		ch.apiProvider.RenameChannel(channelID, name)
+	}
+
+	rows := []ChannelRenamed{row}
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal renamed channel to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
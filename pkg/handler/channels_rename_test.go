@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitChannelsRename(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "true")
+
+	api := &fakeSlackAPI{rename: func(channelID, name string) (*slack.Channel, error) {
+		assert.Equal(t, "C1", channelID)
+		assert.Equal(t, "incident-42-resolved", name)
+		return newCreatedChannel(channelID, name, false), nil
+	}}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	maps := p.ProvideChannelsMaps()
+	maps.Channels["C1"] = provider.Channel{ID: "C1", Name: "#incident-42"}
+	maps.ChannelsInv["#incident-42"] = "C1"
+	ch := NewChannelsHandler(p, zap.NewNop())
+
+	res, err := ch.ChannelsRenameHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "#incident-42",
+		"name":       "#incident-42-resolved",
+	}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"C1"}, csvColumn(t, out, "ChannelID"))
+	assert.Equal(t, []string{"#incident-42-resolved"}, csvColumn(t, out, "Name"))
+
+	maps = p.ProvideChannelsMaps()
+	assert.Equal(t, "#incident-42-resolved", maps.Channels["C1"].Name, "cached channel is renamed")
+	assert.Equal(t, "C1", maps.ChannelsInv["#incident-42-resolved"])
+	assert.NotContains(t, maps.ChannelsInv, "#incident-42")
+}
+
+func TestUnitChannelsRenameValidatesName(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "true")
+
+	api := &fakeSlackAPI{rename: func(channelID, name string) (*slack.Channel, error) {
+		t.Fatal("invalid names must not reach Slack")
+		return nil, nil
+	}}
+	ch := NewChannelsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	for _, name := range []string{"Incident", "incident 42", "v1.2", " "} {
+		_, err := ch.ChannelsRenameHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1", "name": name}))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestUnitChannelsRenameErrors(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "true")
+
+	for code, want := range map[string]string{
+		"name_taken":     "already exists",
+		"not_authorized": "creator",
+		"is_archived":    "archived",
+		"missing_scope":  "channels:write",
+	} {
+		api := &fakeSlackAPI{rename: func(channelID, name string) (*slack.Channel, error) {
+			return nil, slack.SlackErrorResponse{Err: code}
+		}}
+		ch := NewChannelsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+		_, err := ch.ChannelsRenameHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1", "name": "launch"}))
+		require.Error(t, err, code)
+		assert.Contains(t, err.Error(), want)
+	}
+}
+
+func TestUnitChannelsRenameDisabledByDefault(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "")
+
+	ch := NewChannelsHandler(provider.NewWithClient("stdio", &fakeSlackAPI{}, zap.NewNop()), zap.NewNop())
+	_, err := ch.ChannelsRenameHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1", "name": "launch"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SLACK_MCP_CHANNELS_WRITE_TOOL")
+}
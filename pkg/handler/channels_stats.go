@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// channelStatsTotal is the Type of the row summing up all conversation types
+const channelStatsTotal = "total"
+
+// statsChanTypes are the conversation types of channels_stats in output order
+var statsChanTypes = []string{provider.PubChanType, provider.PrivateChanType, "im", "mpim"}
+
+type ChannelTypeStats struct {
+	Type     string `json:"type"`
+	Total    int    `json:"total"`
+	Active   int    `json:"active"`
+	Archived int    `json:"archived"`
+}
+
+// ChannelsStatsHandler counts channels per conversation type. It answers from
+// the channel cache, which holds archived channels too.
+func (ch *ChannelsHandler) ChannelsStatsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsStatsHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	includeArchived := request.GetBool("include_archived", false)
+
+	var api channelsExportAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			ch.logger.Error("Failed to get Slack client", zap.Error(err))
+			return nil, fmt.Errorf("authentication error: %w", err)
+		}
+		api = client
+	} else {
+		if ready, err := ch.apiProvider.IsReady(); !ready {
+			ch.logger.Error("API provider not ready", zap.Error(err))
+			return nil, err
+		}
+		api = ch.apiProvider.Slack()
+	}
+
+	channels, err := ch.exportCandidates(ctx, api, statsChanTypes, includeArchived)
+	if err != nil {
+		ch.logger.Error("Failed to get channels", zap.Error(err))
+		return nil, fmt.Errorf("failed to get channels: %w", err)
+	}
+
+	stats := channelTypeStats(channels)
+	csvBytes, err := gocsv.MarshalBytes(&stats)
+	if err != nil {
+		ch.logger.Error("Failed to marshal channel stats to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// channelTypeStats counts channels per conversation type, followed by a total row
+func channelTypeStats(channels []provider.Channel) []ChannelTypeStats {
+	byType := make(map[string]*ChannelTypeStats, len(statsChanTypes))
+	stats := make([]ChannelTypeStats, len(statsChanTypes)+1)
+	for i, t := range statsChanTypes {
+		stats[i].Type = t
+		byType[t] = &stats[i]
+	}
+	total := &stats[len(statsChanTypes)]
+	total.Type = channelStatsTotal
+
+	for _, c := range channels {
+		for _, s := range []*ChannelTypeStats{byType[channelType(c)], total} {
+			s.Total++
+			if c.IsArchived {
+				s.Archived++
+			} else {
+				s.Active++
+			}
+		}
+	}
+	return stats
+}
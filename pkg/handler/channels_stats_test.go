@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitChannelTypeStats(t *testing.T) {
+	channels := []provider.Channel{
+		{ID: "C1"},
+		{ID: "C2"},
+		{ID: "C3", IsArchived: true},
+		{ID: "G1", IsPrivate: true},
+		{ID: "D1", IsIM: true, IsPrivate: true},
+		{ID: "D2", IsIM: true, IsPrivate: true},
+		{ID: "G2", IsMpIM: true, IsPrivate: true, IsArchived: true},
+	}
+
+	assert.Equal(t, []ChannelTypeStats{
+		{Type: "public_channel", Total: 3, Active: 2, Archived: 1},
+		{Type: "private_channel", Total: 1, Active: 1},
+		{Type: "im", Total: 2, Active: 2},
+		{Type: "mpim", Total: 1, Archived: 1},
+		{Type: "total", Total: 7, Active: 5, Archived: 2},
+	}, channelTypeStats(channels))
+}
+
+func TestUnitChannelTypeStatsEmpty(t *testing.T) {
+	stats := channelTypeStats(nil)
+	assert.Len(t, stats, 5, "every type is listed, even without channels")
+	for _, s := range stats {
+		assert.Zero(t, s.Total, s.Type)
+	}
+}
@@ -12,10 +12,14 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
 	"github.com/korotovsky/slack-mcp-server/pkg/test/util"
 	"github.com/openai/openai-go/packages/param"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/option"
@@ -219,3 +223,150 @@ func TestIntegrationChannelsList(t *testing.T) {
 		})
 	}
 }
+
+func TestUnitParseChannelTypes(t *testing.T) {
+	t.Run("de-duplicates and ignores invalid types", func(t *testing.T) {
+		ch := NewChannelsHandler(nil, zap.NewNop())
+		types, err := ch.parseChannelTypes("im, public_channel,im,bogus,public_channel")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"im", "public_channel"}, types)
+	})
+
+	t.Run("defaults when no valid types provided", func(t *testing.T) {
+		ch := NewChannelsHandler(nil, zap.NewNop())
+		types, err := ch.parseChannelTypes("")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"public_channel", "private_channel"}, types)
+	})
+
+	t.Run("rejects more distinct types than allowed", func(t *testing.T) {
+		t.Setenv("SLACK_MCP_MAX_CHANNEL_TYPES", "2")
+		ch := NewChannelsHandler(nil, zap.NewNop())
+		_, err := ch.parseChannelTypes("im,mpim,public_channel")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "at most 2")
+
+		types, err := ch.parseChannelTypes("im,mpim,im,mpim")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"im", "mpim"}, types)
+	})
+
+	t.Run("invalid configuration falls back to default", func(t *testing.T) {
+		t.Setenv("SLACK_MCP_MAX_CHANNEL_TYPES", "zero")
+		ch := NewChannelsHandler(nil, zap.NewNop())
+		types, err := ch.parseChannelTypes("im,mpim,public_channel,private_channel")
+		require.NoError(t, err)
+		assert.Len(t, types, 4)
+	})
+}
+
+func TestUnitNormalizeChannelName(t *testing.T) {
+	cases := []struct {
+		name    string
+		channel provider.Channel
+		want    string
+	}{
+		{"public channel", provider.Channel{Name: "#general"}, "#general"},
+		{"public channel without prefix", provider.Channel{Name: "general"}, "#general"},
+		{"private channel", provider.Channel{Name: "#secret", IsPrivate: true}, "#secret"},
+		{"private channel without prefix", provider.Channel{Name: "secret", IsPrivate: true}, "#secret"},
+		{"im", provider.Channel{Name: "@john", IsIM: true, IsPrivate: true}, "@john"},
+		{"im without prefix", provider.Channel{Name: "U1234567890", IsIM: true}, "@U1234567890"},
+		{"mpim", provider.Channel{Name: "@mpdm-john--jane-1", IsMpIM: true, IsPrivate: true}, "@mpdm-john--jane-1"},
+		{"mpim without prefix", provider.Channel{Name: "mpdm-john--jane-1", IsMpIM: true}, "@mpdm-john--jane-1"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, normalizeChannelName(tc.channel))
+		})
+	}
+}
+
+func TestUnitSortChannelsByPopularity(t *testing.T) {
+	ids := func(channels []Channel) []string {
+		var out []string
+		for _, c := range channels {
+			out = append(out, c.ID)
+		}
+		return out
+	}
+
+	want := []string{"C3", "C1", "C2", "C5", "C4"}
+	for _, order := range [][]string{{"C1", "C2", "C3", "C4", "C5"}, {"C5", "C4", "C3", "C2", "C1"}, {"C2", "C4", "C1", "C5", "C3"}} {
+		members := map[string]int{"C1": 10, "C2": 10, "C3": 50, "C4": 1, "C5": 10}
+		var channels []Channel
+		for _, id := range order {
+			channels = append(channels, Channel{ID: id, MemberCount: members[id]})
+		}
+		sortChannelsByPopularity(channels)
+		assert.Equal(t, want, ids(channels), "ties are ordered by ID whatever the input order %v", order)
+	}
+}
+
+func TestUnitChannelHelpersUseInjectedLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(core).With(zap.String("request_id", "req-1"))
+
+	channels := map[string]provider.Channel{
+		"C1": {ID: "C1", Name: "general"},
+		"C2": {ID: "C2", Name: "random"},
+	}
+	filtered := filterChannelsByTypes(channels, []string{provider.PubChanType}, logger)
+	paged, _ := paginateChannels(filtered, "", 1, logger)
+	require.Len(t, paged, 1)
+
+	for _, msg := range []string{"Channel filtering complete", "Pagination complete"} {
+		entries := logs.FilterMessage(msg).All()
+		require.Len(t, entries, 1, msg)
+		assert.Equal(t, "req-1", entries[0].ContextMap()["request_id"], msg)
+	}
+}
+
+func TestUnitFilterChannelsByQuery(t *testing.T) {
+	channels := []provider.Channel{
+		{ID: "C1", Name: "#incident-41", Topic: "Resolved"},
+		{ID: "C2", Name: "#incident-42", Purpose: "Checkout outage"},
+		{ID: "C3", Name: "#general", Topic: "Company news"},
+		{ID: "C4", Name: "#ops", Topic: "Incident response rota"},
+	}
+	ids := func(channels []provider.Channel) []string {
+		var out []string
+		for _, c := range channels {
+			out = append(out, c.ID)
+		}
+		return out
+	}
+
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"C1", "C2", "C3", "C4"}},
+		{"incident", []string{"C1", "C2", "C4"}},
+		{"#INCIDENT-4", []string{"C1", "C2"}},
+		{"outage", []string{"C2"}},
+		{"incident-*", []string{"C1", "C2"}},
+		{"#incident-4?", []string{"C1", "C2"}},
+		{"incident-4[2-9]", []string{"C2"}},
+		{"*news", []string{"C3"}},
+		{"nothing", nil},
+	}
+	for _, tc := range cases {
+		got, err := filterChannelsByQuery(channels, tc.query)
+		require.NoError(t, err, tc.query)
+		assert.Equal(t, tc.want, ids(got), tc.query)
+	}
+
+	_, err := filterChannelsByQuery(channels, "incident-[")
+	assert.Error(t, err, "malformed globs are rejected")
+
+	// the query applies before pagination, a page holds matches only
+	matched, err := filterChannelsByQuery(channels, "incident")
+	require.NoError(t, err)
+	paged, next := paginateChannels(matched, "", 2, zap.NewNop())
+	assert.Equal(t, []string{"C1", "C2"}, ids(paged))
+	paged, next = paginateChannels(matched, next, 2, zap.NewNop())
+	assert.Equal(t, []string{"C4"}, ids(paged))
+	assert.Empty(t, next)
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// maxChannelTopicLen is the number of characters Slack allows in the topic
+// and the purpose of a channel
+const maxChannelTopicLen = 250
+
+type ChannelDescription struct {
+	ChannelID string `json:"channelID"`
+	Topic     string `json:"topic"`
+	Purpose   string `json:"purpose"`
+}
+
+// channelsTopicAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type channelsTopicAPI interface {
+	SetTopicOfConversationContext(ctx context.Context, channelID, topic string) (*slack.Channel, error)
+	SetPurposeOfConversationContext(ctx context.Context, channelID, purpose string) (*slack.Channel, error)
+}
+
+// ChannelsSetTopicHandler sets the topic of a channel, e.g. the current
+// status of an incident channel
+func (ch *ChannelsHandler) ChannelsSetTopicHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsSetTopicHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+	return ch.setChannelDescription(ctx, request, true)
+}
+
+// ChannelsSetPurposeHandler sets the purpose of a channel
+func (ch *ChannelsHandler) ChannelsSetPurposeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsSetPurposeHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+	return ch.setChannelDescription(ctx, request, false)
+}
+
+// setChannelDescription sets the topic or the purpose of a channel, an empty
+// value clears it, and updates the cached channel
+func (ch *ChannelsHandler) setChannelDescription(ctx context.Context, request mcp.CallToolRequest, topic bool) (*mcp.CallToolResult, error) {
+	tool, field := "channels_set_topic", "topic"
+	if !topic {
+		tool, field = "channels_set_purpose", "purpose"
+	}
+	if err := channelsWriteEnabled(tool); err != nil {
+		return nil, err
+	}
+
+	input := strings.TrimSpace(request.GetString("channel_id", ""))
+	if input == "" {
+		return nil, errors.New("channel_id must be a string")
+	}
+	value, ok := request.GetArguments()[field].(string)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a string, an empty string clears it", field)
+	}
+	value = strings.TrimSpace(value)
+	if n := len([]rune(value)); n > maxChannelTopicLen {
+		return nil, fmt.Errorf("%s is %d characters long, Slack allows at most %d", field, n, maxChannelTopicLen)
+	}
+	channelID, err := ch.resolveChannelID(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var api channelsTopicAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			ch.logger.Error("Failed to get Slack client", zap.Error(err))
+			return nil, fmt.Errorf("authentication error: %w", err)
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	var channel *slack.Channel
+	if topic {
+		channel, err = api.SetTopicOfConversationContext(ctx, channelID, value)
+	} else {
+		channel, err = api.SetPurposeOfConversationContext(ctx, channelID, value)
+	}
+	if err != nil {
+		switch {
+		case isSlackError(err, "is_archived"):
+			return nil, fmt.Errorf("channel %s is archived, unarchive it before changing its %s: %w", channelID, field, err)
+		case isSlackError(err, "not_in_channel"):
+			return nil, fmt.Errorf("only members can change the %s of channel %s, join it first: %w", field, channelID, err)
+		case isSlackError(err, "too_long"):
+			return nil, fmt.Errorf("%s is too long for Slack: %w", field, err)
+		case isMissingScope(err):
+			return nil, fmt.Errorf("changing the %s of channels requires the channels:write scope (groups:write for private channels), add it to the Slack app and reinstall it: %w", field, err)
+		}
+		if topic {
+			ch.logger.Error("Slack SetTopicOfConversationContext failed", zap.Error(err))
+		} else {
+			ch.logger.Error("Slack SetPurposeOfConversationContext failed", zap.Error(err))
+		}
+		return nil, err
+	}
+
+	row := ChannelDescription{ChannelID: channelID}
+	if channel != nil {
+		row.Topic = channel.Topic.Value
+		row.Purpose = channel.Purpose.Value
+	}
+	if topic {
+		row.Topic = value
+	} else {
+		row.Purpose = value
+	}
+
+	if ch.oauthEnabled {
+		if userCtx, ok := auth.FromContext(ctx); ok {
+			if topic {
+				ch.teamChannels.SetTopic(userCtx.TeamID, userCtx.UserID, channelID, value)
+			} else {
+				ch.teamChannels.SetPurpose(userCtx.TeamID, userCtx.UserID, channelID, value)
+			}
+		}
+	} else if topic {
+		ch.apiProvider.SetChannelTopic(channelID, value)
+	} else {
+		ch.apiProvider.SetChannelPurpose(channelID, value)
+	}
+
+	rows := []ChannelDescription{row}
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal channel description to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
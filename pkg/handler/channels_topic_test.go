@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitChannelsSetTopicAndPurpose(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "true")
+
+	channel := newCreatedChannel("C1", "incident-42", false)
+	channel.Purpose.Value = "Checkout outage"
+	api := &fakeSlackAPI{
+		topic: func(channelID, topic string) (*slack.Channel, error) {
+			channel.Topic.Value = topic
+			return channel, nil
+		},
+		purpose: func(channelID, purpose string) (*slack.Channel, error) {
+			channel.Purpose.Value = purpose
+			return channel, nil
+		},
+	}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	maps := p.ProvideChannelsMaps()
+	maps.Channels["C1"] = provider.Channel{ID: "C1", Name: "#incident-42", Purpose: "Checkout outage"}
+	maps.ChannelsInv["#incident-42"] = "C1"
+	ch := NewChannelsHandler(p, zap.NewNop())
+
+	res, err := ch.ChannelsSetTopicHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "#incident-42",
+		"topic":      " Status: mitigated ",
+	}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"C1"}, csvColumn(t, out, "ChannelID"))
+	assert.Equal(t, []string{"Status: mitigated"}, csvColumn(t, out, "Topic"))
+	assert.Equal(t, []string{"Checkout outage"}, csvColumn(t, out, "Purpose"))
+	assert.Equal(t, "Status: mitigated", p.ProvideChannelsMaps().Channels["C1"].Topic, "cached topic is updated")
+
+	res, err = ch.ChannelsSetPurposeHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1",
+		"purpose":    "",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{""}, csvColumn(t, toolResultText(t, res), "Purpose"), "an empty purpose clears it")
+	assert.Empty(t, p.ProvideChannelsMaps().Channels["C1"].Purpose)
+}
+
+func TestUnitChannelsSetTopicErrors(t *testing.T) {
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "true")
+
+	for code, want := range map[string]string{
+		"is_archived":    "archived",
+		"not_in_channel": "join it first",
+		"missing_scope":  "channels:write",
+	} {
+		api := &fakeSlackAPI{topic: func(channelID, topic string) (*slack.Channel, error) {
+			return nil, slack.SlackErrorResponse{Err: code}
+		}}
+		ch := NewChannelsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+		_, err := ch.ChannelsSetTopicHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1", "topic": "x"}))
+		require.Error(t, err, code)
+		assert.Contains(t, err.Error(), want)
+	}
+
+	ch := NewChannelsHandler(provider.NewWithClient("stdio", &fakeSlackAPI{}, zap.NewNop()), zap.NewNop())
+	_, err := ch.ChannelsSetTopicHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1"}))
+	assert.Error(t, err, "a missing topic is not taken for clearing it")
+	_, err = ch.ChannelsSetTopicHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1", "topic": strings.Repeat("a", 251)}))
+	assert.ErrorContains(t, err, "at most 250")
+
+	t.Setenv("SLACK_MCP_CHANNELS_WRITE_TOOL", "")
+	_, err = ch.ChannelsSetPurposeHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1", "purpose": "x"}))
+	assert.ErrorContains(t, err, "SLACK_MCP_CHANNELS_WRITE_TOOL")
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// maxUnreadsChannels is the number of channels channels_unreads looks up at once,
+// one conversations.info call each
+const maxUnreadsChannels = 50
+
+// ChannelUnreads is the read state of a channel for the authenticated user.
+// A row with a Note reports a channel that could not be looked up.
+type ChannelUnreads struct {
+	ChannelID    string `json:"channelID"`
+	Name         string `json:"name"`
+	UnreadCount  int    `json:"unreadCount"`
+	LastRead     string `json:"lastRead"`
+	LastReadTime string `json:"lastReadTime"`
+	Note         string `json:"note"`
+}
+
+// conversationInfoAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type conversationInfoAPI interface {
+	GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error)
+}
+
+// ChannelsUnreadsHandler returns last_read and unread_count_display of
+// conversations.info for each given channel, channels with the most unread
+// messages first, so that channels and DMs needing attention stand out
+func (ch *ChannelsHandler) ChannelsUnreadsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ChannelsUnreadsHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	inputs := splitChannelIDs(request.GetString("channel_ids", ""))
+	if len(inputs) == 0 {
+		return nil, errors.New("channel_ids must contain at least one channel")
+	}
+	if len(inputs) > maxUnreadsChannels {
+		return nil, fmt.Errorf("too many channels: %d, at most %d channels can be looked up at once", len(inputs), maxUnreadsChannels)
+	}
+
+	var (
+		api      conversationInfoAPI
+		channels map[string]provider.Channel
+	)
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			ch.logger.Error("Failed to get Slack client", zap.Error(err))
+			return nil, fmt.Errorf("authentication error: %w", err)
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+		channels = ch.apiProvider.ProvideChannelsMaps().Channels
+	}
+
+	rows := make([]ChannelUnreads, 0, len(inputs))
+	for _, input := range inputs {
+		id, err := ch.resolveChannelID(input)
+		if err != nil {
+			rows = append(rows, ChannelUnreads{ChannelID: input, Note: "error: " + err.Error()})
+			continue
+		}
+
+		info, err := api.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{ChannelID: id})
+		if err != nil {
+			ch.logger.Warn("Slack GetConversationInfoContext failed", zap.String("channel", id), zap.Error(err))
+			if isMissingScope(err) {
+				return nil, fmt.Errorf("channels_unreads requires the channels:read scope (groups:read, im:read and mpim:read for private channels and DMs): %w", err)
+			}
+			rows = append(rows, ChannelUnreads{ChannelID: id, Note: "error: " + err.Error()})
+			continue
+		}
+
+		name := info.Name
+		if info.IsIM {
+			name = info.User
+		}
+		if cached, ok := channels[info.ID]; ok && cached.Name != "" {
+			name = cached.Name
+		}
+		row := ChannelUnreads{
+			ChannelID:   info.ID,
+			Name:        normalizeChannelName(provider.Channel{Name: name, IsIM: info.IsIM, IsMpIM: info.IsMpIM}),
+			UnreadCount: info.UnreadCountDisplay,
+			LastRead:    info.LastRead,
+		}
+		if info.LastRead != "" && info.LastRead != "0000000000.000000" {
+			if t, err := text.TimestampToIsoRFC3339(info.LastRead); err == nil {
+				row.LastReadTime = t
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	// most unread first, failed lookups last
+	sort.SliceStable(rows, func(i, j int) bool {
+		if (rows[i].Note == "") != (rows[j].Note == "") {
+			return rows[i].Note == ""
+		}
+		return rows[i].UnreadCount > rows[j].UnreadCount
+	})
+
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal unreads to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// resolveChannelID resolves a channel name of the cache to its ID, names are
+// not available in OAuth mode
+func (ch *ChannelsHandler) resolveChannelID(channel string) (string, error) {
+	if !strings.HasPrefix(channel, "#") && !strings.HasPrefix(channel, "@") {
+		return channel, nil
+	}
+	if ch.oauthEnabled {
+		return "", fmt.Errorf("in OAuth mode, please use channel ID (C...) instead of name (%s)", channel)
+	}
+	channelsMaps := ch.apiProvider.ProvideChannelsMaps()
+	chn, ok := channelsMaps.ChannelsInv[channel]
+	if !ok {
+		return "", fmt.Errorf("channel %q not found", channel)
+	}
+	return channelsMaps.Channels[chn].ID, nil
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitChannelsUnreads(t *testing.T) {
+	api := &fakeSlackAPI{info: func(input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+		var c slack.Channel
+		c.ID = input.ChannelID
+		switch input.ChannelID {
+		case "C1":
+			c.Name = "general"
+			c.LastRead = "1700000000.000100"
+			c.UnreadCountDisplay = 3
+		case "D1":
+			c.IsIM = true
+			c.User = "U2"
+			c.LastRead = "1700000500.000100"
+			c.UnreadCountDisplay = 12
+		case "C2":
+			c.Name = "quiet"
+			c.LastRead = "0000000000.000000"
+		default:
+			return nil, slack.SlackErrorResponse{Err: "channel_not_found"}
+		}
+		return &c, nil
+	}}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	maps := p.ProvideChannelsMaps()
+	maps.Channels["C1"] = provider.Channel{ID: "C1", Name: "#general"}
+	maps.ChannelsInv["#general"] = "C1"
+	maps.Channels["D1"] = provider.Channel{ID: "D1", Name: "@bob", IsIM: true}
+	ch := NewChannelsHandler(p, zap.NewNop())
+
+	res, err := ch.ChannelsUnreadsHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_ids": "#general, C9, C2, D1",
+	}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"D1", "C1", "C2", "C9"}, csvColumn(t, out, "ChannelID"), "most unread first, failures last")
+	assert.Equal(t, []string{"@bob", "#general", "#quiet", ""}, csvColumn(t, out, "Name"))
+	assert.Equal(t, []string{"12", "3", "0", "0"}, csvColumn(t, out, "UnreadCount"))
+	assert.Equal(t, []string{"2023-11-14T22:21:40Z", "2023-11-14T22:13:20Z", "", ""}, csvColumn(t, out, "LastReadTime"))
+	assert.Contains(t, csvColumn(t, out, "Note")[3], "channel_not_found")
+
+	_, err = ch.ChannelsUnreadsHandler(context.Background(), newToolRequest(map[string]any{"channel_ids": " , "}))
+	assert.Error(t, err)
+}
@@ -3,13 +3,16 @@ package handler
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gocarina/gocsv"
@@ -17,6 +20,7 @@ import (
 	"github.com/korotovsky/slack-mcp-server/pkg/provider"
 	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
 	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/korotovsky/slack-mcp-server/pkg/transport"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/slack-go/slack"
 	slackGoUtil "github.com/takara2314/slack-go-util"
@@ -26,6 +30,9 @@ import (
 const (
 	defaultConversationsNumericLimit    = 50
 	defaultConversationsExpressionLimit = "1d"
+
+	defaultHighlightContext = 50
+	maxHighlightContext     = 1000
 )
 
 var validFilterKeys = map[string]struct{}{
@@ -52,6 +59,22 @@ type Message struct {
 	Cursor    string `json:"cursor"`
 }
 
+// SearchMessage is a search result with its highlight fragment and permalink,
+// returned instead of Message when either is requested
+type SearchMessage struct {
+	MsgID     string `json:"msgID"`
+	UserID    string `json:"userID"`
+	UserName  string `json:"userUser"`
+	RealName  string `json:"realName"`
+	Channel   string `json:"channelID"`
+	ThreadTs  string `json:"ThreadTs"`
+	Text      string `json:"text"`
+	Time      string `json:"time"`
+	Highlight string `json:"highlight"`
+	Permalink string `json:"permalink"`
+	Cursor    string `json:"cursor"`
+}
+
 type User struct {
 	UserID   string `json:"userID"`
 	UserName string `json:"userName"`
@@ -59,18 +82,25 @@ type User struct {
 }
 
 type conversationParams struct {
-	channel  string
-	limit    int
-	oldest   string
-	latest   string
-	cursor   string
-	activity bool
+	channel    string
+	limit      int
+	oldest     string
+	latest     string
+	cursor     string
+	activity   bool
+	inclusive  bool
+	maxTextLen int
+	format     string
 }
 
 type searchParams struct {
-	query string
-	limit int
-	page  int
+	query            string
+	limit            int
+	page             int
+	maxTextLen       int
+	highlight        bool
+	highlightContext int
+	permalink        bool
 }
 
 type addMessageParams struct {
@@ -78,13 +108,26 @@ type addMessageParams struct {
 	threadTs    string
 	text        string
 	contentType string
+	unfurlLinks *bool // nil leaves the default
+	unfurlMedia *bool
+	broadcast   bool
+	blocks      []slack.Block // Block Kit blocks, text is then the notification fallback
 }
 
 type ConversationsHandler struct {
 	apiProvider  *provider.ApiProvider  // Legacy mode
 	tokenStorage oauth.TokenStorage     // OAuth mode
 	oauthEnabled bool
+	maxFileSize  int
+	maxThread    int // most messages conversations_replies returns with fetch_all
+	readMethods  map[string]bool
+	userGroups   *provider.UserGroupsCache
 	logger       *zap.Logger
+
+	// userLocations caches the timezone of authenticated users, see messageLocation
+	userLocations sync.Map
+	// botNames caches the names of bot users per team, see BotInfoHandler
+	botNames sync.Map
 }
 
 // NewConversationsHandler creates handler for legacy mode
@@ -92,6 +135,10 @@ func NewConversationsHandler(apiProvider *provider.ApiProvider, logger *zap.Logg
 	return &ConversationsHandler{
 		apiProvider:  apiProvider,
 		oauthEnabled: false,
+		maxFileSize:  getMaxFileSize(logger),
+		maxThread:    getMaxThreadMessages(logger),
+		readMethods:  getReadMethods(logger),
+		userGroups:   provider.NewUserGroupsCacheFromEnv(logger),
 		logger:       logger,
 	}
 }
@@ -101,10 +148,20 @@ func NewConversationsHandlerWithOAuth(tokenStorage oauth.TokenStorage, logger *z
 	return &ConversationsHandler{
 		tokenStorage: tokenStorage,
 		oauthEnabled: true,
+		maxFileSize:  getMaxFileSize(logger),
+		maxThread:    getMaxThreadMessages(logger),
+		readMethods:  getReadMethods(logger),
+		userGroups:   provider.NewUserGroupsCacheFromEnv(logger),
 		logger:       logger,
 	}
 }
 
+// oauthHTTPClient is shared by the per-request Slack clients of OAuth mode, it
+// records the warnings of Slack responses for debug_warnings
+var oauthHTTPClient = &http.Client{
+	Transport: transport.NewWarningsTransport(http.DefaultTransport),
+}
+
 // getSlackClient creates a Slack client for the current request (OAuth mode)
 // Returns user client by default
 func (h *ConversationsHandler) getSlackClient(ctx context.Context) (*slack.Client, error) {
@@ -118,7 +175,7 @@ func (h *ConversationsHandler) getSlackClient(ctx context.Context) (*slack.Clien
 	}
 
 	// Use user token by default
-	return slack.New(userCtx.AccessToken), nil
+	return slack.New(userCtx.AccessToken, slack.OptionHTTPClient(oauthHTTPClient)), nil
 }
 
 // getBotSlackClient creates a Slack client using bot token (OAuth mode)
@@ -138,7 +195,7 @@ func (h *ConversationsHandler) getBotSlackClient(ctx context.Context) (*slack.Cl
 	}
 
 	// Use bot token
-	return slack.New(userCtx.BotToken), nil
+	return slack.New(userCtx.BotToken, slack.OptionHTTPClient(oauthHTTPClient)), nil
 }
 
 // getProvider returns the provider (legacy mode) or error (OAuth mode)
@@ -166,22 +223,14 @@ func (ch *ConversationsHandler) UsersResource(ctx context.Context, request mcp.R
 	}
 
 	// Slack auth test
-	ar, err := ch.apiProvider.Slack().AuthTest()
+	ws, err := ch.apiProvider.Workspace(ctx)
 	if err != nil {
 		ch.logger.Error("Slack AuthTest failed", zap.Error(err))
 		return nil, err
 	}
 
-	ws, err := text.Workspace(ar.URL)
-	if err != nil {
-		ch.logger.Error("Failed to parse workspace from URL",
-			zap.String("url", ar.URL),
-			zap.Error(err),
-		)
-		return nil, fmt.Errorf("failed to parse workspace from URL: %v", err)
-	}
-
-	// collect users
+	// collect users, a lazy warm is started so that later reads list all of them
+	ch.apiProvider.EnsureUsersWarm()
 	usersMaps := ch.apiProvider.ProvideUsersMap()
 	users := usersMaps.Users
 	usersList := make([]User, 0, len(users))
@@ -211,7 +260,10 @@ func (ch *ConversationsHandler) UsersResource(ctx context.Context, request mcp.R
 
 // ConversationsAddMessageHandler posts a message and returns it as CSV
 func (ch *ConversationsHandler) ConversationsAddMessageHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ch.logger.Debug("ConversationsAddMessageHandler called", zap.Any("params", request.Params))
+	ch.logger.Debug("ConversationsAddMessageHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
 
 	// Get Slack client (OAuth or legacy)
 	// Check if user wants to post as bot
@@ -241,34 +293,12 @@ func (ch *ConversationsHandler) ConversationsAddMessageHandler(ctx context.Conte
 		return nil, err
 	}
 
-	var options []slack.MsgOption
-	if params.threadTs != "" {
-		options = append(options, slack.MsgOptionTS(params.threadTs))
-	}
-
-	switch params.contentType {
-	case "text/plain":
-		options = append(options, slack.MsgOptionDisableMarkdown())
-		options = append(options, slack.MsgOptionText(params.text, false))
-	case "text/markdown":
-		blocks, err := slackGoUtil.ConvertMarkdownTextToBlocks(params.text)
-		if err != nil {
-			ch.logger.Warn("Markdown parsing error", zap.Error(err))
-			options = append(options, slack.MsgOptionDisableMarkdown())
-			options = append(options, slack.MsgOptionText(params.text, false))
-		} else {
-			options = append(options, slack.MsgOptionBlocks(blocks...))
-		}
-	default:
-		return nil, errors.New("content_type must be either 'text/plain' or 'text/markdown'")
+	if err := ch.resolveThreadParent(ctx, params); err != nil {
+		return nil, err
 	}
-
-	unfurlOpt := os.Getenv("SLACK_MCP_ADD_MESSAGE_UNFURLING")
-	if text.IsUnfurlingEnabled(params.text, unfurlOpt, ch.logger) {
-		options = append(options, slack.MsgOptionEnableLinkUnfurl())
-	} else {
-		options = append(options, slack.MsgOptionDisableLinkUnfurl())
-		options = append(options, slack.MsgOptionDisableMediaUnfurl())
+	options, err := ch.addMessageOptions(params)
+	if err != nil {
+		return nil, err
 	}
 
 	ch.logger.Debug("Posting Slack message",
@@ -288,8 +318,7 @@ func (ch *ConversationsHandler) ConversationsAddMessageHandler(ctx context.Conte
 		return nil, err
 	}
 
-	toolConfig := os.Getenv("SLACK_MCP_ADD_MESSAGE_MARK")
-	if toolConfig == "1" || toolConfig == "true" || toolConfig == "yes" {
+	if envEnabled("SLACK_MCP_ADD_MESSAGE_MARK") {
 		var markErr error
 		if ch.oauthEnabled {
 			markErr = slackClient.MarkConversationContext(ctx, params.channel, respTimestamp)
@@ -323,12 +352,109 @@ func (ch *ConversationsHandler) ConversationsAddMessageHandler(ctx context.Conte
 	ch.logger.Debug("Fetched conversation history", zap.Int("message_count", len(history.Messages)))
 
 	messages := ch.convertMessagesFromHistory(history.Messages, historyParams.ChannelID, false)
+	if loc, err := ch.messageLocation(ctx, request); err == nil {
+		localizeMessageTimes(messages, loc)
+	}
 	return marshalMessagesToCSV(messages)
 }
 
+// resolveThreadParent checks that the parent of a reply exists, Slack does not
+// reliably reject replies to a missing parent, and points replies to a reply at
+// the root of its thread. The lookup uses the user token, the bot may lack the
+// history scopes.
+func (ch *ConversationsHandler) resolveThreadParent(ctx context.Context, params *addMessageParams) error {
+	if params.threadTs == "" {
+		return nil
+	}
+	var api conversationsRepliesAPI
+	if ch.oauthEnabled {
+		userClient, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return err
+		}
+		api = userClient
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+	root, err := ch.fetchThreadRoot(ctx, api, params.channel, params.threadTs)
+	if err != nil {
+		ch.logger.Error("Thread parent not found", zap.String("thread_ts", params.threadTs), zap.Error(err))
+		return fmt.Errorf("cannot reply to thread_ts %s: %w", params.threadTs, err)
+	}
+	// replies to a reply belong to the thread of its root
+	params.threadTs = root.Timestamp
+	return nil
+}
+
+// addMessageOptions returns the Slack options posting the message of params
+func (ch *ConversationsHandler) addMessageOptions(params *addMessageParams) ([]slack.MsgOption, error) {
+	var options []slack.MsgOption
+	if params.threadTs != "" {
+		options = append(options, slack.MsgOptionTS(params.threadTs))
+		if params.broadcast {
+			options = append(options, slack.MsgOptionBroadcast())
+		}
+	}
+
+	switch {
+	case len(params.blocks) > 0:
+		options = append(options, slack.MsgOptionBlocks(params.blocks...))
+		if params.text != "" {
+			options = append(options, slack.MsgOptionText(params.text, false))
+		}
+	case params.contentType == "text/plain":
+		options = append(options, slack.MsgOptionDisableMarkdown())
+		options = append(options, slack.MsgOptionText(params.text, false))
+	case params.contentType == "text/markdown":
+		blocks, err := slackGoUtil.ConvertMarkdownTextToBlocks(params.text)
+		if err != nil {
+			ch.logger.Warn("Markdown parsing error", zap.Error(err))
+			options = append(options, slack.MsgOptionDisableMarkdown())
+			options = append(options, slack.MsgOptionText(params.text, false))
+		} else {
+			options = append(options, slack.MsgOptionBlocks(blocks...))
+		}
+	default:
+		return nil, errors.New("content_type must be either 'text/plain' or 'text/markdown'")
+	}
+
+	// unfurl_links and unfurl_media can only turn off previews that the
+	// SLACK_MCP_ADD_MESSAGE_UNFURLING policy allows, never bypass it
+	unfurlOpt := os.Getenv("SLACK_MCP_ADD_MESSAGE_UNFURLING")
+	unfurlText := params.text
+	if len(params.blocks) > 0 {
+		// links in blocks unfurl too, check them all
+		blocksJSON, err := json.Marshal(params.blocks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode blocks: %w", err)
+		}
+		unfurlText += "\n" + string(blocksJSON)
+	}
+	if text.IsUnfurlingEnabled(unfurlText, unfurlOpt, ch.logger) {
+		if params.unfurlLinks == nil || *params.unfurlLinks {
+			options = append(options, slack.MsgOptionEnableLinkUnfurl())
+		} else {
+			options = append(options, slack.MsgOptionDisableLinkUnfurl())
+		}
+		if params.unfurlMedia != nil && !*params.unfurlMedia {
+			options = append(options, slack.MsgOptionDisableMediaUnfurl())
+		}
+	} else {
+		if (params.unfurlLinks != nil && *params.unfurlLinks) || (params.unfurlMedia != nil && *params.unfurlMedia) {
+			ch.logger.Warn("Unfurling requested but not allowed by SLACK_MCP_ADD_MESSAGE_UNFURLING", zap.String("policy", unfurlOpt))
+		}
+		options = append(options, slack.MsgOptionDisableLinkUnfurl())
+		options = append(options, slack.MsgOptionDisableMediaUnfurl())
+	}
+	return options, nil
+}
+
 // ConversationsHistoryHandler streams conversation history as CSV
 func (ch *ConversationsHandler) ConversationsHistoryHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ch.logger.Debug("ConversationsHistoryHandler called", zap.Any("params", request.Params))
+	ch.logger.Debug("ConversationsHistoryHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
 
 	// Get Slack client (OAuth or legacy)
 	var slackClient *slack.Client
@@ -345,21 +471,29 @@ func (ch *ConversationsHandler) ConversationsHistoryHandler(ctx context.Context,
 		ch.logger.Error("Failed to parse history params", zap.Error(err))
 		return nil, err
 	}
+	loc, err := ch.messageLocation(ctx, request)
+	if err != nil {
+		return nil, err
+	}
 	ch.logger.Debug("History params parsed",
 		zap.String("channel", params.channel),
 		zap.Int("limit", params.limit),
 		zap.String("oldest", params.oldest),
 		zap.String("latest", params.latest),
 		zap.Bool("include_activity", params.activity),
+		zap.Bool("inclusive", params.inclusive),
 	)
 
+	// Slack applies `inclusive` only to the oldest/latest boundaries, the cursor itself
+	// never repeats a message, so the same flag must be sent on every page to keep
+	// a boundary message from being duplicated or skipped.
 	historyParams := slack.GetConversationHistoryParameters{
 		ChannelID: params.channel,
 		Limit:     params.limit,
 		Oldest:    params.oldest,
 		Latest:    params.latest,
 		Cursor:    params.cursor,
-		Inclusive: false,
+		Inclusive: params.inclusive,
 	}
 	
 	var history *slack.GetConversationHistoryResponse
@@ -375,17 +509,33 @@ func (ch *ConversationsHandler) ConversationsHistoryHandler(ctx context.Context,
 
 	ch.logger.Debug("Fetched conversation history", zap.Int("message_count", len(history.Messages)))
 
-	messages := ch.convertMessagesFromHistory(history.Messages, params.channel, params.activity)
+	source, activity := history.Messages, params.activity
+	if request.GetBool("include_edit_events", false) && !activity {
+		source, activity = filterEditEvents(source), true
+	}
+	if request.GetBool("exclude_bots", false) {
+		source = ch.withoutBotMessages(source)
+	}
+	messages := ch.convertMessagesFromHistory(source, params.channel, activity)
+	ch.applyMessageFormat(messages, source, params.format)
+	localizeMessageTimes(messages, loc)
+	truncateMessages(messages, params.maxTextLen)
 
 	if len(messages) > 0 && history.HasMore {
 		messages[len(messages)-1].Cursor = history.ResponseMetaData.NextCursor
 	}
+	if request.GetBool("include_metadata", false) {
+		return marshalMessagesWithMetadataToCSV(messages, source)
+	}
 	return marshalMessagesToCSV(messages)
 }
 
 // ConversationsRepliesHandler streams thread replies as CSV
 func (ch *ConversationsHandler) ConversationsRepliesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ch.logger.Debug("ConversationsRepliesHandler called", zap.Any("params", request.Params))
+	ch.logger.Debug("ConversationsRepliesHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
 
 	// Get Slack client (OAuth or legacy)
 	var slackClient *slack.Client
@@ -407,6 +557,63 @@ func (ch *ConversationsHandler) ConversationsRepliesHandler(ctx context.Context,
 		ch.logger.Error("thread_ts not provided for replies", zap.String("thread_ts", threadTs))
 		return nil, errors.New("thread_ts must be a string")
 	}
+	loc, err := ch.messageLocation(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.GetBool("fetch_all", false) {
+		if params.cursor != "" {
+			return nil, errors.New("cursor cannot be used with fetch_all, which starts at the root of the thread")
+		}
+		maxItems := request.GetInt("max_items", ch.maxThread)
+		if maxItems < 1 || maxItems > ch.maxThread {
+			return nil, fmt.Errorf("max_items must be an integer between 1 and %d (SLACK_MCP_THREAD_MAX_MESSAGES)", ch.maxThread)
+		}
+		var api conversationsRepliesAPI = ch.apiProvider.Slack()
+		if ch.oauthEnabled {
+			api = slackClient
+		}
+		replies, nextCursor, err := fetchThread(ctx, api, params.channel, threadTs, maxItems)
+		if err != nil {
+			ch.logger.Error("GetConversationRepliesContext failed", zap.Error(err))
+			return nil, err
+		}
+		ch.logger.Debug("Fetched whole thread", zap.Int("count", len(replies)))
+		if request.GetBool("exclude_bots", false) {
+			replies = ch.withoutBotMessages(replies)
+		}
+
+		messages := ch.convertMessagesFromHistory(replies, params.channel, params.activity)
+		ch.applyMessageFormat(messages, replies, params.format)
+		localizeMessageTimes(messages, loc)
+		truncateMessages(messages, params.maxTextLen)
+		if len(messages) > 0 {
+			messages[len(messages)-1].Cursor = nextCursor
+		}
+
+		rows := make([]ThreadMessage, 0, len(messages)+1)
+		for _, msg := range messages {
+			rows = append(rows, ThreadMessage{Message: msg})
+		}
+		if nextCursor != "" {
+			ch.logger.Warn("Thread truncated at max_items",
+				zap.String("channel", params.channel),
+				zap.String("thread_ts", threadTs),
+				zap.Int("max_items", maxItems),
+			)
+			rows = append(rows, ThreadMessage{
+				Message: Message{Channel: params.channel, ThreadTs: threadTs},
+				Note:    fmt.Sprintf("truncated: the thread has more than max_items=%d messages, page through the rest with the cursor and without fetch_all, or narrow it down with a time range in limit", maxItems),
+			})
+		}
+		csvBytes, err := gocsv.MarshalBytes(&rows)
+		if err != nil {
+			ch.logger.Error("Failed to marshal thread to CSV", zap.Error(err))
+			return nil, err
+		}
+		return mcp.NewToolResultText(string(csvBytes)), nil
+	}
 
 	repliesParams := slack.GetConversationRepliesParameters{
 		ChannelID: params.channel,
@@ -431,8 +638,14 @@ func (ch *ConversationsHandler) ConversationsRepliesHandler(ctx context.Context,
 		return nil, err
 	}
 	ch.logger.Debug("Fetched conversation replies", zap.Int("count", len(replies)))
+	if request.GetBool("exclude_bots", false) {
+		replies = ch.withoutBotMessages(replies)
+	}
 
 	messages := ch.convertMessagesFromHistory(replies, params.channel, params.activity)
+	ch.applyMessageFormat(messages, replies, params.format)
+	localizeMessageTimes(messages, loc)
+	truncateMessages(messages, params.maxTextLen)
 	if len(messages) > 0 && hasMore {
 		messages[len(messages)-1].Cursor = nextCursor
 	}
@@ -440,7 +653,10 @@ func (ch *ConversationsHandler) ConversationsRepliesHandler(ctx context.Context,
 }
 
 func (ch *ConversationsHandler) ConversationsSearchHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ch.logger.Debug("ConversationsSearchHandler called", zap.Any("params", request.Params))
+	ch.logger.Debug("ConversationsSearchHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
 
 	// Get Slack client (OAuth or legacy)
 	var slackClient *slack.Client
@@ -457,12 +673,16 @@ func (ch *ConversationsHandler) ConversationsSearchHandler(ctx context.Context,
 		ch.logger.Error("Failed to parse search params", zap.Error(err))
 		return nil, err
 	}
+	loc, err := ch.messageLocation(ctx, request)
+	if err != nil {
+		return nil, err
+	}
 	ch.logger.Debug("Search params parsed", zap.String("query", params.query), zap.Int("limit", params.limit), zap.Int("page", params.page))
 
 	searchParams := slack.SearchParameters{
 		Sort:          slack.DEFAULT_SEARCH_SORT,
 		SortDirection: slack.DEFAULT_SEARCH_SORT_DIR,
-		Highlight:     false,
+		Highlight:     params.highlight,
 		Count:         params.limit,
 		Page:          params.page,
 	}
@@ -479,12 +699,67 @@ func (ch *ConversationsHandler) ConversationsSearchHandler(ctx context.Context,
 	}
 	ch.logger.Debug("Search completed", zap.Int("matches", len(messagesRes.Matches)))
 
-	messages := ch.convertMessagesFromSearch(messagesRes.Matches)
+	matches := messagesRes.Matches
+	if request.GetBool("exclude_bots", false) {
+		matches = ch.withoutBotMatches(matches)
+	}
+
+	var highlights, permalinks map[string]string
+	if params.highlight {
+		highlights = searchHighlights(matches, params.highlightContext)
+	}
+	if params.permalink {
+		permalinks = make(map[string]string, len(matches))
+		for _, msg := range matches {
+			permalinks[fmt.Sprintf("#%s/%s", msg.Channel.Name, msg.Timestamp)] = msg.Permalink
+		}
+	}
+	messages := ch.convertMessagesFromSearch(matches)
+	localizeMessageTimes(messages, loc)
+	truncateMessages(messages, params.maxTextLen)
 	if len(messages) > 0 && messagesRes.Pagination.Page < messagesRes.Pagination.PageCount {
 		nextCursor := fmt.Sprintf("page:%d", messagesRes.Pagination.Page+1)
 		messages[len(messages)-1].Cursor = base64.StdEncoding.EncodeToString([]byte(nextCursor))
 	}
-	return marshalMessagesToCSV(messages)
+	if !params.highlight && !params.permalink {
+		return marshalMessagesToCSV(messages)
+	}
+
+	results := make([]SearchMessage, 0, len(messages))
+	for _, msg := range messages {
+		results = append(results, SearchMessage{
+			MsgID:     msg.MsgID,
+			UserID:    msg.UserID,
+			UserName:  msg.UserName,
+			RealName:  msg.RealName,
+			Channel:   msg.Channel,
+			ThreadTs:  msg.ThreadTs,
+			Text:      msg.Text,
+			Time:      msg.Time,
+			Highlight: highlights[msg.Channel+"/"+msg.MsgID],
+			Permalink: permalinks[msg.Channel+"/"+msg.MsgID],
+			Cursor:    msg.Cursor,
+		})
+	}
+	csvBytes, err := gocsv.MarshalBytes(&results)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// searchHighlights returns the highlight fragments of search matches by
+// channel and timestamp, and strips the highlight delimiters of Slack from
+// their text so that the text column reads the same with and without highlighting
+func searchHighlights(matches []slack.SearchMessage, contextLen int) map[string]string {
+	highlights := make(map[string]string, len(matches))
+	for i := range matches {
+		msg := &matches[i]
+		key := fmt.Sprintf("#%s/%s", msg.Channel.Name, msg.Timestamp)
+		highlights[key] = text.HighlightFragment(msg.Text, contextLen)
+		msg.Text = text.StripHighlights(msg.Text)
+	}
+	return highlights
 }
 
 func isChannelAllowed(channel string) bool {
@@ -506,13 +781,20 @@ func isChannelAllowed(channel string) bool {
 			}
 		}
 	}
-	return !isNegated
+	// unlisted channels are only allowed by a list of exclusions
+	return isNegated
 }
 
 func (ch *ConversationsHandler) convertMessagesFromHistory(slackMessages []slack.Message, channel string, includeActivity bool) []Message {
 	// Get users map (if available)
 	var usersMap *provider.UsersCache
 	if !ch.oauthEnabled {
+		userIDs := make([]string, 0, len(slackMessages))
+		for _, msg := range slackMessages {
+			userIDs = append(userIDs, msg.User)
+			userIDs = append(userIDs, text.MentionedUserIDs(msg.Text)...)
+		}
+		ch.apiProvider.ResolveUsers(userIDs)
 		usersMap = ch.apiProvider.ProvideUsersMap()
 	} else {
 		// OAuth mode: no cache, use empty map
@@ -521,6 +803,7 @@ func (ch *ConversationsHandler) convertMessagesFromHistory(slackMessages []slack
 			UsersInv: make(map[string]string),
 		}
 	}
+	names := ch.mentionNames(usersMap)
 	var messages []Message
 	warn := false
 
@@ -545,7 +828,7 @@ func (ch *ConversationsHandler) convertMessagesFromHistory(slackMessages []slack
 			continue
 		}
 
-		msgText := msg.Text + text.AttachmentsTo2CSV(msg.Text, msg.Attachments)
+		msgText := messageText(msg.Text, msg.Blocks, msg.Attachments)
 
 		var reactionParts []string
 		for _, r := range msg.Reactions {
@@ -558,7 +841,7 @@ func (ch *ConversationsHandler) convertMessagesFromHistory(slackMessages []slack
 			UserID:    msg.User,
 			UserName:  userName,
 			RealName:  realName,
-			Text:      text.ProcessText(msgText),
+			Text:      text.ProcessText(text.ResolveMentions(msgText, names)),
 			Channel:   channel,
 			ThreadTs:  msg.ThreadTimestamp,
 			Time:      timestamp,
@@ -583,6 +866,12 @@ func (ch *ConversationsHandler) convertMessagesFromSearch(slackMessages []slack.
 	// Get users map (if available)
 	var usersMap *provider.UsersCache
 	if !ch.oauthEnabled {
+		userIDs := make([]string, 0, len(slackMessages))
+		for _, msg := range slackMessages {
+			userIDs = append(userIDs, msg.User)
+			userIDs = append(userIDs, text.MentionedUserIDs(msg.Text)...)
+		}
+		ch.apiProvider.ResolveUsers(userIDs)
 		usersMap = ch.apiProvider.ProvideUsersMap()
 	} else {
 		// OAuth mode: no cache, use empty map
@@ -591,6 +880,7 @@ func (ch *ConversationsHandler) convertMessagesFromSearch(slackMessages []slack.
 			UsersInv: make(map[string]string),
 		}
 	}
+	names := ch.mentionNames(usersMap)
 	var messages []Message
 	warn := false
 
@@ -611,14 +901,14 @@ func (ch *ConversationsHandler) convertMessagesFromSearch(slackMessages []slack.
 			continue
 		}
 
-		msgText := msg.Text + text.AttachmentsTo2CSV(msg.Text, msg.Attachments)
+		msgText := messageText(msg.Text, msg.Blocks, msg.Attachments)
 
 		messages = append(messages, Message{
 			MsgID:     msg.Timestamp,
 			UserID:    msg.User,
 			UserName:  userName,
 			RealName:  realName,
-			Text:      text.ProcessText(msgText),
+			Text:      text.ProcessText(text.ResolveMentions(msgText, names)),
 			Channel:   fmt.Sprintf("#%s", msg.Channel.Name),
 			ThreadTs:  threadTs,
 			Time:      timestamp,
@@ -649,12 +939,20 @@ func (ch *ConversationsHandler) parseParamsToolConversations(request mcp.CallToo
 	limit := request.GetString("limit", "")
 	cursor := request.GetString("cursor", "")
 	activity := request.GetBool("include_activity_messages", false)
+	inclusive := request.GetBool("inclusive", false)
+	maxTextLen, err := parseMaxTextLen(request)
+	if err != nil {
+		return nil, err
+	}
+	format, err := parseMessageFormat(request)
+	if err != nil {
+		return nil, err
+	}
 
 	var (
 		paramLimit  int
 		paramOldest string
 		paramLatest string
-		err         error
 	)
 	if strings.HasSuffix(limit, "d") || strings.HasSuffix(limit, "w") || strings.HasSuffix(limit, "m") {
 		paramLimit, paramOldest, paramLatest, err = limitByExpression(limit, defaultConversationsExpressionLimit)
@@ -670,6 +968,34 @@ func (ch *ConversationsHandler) parseParamsToolConversations(request mcp.CallToo
 		}
 	}
 
+	// date_range, or explicit oldest/latest, override the bounds of a time range limit
+	if dateRange := request.GetString("date_range", ""); dateRange != "" {
+		if request.GetString("oldest", "") != "" || request.GetString("latest", "") != "" {
+			return nil, errors.New("date_range cannot be combined with oldest or latest")
+		}
+		paramOldest, paramLatest, err = dateRangeBounds(dateRange)
+		if err != nil {
+			ch.logger.Error("Invalid date range", zap.String("date_range", dateRange), zap.Error(err))
+			return nil, err
+		}
+	}
+	for _, bound := range []struct {
+		name string
+		dst  *string
+	}{{"oldest", &paramOldest}, {"latest", &paramLatest}} {
+		ts := strings.TrimSpace(request.GetString(bound.name, ""))
+		if ts == "" {
+			continue
+		}
+		if _, err := parseSlackTimestamp(ts); err != nil {
+			return nil, fmt.Errorf("%s must be a valid timestamp in format 1234567890.123456", bound.name)
+		}
+		*bound.dst = ts
+	}
+	if paramOldest != "" && paramLatest != "" && compareSlackTimestamps(paramOldest, paramLatest) > 0 {
+		return nil, fmt.Errorf("oldest %s must not be after latest %s", paramOldest, paramLatest)
+	}
+
 	if strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@") {
 		if ready, err := ch.apiProvider.IsReady(); !ready {
 			if errors.Is(err, provider.ErrUsersNotReady) {
@@ -696,12 +1022,15 @@ func (ch *ConversationsHandler) parseParamsToolConversations(request mcp.CallToo
 	}
 
 	return &conversationParams{
-		channel:  channel,
-		limit:    paramLimit,
-		oldest:   paramOldest,
-		latest:   paramLatest,
-		cursor:   cursor,
-		activity: activity,
+		channel:    channel,
+		limit:      paramLimit,
+		oldest:     paramOldest,
+		latest:     paramLatest,
+		cursor:     cursor,
+		activity:   activity,
+		inclusive:  inclusive,
+		maxTextLen: maxTextLen,
+		format:     format,
 	}, nil
 }
 
@@ -746,9 +1075,19 @@ func (ch *ConversationsHandler) parseParamsToolAddMessage(request mcp.CallToolRe
 		ch.logger.Error("Invalid thread_ts format", zap.String("thread_ts", threadTs))
 		return nil, errors.New("thread_ts must be a valid timestamp in format 1234567890.123456")
 	}
+	broadcast := request.GetBool("reply_broadcast", false)
+	if broadcast && threadTs == "" {
+		return nil, errors.New("reply_broadcast can only be used when replying in a thread with thread_ts")
+	}
+
+	blocks, err := parseBlocksParam(request.GetString("blocks", ""))
+	if err != nil {
+		ch.logger.Error("Invalid blocks", zap.Error(err))
+		return nil, err
+	}
 
 	msgText := request.GetString("payload", "")
-	if msgText == "" {
+	if msgText == "" && len(blocks) == 0 {
 		ch.logger.Error("Message text missing")
 		return nil, errors.New("text must be a string")
 	}
@@ -759,15 +1098,118 @@ func (ch *ConversationsHandler) parseParamsToolAddMessage(request mcp.CallToolRe
 		return nil, errors.New("content_type must be either 'text/plain' or 'text/markdown'")
 	}
 
+	// broadcast markup written directly into the message is gated like the
+	// @here, @channel and @everyone mentions
+	if !broadcastMentionsAllowed() {
+		markup := text.BroadcastMarkup(msgText)
+		if markup == "" {
+			markup = blocksBroadcast(blocks)
+		}
+		if markup != "" {
+			ch.logger.Error("Broadcast markup in message", zap.String("markup", markup))
+			return nil, broadcastMentionError(fmt.Errorf("%w: %s", text.ErrBroadcastMention, markup))
+		}
+	}
+
+	if mentions := request.GetString("mentions", ""); mentions != "" {
+		msgText, err = ch.applyMentions(msgText, strings.Split(mentions, ","))
+		if err != nil {
+			ch.logger.Error("Failed to apply mentions", zap.Error(err))
+			return nil, err
+		}
+	}
+
 	return &addMessageParams{
 		channel:     channel,
 		threadTs:    threadTs,
 		text:        msgText,
 		contentType: contentType,
+		unfurlLinks: optionalBool(request, "unfurl_links"),
+		unfurlMedia: optionalBool(request, "unfurl_media"),
+		broadcast:   broadcast,
+		blocks:      blocks,
 	}, nil
 }
 
+// applyMentions turns the given @user and #channel mentions of a message into
+// Slack mention markup. Names are resolved from the caches, which OAuth mode
+// does not have, so there mentions must be given by ID, e.g. @U123 or #C123.
+func (ch *ConversationsHandler) applyMentions(msgText string, mentions []string) (string, error) {
+	var dir text.MentionDirectory
+	if !ch.oauthEnabled {
+		dir.Users = ch.apiProvider.ProvideUsersMap().UsersInv
+		dir.Channels = ch.apiProvider.ProvideChannelsMaps().ChannelsInv
+	}
+
+	result, err := text.ApplyMentions(msgText, mentions, dir, broadcastMentionsAllowed())
+	if errors.Is(err, text.ErrBroadcastMention) {
+		return "", broadcastMentionError(err)
+	}
+	if err != nil && ch.oauthEnabled {
+		return "", fmt.Errorf("%w, in OAuth mode mention users and channels by ID, e.g. @U1234567890 or #C1234567890", err)
+	}
+	return result, err
+}
+
+// broadcastMentionsAllowed reports whether posted messages may notify a whole
+// channel with @here, @channel or @everyone, see SLACK_MCP_ADD_MESSAGE_BROADCAST
+func broadcastMentionsAllowed() bool {
+	return envEnabled("SLACK_MCP_ADD_MESSAGE_BROADCAST")
+}
+
+func broadcastMentionError(err error) error {
+	return fmt.Errorf("%w, @here, @channel and @everyone notify every member of the channel. "+
+		"To allow them, set the SLACK_MCP_ADD_MESSAGE_BROADCAST environment variable to true", err)
+}
+
+// optionalBool returns nil when a boolean parameter is not given
+func optionalBool(request mcp.CallToolRequest, name string) *bool {
+	if v, ok := request.GetArguments()[name].(bool); ok {
+		return &v
+	}
+	return nil
+}
+
 func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (*searchParams, error) {
+	finalQuery, err := ch.composeSearchQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	limit := req.GetInt("limit", 100)
+	maxTextLen, err := parseMaxTextLen(req)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := ch.parseSearchCursor(req.GetString("cursor", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	highlightContext := req.GetInt("highlight_context", defaultHighlightContext)
+	if highlightContext < 0 || highlightContext > maxHighlightContext {
+		return nil, fmt.Errorf("highlight_context must be an integer between 0 and %d", maxHighlightContext)
+	}
+
+	ch.logger.Debug("Search parameters built",
+		zap.String("query", finalQuery),
+		zap.Int("limit", limit),
+		zap.Int("page", page),
+	)
+	return &searchParams{
+		query:            finalQuery,
+		limit:            limit,
+		page:             page,
+		maxTextLen:       maxTextLen,
+		highlight:        req.GetBool("highlight", false),
+		highlightContext: highlightContext,
+		permalink:        req.GetBool("include_permalink", false),
+	}, nil
+}
+
+// composeSearchQuery merges the search_query with the filter_* parameters into
+// a Slack search query, shared by message and file search
+func (ch *ConversationsHandler) composeSearchQuery(req mcp.CallToolRequest) (string, error) {
 	rawQuery := strings.TrimSpace(req.GetString("search_query", ""))
 	freeText, filters := splitQuery(rawQuery)
 
@@ -778,14 +1220,14 @@ func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (
 		f, err := ch.paramFormatChannel(chName)
 		if err != nil {
 			ch.logger.Error("Invalid channel filter", zap.String("filter", chName), zap.Error(err))
-			return nil, err
+			return "", err
 		}
 		addFilter(filters, "in", f)
 	} else if im := req.GetString("filter_in_im_or_mpim", ""); im != "" {
 		f, err := ch.paramFormatUser(im)
 		if err != nil {
 			ch.logger.Error("Invalid IM/MPIM filter", zap.String("filter", im), zap.Error(err))
-			return nil, err
+			return "", err
 		}
 		addFilter(filters, "in", f)
 	}
@@ -793,7 +1235,7 @@ func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (
 		f, err := ch.paramFormatUser(with)
 		if err != nil {
 			ch.logger.Error("Invalid with-user filter", zap.String("filter", with), zap.Error(err))
-			return nil, err
+			return "", err
 		}
 		addFilter(filters, "with", f)
 	}
@@ -801,7 +1243,7 @@ func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (
 		f, err := ch.paramFormatUser(from)
 		if err != nil {
 			ch.logger.Error("Invalid from-user filter", zap.String("filter", from), zap.Error(err))
-			return nil, err
+			return "", err
 		}
 		addFilter(filters, "from", f)
 	}
@@ -814,50 +1256,58 @@ func (ch *ConversationsHandler) parseParamsToolSearch(req mcp.CallToolRequest) (
 	)
 	if err != nil {
 		ch.logger.Error("Invalid date filters", zap.Error(err))
-		return nil, err
+		return "", err
 	}
 	for key, val := range dateMap {
 		addFilter(filters, key, val)
 	}
 
-	finalQuery := buildQuery(freeText, filters)
-	limit := req.GetInt("limit", 100)
-	cursor := req.GetString("cursor", "")
+	return buildQuery(freeText, filters), nil
+}
 
-	var (
-		page          int
-		decodedCursor []byte
-	)
-	if cursor != "" {
-		decodedCursor, err = base64.StdEncoding.DecodeString(cursor)
-		if err != nil {
-			ch.logger.Error("Invalid cursor decoding", zap.String("cursor", cursor), zap.Error(err))
-			return nil, fmt.Errorf("invalid cursor: %v", err)
-		}
-		parts := strings.Split(string(decodedCursor), ":")
-		if len(parts) != 2 {
-			ch.logger.Error("Invalid cursor format", zap.String("cursor", cursor))
-			return nil, fmt.Errorf("invalid cursor: %v", cursor)
-		}
-		page, err = strconv.Atoi(parts[1])
-		if err != nil || page < 1 {
-			ch.logger.Error("Invalid cursor page", zap.String("cursor", cursor), zap.Error(err))
-			return nil, fmt.Errorf("invalid cursor page: %v", err)
-		}
-	} else {
-		page = 1
+// parseSearchCursor decodes the page number from a search cursor, page 1 when empty
+func (ch *ConversationsHandler) parseSearchCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 1, nil
+	}
+	decodedCursor, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		ch.logger.Error("Invalid cursor decoding", zap.String("cursor", cursor), zap.Error(err))
+		return 0, fmt.Errorf("invalid cursor: %v", err)
 	}
+	parts := strings.Split(string(decodedCursor), ":")
+	if len(parts) != 2 {
+		ch.logger.Error("Invalid cursor format", zap.String("cursor", cursor))
+		return 0, fmt.Errorf("invalid cursor: %v", cursor)
+	}
+	page, err := strconv.Atoi(parts[1])
+	if err != nil || page < 1 {
+		ch.logger.Error("Invalid cursor page", zap.String("cursor", cursor), zap.Error(err))
+		return 0, fmt.Errorf("invalid cursor page: %v", err)
+	}
+	return page, nil
+}
 
-	ch.logger.Debug("Search parameters built",
-		zap.String("query", finalQuery),
-		zap.Int("limit", limit),
-		zap.Int("page", page),
-	)
-	return &searchParams{
-		query: finalQuery,
-		limit: limit,
-		page:  page,
-	}, nil
+// resolveChannelID turns a channel_id parameter into a channel ID, resolving
+// #channel and @user_dm names from the cache in legacy mode
+func (ch *ConversationsHandler) resolveChannelID(channel string) (string, error) {
+	channel = strings.TrimSpace(channel)
+	if channel == "" {
+		return "", errors.New("channel_id must be a string")
+	}
+	if !strings.HasPrefix(channel, "#") && !strings.HasPrefix(channel, "@") {
+		return channel, nil
+	}
+	if ch.oauthEnabled {
+		return "", fmt.Errorf("in OAuth mode, please use channel ID (C...) instead of name (%s)", channel)
+	}
+
+	channelsMaps := ch.apiProvider.ProvideChannelsMaps()
+	chn, ok := channelsMaps.ChannelsInv[channel]
+	if !ok {
+		return "", fmt.Errorf("channel %q not found", channel)
+	}
+	return channelsMaps.Channels[chn].ID, nil
 }
 
 func (ch *ConversationsHandler) paramFormatUser(raw string) (string, error) {
@@ -920,6 +1370,22 @@ func (ch *ConversationsHandler) paramFormatChannel(raw string) (string, error) {
 	return "", fmt.Errorf("invalid channel format: %q", raw)
 }
 
+// parseMaxTextLen reads the optional max_text_len parameter, 0 meaning no truncation
+func parseMaxTextLen(request mcp.CallToolRequest) (int, error) {
+	maxTextLen := request.GetInt("max_text_len", 0)
+	if maxTextLen < 0 {
+		return 0, errors.New("max_text_len must be a positive integer")
+	}
+	return maxTextLen, nil
+}
+
+// truncateMessages cuts the text of every message to maxTextLen characters
+func truncateMessages(messages []Message, maxTextLen int) {
+	for i := range messages {
+		messages[i].Text = text.TruncateText(messages[i].Text, maxTextLen)
+	}
+}
+
 func marshalMessagesToCSV(messages []Message) (*mcp.CallToolResult, error) {
 	csvBytes, err := gocsv.MarshalBytes(&messages)
 	if err != nil {
@@ -928,6 +1394,25 @@ func marshalMessagesToCSV(messages []Message) (*mcp.CallToolResult, error) {
 	return mcp.NewToolResultText(string(csvBytes)), nil
 }
 
+// mentionNames resolves the mentions in message text from the users cache and,
+// outside of OAuth mode, the channels cache
+func (ch *ConversationsHandler) mentionNames(usersMap *provider.UsersCache) text.MentionNames {
+	names := text.MentionNames{
+		User: func(id string) (string, bool) {
+			u, ok := usersMap.Users[id]
+			return u.Name, ok
+		},
+	}
+	if !ch.oauthEnabled {
+		channels := ch.apiProvider.ProvideChannelsMaps().Channels
+		names.Channel = func(id string) (string, bool) {
+			c, ok := channels[id]
+			return c.Name, ok
+		}
+	}
+	return names
+}
+
 func getUserInfo(userID string, usersMap map[string]slack.User) (userName, realName string, ok bool) {
 	if u, ok := usersMap[userID]; ok {
 		return u.Name, u.RealName, true
@@ -983,6 +1468,20 @@ func limitByExpression(limit, defaultLimit string) (slackLimit int, oldest, late
 	return 100, oldest, latest, nil
 }
 
+// dateRangeBounds converts a date_range expression such as 7d, yesterday or
+// 2024-01-01..2024-02-01 into oldest and latest Slack timestamps. oldest is
+// empty when the range has no start.
+func dateRangeBounds(expr string) (oldest, latest string, err error) {
+	from, to, err := text.ParseDateRange(expr, time.Now())
+	if err != nil {
+		return "", "", err
+	}
+	if !from.IsZero() {
+		oldest = formatSlackTimestamp(from)
+	}
+	return oldest, formatSlackTimestamp(to), nil
+}
+
 func extractThreadTS(rawurl string) (string, error) {
 	u, err := url.Parse(rawurl)
 	if err != nil {
@@ -1176,6 +1675,24 @@ func splitQuery(q string) (freeText []string, filters map[string][]string) {
 	return
 }
 
+// SearchQueryChannels returns the channels of the in: filters written into a
+// search query, which Slack searches in addition to the filter_in_* ones.
+// Channel markup such as <#C123|general> is returned as the channel ID.
+func SearchQueryChannels(query string) []string {
+	_, filters := splitQuery(query)
+	var channels []string
+	for _, val := range filters["in"] {
+		if strings.HasPrefix(val, "<#") {
+			val = strings.TrimPrefix(val, "<#")
+			if i := strings.IndexAny(val, "|>"); i >= 0 {
+				val = val[:i]
+			}
+		}
+		channels = append(channels, val)
+	}
+	return channels
+}
+
 func addFilter(filters map[string][]string, key, val string) {
 	for _, existing := range filters[key] {
 		if existing == val {
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"github.com/slack-go/slack"
+)
+
+// isBotUser reports whether a user is a bot or an app user, Slackbot included
+func isBotUser(user slack.User) bool {
+	return user.IsBot || user.IsAppUser || user.ID == "USLACKBOT"
+}
+
+// isBotMessage reports whether a message was posted by a bot or an app: a
+// bot_message, a bot_id, the app_id of its bot profile or a bot author
+func isBotMessage(msg slack.Message, users map[string]slack.User) bool {
+	if msg.SubType == slack.MsgSubTypeBotMessage || msg.BotID != "" {
+		return true
+	}
+	if msg.BotProfile != nil && (msg.BotProfile.AppID != "" || msg.BotProfile.ID != "") {
+		return true
+	}
+	if user, ok := users[msg.User]; ok {
+		return isBotUser(user)
+	}
+	return msg.User == "USLACKBOT"
+}
+
+// isBotSearchMatch reports whether a search match was posted by a bot or an
+// app. Search results carry no bot_id, matches of bots without a user have
+// a username only.
+func isBotSearchMatch(msg slack.SearchMessage, users map[string]slack.User) bool {
+	if msg.User == "" {
+		return msg.Username != ""
+	}
+	if user, ok := users[msg.User]; ok {
+		return isBotUser(user)
+	}
+	return msg.User == "USLACKBOT"
+}
+
+// cachedAuthors returns the users of the cache once the given authors missing
+// from it are fetched. In OAuth mode there is no cache and bots are told apart
+// by the messages alone.
+func (ch *ConversationsHandler) cachedAuthors(userIDs []string) map[string]slack.User {
+	if ch.oauthEnabled {
+		return nil
+	}
+	ch.apiProvider.ResolveUsers(userIDs)
+	return ch.apiProvider.ProvideUsersMap().Users
+}
+
+// withoutBotMessages drops the messages posted by bots and apps
+func (ch *ConversationsHandler) withoutBotMessages(msgs []slack.Message) []slack.Message {
+	userIDs := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		userIDs = append(userIDs, msg.User)
+	}
+	users := ch.cachedAuthors(userIDs)
+
+	kept := make([]slack.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		if !isBotMessage(msg, users) {
+			kept = append(kept, msg)
+		}
+	}
+	return kept
+}
+
+// withoutBotMatches drops the search matches posted by bots and apps
+func (ch *ConversationsHandler) withoutBotMatches(matches []slack.SearchMessage) []slack.SearchMessage {
+	userIDs := make([]string, 0, len(matches))
+	for _, msg := range matches {
+		userIDs = append(userIDs, msg.User)
+	}
+	users := ch.cachedAuthors(userIDs)
+
+	kept := make([]slack.SearchMessage, 0, len(matches))
+	for _, msg := range matches {
+		if !isBotSearchMatch(msg, users) {
+			kept = append(kept, msg)
+		}
+	}
+	return kept
+}
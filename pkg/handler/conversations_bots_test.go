@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitConversationsHistoryExcludeBots(t *testing.T) {
+	api := &fakeSlackAPI{history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		resp := &slack.GetConversationHistoryResponse{Messages: []slack.Message{
+			{Msg: slack.Msg{Timestamp: "1700000006.000000", User: "U1", Text: "looks good"}},
+			{Msg: slack.Msg{Timestamp: "1700000005.000000", SubType: "bot_message", Username: "ci", Text: "build passed"}},
+			{Msg: slack.Msg{Timestamp: "1700000004.000000", User: "U2", BotID: "B2", Text: "posted by an app"}},
+			{Msg: slack.Msg{Timestamp: "1700000003.000000", User: "U3", BotProfile: &slack.BotProfile{AppID: "A3"}, Text: "app profile"}},
+			{Msg: slack.Msg{Timestamp: "1700000002.000000", User: "U4", Text: "app user"}},
+			{Msg: slack.Msg{Timestamp: "1700000001.000000", User: "USLACKBOT", Text: "reminder"}},
+		}}
+		resp.Ok = true
+		return resp, nil
+	}}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	p.ProvideUsersMap().Users["U1"] = slack.User{ID: "U1", Name: "alice"}
+	p.ProvideUsersMap().Users["U4"] = slack.User{ID: "U4", Name: "jira", IsAppUser: true}
+	ch := NewConversationsHandler(p, zap.NewNop())
+
+	res, err := ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"limit":      "10",
+	}))
+	require.NoError(t, err)
+	assert.Len(t, csvColumn(t, toolResultText(t, res), "MsgID"), 6, "bots are kept by default")
+
+	res, err = ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id":   "C1234567890",
+		"limit":        "10",
+		"exclude_bots": true,
+	}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"1700000006.000000"}, csvColumn(t, out, "MsgID"))
+	assert.Equal(t, []string{"looks good"}, csvColumn(t, out, "Text"))
+}
+
+func TestUnitConversationsSearchExcludeBots(t *testing.T) {
+	api := &fakeSlackAPI{searchMsg: func(query string, params slack.SearchParameters) (*slack.SearchMessages, error) {
+		return &slack.SearchMessages{Matches: []slack.SearchMessage{
+			{Channel: slack.CtxChannel{ID: "C1", Name: "ops"}, User: "U1", Timestamp: "1700000003.000000", Text: "deploy done"},
+			{Channel: slack.CtxChannel{ID: "C1", Name: "ops"}, Username: "ci", Timestamp: "1700000002.000000", Text: "deploy passed"},
+			{Channel: slack.CtxChannel{ID: "C1", Name: "ops"}, User: "B1", Timestamp: "1700000001.000000", Text: "deploy started"},
+		}}, nil
+	}}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	p.ProvideUsersMap().Users["U1"] = slack.User{ID: "U1", Name: "alice"}
+	p.ProvideUsersMap().Users["B1"] = slack.User{ID: "B1", Name: "deploybot", IsBot: true}
+	ch := NewConversationsHandler(p, zap.NewNop())
+
+	res, err := ch.ConversationsSearchHandler(context.Background(), newToolRequest(map[string]any{"search_query": "deploy"}))
+	require.NoError(t, err)
+	assert.Len(t, csvColumn(t, toolResultText(t, res), "MsgID"), 3)
+
+	res, err = ch.ConversationsSearchHandler(context.Background(), newToolRequest(map[string]any{
+		"search_query":      "deploy",
+		"exclude_bots":      true,
+		"include_permalink": true,
+	}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"1700000003.000000"}, csvColumn(t, out, "MsgID"))
+	assert.Equal(t, []string{"alice"}, csvColumn(t, out, "UserName"))
+}
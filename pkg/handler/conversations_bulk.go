@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	maxBulkChannels          = 20
+	bulkHistoryConcurrency   = 4
+	defaultBulkChannelLimit  = 50
+	maxBulkChannelLimit      = 200
+	defaultBulkTotalMessages = 300
+	maxBulkTotalMessages     = 1000
+)
+
+// BulkMessage is a message of conversations_bulk_history. Rows with a Note
+// and no MsgID report a failed channel or messages left out of the response.
+type BulkMessage struct {
+	Channel   string `json:"channelID"`
+	MsgID     string `json:"msgID"`
+	UserID    string `json:"userID"`
+	UserName  string `json:"userUser"`
+	RealName  string `json:"realName"`
+	ThreadTs  string `json:"ThreadTs"`
+	Text      string `json:"text"`
+	Time      string `json:"time"`
+	Reactions string `json:"reactions,omitempty"`
+	Note      string `json:"note,omitempty"`
+}
+
+// conversationsHistoryAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type conversationsHistoryAPI interface {
+	GetConversationHistoryContext(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error)
+}
+
+// bulkChannelResult is the outcome of fetching one channel
+type bulkChannelResult struct {
+	input    string // channel as requested, reported when it cannot be resolved
+	channel  string
+	messages []Message
+	hasMore  bool
+	err      error
+}
+
+// ConversationsBulkHistoryHandler fetches the history of several channels over
+// the same time window and returns it as one CSV
+func (ch *ConversationsHandler) ConversationsBulkHistoryHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsBulkHistoryHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	var api conversationsHistoryAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	inputs := splitChannelIDs(request.GetString("channel_ids", ""))
+	if len(inputs) == 0 {
+		return nil, errors.New("channel_ids must contain at least one channel")
+	}
+	if len(inputs) > maxBulkChannels {
+		return nil, fmt.Errorf("too many channels: %d, at most %d channels can be fetched at once", len(inputs), maxBulkChannels)
+	}
+
+	limit := request.GetString("limit", defaultConversationsExpressionLimit)
+	if !strings.HasSuffix(limit, "d") && !strings.HasSuffix(limit, "w") && !strings.HasSuffix(limit, "m") {
+		return nil, fmt.Errorf("invalid limit %q: must be a time range such as 1d, 1w or 30d", limit)
+	}
+	_, oldest, latest, err := limitByExpression(limit, defaultConversationsExpressionLimit)
+	if err != nil {
+		return nil, err
+	}
+	if dateRange := request.GetString("date_range", ""); dateRange != "" {
+		if oldest, latest, err = dateRangeBounds(dateRange); err != nil {
+			return nil, err
+		}
+	}
+
+	perChannel := request.GetInt("per_channel_limit", defaultBulkChannelLimit)
+	if perChannel < 1 || perChannel > maxBulkChannelLimit {
+		return nil, fmt.Errorf("per_channel_limit must be an integer between 1 and %d", maxBulkChannelLimit)
+	}
+	maxMessages := request.GetInt("max_messages", defaultBulkTotalMessages)
+	if maxMessages < 1 || maxMessages > maxBulkTotalMessages {
+		return nil, fmt.Errorf("max_messages must be an integer between 1 and %d", maxBulkTotalMessages)
+	}
+	activity := request.GetBool("include_activity_messages", false)
+	excludeBots := request.GetBool("exclude_bots", false)
+	maxTextLen, err := parseMaxTextLen(request)
+	if err != nil {
+		return nil, err
+	}
+	format, err := parseMessageFormat(request)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := ch.messageLocation(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]bulkChannelResult, len(inputs))
+	sem := make(chan struct{}, bulkHistoryConcurrency)
+	var wg sync.WaitGroup
+	for i, input := range inputs {
+		results[i].input = input
+		channel, err := ch.resolveChannelID(input)
+		if err != nil {
+			results[i].err = err
+			continue
+		}
+		results[i].channel = channel
+
+		wg.Add(1)
+		go func(res *bulkChannelResult) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			history, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+				ChannelID: res.channel,
+				Limit:     perChannel,
+				Oldest:    oldest,
+				Latest:    latest,
+			})
+			if err != nil {
+				res.err = err
+				return
+			}
+			res.hasMore = history.HasMore
+			source := history.Messages
+			if excludeBots {
+				source = ch.withoutBotMessages(source)
+			}
+			res.messages = ch.convertMessagesFromHistory(source, res.channel, activity)
+			ch.applyMessageFormat(res.messages, source, format)
+			localizeMessageTimes(res.messages, loc)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	quotas := bulkQuotas(results, maxMessages)
+
+	rows := []BulkMessage{}
+	total := 0
+	for i, res := range results {
+		if res.err != nil {
+			ch.logger.Warn("Failed to fetch channel history",
+				zap.String("channel", res.input),
+				zap.Error(res.err),
+			)
+			channel := res.channel
+			if channel == "" {
+				channel = res.input
+			}
+			rows = append(rows, BulkMessage{Channel: channel, Note: "error: " + res.err.Error()})
+			continue
+		}
+
+		for _, msg := range res.messages[:quotas[i]] {
+			rows = append(rows, BulkMessage{
+				Channel:   msg.Channel,
+				MsgID:     msg.MsgID,
+				UserID:    msg.UserID,
+				UserName:  msg.UserName,
+				RealName:  msg.RealName,
+				ThreadTs:  msg.ThreadTs,
+				Text:      text.TruncateText(msg.Text, maxTextLen),
+				Time:      msg.Time,
+				Reactions: msg.Reactions,
+			})
+		}
+		total += quotas[i]
+
+		if omitted := len(res.messages) - quotas[i]; omitted > 0 {
+			rows = append(rows, BulkMessage{
+				Channel: res.channel,
+				Note:    fmt.Sprintf("truncated: %d older messages omitted to stay within max_messages=%d", omitted, maxMessages),
+			})
+		} else if res.hasMore {
+			rows = append(rows, BulkMessage{
+				Channel: res.channel,
+				Note:    fmt.Sprintf("truncated: more messages in the time range than per_channel_limit=%d, use conversations_history to page through them", perChannel),
+			})
+		}
+	}
+
+	ch.logger.Debug("Fetched bulk history",
+		zap.Int("channels", len(results)),
+		zap.Int("messages", total),
+	)
+
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal messages to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// bulkQuotas shares maxMessages between channels so that a busy channel cannot
+// crowd out quiet ones: every channel keeps its newest messages, one at a time
+// in turn, until the cap is reached
+func bulkQuotas(results []bulkChannelResult, maxMessages int) []int {
+	quotas := make([]int, len(results))
+	remaining := maxMessages
+	for remaining > 0 {
+		progressed := false
+		for i, res := range results {
+			if remaining == 0 {
+				break
+			}
+			if quotas[i] < len(res.messages) {
+				quotas[i]++
+				remaining--
+				progressed = true
+			}
+		}
+		if !progressed {
+			break
+		}
+	}
+	return quotas
+}
+
+// splitChannelIDs splits a comma-separated channel list, dropping blanks and duplicates
+func splitChannelIDs(raw string) []string {
+	var (
+		ids  []string
+		seen = make(map[string]bool)
+	)
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" || seen[item] {
+			continue
+		}
+		seen[item] = true
+		ids = append(ids, item)
+	}
+	return ids
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// bulkHistory returns n messages per channel, newest first
+func bulkHistory(counts map[string]int) func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	return func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		n, ok := counts[params.ChannelID]
+		if !ok {
+			return nil, slack.SlackErrorResponse{Err: "channel_not_found"}
+		}
+		resp := &slack.GetConversationHistoryResponse{}
+		for i := n; i > 0 && len(resp.Messages) < params.Limit; i-- {
+			resp.Messages = append(resp.Messages, slack.Message{Msg: slack.Msg{
+				Timestamp: fmt.Sprintf("1700000000.%06d", i),
+				User:      "U1",
+				Text:      fmt.Sprintf("%s-%d", params.ChannelID, i),
+			}})
+		}
+		resp.HasMore = n > params.Limit
+		return resp, nil
+	}
+}
+
+func TestUnitConversationsBulkHistory(t *testing.T) {
+	api := &fakeSlackAPI{history: bulkHistory(map[string]int{"C1": 2, "C2": 1})}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.ConversationsBulkHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_ids": "C1, C404, C2, C1",
+	}))
+	require.NoError(t, err)
+
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"C1", "C1", "C404", "C2"}, csvColumn(t, out, "Channel"))
+	assert.Equal(t, []string{"C1-2", "C1-1", "", "C2-1"}, csvColumn(t, out, "Text"))
+	assert.Equal(t, []string{"", "", "error: channel_not_found", ""}, csvColumn(t, out, "Note"))
+}
+
+func TestUnitConversationsBulkHistoryTimeWindow(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		params []*slack.GetConversationHistoryParameters
+	)
+	api := &fakeSlackAPI{history: func(p *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		params = append(params, p)
+		return &slack.GetConversationHistoryResponse{}, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	_, err := ch.ConversationsBulkHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_ids":       "C1,C2,C3",
+		"limit":             "1w",
+		"per_channel_limit": float64(10),
+	}))
+	require.NoError(t, err)
+
+	require.Len(t, params, 3)
+	for _, p := range params {
+		assert.Equal(t, params[0].Oldest, p.Oldest, "all channels share the time range")
+		assert.Equal(t, params[0].Latest, p.Latest)
+		assert.NotEmpty(t, p.Oldest)
+		assert.Equal(t, 10, p.Limit)
+	}
+}
+
+func TestUnitConversationsBulkHistoryTruncation(t *testing.T) {
+	api := &fakeSlackAPI{history: bulkHistory(map[string]int{"C1": 10, "C2": 2, "C3": 30})}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.ConversationsBulkHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_ids":       "C1,C2,C3",
+		"per_channel_limit": float64(20),
+		"max_messages":      float64(10),
+	}))
+	require.NoError(t, err)
+
+	out := toolResultText(t, res)
+	counts := map[string]int{}
+	var notes []string
+	for i, id := range csvColumn(t, out, "MsgID") {
+		channel := csvColumn(t, out, "Channel")[i]
+		if id != "" {
+			counts[channel]++
+			continue
+		}
+		notes = append(notes, channel+" "+csvColumn(t, out, "Note")[i])
+	}
+
+	// the quiet channel keeps all its messages, the others share the rest
+	assert.Equal(t, map[string]int{"C1": 4, "C2": 2, "C3": 4}, counts)
+	require.Len(t, notes, 2)
+	assert.True(t, strings.HasPrefix(notes[0], "C1 truncated: 6 older messages omitted"), notes[0])
+	assert.True(t, strings.HasPrefix(notes[1], "C3 truncated: 16 older messages omitted"), notes[1])
+}
+
+func TestUnitConversationsBulkHistoryPerChannelLimit(t *testing.T) {
+	api := &fakeSlackAPI{history: bulkHistory(map[string]int{"C1": 5})}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.ConversationsBulkHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_ids":       "C1",
+		"per_channel_limit": float64(3),
+	}))
+	require.NoError(t, err)
+
+	notes := csvColumn(t, toolResultText(t, res), "Note")
+	require.Len(t, notes, 4)
+	assert.Contains(t, notes[3], "per_channel_limit=3")
+}
+
+func TestUnitConversationsBulkHistoryInvalidParams(t *testing.T) {
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", &fakeSlackAPI{}, zap.NewNop()), zap.NewNop())
+
+	many := make([]string, maxBulkChannels+1)
+	for i := range many {
+		many[i] = fmt.Sprintf("C%d", i)
+	}
+
+	for name, args := range map[string]map[string]any{
+		"no channels":       {"channel_ids": " , "},
+		"too many channels": {"channel_ids": strings.Join(many, ",")},
+		"numeric limit":     {"channel_ids": "C1", "limit": "50"},
+		"per channel limit": {"channel_ids": "C1", "per_channel_limit": float64(0)},
+		"max messages":      {"channel_ids": "C1", "max_messages": float64(maxBulkTotalMessages + 1)},
+	} {
+		_, err := ch.ConversationsBulkHistoryHandler(context.Background(), newToolRequest(args))
+		assert.Error(t, err, name)
+	}
+}
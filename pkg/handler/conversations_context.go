@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/limiter"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultContextMessages = 5
+	maxContextMessages     = 100
+
+	// conversations.history always returns newest messages first, so messages
+	// following ts are looked up in a time window that grows until it holds enough
+	contextInitialWindow = 10 * time.Minute
+	contextWindowGrowth  = 8
+	contextPageLimit     = 200
+	contextMaxPages      = 3
+	// contextMaxCalls caps the conversations.history calls of one invocation,
+	// the messages before ts included
+	contextMaxCalls = 10
+)
+
+var errContextTooBusy = errors.New("failed to fetch messages after ts: channel too busy around this time")
+
+// newContextLimiter paces the conversations.history calls of one invocation
+var newContextLimiter = limiter.Tier3.Limiter
+
+// ConversationsContextHandler returns messages before and after a timestamp as CSV
+func (ch *ConversationsHandler) ConversationsContextHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsContextHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	// Get Slack client (OAuth or legacy)
+	var slackClient *slack.Client
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		slackClient = client
+	}
+
+	channel, err := ch.resolveChannelID(request.GetString("channel_id", ""))
+	if err != nil {
+		ch.logger.Error("Failed to resolve channel for context", zap.Error(err))
+		return nil, err
+	}
+
+	ts := request.GetString("ts", "")
+	center, err := parseSlackTimestamp(ts)
+	if err != nil {
+		ch.logger.Error("Invalid ts format", zap.String("ts", ts))
+		return nil, err
+	}
+
+	n := request.GetInt("context", defaultContextMessages)
+	if n < 1 || n > maxContextMessages {
+		return nil, fmt.Errorf("context must be an integer between 1 and %d", maxContextMessages)
+	}
+	loc, err := ch.messageLocation(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// every call waits for the limiter and counts against contextMaxCalls
+	rl := newContextLimiter()
+	calls := 0
+	history := func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		if calls >= contextMaxCalls {
+			return nil, errContextTooBusy
+		}
+		calls++
+		if err := rl.Wait(ctx); err != nil {
+			return nil, err
+		}
+		params.ChannelID = channel
+		if ch.oauthEnabled {
+			return slackClient.GetConversationHistoryContext(ctx, params)
+		}
+		return ch.apiProvider.Slack().GetConversationHistoryContext(ctx, params)
+	}
+
+	// The center message and the ones before it, newest first
+	before, err := history(&slack.GetConversationHistoryParameters{
+		Latest:    ts,
+		Inclusive: true,
+		Limit:     n + 1,
+	})
+	if err != nil {
+		ch.logger.Error("GetConversationHistoryContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	// Without the center message (e.g. a thread reply) one extra older message was fetched
+	beforeMsgs := before.Messages
+	if len(beforeMsgs) > n && beforeMsgs[0].Timestamp != ts {
+		beforeMsgs = beforeMsgs[:n]
+	}
+
+	after, err := ch.fetchMessagesAfter(history, center, n)
+	if err != nil {
+		ch.logger.Error("GetConversationHistoryContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	msgs := append(after, beforeMsgs...)
+	// chronological order
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return compareSlackTimestamps(msgs[i].Timestamp, msgs[j].Timestamp) < 0
+	})
+
+	ch.logger.Debug("Fetched context messages",
+		zap.Int("before", len(beforeMsgs)),
+		zap.Int("after", len(after)),
+	)
+
+	messages := ch.convertMessagesFromHistory(msgs, channel, false)
+	localizeMessageTimes(messages, loc)
+	return marshalMessagesToCSV(messages)
+}
+
+// fetchMessagesAfter returns up to n messages immediately following center.
+// Slack only pages from the newest message backwards, so a window after center
+// is grown until it holds n messages (or reaches now) and shrunk if it is too
+// busy to be paged to its oldest end, until history refuses further calls.
+func (ch *ConversationsHandler) fetchMessagesAfter(
+	history func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error),
+	center time.Time,
+	n int,
+) ([]slack.Message, error) {
+	window := contextInitialWindow
+	oldest := formatSlackTimestamp(center)
+
+	for {
+		end := center.Add(window)
+		reachedNow := !end.Before(time.Now())
+
+		params := &slack.GetConversationHistoryParameters{
+			Oldest:    oldest,
+			Inclusive: false,
+			Limit:     contextPageLimit,
+		}
+		if !reachedNow {
+			params.Latest = formatSlackTimestamp(end)
+		}
+
+		var msgs []slack.Message
+		complete := false
+		for page := 0; page < contextMaxPages; page++ {
+			resp, err := history(params)
+			if err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, resp.Messages...)
+			if !resp.HasMore {
+				complete = true
+				break
+			}
+			params.Cursor = resp.ResponseMetaData.NextCursor
+		}
+
+		if !complete {
+			// too many messages to reach the ones right after center
+			window /= contextWindowGrowth
+			continue
+		}
+
+		if len(msgs) >= n || reachedNow {
+			// msgs are newest first, keep the n oldest
+			if len(msgs) > n {
+				msgs = msgs[len(msgs)-n:]
+			}
+			return msgs, nil
+		}
+		window *= contextWindowGrowth
+	}
+}
+
+func parseSlackTimestamp(ts string) (time.Time, error) {
+	sec, frac, ok := strings.Cut(ts, ".")
+	if !ok || len(frac) != 6 {
+		return time.Time{}, errors.New("ts must be a valid timestamp in format 1234567890.123456")
+	}
+	s, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.New("ts must be a valid timestamp in format 1234567890.123456")
+	}
+	us, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.New("ts must be a valid timestamp in format 1234567890.123456")
+	}
+	return time.Unix(s, us*int64(time.Microsecond)), nil
+}
+
+func formatSlackTimestamp(t time.Time) string {
+	return fmt.Sprintf("%d.%06d", t.Unix(), t.Nanosecond()/int(time.Microsecond))
+}
+
+func compareSlackTimestamps(a, b string) int {
+	ta, errA := parseSlackTimestamp(a)
+	tb, errB := parseSlackTimestamp(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return ta.Compare(tb)
+}
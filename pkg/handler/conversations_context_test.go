@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// unlimitedContextHistory lifts the Tier3 pacing of conversations_context for a test
+func unlimitedContextHistory(t *testing.T) {
+	orig := newContextLimiter
+	newContextLimiter = func() *rate.Limiter { return rate.NewLimiter(rate.Inf, 1) }
+	t.Cleanup(func() { newContextLimiter = orig })
+}
+
+// fakeChannelHistory emulates conversations.history over messages sorted oldest
+// first: results are newest first, bounded by oldest/latest and paged by limit.
+func fakeChannelHistory(msgs []slack.Message) func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	return func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		var window []slack.Message
+		for i := len(msgs) - 1; i >= 0; i-- {
+			ts := msgs[i].Timestamp
+			if params.Latest != "" {
+				c := compareSlackTimestamps(ts, params.Latest)
+				if c > 0 || (c == 0 && !params.Inclusive) {
+					continue
+				}
+			}
+			if params.Oldest != "" {
+				c := compareSlackTimestamps(ts, params.Oldest)
+				if c < 0 || (c == 0 && !params.Inclusive) {
+					continue
+				}
+			}
+			window = append(window, msgs[i])
+		}
+
+		offset := 0
+		if params.Cursor != "" {
+			offset, _ = strconv.Atoi(params.Cursor)
+		}
+		end := offset + params.Limit
+		resp := &slack.GetConversationHistoryResponse{}
+		if end < len(window) {
+			resp.HasMore = true
+			resp.ResponseMetaData.NextCursor = strconv.Itoa(end)
+		} else {
+			end = len(window)
+		}
+		resp.Messages = window[offset:end]
+		return resp, nil
+	}
+}
+
+func contextTestMessages(start time.Time, step time.Duration, count int) []slack.Message {
+	msgs := make([]slack.Message, count)
+	for i := range msgs {
+		msgs[i].Timestamp = formatSlackTimestamp(start.Add(time.Duration(i) * step))
+		msgs[i].User = "U" + strconv.Itoa(i)
+		msgs[i].Text = "message " + strconv.Itoa(i)
+	}
+	return msgs
+}
+
+func TestUnitConversationsContext(t *testing.T) {
+	unlimitedContextHistory(t)
+	start := time.Now().Add(-30 * 24 * time.Hour).Truncate(time.Second)
+
+	cases := []struct {
+		name   string
+		step   time.Duration
+		center int
+		want   []string
+	}{
+		{"dense channel", time.Second, 15, []string{"12", "13", "14", "15", "16", "17", "18"}},
+		{"sparse channel", 5 * time.Hour, 15, []string{"12", "13", "14", "15", "16", "17", "18"}},
+		{"near the end", time.Minute, 28, []string{"25", "26", "27", "28", "29"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			msgs := contextTestMessages(start, tc.step, 30)
+			api := &fakeSlackAPI{history: fakeChannelHistory(msgs)}
+			ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+			res, err := ch.ConversationsContextHandler(context.Background(), newToolRequest(map[string]any{
+				"channel_id": "C1234567890",
+				"ts":         msgs[tc.center].Timestamp,
+				"context":    3,
+			}))
+			require.NoError(t, err)
+
+			var want []string
+			for _, i := range tc.want {
+				want = append(want, "U"+i)
+			}
+			assert.Equal(t, want, csvColumn(t, toolResultText(t, res), "UserID"))
+		})
+	}
+}
+
+func TestUnitConversationsContextThreadReply(t *testing.T) {
+	unlimitedContextHistory(t)
+	start := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	msgs := contextTestMessages(start, time.Minute, 10)
+	api := &fakeSlackAPI{history: fakeChannelHistory(msgs)}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	// a reply posted between messages 4 and 5 is not part of the channel history
+	replyTs := formatSlackTimestamp(start.Add(4*time.Minute + 30*time.Second))
+	res, err := ch.ConversationsContextHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"ts":         replyTs,
+		"context":    2,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U3", "U4", "U5", "U6"}, csvColumn(t, toolResultText(t, res), "UserID"))
+}
+
+func TestUnitConversationsContextCallBudget(t *testing.T) {
+	unlimitedContextHistory(t)
+	start := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	msgs := contextTestMessages(start, time.Second, 30)
+	history := fakeChannelHistory(msgs)
+
+	// a channel so busy that no window can be paged to its oldest end
+	calls := 0
+	api := &fakeSlackAPI{history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		calls++
+		resp, err := history(params)
+		if err != nil {
+			return nil, err
+		}
+		resp.HasMore = true
+		resp.ResponseMetaData.NextCursor = "1"
+		return resp, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	_, err := ch.ConversationsContextHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"ts":         msgs[15].Timestamp,
+		"context":    3,
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "too busy")
+	assert.Equal(t, contextMaxCalls, calls)
+}
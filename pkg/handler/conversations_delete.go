@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+type DeletedMessage struct {
+	Channel string `json:"channelID"`
+	MsgID   string `json:"msgID"`
+}
+
+// messageDeleteAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type messageDeleteAPI interface {
+	DeleteMessageContext(ctx context.Context, channel, messageTimestamp string) (string, string, error)
+}
+
+// destructiveToolsAllowed reports whether SLACK_MCP_ALLOW_DESTRUCTIVE enables
+// the tools that cannot be undone, such as conversations_delete_message
+func destructiveToolsAllowed() bool {
+	return envEnabled("SLACK_MCP_ALLOW_DESTRUCTIVE")
+}
+
+// ConversationsDeleteMessageHandler deletes a message with chat.delete
+func (ch *ConversationsHandler) ConversationsDeleteMessageHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsDeleteMessageHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	if !destructiveToolsAllowed() {
+		return nil, errors.New("by default, the conversations_delete_message tool is disabled, deleted messages cannot be restored. " +
+			"To enable it, set the SLACK_MCP_ALLOW_DESTRUCTIVE environment variable to true")
+	}
+
+	channel, err := ch.resolveChannelID(request.GetString("channel_id", ""))
+	if err != nil {
+		return nil, err
+	}
+	ts := request.GetString("ts", "")
+	if _, err := parseSlackTimestamp(ts); err != nil {
+		ch.logger.Error("Invalid ts format", zap.String("ts", ts))
+		return nil, err
+	}
+
+	var api messageDeleteAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	respChannel, respTs, err := api.DeleteMessageContext(ctx, channel, ts)
+	switch {
+	case err == nil:
+	case isSlackError(err, "message_not_found"):
+		return nil, fmt.Errorf("message %s not found in channel %s: %w", ts, channel, err)
+	case isSlackError(err, "cant_delete_message"):
+		return nil, fmt.Errorf("message %s cannot be deleted with this token, users can only delete their own messages unless they are workspace admins: %w", ts, err)
+	default:
+		ch.logger.Error("Slack DeleteMessageContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	rows := []DeletedMessage{{
+		Channel: respChannel,
+		MsgID:   respTs,
+	}}
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal deleted message to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitConversationsDeleteMessage(t *testing.T) {
+	var deleted []string
+	api := &fakeSlackAPI{delete: func(channel, ts string) (string, string, error) {
+		switch ts {
+		case "1700000000.000404":
+			return "", "", slack.SlackErrorResponse{Err: "message_not_found"}
+		case "1700000000.000403":
+			return "", "", slack.SlackErrorResponse{Err: "cant_delete_message"}
+		}
+		deleted = append(deleted, channel+"/"+ts)
+		return channel, ts, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	del := func(ts string) (string, error) {
+		res, err := ch.ConversationsDeleteMessageHandler(context.Background(), newToolRequest(map[string]any{
+			"channel_id": "C1234567890",
+			"ts":         ts,
+		}))
+		if err != nil {
+			return "", err
+		}
+		return toolResultText(t, res), nil
+	}
+
+	_, err := del("1700000000.000100")
+	require.Error(t, err, "disabled by default")
+	assert.Contains(t, err.Error(), "SLACK_MCP_ALLOW_DESTRUCTIVE")
+	assert.Empty(t, deleted)
+
+	t.Setenv("SLACK_MCP_ALLOW_DESTRUCTIVE", "true")
+
+	out, err := del("1700000000.000100")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1700000000.000100"}, csvColumn(t, out, "MsgID"))
+	assert.Equal(t, []string{"C1234567890/1700000000.000100"}, deleted)
+
+	_, err = del("1700000000.000404")
+	assert.ErrorContains(t, err, "not found")
+
+	_, err = del("1700000000.000403")
+	assert.ErrorContains(t, err, "own messages")
+
+	_, err = del("yesterday")
+	assert.Error(t, err)
+	assert.Len(t, deleted, 1)
+}
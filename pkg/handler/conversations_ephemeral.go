@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type EphemeralMessage struct {
+	Channel string `json:"channelID"`
+	UserID  string `json:"userID"`
+	MsgID   string `json:"msgID"`
+}
+
+// ephemeralAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type ephemeralAPI interface {
+	PostEphemeralContext(ctx context.Context, channelID, userID string, options ...slack.MsgOption) (string, error)
+}
+
+// ConversationsPostEphemeralHandler posts a message to a channel that only the
+// given user sees, with chat.postEphemeral. OAuth mode posts with the bot token,
+// so that the message comes from the app rather than from the user. It is
+// subject to the same SLACK_MCP_ADD_MESSAGE_TOOL policy as conversations_add_message.
+func (ch *ConversationsHandler) ConversationsPostEphemeralHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsPostEphemeralHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	params, err := ch.parseParamsToolAddMessage(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse post-ephemeral params", zap.Error(err))
+		return nil, err
+	}
+	userIDs, err := ch.parseUserIDs(request.GetString("user_id", ""))
+	if err != nil {
+		return nil, err
+	}
+	if len(userIDs) != 1 {
+		return nil, errors.New("user_id must be a single user ID")
+	}
+	userID := userIDs[0]
+
+	var api ephemeralAPI
+	if ch.oauthEnabled {
+		client, err := ch.getBotSlackClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("in OAuth mode, ephemeral messages are posted with the bot token: %w", err)
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	if err := ch.resolveThreadParent(ctx, params); err != nil {
+		return nil, err
+	}
+	options, err := ch.addMessageOptions(params)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := api.PostEphemeralContext(ctx, params.channel, userID, options...)
+	switch {
+	case err == nil:
+	case isSlackError(err, "user_not_in_channel"):
+		return nil, fmt.Errorf("user %s is not a member of channel %s, ephemeral messages can only be shown to members: %w", userID, params.channel, err)
+	case isSlackError(err, "not_in_channel"), isSlackError(err, "channel_not_found"):
+		return nil, fmt.Errorf("cannot post to channel %s, the app must be a member of it: %w", params.channel, err)
+	default:
+		ch.logger.Error("Slack PostEphemeralContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	rows := []EphemeralMessage{{
+		Channel: params.channel,
+		UserID:  userID,
+		MsgID:   ts,
+	}}
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal ephemeral message to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitConversationsPostEphemeral(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "C1234567890")
+
+	var (
+		gotChannel, gotUser string
+		values              url.Values
+	)
+	api := &fakeSlackAPI{ephemeral: func(channel, userID string, options ...slack.MsgOption) (string, error) {
+		if userID == "U0OUTSIDE" {
+			return "", slack.SlackErrorResponse{Err: "user_not_in_channel"}
+		}
+		gotChannel, gotUser = channel, userID
+		var err error
+		_, values, err = slack.UnsafeApplyMsgOptions("token", channel, "https://slack.com/api/", options...)
+		require.NoError(t, err)
+		return "1700000000.000500", nil
+	}}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	p.ProvideUsersMap().UsersInv["alice"] = "U0ALICE"
+	ch := NewConversationsHandler(p, zap.NewNop())
+
+	post := func(channel, user string) (string, error) {
+		res, err := ch.ConversationsPostEphemeralHandler(context.Background(), newToolRequest(map[string]any{
+			"channel_id":   channel,
+			"user_id":      user,
+			"payload":      "only you can see this",
+			"content_type": "text/plain",
+		}))
+		if err != nil {
+			return "", err
+		}
+		return toolResultText(t, res), nil
+	}
+
+	out, err := post("C1234567890", "@alice")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1700000000.000500"}, csvColumn(t, out, "MsgID"))
+	assert.Equal(t, []string{"U0ALICE"}, csvColumn(t, out, "UserID"))
+	assert.Equal(t, "C1234567890", gotChannel)
+	assert.Equal(t, "U0ALICE", gotUser)
+	assert.Equal(t, "only you can see this", values.Get("text"))
+
+	_, err = post("C1234567890", "U0OUTSIDE")
+	assert.ErrorContains(t, err, "not a member")
+
+	_, err = post("C1234567890", "")
+	assert.ErrorContains(t, err, "user_id")
+
+	_, err = post("C1234567890", "U1,U2")
+	assert.ErrorContains(t, err, "single user")
+
+	_, err = post("C0987654321", "U0ALICE")
+	assert.ErrorContains(t, err, "not allowed", "the add-message channel policy applies")
+}
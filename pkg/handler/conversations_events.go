@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultChannelEventsLimit    = "30d"
+	defaultChannelEventsMaxItems = 1000
+	maxChannelEventsMaxItems     = 5000
+	channelEventsPageSize        = 200
+)
+
+// channelEventSubtypes are the system message subtypes recording membership and
+// channel changes. Only messages with one of them are reported, normal messages
+// and other activity such as file shares or bot messages never are.
+var channelEventSubtypes = map[string]bool{
+	slack.MsgSubTypeChannelJoin:      true,
+	slack.MsgSubTypeChannelLeave:     true,
+	slack.MsgSubTypeChannelTopic:     true,
+	slack.MsgSubTypeChannelPurpose:   true,
+	slack.MsgSubTypeChannelName:      true,
+	slack.MsgSubTypeChannelArchive:   true,
+	slack.MsgSubTypeChannelUnarchive: true,
+	slack.MsgSubTypeGroupJoin:        true,
+	slack.MsgSubTypeGroupLeave:       true,
+	slack.MsgSubTypeGroupTopic:       true,
+	slack.MsgSubTypeGroupPurpose:     true,
+	slack.MsgSubTypeGroupName:        true,
+	slack.MsgSubTypeGroupArchive:     true,
+	slack.MsgSubTypeGroupUnarchive:   true,
+	"channel_convert_to_private":     true,
+	"channel_convert_to_public":      true,
+}
+
+// ChannelEvent is a membership or channel change of conversations_channel_events.
+// A row with a Note and no MsgID reports that the scan stopped at max_items.
+type ChannelEvent struct {
+	Time     string `json:"time"`
+	MsgID    string `json:"msgID"`
+	Event    string `json:"event"`
+	UserID   string `json:"userID"`
+	UserName string `json:"userUser"`
+	RealName string `json:"realName"`
+	Detail   string `json:"detail"` // new topic, purpose or name
+	Note     string `json:"note,omitempty"`
+}
+
+// ConversationsChannelEventsHandler scans the history of a channel for joins,
+// leaves, topic, purpose and name changes and returns them as a timeline,
+// oldest first. It needs no admin scopes, unlike the audit logs API.
+func (ch *ConversationsHandler) ConversationsChannelEventsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsChannelEventsHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	var api conversationsHistoryAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	channel, err := ch.resolveChannelID(request.GetString("channel_id", ""))
+	if err != nil {
+		ch.logger.Error("Failed to resolve channel for channel events", zap.Error(err))
+		return nil, err
+	}
+
+	limit := request.GetString("limit", defaultChannelEventsLimit)
+	if !strings.HasSuffix(limit, "d") && !strings.HasSuffix(limit, "w") && !strings.HasSuffix(limit, "m") {
+		return nil, fmt.Errorf("invalid limit %q: must be a time range such as 1d, 1w or 30d", limit)
+	}
+	_, oldest, latest, err := limitByExpression(limit, defaultChannelEventsLimit)
+	if err != nil {
+		return nil, err
+	}
+	if dateRange := request.GetString("date_range", ""); dateRange != "" {
+		if oldest, latest, err = dateRangeBounds(dateRange); err != nil {
+			return nil, err
+		}
+	}
+
+	maxItems := request.GetInt("max_items", defaultChannelEventsMaxItems)
+	if maxItems < 1 || maxItems > maxChannelEventsMaxItems {
+		return nil, fmt.Errorf("max_items must be an integer between 1 and %d", maxChannelEventsMaxItems)
+	}
+	loc, err := ch.messageLocation(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events    []slack.Message
+		scanned   int
+		truncated bool
+		cursor    string
+	)
+	for {
+		history, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: channel,
+			Limit:     min(channelEventsPageSize, maxItems-scanned),
+			Oldest:    oldest,
+			Latest:    latest,
+			Cursor:    cursor,
+		})
+		if err != nil {
+			ch.logger.Error("GetConversationHistoryContext failed", zap.Error(err))
+			return nil, err
+		}
+
+		for _, msg := range history.Messages {
+			if scanned == maxItems {
+				truncated = true
+				break
+			}
+			scanned++
+			if channelEventSubtypes[msg.SubType] {
+				events = append(events, msg)
+			}
+		}
+
+		cursor = history.ResponseMetaData.NextCursor
+		if truncated || !history.HasMore || cursor == "" {
+			break
+		}
+		if scanned == maxItems {
+			truncated = true
+			break
+		}
+	}
+
+	ch.logger.Debug("Scanned channel history for events",
+		zap.String("channel", channel),
+		zap.Int("scanned", scanned),
+		zap.Int("events", len(events)),
+	)
+
+	bySource := make(map[string]slack.Message, len(events))
+	for _, msg := range events {
+		bySource[msg.Timestamp] = msg
+	}
+	converted := ch.convertMessagesFromHistory(events, channel, true)
+	localizeMessageTimes(converted, loc)
+
+	rows := make([]ChannelEvent, 0, len(converted)+1)
+	if truncated {
+		rows = append(rows, ChannelEvent{
+			Note: fmt.Sprintf("truncated: stopped after scanning max_items=%d messages, older events in the time range are not listed", maxItems),
+		})
+	}
+	// history is newest first, the timeline oldest first
+	for i := len(converted) - 1; i >= 0; i-- {
+		msg := converted[i]
+		src := bySource[msg.MsgID]
+		rows = append(rows, ChannelEvent{
+			Time:     msg.Time,
+			MsgID:    msg.MsgID,
+			Event:    src.SubType,
+			UserID:   msg.UserID,
+			UserName: msg.UserName,
+			RealName: msg.RealName,
+			Detail:   channelEventDetail(src),
+		})
+	}
+
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal channel events to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// channelEventDetail returns what a topic, purpose or name change changed to
+func channelEventDetail(msg slack.Message) string {
+	switch msg.SubType {
+	case slack.MsgSubTypeChannelTopic, slack.MsgSubTypeGroupTopic:
+		return msg.Topic
+	case slack.MsgSubTypeChannelPurpose, slack.MsgSubTypeGroupPurpose:
+		return msg.Purpose
+	case slack.MsgSubTypeChannelName, slack.MsgSubTypeGroupName:
+		if msg.OldName != "" {
+			return msg.OldName + " -> " + msg.Name
+		}
+		return msg.Name
+	}
+	return ""
+}
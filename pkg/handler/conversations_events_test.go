@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitConversationsChannelEvents(t *testing.T) {
+	pages := map[string][]slack.Message{
+		"": {
+			{Msg: slack.Msg{Timestamp: "1700000005.000000", User: "U1", Text: "hello everyone"}},
+			{Msg: slack.Msg{Timestamp: "1700000004.000000", User: "U2", SubType: "channel_name", Name: "launch", OldName: "project-x"}},
+			{Msg: slack.Msg{Timestamp: "1700000003.000000", User: "U2", SubType: "file_share", Text: "a file"}},
+		},
+		"page2": {
+			{Msg: slack.Msg{Timestamp: "1700000002.000000", User: "U2", SubType: "channel_topic", Topic: "Launch on Monday"}},
+			{Msg: slack.Msg{Timestamp: "1700000001.000000", User: "U2", SubType: "channel_join", Text: "<@U2> has joined the channel"}},
+		},
+	}
+	var calls int
+	api := &fakeSlackAPI{history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		calls++
+		resp := &slack.GetConversationHistoryResponse{Messages: pages[params.Cursor]}
+		if params.Cursor == "" {
+			resp.HasMore = true
+			resp.ResponseMetaData.NextCursor = "page2"
+		}
+		resp.Ok = true
+		return resp, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.ConversationsChannelEventsHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1234567890"}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"channel_join", "channel_topic", "channel_name"}, csvColumn(t, out, "Event"), "only system subtypes, oldest first")
+	assert.Equal(t, []string{"", "Launch on Monday", "project-x -> launch"}, csvColumn(t, out, "Detail"))
+	assert.Equal(t, 2, calls)
+
+	// the scan stops at max_items and says so
+	calls = 0
+	res, err = ch.ConversationsChannelEventsHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1234567890", "max_items": 3}))
+	require.NoError(t, err)
+	out = toolResultText(t, res)
+	assert.Equal(t, []string{"", "channel_name"}, csvColumn(t, out, "Event"))
+	assert.Contains(t, csvColumn(t, out, "Note")[0], "max_items=3")
+	assert.Equal(t, 1, calls)
+
+	_, err = ch.ConversationsChannelEventsHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1234567890", "max_items": 0}))
+	assert.Error(t, err)
+	_, err = ch.ConversationsChannelEventsHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1234567890", "limit": "10"}))
+	assert.Error(t, err)
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+)
+
+// formats of the message text of the history tools
+const (
+	messageFormatText     = "text"     // plain text, Slack markup removed
+	messageFormatMarkdown = "markdown" // mrkdwn converted to standard Markdown
+)
+
+// parseMessageFormat reads the format parameter of the history tools
+func parseMessageFormat(request mcp.CallToolRequest) (string, error) {
+	switch format := request.GetString("format", messageFormatText); format {
+	case messageFormatText, messageFormatMarkdown:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid format %q: must be %q or %q", format, messageFormatText, messageFormatMarkdown)
+	}
+}
+
+// messageText returns the text of a message with its attachments, or the
+// flattened blocks and attachments of messages without text, which apps often post
+func messageText(msgText string, blocks slack.Blocks, attachments []slack.Attachment) string {
+	if msgText == "" {
+		return text.FlattenMessage(blocks, attachments)
+	}
+	return msgText + text.AttachmentsTo2CSV(msgText, attachments)
+}
+
+// applyMessageFormat replaces the text of converted messages with the text of
+// the original Slack messages in the given format. The text format is what
+// the conversion already returns.
+func (ch *ConversationsHandler) applyMessageFormat(messages []Message, source []slack.Message, format string) {
+	if format != messageFormatMarkdown {
+		return
+	}
+
+	byTs := make(map[string]slack.Message, len(source))
+	for _, msg := range source {
+		byTs[msg.Timestamp] = msg
+	}
+	usersMap := &provider.UsersCache{Users: map[string]slack.User{}}
+	if !ch.oauthEnabled {
+		usersMap = ch.apiProvider.ProvideUsersMap()
+	}
+	names := ch.mentionNames(usersMap)
+
+	for i := range messages {
+		if msg, ok := byTs[messages[i].MsgID]; ok {
+			messages[i].Text = text.MrkdwnToMarkdown(messageText(msg.Text, msg.Blocks, msg.Attachments), names)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// MessageDetail is a single message of conversations_get_message with its
+// reactions and the metadata of its files
+type MessageDetail struct {
+	MsgID     string `json:"msgID"`
+	UserID    string `json:"userID"`
+	UserName  string `json:"userUser"`
+	RealName  string `json:"realName"`
+	Channel   string `json:"channelID"`
+	ThreadTs  string `json:"ThreadTs"`
+	Text      string `json:"text"`
+	Time      string `json:"time"`
+	Reactions string `json:"reactions"`
+	Files     string `json:"files"` // id:name:mimetype:size of each file, separated by |
+}
+
+// messageAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type messageAPI interface {
+	conversationsRepliesAPI
+	GetConversationHistoryContext(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error)
+}
+
+// ConversationsGetMessageHandler returns a single message by permalink or by
+// channel_id and ts. Channel messages are looked up with conversations.history,
+// thread replies, which history does not list, with conversations.replies.
+func (ch *ConversationsHandler) ConversationsGetMessageHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsGetMessageHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	var channel, ts, threadTs string
+	if permalink := strings.TrimSpace(request.GetString("permalink", "")); permalink != "" {
+		if request.GetString("channel_id", "") != "" || request.GetString("ts", "") != "" {
+			return nil, errors.New("give either permalink or channel_id and ts, not both")
+		}
+		link, err := text.ParsePermalink(permalink)
+		if err != nil {
+			return nil, err
+		}
+		channel, ts, threadTs = link.ChannelID, link.Ts, link.ThreadTs
+	} else {
+		var err error
+		channel, err = ch.resolveChannelID(request.GetString("channel_id", ""))
+		if err != nil {
+			return nil, fmt.Errorf("permalink or channel_id and ts are required: %w", err)
+		}
+		ts = request.GetString("ts", "")
+		if _, err := parseSlackTimestamp(ts); err != nil {
+			ch.logger.Error("Invalid ts format", zap.String("ts", ts))
+			return nil, err
+		}
+	}
+
+	loc, err := ch.messageLocation(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var api messageAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	msg, err := ch.fetchMessage(ctx, api, channel, ts, threadTs)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep activity messages, e.g. a file share
+	converted := ch.convertMessagesFromHistory([]slack.Message{msg}, channel, true)
+	localizeMessageTimes(converted, loc)
+	if len(converted) == 0 {
+		return nil, errors.New("failed to convert message")
+	}
+	m := converted[0]
+
+	var files []string
+	for _, f := range msg.Files {
+		files = append(files, fmt.Sprintf("%s:%s:%s:%d", f.ID, f.Name, f.Mimetype, f.Size))
+	}
+	rows := []MessageDetail{{
+		MsgID:     m.MsgID,
+		UserID:    m.UserID,
+		UserName:  m.UserName,
+		RealName:  m.RealName,
+		Channel:   m.Channel,
+		ThreadTs:  m.ThreadTs,
+		Text:      m.Text,
+		Time:      m.Time,
+		Reactions: m.Reactions,
+		Files:     strings.Join(files, "|"),
+	}}
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal message to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// fetchMessage returns the message ts of a channel. threadTs, when known from
+// a permalink, marks a thread reply and skips the history lookup.
+func (ch *ConversationsHandler) fetchMessage(ctx context.Context, api messageAPI, channel, ts, threadTs string) (slack.Message, error) {
+	if threadTs == "" {
+		history, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: channel,
+			Latest:    ts,
+			Limit:     1,
+			Inclusive: true,
+		})
+		if err != nil {
+			ch.logger.Error("GetConversationHistoryContext failed", zap.Error(err))
+			return slack.Message{}, err
+		}
+		if len(history.Messages) > 0 && history.Messages[0].Timestamp == ts {
+			return history.Messages[0], nil
+		}
+	}
+
+	// conversations.replies lists the root first, then the replies from oldest
+	msgs, _, _, err := api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: channel,
+		Timestamp: ts,
+		Oldest:    ts,
+		Limit:     2,
+		Inclusive: true,
+	})
+	switch {
+	case err == nil:
+	case isSlackError(err, "thread_not_found"), isSlackError(err, "message_not_found"):
+		return slack.Message{}, fmt.Errorf("message %s not found in channel %s: %w", ts, channel, err)
+	default:
+		ch.logger.Error("GetConversationRepliesContext failed", zap.Error(err))
+		return slack.Message{}, err
+	}
+	for _, msg := range msgs {
+		if msg.Timestamp == ts {
+			return msg, nil
+		}
+	}
+	return slack.Message{}, fmt.Errorf("message %s not found in channel %s", ts, channel)
+}
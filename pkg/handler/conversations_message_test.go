@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitConversationsGetMessage(t *testing.T) {
+	channelMsg := slack.Message{Msg: slack.Msg{
+		Timestamp: "1700000000.000100",
+		User:      "U1",
+		Text:      "quarterly report",
+		Reactions: []slack.ItemReaction{{Name: "eyes", Count: 2}},
+		Files:     []slack.File{{ID: "F1", Name: "report.pdf", Mimetype: "application/pdf", Size: 1024}},
+	}}
+	root := slack.Message{Msg: slack.Msg{Timestamp: "1700000000.000200", ThreadTimestamp: "1700000000.000200", User: "U1", Text: "root"}}
+	reply := slack.Message{Msg: slack.Msg{Timestamp: "1700000000.000300", ThreadTimestamp: "1700000000.000200", User: "U2", Text: "a reply"}}
+
+	var historyCalls, repliesCalls int
+	api := &fakeSlackAPI{
+		history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+			historyCalls++
+			assert.Equal(t, 1, params.Limit)
+			assert.True(t, params.Inclusive)
+			resp := &slack.GetConversationHistoryResponse{}
+			resp.Ok = true
+			// history never lists replies, the next older channel message is returned
+			if params.Latest >= channelMsg.Timestamp {
+				resp.Messages = []slack.Message{channelMsg}
+			}
+			if params.Latest >= root.Timestamp {
+				resp.Messages = []slack.Message{root}
+			}
+			return resp, nil
+		},
+		replies: func(params *slack.GetConversationRepliesParameters) ([]slack.Message, error) {
+			repliesCalls++
+			if params.Timestamp == reply.Timestamp {
+				return []slack.Message{root, reply}, nil
+			}
+			return nil, slack.SlackErrorResponse{Err: "thread_not_found"}
+		},
+	}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	get := func(args map[string]any) (string, error) {
+		res, err := ch.ConversationsGetMessageHandler(context.Background(), newToolRequest(args))
+		if err != nil {
+			return "", err
+		}
+		return toolResultText(t, res), nil
+	}
+
+	out, err := get(map[string]any{"channel_id": "C1234567890", "ts": "1700000000.000100"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"quarterly report"}, csvColumn(t, out, "Text"))
+	assert.Equal(t, []string{"eyes:2"}, csvColumn(t, out, "Reactions"))
+	assert.Equal(t, []string{"F1:report.pdf:application/pdf:1024"}, csvColumn(t, out, "Files"))
+	assert.Equal(t, 0, repliesCalls)
+
+	// a reply by channel and ts falls back to conversations.replies
+	out, err = get(map[string]any{"channel_id": "C1234567890", "ts": "1700000000.000300"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a reply"}, csvColumn(t, out, "Text"))
+	assert.Equal(t, []string{"1700000000.000200"}, csvColumn(t, out, "ThreadTs"))
+
+	// a permalink of a reply goes to conversations.replies directly
+	historyCalls = 0
+	out, err = get(map[string]any{"permalink": "https://acme.slack.com/archives/C1234567890/p1700000000000300?thread_ts=1700000000.000200&cid=C1234567890"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a reply"}, csvColumn(t, out, "Text"))
+	assert.Equal(t, 0, historyCalls)
+
+	out, err = get(map[string]any{"permalink": "https://acme.slack.com/archives/C1234567890/p1700000000000100"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1700000000.000100"}, csvColumn(t, out, "MsgID"))
+
+	_, err = get(map[string]any{"channel_id": "C1234567890", "ts": "1700000000.000150"})
+	assert.ErrorContains(t, err, "not found in channel C1234567890")
+
+	_, err = get(map[string]any{"permalink": "https://acme.slack.com/archives/C1234567890/p1700000000000100", "ts": "1700000000.000100"})
+	assert.Error(t, err)
+	_, err = get(map[string]any{"permalink": "https://acme.slack.com/files/U1/F1/report.pdf"})
+	assert.Error(t, err)
+	_, err = get(map[string]any{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"github.com/gocarina/gocsv"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+)
+
+// editSubtypes are the message subtypes recording edits and deletions, which
+// include_edit_events keeps without the other activity messages
+var editSubtypes = map[string]bool{
+	slack.MsgSubTypeMessageChanged: true,
+	slack.MsgSubTypeMessageDeleted: true,
+	"tombstone":                    true,
+}
+
+// MessageWithMetadata is a message of conversations_history with
+// include_metadata. Slack only keeps the latest edit of a message, so a
+// message edited several times reports its last edit only.
+type MessageWithMetadata struct {
+	MsgID      string `json:"msgID"`
+	UserID     string `json:"userID"`
+	UserName   string `json:"userUser"`
+	RealName   string `json:"realName"`
+	Channel    string `json:"channelID"`
+	ThreadTs   string `json:"ThreadTs"`
+	Text       string `json:"text"`
+	Time       string `json:"time"`
+	Reactions  string `json:"reactions,omitempty"`
+	Subtype    string `json:"subtype,omitempty"`
+	EditedTs   string `json:"editedTs,omitempty"`
+	EditedUser string `json:"editedUser,omitempty"`
+	Cursor     string `json:"cursor"`
+}
+
+// filterEditEvents keeps regular messages and edit events, dropping the other
+// activity messages, so that the result can be converted with activity messages
+// included. A message_changed event gets the text and author of the edited message.
+func filterEditEvents(msgs []slack.Message) []slack.Message {
+	kept := make([]slack.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg.SubType != "" && msg.SubType != "bot_message" && !editSubtypes[msg.SubType] {
+			continue
+		}
+		if msg.SubType == slack.MsgSubTypeMessageChanged && msg.SubMessage != nil {
+			if msg.Text == "" {
+				msg.Text = msg.SubMessage.Text
+			}
+			if msg.User == "" {
+				msg.User = msg.SubMessage.User
+			}
+		}
+		kept = append(kept, msg)
+	}
+	return kept
+}
+
+// editedBy returns the latest edit of a message. A message_changed event
+// carries the edited message in its message field.
+func editedBy(msg slack.Message) *slack.Edited {
+	if msg.Edited != nil {
+		return msg.Edited
+	}
+	if msg.SubMessage != nil && msg.SubMessage.Edited != nil {
+		return msg.SubMessage.Edited
+	}
+	return nil
+}
+
+// marshalMessagesWithMetadataToCSV adds the subtype and edit columns of the
+// original Slack messages to converted messages
+func marshalMessagesWithMetadataToCSV(messages []Message, source []slack.Message) (*mcp.CallToolResult, error) {
+	byTs := make(map[string]slack.Message, len(source))
+	for _, msg := range source {
+		byTs[msg.Timestamp] = msg
+	}
+
+	rows := make([]MessageWithMetadata, 0, len(messages))
+	for _, msg := range messages {
+		row := MessageWithMetadata{
+			MsgID:     msg.MsgID,
+			UserID:    msg.UserID,
+			UserName:  msg.UserName,
+			RealName:  msg.RealName,
+			Channel:   msg.Channel,
+			ThreadTs:  msg.ThreadTs,
+			Text:      msg.Text,
+			Time:      msg.Time,
+			Reactions: msg.Reactions,
+			Cursor:    msg.Cursor,
+		}
+		if src, ok := byTs[msg.MsgID]; ok {
+			row.Subtype = src.SubType
+			if edited := editedBy(src); edited != nil {
+				row.EditedTs = edited.Timestamp
+				row.EditedUser = edited.User
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
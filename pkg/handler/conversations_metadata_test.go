@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// editedHistoryFixture is a conversations.history response with a message
+// edited twice (only the last edit is kept), an edit event, a deleted thread
+// root and an activity message
+const editedHistoryFixture = `{
+	"ok": true,
+	"messages": [
+		{"type": "message", "user": "U1", "text": "Deploy at 5pm", "ts": "1700000400.000100",
+			"edited": {"user": "U2", "ts": "1700000900.000000"}},
+		{"type": "message", "subtype": "message_changed", "ts": "1700000300.000100",
+			"message": {"type": "message", "user": "U1", "text": "Fixed typo", "ts": "1700000000.000100",
+				"edited": {"user": "U1", "ts": "1700000300.000100"}}},
+		{"type": "message", "subtype": "tombstone", "user": "USLACKBOT", "text": "This message was deleted.",
+			"ts": "1700000200.000100", "thread_ts": "1700000200.000100"},
+		{"type": "message", "subtype": "channel_join", "user": "U3", "text": "<@U3> has joined the channel", "ts": "1700000100.000100"},
+		{"type": "message", "user": "U1", "text": "Good morning", "ts": "1700000000.000200"}
+	]
+}`
+
+func newEditedHistoryHandler(t *testing.T) *ConversationsHandler {
+	var fixture slack.GetConversationHistoryResponse
+	require.NoError(t, json.Unmarshal([]byte(editedHistoryFixture), &fixture))
+
+	api := &fakeSlackAPI{history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		resp := fixture
+		return &resp, nil
+	}}
+	return NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+}
+
+func TestUnitConversationsHistoryMetadata(t *testing.T) {
+	ch := newEditedHistoryHandler(t)
+
+	res, err := ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id":       "C1234567890",
+		"limit":            "10",
+		"include_metadata": true,
+	}))
+	require.NoError(t, err)
+
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"1700000400.000100", "1700000000.000200"}, csvColumn(t, out, "MsgID"))
+	assert.Equal(t, []string{"1700000900.000000", ""}, csvColumn(t, out, "EditedTs"), "only the latest edit is available")
+	assert.Equal(t, []string{"U2", ""}, csvColumn(t, out, "EditedUser"))
+	assert.Equal(t, []string{"", ""}, csvColumn(t, out, "Subtype"))
+
+	res, err = ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"limit":      "10",
+	}))
+	require.NoError(t, err)
+	assert.NotContains(t, csvHeader(t, toolResultText(t, res)), "EditedTs", "metadata columns are only added on request")
+}
+
+func TestUnitConversationsHistoryEditEvents(t *testing.T) {
+	ch := newEditedHistoryHandler(t)
+
+	res, err := ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id":          "C1234567890",
+		"limit":               "10",
+		"include_metadata":    true,
+		"include_edit_events": true,
+	}))
+	require.NoError(t, err)
+
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"1700000400.000100", "1700000300.000100", "1700000200.000100", "1700000000.000200"}, csvColumn(t, out, "MsgID"),
+		"edit events are kept without other activity messages")
+	assert.Equal(t, []string{"", "message_changed", "tombstone", ""}, csvColumn(t, out, "Subtype"))
+	assert.Equal(t, "Fixed typo", csvColumn(t, out, "Text")[1], "a message_changed event shows the edited text")
+	assert.Equal(t, "U1", csvColumn(t, out, "UserID")[1])
+	assert.Equal(t, "1700000300.000100", csvColumn(t, out, "EditedTs")[1])
+
+	headers := csvHeader(t, out)
+	assert.Equal(t, "Cursor", headers[len(headers)-1], "the cursor stays the last column")
+}
+
+func csvHeader(t *testing.T, out string) []string {
+	t.Helper()
+	header, err := csv.NewReader(strings.NewReader(out)).Read()
+	require.NoError(t, err, "failed to parse CSV")
+	return header
+}
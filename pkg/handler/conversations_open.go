@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// Slack allows at most 8 other users in a group DM (9 participants with the caller)
+const maxOpenConversationUsers = 8
+
+type OpenedConversation struct {
+	ChannelID   string `json:"channelID"`
+	Type        string `json:"type"`
+	Users       string `json:"users"`
+	AlreadyOpen bool   `json:"alreadyOpen"`
+}
+
+// ConversationsOpenHandler opens a DM with one user or an MPIM with several,
+// returning the existing conversation if it is already open
+func (ch *ConversationsHandler) ConversationsOpenHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsOpenHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	// Get Slack client (OAuth or legacy)
+	var slackClient *slack.Client
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		slackClient = client
+	}
+
+	users, err := ch.parseUserIDs(request.GetString("user_ids", ""))
+	if err != nil {
+		ch.logger.Error("Failed to parse user_ids", zap.Error(err))
+		return nil, err
+	}
+	if len(users) > maxOpenConversationUsers {
+		return nil, fmt.Errorf("too many users: %d, a group DM can include at most %d users besides yourself", len(users), maxOpenConversationUsers)
+	}
+
+	params := &slack.OpenConversationParameters{
+		Users:    users,
+		ReturnIM: true,
+	}
+
+	var (
+		channel     *slack.Channel
+		alreadyOpen bool
+	)
+	if ch.oauthEnabled {
+		channel, _, alreadyOpen, err = slackClient.OpenConversationContext(ctx, params)
+	} else {
+		channel, _, alreadyOpen, err = ch.apiProvider.Slack().OpenConversationContext(ctx, params)
+	}
+	if err != nil {
+		ch.logger.Error("Slack OpenConversationContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	convType := "im"
+	if len(users) > 1 {
+		convType = "mpim"
+	}
+
+	opened := []OpenedConversation{{
+		ChannelID:   channel.ID,
+		Type:        convType,
+		Users:       strings.Join(users, ","),
+		AlreadyOpen: alreadyOpen,
+	}}
+
+	csvBytes, err := gocsv.MarshalBytes(&opened)
+	if err != nil {
+		ch.logger.Error("Failed to marshal conversation to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// parseUserIDs splits a comma-separated list of user IDs, resolving @username
+// from the users cache in legacy mode, and drops duplicates
+func (ch *ConversationsHandler) parseUserIDs(raw string) ([]string, error) {
+	var users *provider.UsersCache
+	if !ch.oauthEnabled {
+		users = ch.apiProvider.ProvideUsersMap()
+	}
+	return parseUserIDList(raw, users)
+}
+
+// parseUserIDList splits a comma-separated list of user IDs, resolving
+// @username from users, nil in OAuth mode where there is no users cache
+func parseUserIDList(raw string, users *provider.UsersCache) ([]string, error) {
+	var (
+		ids  []string
+		seen = make(map[string]bool)
+	)
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		id := item
+		if strings.HasPrefix(item, "@") {
+			if users == nil {
+				return nil, fmt.Errorf("in OAuth mode, please use user ID (U...) instead of name: %s", item)
+			}
+			uid, ok := users.UsersInv[strings.TrimPrefix(item, "@")]
+			if !ok {
+				return nil, fmt.Errorf("user %q not found", item)
+			}
+			id = uid
+		} else if provider.LikelyChannelTypes(id) != nil {
+			// catch a conversation ID passed by mistake before calling Slack
+			return nil, fmt.Errorf("%q looks like a conversation ID, user_ids must contain user IDs (U...) or @names", item)
+		}
+
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, errors.New("user_ids must contain at least one user ID")
+	}
+	return ids, nil
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitConversationsOpen(t *testing.T) {
+	// emulates conversations.open: the same set of users always maps to the same conversation
+	opened := map[string]string{}
+	api := &fakeSlackAPI{open: func(params *slack.OpenConversationParameters) (*slack.Channel, bool, bool, error) {
+		assert.True(t, params.ReturnIM)
+		key := strings.Join(params.Users, ",")
+		id, already := opened[key]
+		if !already {
+			id = "D" + key
+			if len(params.Users) > 1 {
+				id = "G" + key
+			}
+			opened[key] = id
+		}
+		var ch slack.Channel
+		ch.ID = id
+		return &ch, already, already, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	cases := []struct {
+		name        string
+		userIDs     string
+		wantID      string
+		wantType    string
+		alreadyOpen string
+	}{
+		{"dm", "U1", "DU1", "im", "false"},
+		{"dm again", " U1 ", "DU1", "im", "true"},
+		{"mpim", "U1,U2,U2", "GU1,U2", "mpim", "false"},
+		{"mpim again", "U1,U2", "GU1,U2", "mpim", "true"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := ch.ConversationsOpenHandler(context.Background(), newToolRequest(map[string]any{
+				"user_ids": tc.userIDs,
+			}))
+			require.NoError(t, err)
+
+			out := toolResultText(t, res)
+			assert.Equal(t, []string{tc.wantID}, csvColumn(t, out, "ChannelID"))
+			assert.Equal(t, []string{tc.wantType}, csvColumn(t, out, "Type"))
+			assert.Equal(t, []string{tc.alreadyOpen}, csvColumn(t, out, "AlreadyOpen"))
+		})
+	}
+}
+
+func TestUnitConversationsOpenInvalidUsers(t *testing.T) {
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", &fakeSlackAPI{}, zap.NewNop()), zap.NewNop())
+
+	_, err := ch.ConversationsOpenHandler(context.Background(), newToolRequest(map[string]any{
+		"user_ids": " , ",
+	}))
+	require.Error(t, err)
+
+	_, err = ch.ConversationsOpenHandler(context.Background(), newToolRequest(map[string]any{
+		"user_ids": "U1,U2,U3,U4,U5,U6,U7,U8,U9",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at most 8 users")
+
+	// a conversation ID is rejected before calling Slack
+	_, err = ch.ConversationsOpenHandler(context.Background(), newToolRequest(map[string]any{
+		"user_ids": "U1,D0123456789",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "looks like a conversation ID")
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type MessagePermalink struct {
+	Channel   string `json:"channelID"`
+	MsgID     string `json:"msgID"`
+	ThreadTs  string `json:"threadTs"`
+	Permalink string `json:"permalink"`
+}
+
+// permalinkAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type permalinkAPI interface {
+	GetPermalinkContext(ctx context.Context, params *slack.PermalinkParameters) (string, error)
+}
+
+// ConversationsGetPermalinkHandler returns the permalink of a message with chat.getPermalink
+func (ch *ConversationsHandler) ConversationsGetPermalinkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsGetPermalinkHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	channel, err := ch.resolveChannelID(request.GetString("channel_id", ""))
+	if err != nil {
+		return nil, err
+	}
+	ts := request.GetString("ts", "")
+	if _, err := parseSlackTimestamp(ts); err != nil {
+		ch.logger.Error("Invalid ts format", zap.String("ts", ts))
+		return nil, err
+	}
+
+	var api permalinkAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	permalink, err := api.GetPermalinkContext(ctx, &slack.PermalinkParameters{Channel: channel, Ts: ts})
+	switch {
+	case err == nil:
+	case isSlackError(err, "message_not_found"):
+		return nil, fmt.Errorf("message %s not found in channel %s: %w", ts, channel, err)
+	case isSlackError(err, "channel_not_found"):
+		return nil, fmt.Errorf("channel %s not found or not accessible with this token: %w", channel, err)
+	default:
+		ch.logger.Error("Slack GetPermalinkContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	row := MessagePermalink{
+		Channel:   channel,
+		MsgID:     ts,
+		Permalink: permalink,
+	}
+	// replies link to their thread, which the permalink carries as thread_ts
+	if link, err := text.ParsePermalink(permalink); err == nil {
+		row.ThreadTs = link.ThreadTs
+	}
+
+	rows := []MessagePermalink{row}
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal permalink to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitConversationsGetPermalink(t *testing.T) {
+	api := &fakeSlackAPI{permalink: func(params *slack.PermalinkParameters) (string, error) {
+		switch params.Ts {
+		case "1700000000.000404":
+			return "", slack.SlackErrorResponse{Err: "message_not_found"}
+		case "1700000000.000200":
+			return "https://acme.slack.com/archives/" + params.Channel + "/p1700000000000200?thread_ts=1700000000.000100&cid=" + params.Channel, nil
+		}
+		return "https://acme.slack.com/archives/" + params.Channel + "/p1700000000000100", nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	get := func(ts string) (string, error) {
+		res, err := ch.ConversationsGetPermalinkHandler(context.Background(), newToolRequest(map[string]any{
+			"channel_id": "C1234567890",
+			"ts":         ts,
+		}))
+		if err != nil {
+			return "", err
+		}
+		return toolResultText(t, res), nil
+	}
+
+	out, err := get("1700000000.000100")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://acme.slack.com/archives/C1234567890/p1700000000000100"}, csvColumn(t, out, "Permalink"))
+	assert.Equal(t, []string{""}, csvColumn(t, out, "ThreadTs"))
+
+	out, err = get("1700000000.000200")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1700000000.000200"}, csvColumn(t, out, "MsgID"))
+	assert.Equal(t, []string{"1700000000.000100"}, csvColumn(t, out, "ThreadTs"), "replies carry their thread")
+
+	_, err = get("1700000000.000404")
+	assert.ErrorContains(t, err, "not found in channel C1234567890")
+
+	_, err = get("yesterday")
+	assert.Error(t, err)
+}
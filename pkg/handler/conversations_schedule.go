@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// maxScheduleAhead is how far ahead chat.scheduleMessage accepts post_at
+const maxScheduleAhead = 120 * 24 * time.Hour
+
+// localPostAtLayouts are the post_at layouts without a UTC offset, which are
+// taken as a time of the timezone of the authenticated user
+var localPostAtLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+type ScheduledMessage struct {
+	Channel            string `json:"channelID"`
+	ScheduledMessageID string `json:"scheduledMessageID"`
+	PostAt             string `json:"postAt"`
+}
+
+// ConversationsScheduleMessageHandler schedules a message with chat.scheduleMessage.
+// It is subject to the same SLACK_MCP_ADD_MESSAGE_TOOL policy as conversations_add_message.
+func (ch *ConversationsHandler) ConversationsScheduleMessageHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsScheduleMessageHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	params, err := ch.parseParamsToolAddMessage(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse schedule-message params", zap.Error(err))
+		return nil, err
+	}
+
+	postAt, err := parsePostAt(request.GetString("post_at", ""), time.Now(), func() (*time.Location, error) {
+		return ch.authedUserLocation(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ch.resolveThreadParent(ctx, params); err != nil {
+		return nil, err
+	}
+	options, err := ch.addMessageOptions(params)
+	if err != nil {
+		return nil, err
+	}
+
+	ch.logger.Debug("Scheduling Slack message",
+		zap.String("channel", params.channel),
+		zap.String("thread_ts", params.threadTs),
+		zap.Time("post_at", postAt),
+	)
+
+	unix := strconv.FormatInt(postAt.Unix(), 10)
+	var respChannel, scheduledID string
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		respChannel, scheduledID, err = client.ScheduleMessageContext(ctx, params.channel, unix, options...)
+		if err != nil {
+			ch.logger.Error("Slack ScheduleMessageContext failed", zap.Error(err))
+			return nil, err
+		}
+	} else {
+		respChannel, scheduledID, err = ch.apiProvider.Slack().ScheduleMessageContext(ctx, params.channel, unix, options...)
+		if err != nil {
+			ch.logger.Error("Slack ScheduleMessageContext failed", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	rows := []ScheduledMessage{{
+		Channel:            respChannel,
+		ScheduledMessageID: scheduledID,
+		PostAt:             postAt.UTC().Format(time.RFC3339),
+	}}
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal scheduled message to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// parsePostAt parses unix seconds, an RFC3339 time or a time without offset,
+// such as 2025-01-02 15:04, in the location returned by userLocation, which is
+// only called for the latter. The time must be in the future and at most 120
+// days ahead, the limit of chat.scheduleMessage.
+func parsePostAt(value string, now time.Time, userLocation func() (*time.Location, error)) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, errors.New("post_at must be unix seconds, an RFC3339 time or a local time such as 2025-01-02 15:04")
+	}
+
+	var (
+		at  time.Time
+		err error
+	)
+	if secs, convErr := strconv.ParseInt(value, 10, 64); convErr == nil {
+		at = time.Unix(secs, 0)
+	} else if at, err = time.Parse(time.RFC3339, value); err != nil {
+		at, err = parseLocalPostAt(value, userLocation)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	if !at.After(now) {
+		return time.Time{}, fmt.Errorf("post_at %q must be in the future", value)
+	}
+	if at.Sub(now) > maxScheduleAhead {
+		return time.Time{}, fmt.Errorf("post_at %q is too far ahead, Slack schedules messages at most 120 days in advance", value)
+	}
+	return at, nil
+}
+
+func parseLocalPostAt(value string, userLocation func() (*time.Location, error)) (time.Time, error) {
+	for _, layout := range localPostAtLayouts {
+		if _, err := time.Parse(layout, value); err != nil {
+			continue
+		}
+		loc, err := userLocation()
+		if err != nil {
+			return time.Time{}, fmt.Errorf("cannot resolve the timezone of post_at %q, give it with a UTC offset: %w", value, err)
+		}
+		return time.ParseInLocation(layout, value, loc)
+	}
+	return time.Time{}, fmt.Errorf("invalid post_at %q: must be unix seconds, an RFC3339 time such as 2025-01-02T15:04:05Z or a local time such as 2025-01-02 15:04", value)
+}
+
+// authedUserLocation returns the timezone of the authenticated user from the
+// users cache, or from users.info when the user is not cached
+func (ch *ConversationsHandler) authedUserLocation(ctx context.Context) (*time.Location, error) {
+	var tz string
+	if ch.oauthEnabled {
+		userCtx, ok := auth.FromContext(ctx)
+		if !ok {
+			return nil, fmt.Errorf("user context not found")
+		}
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		user, err := client.GetUserInfoContext(ctx, userCtx.UserID)
+		if err != nil {
+			return nil, err
+		}
+		tz = user.TZ
+	} else {
+		id, err := ch.apiProvider.Identity(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the authenticated user: %w", err)
+		}
+		if user, ok := ch.apiProvider.ProvideUsersMap().Users[id.UserID]; ok {
+			tz = user.TZ
+		} else {
+			users, err := ch.apiProvider.Slack().GetUsersInfo(id.UserID)
+			if err != nil {
+				return nil, err
+			}
+			if len(*users) > 0 {
+				tz = (*users)[0].TZ
+			}
+		}
+	}
+	if tz == "" {
+		return nil, errors.New("the authenticated user has no timezone")
+	}
+	return time.LoadLocation(tz)
+}
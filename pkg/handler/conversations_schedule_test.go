@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitParsePostAt(t *testing.T) {
+	now := time.Date(2025, 1, 2, 15, 0, 0, 0, time.UTC)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	userLocation := func() (*time.Location, error) { return berlin, nil }
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{value: "1735837200", want: time.Date(2025, 1, 2, 17, 0, 0, 0, time.UTC)},
+		{value: "2025-01-03T09:00:00Z", want: time.Date(2025, 1, 3, 9, 0, 0, 0, time.UTC)},
+		{value: "2025-01-03T09:00:00+02:00", want: time.Date(2025, 1, 3, 7, 0, 0, 0, time.UTC)},
+		{value: "2025-01-03 09:00", want: time.Date(2025, 1, 3, 8, 0, 0, 0, time.UTC)},
+		{value: "2025-01-03T09:00:30", want: time.Date(2025, 1, 3, 8, 0, 30, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parsePostAt(tt.value, now, userLocation)
+		require.NoError(t, err, tt.value)
+		assert.True(t, tt.want.Equal(got), "%s: got %s, want %s", tt.value, got, tt.want)
+	}
+
+	for _, value := range []string{"", "tomorrow", "2025-01-02T14:00:00Z", "1735830000", "2025-06-01T09:00:00Z", "03.01.2025 09:00"} {
+		_, err := parsePostAt(value, now, userLocation)
+		assert.Error(t, err, value)
+	}
+
+	_, err = parsePostAt("2025-01-03 09:00", now, func() (*time.Location, error) {
+		return nil, errors.New("no timezone")
+	})
+	assert.ErrorContains(t, err, "UTC offset")
+	_, err = parsePostAt("2025-01-03T09:00:00Z", now, func() (*time.Location, error) {
+		t.Fatal("the timezone is only resolved for local times")
+		return nil, nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestUnitConversationsScheduleMessage(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "true")
+
+	var gotChannel, gotPostAt string
+	api := &fakeSlackAPI{
+		authTest: func() (*slack.AuthTestResponse, error) {
+			return &slack.AuthTestResponse{URL: "https://acme.slack.com/", UserID: "U1"}, nil
+		},
+		usersInfo: func(users ...string) (*[]slack.User, error) {
+			return &[]slack.User{{ID: "U1", TZ: "America/New_York"}}, nil
+		},
+		schedule: func(channel, postAt string, options ...slack.MsgOption) (string, string, error) {
+			gotChannel, gotPostAt = channel, postAt
+			return channel, "Q1298393284", nil
+		},
+	}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	at := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	res, err := ch.ConversationsScheduleMessageHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id":   "C1234567890",
+		"payload":      "standup in 5 minutes",
+		"content_type": "text/plain",
+		"post_at":      strconv.FormatInt(at.Unix(), 10),
+	}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"Q1298393284"}, csvColumn(t, out, "ScheduledMessageID"))
+	assert.Equal(t, []string{at.UTC().Format(time.RFC3339)}, csvColumn(t, out, "PostAt"))
+	assert.Equal(t, "C1234567890", gotChannel)
+	assert.Equal(t, strconv.FormatInt(at.Unix(), 10), gotPostAt)
+
+	// local times are in the timezone of the authenticated user
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	local := time.Now().In(newYork).Add(72 * time.Hour).Truncate(time.Minute)
+	_, err = ch.ConversationsScheduleMessageHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id":   "C1234567890",
+		"payload":      "hello",
+		"content_type": "text/plain",
+		"post_at":      local.Format("2006-01-02 15:04"),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, strconv.FormatInt(local.Unix(), 10), gotPostAt)
+
+	gotPostAt = ""
+	_, err = ch.ConversationsScheduleMessageHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"payload":    "hello",
+		"post_at":    time.Now().Add(121 * 24 * time.Hour).UTC().Format(time.RFC3339),
+	}))
+	assert.ErrorContains(t, err, "120 days")
+	assert.Empty(t, gotPostAt, "nothing is scheduled")
+}
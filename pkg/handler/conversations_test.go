@@ -3,7 +3,10 @@ package handler
 import (
 	"context"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
 	"regexp"
 	"strconv"
@@ -12,13 +15,17 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
 	"github.com/korotovsky/slack-mcp-server/pkg/test/util"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/option"
 	"github.com/openai/openai-go/packages/param"
 	"github.com/openai/openai-go/responses"
+	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func TestIntegrationConversations(t *testing.T) {
@@ -597,3 +604,830 @@ func TestUnitLimitByExpression_Invalid(t *testing.T) {
 		})
 	}
 }
+
+// fakeSlackAPI implements provider.SlackAPI for unit tests, methods which are
+// not overridden panic through the nil embedded interface.
+type fakeSlackAPI struct {
+	provider.SlackAPI
+
+	history   func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error)
+	reactions func(item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error)
+	react     func(name string, item slack.ItemRef, add bool) error
+	open      func(params *slack.OpenConversationParameters) (*slack.Channel, bool, bool, error)
+	fileInfo  func(fileID string) (*slack.File, error)
+	file      func(downloadURL string, writer io.Writer) error
+	upload    func(params slack.UploadFileV2Parameters) (*slack.FileSummary, error)
+	pins      func(channel string) ([]slack.Item, error)
+	info      func(input *slack.GetConversationInfoInput) (*slack.Channel, error)
+	inChannel func(params *slack.GetUsersInConversationParameters) ([]string, string, error)
+	post      func(channel string, options ...slack.MsgOption) (string, string, error)
+	schedule  func(channel, postAt string, options ...slack.MsgOption) (string, string, error)
+	delete    func(channel, ts string) (string, string, error)
+	ephemeral func(channel, userID string, options ...slack.MsgOption) (string, error)
+	permalink func(params *slack.PermalinkParameters) (string, error)
+	archive   func(channelID string, archive bool) error
+	create    func(params slack.CreateConversationParams) (*slack.Channel, error)
+	topic     func(channelID, topic string) (*slack.Channel, error)
+	purpose   func(channelID, purpose string) (*slack.Channel, error)
+	invite    func(channelID string, users ...string) (*slack.Channel, error)
+	join      func(channelID string) (*slack.Channel, string, error)
+	leave     func(channelID string) (bool, error)
+	rename    func(channelID, name string) (*slack.Channel, error)
+	search    func(query string, params slack.SearchParameters) (*slack.SearchFiles, error)
+	searchMsg func(query string, params slack.SearchParameters) (*slack.SearchMessages, error)
+	call      func(method string, params url.Values) (json.RawMessage, error)
+	byEmail   func(email string) (*slack.User, error)
+	userInfo  func(user string) (*slack.User, error)
+	profile   func(params *slack.GetUserProfileParameters) (*slack.UserProfile, error)
+	presence  func(user string) (*slack.UserPresence, error)
+	replies   func(params *slack.GetConversationRepliesParameters) ([]slack.Message, error)
+	thread    func(params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error) // paginated replies, used over replies when set
+	usersInfo func(users ...string) (*[]slack.User, error)
+	users     func() ([]slack.User, error)
+	reminders func() ([]*slack.Reminder, error)
+	remind    func(userID, text, time string) (*slack.Reminder, error)
+	authTest  func() (*slack.AuthTestResponse, error)
+	groups    func() ([]slack.UserGroup, error)
+	members   func(userGroup string) ([]string, error)
+	setGroup  func(userGroup, members string) (slack.UserGroup, error)
+}
+
+func (f *fakeSlackAPI) GetUserGroupsContext(_ context.Context, _ ...slack.GetUserGroupsOption) ([]slack.UserGroup, error) {
+	return f.groups()
+}
+
+func (f *fakeSlackAPI) GetUserGroupMembersContext(_ context.Context, userGroup string, _ ...slack.GetUserGroupMembersOption) ([]string, error) {
+	return f.members(userGroup)
+}
+
+func (f *fakeSlackAPI) UpdateUserGroupMembersContext(_ context.Context, userGroup string, members string, _ ...slack.UpdateUserGroupMembersOption) (slack.UserGroup, error) {
+	return f.setGroup(userGroup, members)
+}
+
+func (f *fakeSlackAPI) ListRemindersContext(_ context.Context) ([]*slack.Reminder, error) {
+	return f.reminders()
+}
+
+func (f *fakeSlackAPI) AddUserReminderContext(_ context.Context, userID, text, time string) (*slack.Reminder, error) {
+	return f.remind(userID, text, time)
+}
+
+// AuthTest fails unless the test sets authTest, so that the authenticated
+// user's timezone is unknown and message times are shown in UTC
+func (f *fakeSlackAPI) AuthTest() (*slack.AuthTestResponse, error) {
+	if f.authTest == nil {
+		return nil, slack.SlackErrorResponse{Err: "not_authed"}
+	}
+	return f.authTest()
+}
+
+func (f *fakeSlackAPI) AuthTestContext(_ context.Context) (*slack.AuthTestResponse, error) {
+	return f.AuthTest()
+}
+
+// GetUsersInfo resolves no users unless the test sets usersInfo, as the
+// provider looks up uncached message authors before the users cache is warm
+func (f *fakeSlackAPI) GetUsersInfo(users ...string) (*[]slack.User, error) {
+	if f.usersInfo == nil {
+		return &[]slack.User{}, nil
+	}
+	return f.usersInfo(users...)
+}
+
+func (f *fakeSlackAPI) GetUserInfoContext(_ context.Context, user string) (*slack.User, error) {
+	return f.userInfo(user)
+}
+
+func (f *fakeSlackAPI) GetUserProfileContext(_ context.Context, params *slack.GetUserProfileParameters) (*slack.UserProfile, error) {
+	return f.profile(params)
+}
+
+func (f *fakeSlackAPI) GetUserPresenceContext(_ context.Context, user string) (*slack.UserPresence, error) {
+	return f.presence(user)
+}
+
+func (f *fakeSlackAPI) GetUsersContext(_ context.Context, _ ...slack.GetUsersOption) ([]slack.User, error) {
+	return f.users()
+}
+
+func (f *fakeSlackAPI) GetConversationRepliesContext(_ context.Context, params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error) {
+	if f.thread != nil {
+		return f.thread(params)
+	}
+	msgs, err := f.replies(params)
+	return msgs, false, "", err
+}
+
+func (f *fakeSlackAPI) GetUserByEmailContext(_ context.Context, email string) (*slack.User, error) {
+	return f.byEmail(email)
+}
+
+func (f *fakeSlackAPI) CallMethodContext(_ context.Context, method string, params url.Values) (json.RawMessage, error) {
+	return f.call(method, params)
+}
+
+func (f *fakeSlackAPI) SearchFilesContext(_ context.Context, query string, params slack.SearchParameters) (*slack.SearchFiles, error) {
+	return f.search(query, params)
+}
+
+func (f *fakeSlackAPI) SearchContext(_ context.Context, query string, params slack.SearchParameters) (*slack.SearchMessages, *slack.SearchFiles, error) {
+	msgs, err := f.searchMsg(query, params)
+	return msgs, nil, err
+}
+
+func (f *fakeSlackAPI) GetUsersInConversationContext(_ context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+	return f.inChannel(params)
+}
+
+func (f *fakeSlackAPI) ArchiveConversationContext(_ context.Context, channelID string) error {
+	return f.archive(channelID, true)
+}
+
+func (f *fakeSlackAPI) UnArchiveConversationContext(_ context.Context, channelID string) error {
+	return f.archive(channelID, false)
+}
+
+func (f *fakeSlackAPI) CreateConversationContext(_ context.Context, params slack.CreateConversationParams) (*slack.Channel, error) {
+	return f.create(params)
+}
+
+func (f *fakeSlackAPI) SetTopicOfConversationContext(_ context.Context, channelID, topic string) (*slack.Channel, error) {
+	return f.topic(channelID, topic)
+}
+
+func (f *fakeSlackAPI) SetPurposeOfConversationContext(_ context.Context, channelID, purpose string) (*slack.Channel, error) {
+	return f.purpose(channelID, purpose)
+}
+
+func (f *fakeSlackAPI) InviteUsersToConversationContext(_ context.Context, channelID string, users ...string) (*slack.Channel, error) {
+	return f.invite(channelID, users...)
+}
+
+func (f *fakeSlackAPI) JoinConversationContext(_ context.Context, channelID string) (*slack.Channel, string, []string, error) {
+	channel, warning, err := f.join(channelID)
+	return channel, warning, nil, err
+}
+
+func (f *fakeSlackAPI) LeaveConversationContext(_ context.Context, channelID string) (bool, error) {
+	return f.leave(channelID)
+}
+
+func (f *fakeSlackAPI) RenameConversationContext(_ context.Context, channelID, channelName string) (*slack.Channel, error) {
+	return f.rename(channelID, channelName)
+}
+
+func (f *fakeSlackAPI) PostMessageContext(_ context.Context, channel string, options ...slack.MsgOption) (string, string, error) {
+	return f.post(channel, options...)
+}
+
+func (f *fakeSlackAPI) ScheduleMessageContext(_ context.Context, channel, postAt string, options ...slack.MsgOption) (string, string, error) {
+	return f.schedule(channel, postAt, options...)
+}
+
+func (f *fakeSlackAPI) DeleteMessageContext(_ context.Context, channel, ts string) (string, string, error) {
+	return f.delete(channel, ts)
+}
+
+func (f *fakeSlackAPI) PostEphemeralContext(_ context.Context, channel, userID string, options ...slack.MsgOption) (string, error) {
+	return f.ephemeral(channel, userID, options...)
+}
+
+func (f *fakeSlackAPI) GetPermalinkContext(_ context.Context, params *slack.PermalinkParameters) (string, error) {
+	return f.permalink(params)
+}
+
+func (f *fakeSlackAPI) GetConversationInfoContext(_ context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	return f.info(input)
+}
+
+func (f *fakeSlackAPI) ListPinsContext(_ context.Context, channel string) ([]slack.Item, *slack.Paging, error) {
+	items, err := f.pins(channel)
+	return items, nil, err
+}
+
+func (f *fakeSlackAPI) GetFileInfoContext(_ context.Context, fileID string, _, _ int) (*slack.File, []slack.Comment, *slack.Paging, error) {
+	file, err := f.fileInfo(fileID)
+	return file, nil, nil, err
+}
+
+func (f *fakeSlackAPI) GetFileContext(_ context.Context, downloadURL string, writer io.Writer) error {
+	return f.file(downloadURL, writer)
+}
+
+func (f *fakeSlackAPI) UploadFileV2Context(_ context.Context, params slack.UploadFileV2Parameters) (*slack.FileSummary, error) {
+	return f.upload(params)
+}
+
+func (f *fakeSlackAPI) GetConversationHistoryContext(_ context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	return f.history(params)
+}
+
+func (f *fakeSlackAPI) OpenConversationContext(_ context.Context, params *slack.OpenConversationParameters) (*slack.Channel, bool, bool, error) {
+	return f.open(params)
+}
+
+func (f *fakeSlackAPI) GetReactionsContext(_ context.Context, item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error) {
+	return f.reactions(item, params)
+}
+
+func (f *fakeSlackAPI) AddReactionContext(_ context.Context, name string, item slack.ItemRef) error {
+	return f.react(name, item, true)
+}
+
+func (f *fakeSlackAPI) RemoveReactionContext(_ context.Context, name string, item slack.ItemRef) error {
+	return f.react(name, item, false)
+}
+
+func newToolRequest(args map[string]any) mcp.CallToolRequest {
+	var req mcp.CallToolRequest
+	req.Params.Arguments = args
+	return req
+}
+
+func csvColumn(t *testing.T, out string, column string) []string {
+	t.Helper()
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	require.NoError(t, err, "failed to parse CSV")
+	require.NotEmpty(t, rows, "CSV must have a header row, even without data rows")
+	idx := -1
+	for i, col := range rows[0] {
+		if col == column {
+			idx = i
+		}
+	}
+	require.NotEqualf(t, -1, idx, "CSV did not contain column %q", column)
+	var values []string
+	for _, row := range rows[1:] {
+		values = append(values, row[idx])
+	}
+	return values
+}
+
+func toolResultText(t *testing.T, res *mcp.CallToolResult) string {
+	t.Helper()
+	require.NotNil(t, res)
+	require.NotEmpty(t, res.Content)
+	tc, ok := res.Content[0].(mcp.TextContent)
+	require.True(t, ok, "expected text content")
+	return tc.Text
+}
+
+func TestUnitConversationsHistoryInclusiveBoundary(t *testing.T) {
+	const pageSize = 3
+
+	// historyPage emulates conversations.history: messages newest first, bounded
+	// by oldest/latest according to inclusive, and a cursor pointing at the next
+	// message to return.
+	historyPage := func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		oldest, err := strconv.ParseInt(strings.TrimSuffix(params.Oldest, ".000000"), 10, 64)
+		require.NoError(t, err)
+
+		var all []slack.Message
+		for i := int64(3); i >= -1; i-- {
+			ts := fmt.Sprintf("%d.000000", oldest+i)
+			if i < 0 || (i == 0 && !params.Inclusive) {
+				continue
+			}
+			all = append(all, slack.Message{Msg: slack.Msg{Timestamp: ts, User: "U1", Text: "message " + ts}})
+		}
+
+		start := 0
+		if params.Cursor != "" {
+			for i, m := range all {
+				if "next_ts:"+m.Timestamp == params.Cursor {
+					start = i
+				}
+			}
+		}
+		end := start + pageSize
+		if end > len(all) {
+			end = len(all)
+		}
+
+		resp := &slack.GetConversationHistoryResponse{Messages: all[start:end]}
+		resp.Ok = true
+		if end < len(all) {
+			resp.HasMore = true
+			resp.ResponseMetaData.NextCursor = "next_ts:" + all[end].Timestamp
+		}
+		return resp, nil
+	}
+
+	for _, inclusive := range []bool{true, false} {
+		t.Run(fmt.Sprintf("inclusive=%v", inclusive), func(t *testing.T) {
+			var oldest string
+			api := &fakeSlackAPI{history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+				assert.Equal(t, inclusive, params.Inclusive, "inclusive must be forwarded on every page")
+				oldest = params.Oldest
+				return historyPage(params)
+			}}
+			ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+			res, err := ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+				"channel_id": "C1234567890",
+				"limit":      "1d",
+				"inclusive":  inclusive,
+			}))
+			require.NoError(t, err)
+			page1 := toolResultText(t, res)
+
+			seen := map[string]int{}
+			for _, id := range csvColumn(t, page1, "MsgID") {
+				seen[id]++
+			}
+
+			cursors := csvColumn(t, page1, "Cursor")
+			if next := cursors[len(cursors)-1]; next != "" {
+				res, err = ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+					"channel_id": "C1234567890",
+					"limit":      "1d",
+					"cursor":     next,
+					"inclusive":  inclusive,
+				}))
+				require.NoError(t, err)
+				for _, id := range csvColumn(t, toolResultText(t, res), "MsgID") {
+					seen[id]++
+				}
+			}
+
+			for id, n := range seen {
+				assert.Equalf(t, 1, n, "message %s returned %d times across pages", id, n)
+			}
+			if inclusive {
+				assert.Len(t, seen, 4)
+				assert.Contains(t, seen, oldest, "boundary message must be returned once")
+			} else {
+				assert.Len(t, seen, 3)
+				assert.NotContains(t, seen, oldest, "boundary message must be skipped")
+			}
+		})
+	}
+}
+
+func TestUnitConversationsAddMessageUnfurl(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "true")
+
+	post := func(t *testing.T, policy string, args map[string]any) url.Values {
+		t.Helper()
+		t.Setenv("SLACK_MCP_ADD_MESSAGE_UNFURLING", policy)
+
+		var values url.Values
+		api := &fakeSlackAPI{
+			post: func(channel string, options ...slack.MsgOption) (string, string, error) {
+				var err error
+				_, values, err = slack.UnsafeApplyMsgOptions("token", channel, "https://slack.com/api/", options...)
+				require.NoError(t, err)
+				return channel, "1700000000.000100", nil
+			},
+			history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+				return &slack.GetConversationHistoryResponse{}, nil
+			},
+		}
+		ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+		args["channel_id"] = "C1234567890"
+		args["payload"] = "see https://example.com"
+		args["content_type"] = "text/plain"
+		_, err := ch.ConversationsAddMessageHandler(context.Background(), newToolRequest(args))
+		require.NoError(t, err)
+		return values
+	}
+
+	values := post(t, "true", map[string]any{})
+	assert.Equal(t, "true", values.Get("unfurl_links"))
+	assert.False(t, values.Has("unfurl_media"), "Slack's default is kept")
+
+	values = post(t, "true", map[string]any{"unfurl_links": false, "unfurl_media": false})
+	assert.Equal(t, "false", values.Get("unfurl_links"))
+	assert.Equal(t, "false", values.Get("unfurl_media"))
+
+	values = post(t, "true", map[string]any{"unfurl_links": true, "unfurl_media": true})
+	assert.Equal(t, "true", values.Get("unfurl_links"))
+	assert.False(t, values.Has("unfurl_media"))
+
+	// the policy cannot be bypassed
+	values = post(t, "", map[string]any{"unfurl_links": true, "unfurl_media": true})
+	assert.Equal(t, "false", values.Get("unfurl_links"))
+	assert.Equal(t, "false", values.Get("unfurl_media"))
+}
+
+func TestUnitConversationsHistoryMaxTextLen(t *testing.T) {
+	api := &fakeSlackAPI{history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		resp := &slack.GetConversationHistoryResponse{Messages: []slack.Message{
+			{Msg: slack.Msg{Timestamp: "1700000002.000000", User: "U1", Text: "Привет, как дела? Всё хорошо"}},
+			{Msg: slack.Msg{Timestamp: "1700000001.000000", User: "U1", Text: "短い"}},
+		}}
+		resp.Ok = true
+		return resp, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id":   "C1234567890",
+		"limit":        "10",
+		"max_text_len": 8,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Привет, …", "短い"}, csvColumn(t, toolResultText(t, res), "Text"))
+
+	res, err = ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"limit":      "10",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Привет, как дела? Всё хорошо", "短い"}, csvColumn(t, toolResultText(t, res), "Text"), "no truncation by default")
+
+	_, err = ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id":   "C1234567890",
+		"limit":        "10",
+		"max_text_len": -1,
+	}))
+	assert.Error(t, err)
+}
+
+func TestUnitConversationsHistoryOldestLatest(t *testing.T) {
+	var got *slack.GetConversationHistoryParameters
+	api := &fakeSlackAPI{history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		got = params
+		resp := &slack.GetConversationHistoryResponse{Messages: []slack.Message{
+			{Msg: slack.Msg{Timestamp: "1700000050.000000", User: "U1", Text: "in range"}},
+		}}
+		resp.Ok = true
+		resp.HasMore = true
+		resp.ResponseMetaData.NextCursor = "next"
+		return resp, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"limit":      "20",
+		"oldest":     "1700000000.000000",
+		"latest":     "1700000100.000000",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, 20, got.Limit)
+	assert.Equal(t, "1700000000.000000", got.Oldest)
+	assert.Equal(t, "1700000100.000000", got.Latest)
+	assert.Equal(t, []string{"next"}, csvColumn(t, toolResultText(t, res), "Cursor"))
+
+	// the bounds are kept on the next page
+	_, err = ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"cursor":     "next",
+		"oldest":     "1700000000.000000",
+		"latest":     "1700000100.000000",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "next", got.Cursor)
+	assert.Equal(t, "1700000000.000000", got.Oldest)
+	assert.Equal(t, "1700000100.000000", got.Latest)
+
+	// oldest overrides the start of a time range limit only
+	_, err = ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"limit":      "1w",
+		"oldest":     formatSlackTimestamp(time.Now().Add(-time.Hour).Truncate(time.Second)),
+	}))
+	require.NoError(t, err)
+	assert.NotEmpty(t, got.Latest)
+
+	_, err = ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"limit":      "1d",
+		"date_range": "2024-01-01..2024-01-31",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, formatSlackTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)), got.Oldest)
+	assert.Equal(t, formatSlackTimestamp(time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local)), got.Latest)
+
+	for _, bounds := range []map[string]any{
+		{"date_range": "last week"},
+		{"date_range": "7d", "oldest": "1700000000.000000"},
+		{"oldest": "yesterday"},
+		{"latest": "1700000100"},
+		{"oldest": "1700000100.000000", "latest": "1700000000.000000"},
+	} {
+		args := map[string]any{"channel_id": "C1234567890", "limit": "20"}
+		for k, v := range bounds {
+			args[k] = v
+		}
+		_, err := ch.ConversationsHistoryHandler(context.Background(), newToolRequest(args))
+		assert.Error(t, err, bounds)
+	}
+}
+
+func TestUnitConversationsHistoryResolvesMentions(t *testing.T) {
+	api := &fakeSlackAPI{history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		resp := &slack.GetConversationHistoryResponse{Messages: []slack.Message{
+			{Msg: slack.Msg{Timestamp: "1700000001.000000", User: "U1", Text: "<@U2> can you check <#C1>? cc <!here> <@U9>"}},
+		}}
+		resp.Ok = true
+		return resp, nil
+	}}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	p.ProvideUsersMap().Users["U1"] = slack.User{ID: "U1", Name: "alice", RealName: "Alice Doe"}
+	p.ProvideUsersMap().Users["U2"] = slack.User{ID: "U2", Name: "bob", RealName: "Bob Roe"}
+	p.ProvideChannelsMaps().Channels["C1"] = provider.Channel{ID: "C1", Name: "#ops"}
+	ch := NewConversationsHandler(p, zap.NewNop())
+
+	res, err := ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"limit":      "10",
+	}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"@bob can you check #ops? cc @here @U9"}, csvColumn(t, out, "Text"))
+	assert.Equal(t, []string{"alice"}, csvColumn(t, out, "UserName"))
+	assert.Equal(t, []string{"Alice Doe"}, csvColumn(t, out, "RealName"))
+}
+
+func TestUnitConversationsHistoryMarkdownFormat(t *testing.T) {
+	api := &fakeSlackAPI{history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		resp := &slack.GetConversationHistoryResponse{Messages: []slack.Message{
+			{Msg: slack.Msg{Timestamp: "1700000001.000000", User: "U1", Text: "*Deploy* of <https://ci.example.com/42|build 42> by <@U1> &gt; done"}},
+		}}
+		resp.Ok = true
+		return resp, nil
+	}}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	p.ProvideUsersMap().Users["U1"] = slack.User{ID: "U1", Name: "alice"}
+	ch := NewConversationsHandler(p, zap.NewNop())
+
+	history := func(args map[string]any) (string, error) {
+		args["channel_id"] = "C1234567890"
+		args["limit"] = "10"
+		res, err := ch.ConversationsHistoryHandler(context.Background(), newToolRequest(args))
+		if err != nil {
+			return "", err
+		}
+		return toolResultText(t, res), nil
+	}
+
+	out, err := history(map[string]any{"format": "markdown"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"**Deploy** of [build 42](https://ci.example.com/42) by @alice > done"}, csvColumn(t, out, "Text"))
+
+	out, err = history(map[string]any{"format": "markdown", "max_text_len": 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"**Deploy**…"}, csvColumn(t, out, "Text"), "markdown is truncated after conversion")
+
+	out, err = history(map[string]any{})
+	require.NoError(t, err)
+	assert.NotContains(t, csvColumn(t, out, "Text")[0], "**", "plain text by default")
+
+	_, err = history(map[string]any{"format": "html"})
+	assert.Error(t, err)
+}
+
+func TestUnitConversationsHistoryFlattensBlocks(t *testing.T) {
+	api := &fakeSlackAPI{history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		resp := &slack.GetConversationHistoryResponse{Messages: []slack.Message{
+			{Msg: slack.Msg{Timestamp: "1700000002.000000", BotID: "B1", Blocks: slack.Blocks{BlockSet: []slack.Block{
+				slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, "Deploy finished", false, false), nil, nil),
+			}}}},
+			{Msg: slack.Msg{Timestamp: "1700000001.000000", BotID: "B1", Attachments: []slack.Attachment{{Fallback: "Build failed"}}}},
+			{Msg: slack.Msg{Timestamp: "1700000000.000000", User: "U1", Text: "plain", Blocks: slack.Blocks{BlockSet: []slack.Block{
+				slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, "ignored", false, false), nil, nil),
+			}}}},
+		}}
+		resp.Ok = true
+		return resp, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"limit":      "10",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Deploy finished", "Build failed", "plain"}, csvColumn(t, toolResultText(t, res), "Text"))
+}
+
+func TestUnitIsChannelAllowed(t *testing.T) {
+	tests := []struct {
+		policy  string
+		channel string
+		want    bool
+	}{
+		{policy: "true", channel: "C1", want: true},
+		{policy: "1", channel: "C1", want: true},
+		{policy: "C1,D2", channel: "C1", want: true},
+		{policy: "C1,D2", channel: "D2", want: true},
+		{policy: "C1,D2", channel: "C3", want: false},
+		{policy: "!C1", channel: "C1", want: false},
+		{policy: "!C1,!C2", channel: "C2", want: false},
+		{policy: "!C1", channel: "C3", want: true},
+	}
+	for _, tt := range tests {
+		t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", tt.policy)
+		assert.Equal(t, tt.want, isChannelAllowed(tt.channel), "%s with policy %s", tt.channel, tt.policy)
+	}
+}
+
+func TestUnitConversationsAddMessageReplyBroadcast(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "true")
+
+	var values url.Values
+	api := &fakeSlackAPI{
+		post: func(channel string, options ...slack.MsgOption) (string, string, error) {
+			var err error
+			_, values, err = slack.UnsafeApplyMsgOptions("token", channel, "https://slack.com/api/", options...)
+			require.NoError(t, err)
+			return channel, "1700000000.000200", nil
+		},
+		history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+			return &slack.GetConversationHistoryResponse{}, nil
+		},
+		replies: func(params *slack.GetConversationRepliesParameters) ([]slack.Message, error) {
+			return []slack.Message{{Msg: slack.Msg{Timestamp: params.Timestamp}}}, nil
+		},
+	}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	post := func(args map[string]any) error {
+		values = nil
+		args["channel_id"] = "C1234567890"
+		args["payload"] = "hello"
+		args["content_type"] = "text/plain"
+		_, err := ch.ConversationsAddMessageHandler(context.Background(), newToolRequest(args))
+		return err
+	}
+
+	require.NoError(t, post(map[string]any{"thread_ts": "1700000000.000100", "reply_broadcast": true}))
+	assert.Equal(t, "1700000000.000100", values.Get("thread_ts"))
+	assert.Equal(t, "true", values.Get("reply_broadcast"))
+
+	require.NoError(t, post(map[string]any{"thread_ts": "1700000000.000100"}))
+	assert.False(t, values.Has("reply_broadcast"), "not broadcast by default")
+
+	err := post(map[string]any{"reply_broadcast": true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "thread_ts")
+	assert.Nil(t, values, "nothing is posted")
+}
+
+func TestUnitConversationsAddMessageThreadParent(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "true")
+
+	var values url.Values
+	api := &fakeSlackAPI{
+		post: func(channel string, options ...slack.MsgOption) (string, string, error) {
+			var err error
+			_, values, err = slack.UnsafeApplyMsgOptions("token", channel, "https://slack.com/api/", options...)
+			require.NoError(t, err)
+			return channel, "1700000000.000300", nil
+		},
+		history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+			return &slack.GetConversationHistoryResponse{}, nil
+		},
+		replies: func(params *slack.GetConversationRepliesParameters) ([]slack.Message, error) {
+			switch params.Timestamp {
+			case "1700000000.000100", "1700000000.000200":
+				// the ts of a reply lists the root of its thread first
+				return []slack.Message{{Msg: slack.Msg{Timestamp: "1700000000.000100", ThreadTimestamp: "1700000000.000100"}}}, nil
+			}
+			return nil, slack.SlackErrorResponse{Err: "thread_not_found"}
+		},
+	}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	post := func(threadTs string) error {
+		values = nil
+		_, err := ch.ConversationsAddMessageHandler(context.Background(), newToolRequest(map[string]any{
+			"channel_id":   "C1234567890",
+			"payload":      "hello",
+			"content_type": "text/plain",
+			"thread_ts":    threadTs,
+		}))
+		return err
+	}
+
+	require.NoError(t, post("1700000000.000100"))
+	assert.Equal(t, "1700000000.000100", values.Get("thread_ts"))
+
+	require.NoError(t, post("1700000000.000200"))
+	assert.Equal(t, "1700000000.000100", values.Get("thread_ts"), "replies to a reply go to the thread of its root")
+
+	err := post("1700000000.000999")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+	assert.Nil(t, values, "nothing is posted")
+}
+
+func TestUnitConversationsAddMessageMentions(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "true")
+
+	var values url.Values
+	api := &fakeSlackAPI{
+		post: func(channel string, options ...slack.MsgOption) (string, string, error) {
+			var err error
+			_, values, err = slack.UnsafeApplyMsgOptions("token", channel, "https://slack.com/api/", options...)
+			require.NoError(t, err)
+			return channel, "1700000000.000300", nil
+		},
+		history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+			return &slack.GetConversationHistoryResponse{}, nil
+		},
+	}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	p.ProvideUsersMap().UsersInv["alice"] = "U1"
+	p.ProvideChannelsMaps().ChannelsInv["#general"] = "C1"
+	ch := NewConversationsHandler(p, zap.NewNop())
+
+	post := func(payload, mentions string) error {
+		values = nil
+		_, err := ch.ConversationsAddMessageHandler(context.Background(), newToolRequest(map[string]any{
+			"channel_id":   "C1234567890",
+			"payload":      payload,
+			"content_type": "text/plain",
+			"mentions":     mentions,
+		}))
+		return err
+	}
+
+	require.NoError(t, post("@alice please check #general", "@alice,#general"))
+	assert.Equal(t, "<@U1> please check <#C1>", values.Get("text"))
+
+	require.NoError(t, post("deploy is done", "@alice, @U2345678"))
+	assert.Equal(t, "<@U1> <@U2345678> deploy is done", values.Get("text"), "missing mentions are prepended")
+
+	err := post("hi @bob", "@bob")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "@bob")
+	assert.Nil(t, values, "nothing is posted")
+
+	err = post("heads up", "@here")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SLACK_MCP_ADD_MESSAGE_BROADCAST")
+	assert.Nil(t, values)
+
+	// markup written into the payload is gated the same way
+	for _, payload := range []string{"<!channel> heads up", "heads up <!here|here>", "<!EVERYONE>"} {
+		err = post(payload, "")
+		require.Error(t, err, payload)
+		assert.Contains(t, err.Error(), "SLACK_MCP_ADD_MESSAGE_BROADCAST")
+		assert.Nil(t, values, "nothing is posted")
+	}
+
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_BROADCAST", "true")
+	require.NoError(t, post("heads up", "@here"))
+	assert.Equal(t, "<!here> heads up", values.Get("text"))
+	require.NoError(t, post("<!channel> heads up", ""))
+	assert.Equal(t, "<!channel> heads up", values.Get("text"))
+}
+
+func TestUnitConversationsSearchHighlight(t *testing.T) {
+	var highlight bool
+	api := &fakeSlackAPI{searchMsg: func(query string, params slack.SearchParameters) (*slack.SearchMessages, error) {
+		highlight = params.Highlight
+		text := "the deploy failed"
+		if params.Highlight {
+			text = "the \ue000deploy\ue001 failed"
+		}
+		return &slack.SearchMessages{Matches: []slack.SearchMessage{{
+			Channel:   slack.CtxChannel{ID: "C1", Name: "ops"},
+			User:      "U1",
+			Timestamp: "1700000000.000100",
+			Text:      text,
+			Permalink: "https://acme.slack.com/archives/C1/p1700000000000100",
+		}}}, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.ConversationsSearchHandler(context.Background(), newToolRequest(map[string]any{"search_query": "deploy"}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.False(t, highlight, "highlighting is off by default")
+	assert.NotContains(t, out, "Highlight")
+	assert.NotContains(t, out, "Permalink")
+	assert.Equal(t, []string{"the deploy failed"}, csvColumn(t, out, "Text"))
+
+	res, err = ch.ConversationsSearchHandler(context.Background(), newToolRequest(map[string]any{
+		"search_query":      "deploy",
+		"highlight":         true,
+		"highlight_context": 4,
+	}))
+	require.NoError(t, err)
+	out = toolResultText(t, res)
+	assert.True(t, highlight)
+	assert.Equal(t, []string{"the deploy failed"}, csvColumn(t, out, "Text"), "delimiters are stripped from the text")
+	assert.Equal(t, []string{"the **deploy** fai…"}, csvColumn(t, out, "Highlight"))
+	assert.Equal(t, []string{""}, csvColumn(t, out, "Permalink"))
+
+	res, err = ch.ConversationsSearchHandler(context.Background(), newToolRequest(map[string]any{
+		"search_query":      "deploy",
+		"include_permalink": true,
+	}))
+	require.NoError(t, err)
+	out = toolResultText(t, res)
+	assert.False(t, highlight)
+	assert.Equal(t, []string{"https://acme.slack.com/archives/C1/p1700000000000100"}, csvColumn(t, out, "Permalink"))
+	assert.Equal(t, []string{""}, csvColumn(t, out, "Highlight"))
+
+	_, err = ch.ConversationsSearchHandler(context.Background(), newToolRequest(map[string]any{
+		"search_query":      "deploy",
+		"highlight_context": -1,
+	}))
+	assert.Error(t, err)
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// ThreadRoot is the root message of the thread a message belongs to
+type ThreadRoot struct {
+	MsgID      string `json:"msgID"`
+	UserID     string `json:"userID"`
+	UserName   string `json:"userUser"`
+	RealName   string `json:"realName"`
+	Channel    string `json:"channelID"`
+	ThreadTs   string `json:"ThreadTs"`
+	Text       string `json:"text"`
+	Time       string `json:"time"`
+	ReplyCount int    `json:"replyCount"`
+	InThread   bool   `json:"inThread"` // false when the message is not part of a thread
+	IsReply    bool   `json:"isReply"`  // true when the requested ts is a reply and not the root itself
+}
+
+// ThreadMessage is a message of conversations_replies with fetch_all. A row
+// with a Note and no MsgID reports that the thread was cut at max_items.
+type ThreadMessage struct {
+	Message
+	Note string `json:"note,omitempty"`
+}
+
+const (
+	threadPageSize           = 200
+	defaultThreadMaxMessages = 1000 // messages fetch_all returns before handing back a cursor
+)
+
+// getMaxThreadMessages reads the most messages fetch_all returns from
+// SLACK_MCP_THREAD_MAX_MESSAGES, max_items can only lower it
+func getMaxThreadMessages(logger *zap.Logger) int {
+	v := os.Getenv("SLACK_MCP_THREAD_MAX_MESSAGES")
+	if v == "" {
+		return defaultThreadMaxMessages
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		logger.Warn("Invalid SLACK_MCP_THREAD_MAX_MESSAGES, using default",
+			zap.String("value", v),
+			zap.Int("default", defaultThreadMaxMessages),
+		)
+		return defaultThreadMaxMessages
+	}
+	return n
+}
+
+// conversationsRepliesAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type conversationsRepliesAPI interface {
+	GetConversationRepliesContext(ctx context.Context, params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error)
+}
+
+// ConversationsThreadRootHandler returns the root of the thread a message belongs to.
+// conversations.replies accepts the ts of any message in a thread and lists the
+// root first, so the root is found with a single call whether ts is a reply or
+// the root, without looking up the message itself first.
+func (ch *ConversationsHandler) ConversationsThreadRootHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ConversationsThreadRootHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	var api conversationsRepliesAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	channel, err := ch.resolveChannelID(request.GetString("channel_id", ""))
+	if err != nil {
+		ch.logger.Error("Failed to resolve channel for thread root", zap.Error(err))
+		return nil, err
+	}
+
+	ts := request.GetString("ts", "")
+	if _, err := parseSlackTimestamp(ts); err != nil {
+		ch.logger.Error("Invalid ts format", zap.String("ts", ts))
+		return nil, err
+	}
+	loc, err := ch.messageLocation(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := ch.fetchThreadRoot(ctx, api, channel, ts)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep activity messages, a root may be e.g. a channel_join or a file share
+	converted := ch.convertMessagesFromHistory([]slack.Message{root}, channel, true)
+	localizeMessageTimes(converted, loc)
+	if len(converted) == 0 {
+		return nil, errors.New("failed to convert thread root message")
+	}
+	msg := converted[0]
+
+	roots := []ThreadRoot{{
+		MsgID:      msg.MsgID,
+		UserID:     msg.UserID,
+		UserName:   msg.UserName,
+		RealName:   msg.RealName,
+		Channel:    msg.Channel,
+		ThreadTs:   msg.ThreadTs,
+		Text:       msg.Text,
+		Time:       msg.Time,
+		ReplyCount: root.ReplyCount,
+		InThread:   root.ThreadTimestamp != "",
+		IsReply:    root.Timestamp != ts,
+	}}
+	csvBytes, err := gocsv.MarshalBytes(&roots)
+	if err != nil {
+		ch.logger.Error("Failed to marshal thread root to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// fetchThreadRoot returns the root of the thread the message ts belongs to, or
+// the message itself when it is not in a thread, failing when the channel has
+// no message ts
+func (ch *ConversationsHandler) fetchThreadRoot(ctx context.Context, api conversationsRepliesAPI, channel, ts string) (slack.Message, error) {
+	msgs, _, _, err := api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: channel,
+		Timestamp: ts,
+		Limit:     1,
+		Inclusive: true,
+	})
+	switch {
+	case err == nil:
+	case isSlackError(err, "thread_not_found"), isSlackError(err, "message_not_found"):
+		return slack.Message{}, fmt.Errorf("message %s not found in channel %s: %w", ts, channel, err)
+	default:
+		ch.logger.Error("GetConversationRepliesContext failed", zap.Error(err))
+		return slack.Message{}, err
+	}
+	if len(msgs) == 0 {
+		return slack.Message{}, fmt.Errorf("message %s not found in channel %s", ts, channel)
+	}
+	return msgs[0], nil
+}
+
+// fetchThread pages through conversations.replies and returns the whole thread,
+// root first, regardless of its age. When the thread has more than
+// maxMessages messages, the cursor to continue with is returned as well.
+func fetchThread(ctx context.Context, api conversationsRepliesAPI, channel, threadTs string, maxMessages int) ([]slack.Message, string, error) {
+	var (
+		thread []slack.Message
+		cursor string
+	)
+	for {
+		replies, hasMore, nextCursor, err := api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: channel,
+			Timestamp: threadTs,
+			Limit:     min(threadPageSize, maxMessages-len(thread)),
+			Cursor:    cursor,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		thread = append(thread, replies...)
+		if !hasMore || nextCursor == "" {
+			return thread, "", nil
+		}
+		cursor = nextCursor
+		if len(thread) >= maxMessages {
+			return thread, cursor, nil
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitConversationsThreadRoot(t *testing.T) {
+	root := slack.Message{Msg: slack.Msg{
+		Timestamp:       "1700000000.000100",
+		ThreadTimestamp: "1700000000.000100",
+		User:            "U1",
+		Text:            "Deploy failed",
+		ReplyCount:      3,
+	}}
+	lone := slack.Message{Msg: slack.Msg{Timestamp: "1700000100.000100", User: "U1", Text: "Lunch?"}}
+
+	calls := 0
+	api := &fakeSlackAPI{replies: func(params *slack.GetConversationRepliesParameters) ([]slack.Message, error) {
+		calls++
+		assert.Equal(t, "C1234567890", params.ChannelID)
+		assert.Equal(t, 1, params.Limit)
+		switch params.Timestamp {
+		case "1700000000.000100", "1700000000.000200":
+			return []slack.Message{root}, nil
+		case "1700000100.000100":
+			return []slack.Message{lone}, nil
+		}
+		return nil, slack.SlackErrorResponse{Err: "thread_not_found"}
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	for _, tc := range []struct {
+		name     string
+		ts       string
+		root     string
+		inThread string
+		isReply  string
+	}{
+		{"reply", "1700000000.000200", "1700000000.000100", "true", "true"},
+		{"root", "1700000000.000100", "1700000000.000100", "true", "false"},
+		{"not in a thread", "1700000100.000100", "1700000100.000100", "false", "false"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			calls = 0
+			res, err := ch.ConversationsThreadRootHandler(context.Background(), newToolRequest(map[string]any{
+				"channel_id": "C1234567890",
+				"ts":         tc.ts,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, 1, calls, "the root must be found with a single call")
+
+			out := toolResultText(t, res)
+			assert.Equal(t, []string{tc.root}, csvColumn(t, out, "MsgID"))
+			assert.Equal(t, []string{tc.inThread}, csvColumn(t, out, "InThread"))
+			assert.Equal(t, []string{tc.isReply}, csvColumn(t, out, "IsReply"))
+		})
+	}
+
+	_, err := ch.ConversationsThreadRootHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"ts":         "1600000000.000100",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	_, err = ch.ConversationsThreadRootHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"ts":         "yesterday",
+	}))
+	assert.Error(t, err)
+}
+
+func TestUnitConversationsRepliesFetchAll(t *testing.T) {
+	const replyCount = 1203
+
+	var calls []*slack.GetConversationRepliesParameters
+	api := &fakeSlackAPI{thread: func(params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error) {
+		calls = append(calls, params)
+		assert.Empty(t, params.Oldest, "the whole thread is fetched regardless of its age")
+		start := 0
+		if params.Cursor != "" {
+			var err error
+			start, err = strconv.Atoi(params.Cursor)
+			require.NoError(t, err)
+		}
+		end := min(start+params.Limit, replyCount)
+		var msgs []slack.Message
+		for i := start; i < end; i++ {
+			msgs = append(msgs, slack.Message{Msg: slack.Msg{
+				Timestamp:       fmt.Sprintf("1700000000.%06d", i),
+				ThreadTimestamp: "1700000000.000000",
+				User:            "U1",
+				Text:            "reply",
+			}})
+		}
+		if end < replyCount {
+			return msgs, true, strconv.Itoa(end), nil
+		}
+		return msgs, false, "", nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	fetch := func(threadTs string) string {
+		res, err := ch.ConversationsRepliesHandler(context.Background(), newToolRequest(map[string]any{
+			"channel_id": "C1234567890",
+			"thread_ts":  threadTs,
+			"limit":      "1d",
+			"fetch_all":  true,
+		}))
+		require.NoError(t, err)
+		return toolResultText(t, res)
+	}
+
+	out := fetch("1700000000.000000")
+	ids := csvColumn(t, out, "MsgID")
+	require.Len(t, ids, defaultThreadMaxMessages+1)
+	assert.Equal(t, "1700000000.000000", ids[0])
+	assert.Equal(t, "1700000000.000999", ids[defaultThreadMaxMessages-1])
+	cursors := csvColumn(t, out, "Cursor")
+	assert.Equal(t, "1000", cursors[defaultThreadMaxMessages-1], "a thread longer than the cap returns a cursor")
+	assert.Empty(t, ids[defaultThreadMaxMessages])
+	notes := csvColumn(t, out, "Note")
+	assert.Contains(t, notes[defaultThreadMaxMessages], "truncated")
+	assert.Len(t, calls, 5)
+	for _, call := range calls {
+		assert.LessOrEqual(t, call.Limit, threadPageSize)
+	}
+
+	// max_items lowers the cap, SLACK_MCP_THREAD_MAX_MESSAGES bounds it
+	calls = nil
+	res, err := ch.ConversationsRepliesHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"thread_ts":  "1700000000.000000",
+		"fetch_all":  true,
+		"max_items":  250,
+	}))
+	require.NoError(t, err)
+	ids = csvColumn(t, toolResultText(t, res), "MsgID")
+	require.Len(t, ids, 251)
+	assert.Equal(t, "1700000000.000249", ids[249])
+	assert.Len(t, calls, 2)
+
+	_, err = ch.ConversationsRepliesHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"thread_ts":  "1700000000.000000",
+		"fetch_all":  true,
+		"max_items":  defaultThreadMaxMessages + 1,
+	}))
+	assert.ErrorContains(t, err, "SLACK_MCP_THREAD_MAX_MESSAGES")
+
+	t.Setenv("SLACK_MCP_THREAD_MAX_MESSAGES", "5000")
+	ch = NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+	out = fetch("1700000000.000000")
+	ids = csvColumn(t, out, "MsgID")
+	require.Len(t, ids, replyCount, "a thread within the cap has no note row")
+	assert.Empty(t, csvColumn(t, out, "Cursor")[replyCount-1])
+
+	_, err = ch.ConversationsRepliesHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"thread_ts":  "1700000000.000000",
+		"cursor":     "1000",
+		"fetch_all":  true,
+	}))
+	assert.ErrorContains(t, err, "fetch_all")
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.uber.org/zap"
+)
+
+// messageLocation returns the timezone the time column of messages is shown
+// in: the timezone parameter, by default the authenticated user's timezone,
+// and UTC when that is unknown. The user's timezone is cached once resolved.
+func (ch *ConversationsHandler) messageLocation(ctx context.Context, request mcp.CallToolRequest) (*time.Location, error) {
+	if tz := strings.TrimSpace(request.GetString("timezone", "")); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil || tz == "Local" {
+			return nil, fmt.Errorf("invalid timezone %q: expected an IANA timezone such as Europe/Berlin, America/New_York or UTC", tz)
+		}
+		return loc, nil
+	}
+
+	key := ""
+	if ch.oauthEnabled {
+		userCtx, ok := auth.FromContext(ctx)
+		if !ok {
+			return time.UTC, nil
+		}
+		key = userCtx.TeamID + "/" + userCtx.UserID
+	}
+	if loc, ok := ch.userLocations.Load(key); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := ch.authedUserLocation(ctx)
+	if err != nil {
+		ch.logger.Debug("Timezone of the authenticated user unknown, showing times in UTC", zap.Error(err))
+		return time.UTC, nil
+	}
+	ch.userLocations.Store(key, loc)
+	return loc, nil
+}
+
+// localizeMessageTimes renders the time column of messages in loc. It is
+// derived from the raw ts, which stays in the msgID column.
+func localizeMessageTimes(messages []Message, loc *time.Location) {
+	for i := range messages {
+		if t, err := parseSlackTimestamp(messages[i].MsgID); err == nil {
+			messages[i].Time = t.In(loc).Format(time.RFC3339)
+		}
+	}
+}
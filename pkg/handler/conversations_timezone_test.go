@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitConversationsHistoryTimezone(t *testing.T) {
+	historyCalls := 0
+	api := &fakeSlackAPI{
+		history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+			historyCalls++
+			resp := &slack.GetConversationHistoryResponse{Messages: []slack.Message{
+				{Msg: slack.Msg{Timestamp: "1700000000.000100", User: "U1", Text: "hello"}},
+			}}
+			resp.Ok = true
+			return resp, nil
+		},
+		authTest: func() (*slack.AuthTestResponse, error) {
+			return &slack.AuthTestResponse{URL: "https://acme.slack.com/", UserID: "U1"}, nil
+		},
+	}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	p.ProvideUsersMap().Users["U1"] = slack.User{ID: "U1", Name: "alice", TZ: "America/New_York"}
+	ch := NewConversationsHandler(p, zap.NewNop())
+
+	res, err := ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"limit":      "10",
+	}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"2023-11-14T17:13:20-05:00"}, csvColumn(t, out, "Time"), "times default to the timezone of the authenticated user")
+	assert.Equal(t, []string{"1700000000.000100"}, csvColumn(t, out, "MsgID"), "the raw ts is kept")
+
+	res, err = ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"limit":      "10",
+		"timezone":   "Asia/Tokyo",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2023-11-15T07:13:20+09:00"}, csvColumn(t, toolResultText(t, res), "Time"))
+
+	_, err = ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"limit":      "10",
+		"timezone":   "Mars/Olympus",
+	}))
+	assert.ErrorContains(t, err, "invalid timezone")
+	assert.Equal(t, 2, historyCalls, "an invalid timezone is rejected before calling Slack")
+}
+
+func TestUnitConversationsHistoryTimezoneUnknown(t *testing.T) {
+	api := &fakeSlackAPI{history: func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		resp := &slack.GetConversationHistoryResponse{Messages: []slack.Message{
+			{Msg: slack.Msg{Timestamp: "1700000000.000100", User: "U1", Text: "hello"}},
+		}}
+		resp.Ok = true
+		return resp, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.ConversationsHistoryHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"limit":      "10",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2023-11-14T22:13:20Z"}, csvColumn(t, toolResultText(t, res), "Time"), "times are in UTC when the user's timezone is unknown")
+}
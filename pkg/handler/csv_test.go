@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gocarina/gocsv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUnitEmptyResultsKeepCSVHeader guards that tools listing rows answer an
+// empty result with the header row alone, so that clients can tell "no
+// results" from an error and still see the schema
+func TestUnitEmptyResultsKeepCSVHeader(t *testing.T) {
+	cases := []struct {
+		name   string
+		rows   any
+		header string
+	}{
+		{"channels", &[]Channel{}, "ID,Name,Topic,Purpose,MemberCount,Cursor"},
+		{"nil channels", new([]Channel), "ID,Name,Topic,Purpose,MemberCount,Cursor"},
+		{"channel export", &[]ChannelExport{}, "ID,"},
+		{"messages", &[]Message{}, "MsgID,"},
+		{"bulk messages", &[]BulkMessage{}, "Channel,MsgID,"},
+		{"reactions", &[]Reaction{}, "Name,Count,UserIDs,UserNames"},
+		{"pins", &[]PinnedItem{}, "Type,"},
+		{"file matches", &[]FileMatch{}, "ID,Name,"},
+		{"users", &[]User{}, "UserID,"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			csvBytes, err := gocsv.MarshalBytes(tc.rows)
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSuffix(string(csvBytes), "\n"), "\n")
+			require.Len(t, lines, 1, "only the header row is expected")
+			assert.True(t, strings.HasPrefix(lines[0], tc.header), "header %q should start with %q", lines[0], tc.header)
+		})
+	}
+}
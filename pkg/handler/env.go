@@ -0,0 +1,13 @@
+package handler
+
+import "os"
+
+// envEnabled reports whether the environment variable name is set to true, 1 or yes,
+// the values with which the opt-in tools are enabled
+func envEnabled(name string) bool {
+	switch os.Getenv(name) {
+	case "true", "1", "yes":
+		return true
+	}
+	return false
+}
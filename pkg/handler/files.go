@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const defaultMaxFileSize = 1 << 20 // 1 MiB
+
+var errFileTooLarge = errors.New("file exceeds the maximum download size")
+
+type FileInfo struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Title     string `json:"title"`
+	Mimetype  string `json:"mimetype"`
+	Size      int    `json:"size"`
+	Permalink string `json:"permalink"`
+	Content   string `json:"content,omitempty"` // base64, only with include_content
+}
+
+type FileMatch struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Title     string `json:"title"`
+	Filetype  string `json:"filetype"`
+	Size      int    `json:"size"`
+	UserID    string `json:"userID"`
+	Channels  string `json:"channels"`
+	Time      string `json:"time"`
+	Permalink string `json:"permalink"`
+	Cursor    string `json:"cursor"`
+}
+
+// getMaxFileSize reads the download cap for files_get from SLACK_MCP_FILE_MAX_BYTES,
+// falling back to the default on invalid values
+func getMaxFileSize(logger *zap.Logger) int {
+	v := os.Getenv("SLACK_MCP_FILE_MAX_BYTES")
+	if v == "" {
+		return defaultMaxFileSize
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		logger.Warn("Invalid SLACK_MCP_FILE_MAX_BYTES, using default",
+			zap.String("value", v),
+			zap.Int("default", defaultMaxFileSize),
+		)
+		return defaultMaxFileSize
+	}
+	return n
+}
+
+// FilesGetHandler returns file metadata and optionally its base64 content as CSV
+func (ch *ConversationsHandler) FilesGetHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("FilesGetHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	// Get Slack client (OAuth or legacy)
+	var api fileContentAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	fileID := request.GetString("file_id", "")
+	if fileID == "" {
+		return nil, errors.New("file_id must be a string")
+	}
+	includeContent := request.GetBool("include_content", false)
+
+	file, _, _, err := api.GetFileInfoContext(ctx, fileID, 0, 0)
+	if err != nil {
+		ch.logger.Error("Slack GetFileInfoContext failed", zap.Error(err))
+		if isMissingScope(err) {
+			return nil, fmt.Errorf("files_get requires the files:read scope, add it to the Slack app and reinstall it: %w", err)
+		}
+		return nil, err
+	}
+
+	info := FileInfo{
+		ID:        file.ID,
+		Name:      file.Name,
+		Title:     file.Title,
+		Mimetype:  file.Mimetype,
+		Size:      file.Size,
+		Permalink: file.Permalink,
+	}
+
+	if includeContent {
+		content, err := ch.readFileContent(ctx, api, file)
+		if err != nil {
+			return nil, err
+		}
+		info.Content = base64.StdEncoding.EncodeToString(content)
+	}
+
+	infos := []FileInfo{info}
+	csvBytes, err := gocsv.MarshalBytes(&infos)
+	if err != nil {
+		ch.logger.Error("Failed to marshal file info to CSV", zap.Error(err))
+		return nil, err
+	}
+	res := mcp.NewToolResultText(string(csvBytes))
+	if request.GetBool("include_links", false) {
+		res = withResourceLinks(res, []mcp.ResourceLink{permalinkLink(info.Permalink, info.Name, info.Title)})
+	}
+	return res, nil
+}
+
+// limitedWriter fails once more than remaining bytes are written
+type limitedWriter struct {
+	w         io.Writer
+	remaining int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if len(p) > l.remaining {
+		return 0, errFileTooLarge
+	}
+	l.remaining -= len(p)
+	return l.w.Write(p)
+}
+
+// FilesSearchHandler searches files with search.files and returns the matches as CSV
+func (ch *ConversationsHandler) FilesSearchHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("FilesSearchHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	// Get Slack client (OAuth or legacy), search is only available to user tokens
+	var slackClient *slack.Client
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		slackClient = client
+	}
+
+	query, err := ch.composeSearchQuery(request)
+	if err != nil {
+		ch.logger.Error("Failed to parse search filters", zap.Error(err))
+		return nil, err
+	}
+	if query == "" {
+		return nil, errors.New("search_query or at least one filter must be provided")
+	}
+	limit := request.GetInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		return nil, errors.New("limit must be an integer between 1 and 100")
+	}
+	page, err := ch.parseSearchCursor(request.GetString("cursor", ""))
+	if err != nil {
+		return nil, err
+	}
+	loc, err := ch.messageLocation(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	ch.logger.Debug("File search params parsed", zap.String("query", query), zap.Int("limit", limit), zap.Int("page", page))
+
+	searchParams := slack.SearchParameters{
+		Sort:          slack.DEFAULT_SEARCH_SORT,
+		SortDirection: slack.DEFAULT_SEARCH_SORT_DIR,
+		Highlight:     false,
+		Count:         limit,
+		Page:          page,
+	}
+
+	var filesRes *slack.SearchFiles
+	if ch.oauthEnabled {
+		filesRes, err = slackClient.SearchFilesContext(ctx, query, searchParams)
+	} else {
+		filesRes, err = ch.apiProvider.Slack().SearchFilesContext(ctx, query, searchParams)
+	}
+	if err != nil {
+		ch.logger.Error("Slack SearchFilesContext failed", zap.Error(err))
+		if isSlackError(err, "not_allowed_token_type") {
+			return nil, fmt.Errorf("files_search requires a user token, search is not available to bot tokens: %w", err)
+		}
+		if isMissingScope(err) {
+			return nil, fmt.Errorf("files_search requires the search:read scope, add it to the Slack app and reinstall it: %w", err)
+		}
+		return nil, err
+	}
+	ch.logger.Debug("File search completed", zap.Int("matches", len(filesRes.Matches)))
+
+	matches := []FileMatch{}
+	for _, f := range filesRes.Matches {
+		// files shared in private channels and DMs are listed apart from public ones
+		var channels []string
+		channels = append(channels, f.Channels...)
+		channels = append(channels, f.Groups...)
+		channels = append(channels, f.IMs...)
+
+		match := FileMatch{
+			ID:        f.ID,
+			Name:      f.Name,
+			Title:     f.Title,
+			Filetype:  f.Filetype,
+			Size:      f.Size,
+			UserID:    f.User,
+			Channels:  strings.Join(channels, ","),
+			Permalink: f.Permalink,
+		}
+		if f.Created != 0 {
+			match.Time = f.Created.Time().In(loc).Format(time.RFC3339)
+		}
+		matches = append(matches, match)
+	}
+
+	if len(matches) > 0 && filesRes.Pagination.Page < filesRes.Pagination.PageCount {
+		nextCursor := fmt.Sprintf("page:%d", filesRes.Pagination.Page+1)
+		matches[len(matches)-1].Cursor = base64.StdEncoding.EncodeToString([]byte(nextCursor))
+	}
+
+	csvBytes, err := gocsv.MarshalBytes(&matches)
+	if err != nil {
+		ch.logger.Error("Failed to marshal files to CSV", zap.Error(err))
+		return nil, err
+	}
+	res := mcp.NewToolResultText(string(csvBytes))
+	if request.GetBool("include_links", false) {
+		links := make([]mcp.ResourceLink, 0, len(matches))
+		for _, m := range matches {
+			links = append(links, permalinkLink(m.Permalink, m.Name, m.Title))
+		}
+		res = withResourceLinks(res, links)
+	}
+	return res, nil
+}
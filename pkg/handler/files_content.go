@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// fileContentAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type fileContentAPI interface {
+	GetFileInfoContext(ctx context.Context, fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error)
+	GetFileContext(ctx context.Context, downloadURL string, writer io.Writer) error
+}
+
+// FilesGetContentHandler downloads a file and returns its content, text files
+// inline and binary files as an embedded base64 blob
+func (ch *ConversationsHandler) FilesGetContentHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("FilesGetContentHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	fileID := strings.TrimSpace(request.GetString("file_id", ""))
+	if fileID == "" {
+		return nil, errors.New("file_id must be a string")
+	}
+
+	var api fileContentAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	file, content, err := ch.downloadFile(ctx, api, fileID, "files_get_content")
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType := fileMIMEType(file, content)
+	if isTextContent(mimeType, content) {
+		return mcp.NewToolResultText(string(content)), nil
+	}
+	return mcp.NewToolResultResource(
+		fmt.Sprintf("File %s (%s, %s, %d bytes) is binary, its content is embedded base64 encoded.", file.ID, file.Name, mimeType, len(content)),
+		mcp.BlobResourceContents{
+			URI:      file.Permalink,
+			MIMEType: mimeType,
+			Blob:     base64.StdEncoding.EncodeToString(content),
+		},
+	), nil
+}
+
+// FileResource returns the content of the file of a slack://<workspace>/files/<id>
+// URI, as text for text files and as a base64 blob otherwise
+func (ch *ConversationsHandler) FileResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	ch.logger.Debug("FileResource called", zap.Any("params", request.Params))
+
+	// mark3labs/mcp-go does not support middlewares for resources.
+	if authenticated, err := auth.IsAuthenticated(ctx, ch.apiProvider.ServerTransport(), ch.logger); !authenticated {
+		ch.logger.Error("Authentication failed for file resource", zap.Error(err))
+		return nil, err
+	}
+
+	// template variables are matched as a list of values
+	var fileID string
+	switch v := request.Params.Arguments["id"].(type) {
+	case string:
+		fileID = v
+	case []string:
+		if len(v) == 1 {
+			fileID = v[0]
+		}
+	}
+	if fileID == "" {
+		return nil, fmt.Errorf("resource URI %q does not name a file, expected slack://<workspace>/files/<file_id>", request.Params.URI)
+	}
+
+	file, content, err := ch.downloadFile(ctx, ch.apiProvider.Slack(), fileID, "the files resource")
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType := fileMIMEType(file, content)
+	if isTextContent(mimeType, content) {
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: mimeType,
+				Text:     string(content),
+			},
+		}, nil
+	}
+	return []mcp.ResourceContents{
+		mcp.BlobResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: mimeType,
+			Blob:     base64.StdEncoding.EncodeToString(content),
+		},
+	}, nil
+}
+
+// downloadFile looks up a file with files.info and downloads its content,
+// failing for files larger than the maximum file size
+func (ch *ConversationsHandler) downloadFile(ctx context.Context, api fileContentAPI, fileID, caller string) (*slack.File, []byte, error) {
+	file, _, _, err := api.GetFileInfoContext(ctx, fileID, 0, 0)
+	if err != nil {
+		ch.logger.Error("Slack GetFileInfoContext failed", zap.Error(err))
+		switch {
+		case isSlackError(err, "file_not_found"), isSlackError(err, "file_deleted"):
+			return nil, nil, fmt.Errorf("file %s not found or not accessible: %w", fileID, err)
+		case isMissingScope(err):
+			return nil, nil, fmt.Errorf("%s requires the files:read scope, add it to the Slack app and reinstall it: %w", caller, err)
+		}
+		return nil, nil, err
+	}
+
+	content, err := ch.readFileContent(ctx, api, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, content, nil
+}
+
+// readFileContent downloads the content of a file from its private URL
+func (ch *ConversationsHandler) readFileContent(ctx context.Context, api fileContentAPI, file *slack.File) ([]byte, error) {
+	if file.Size > ch.maxFileSize {
+		return nil, fmt.Errorf("file %s is %d bytes, content can only be downloaded for files up to %d bytes (SLACK_MCP_FILE_MAX_BYTES)", file.ID, file.Size, ch.maxFileSize)
+	}
+	downloadURL := file.URLPrivateDownload
+	if downloadURL == "" {
+		downloadURL = file.URLPrivate
+	}
+	if downloadURL == "" {
+		return nil, fmt.Errorf("file %s has no downloadable content", file.ID)
+	}
+
+	// the Slack client sends the token as Authorization header to the private URL;
+	// the size is enforced again while reading as files.info may be stale
+	var buf bytes.Buffer
+	w := &limitedWriter{w: &buf, remaining: ch.maxFileSize}
+	if err := api.GetFileContext(ctx, downloadURL, w); err != nil {
+		ch.logger.Error("Slack GetFileContext failed", zap.Error(err))
+		if errors.Is(err, errFileTooLarge) {
+			return nil, fmt.Errorf("file %s is larger than %d bytes (SLACK_MCP_FILE_MAX_BYTES)", file.ID, ch.maxFileSize)
+		}
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// fileMIMEType returns the MIME type Slack reports for a file, falling back
+// to its extension and then to sniffing the content
+func fileMIMEType(file *slack.File, content []byte) string {
+	if file.Mimetype != "" && file.Mimetype != "application/octet-stream" {
+		return file.Mimetype
+	}
+	if t := mime.TypeByExtension(path.Ext(file.Name)); t != "" {
+		return t
+	}
+	return http.DetectContentType(content)
+}
+
+// isTextContent reports whether content of a MIME type can be returned as
+// text, which also requires it to be valid UTF-8
+func isTextContent(mimeType string, content []byte) bool {
+	if !utf8.Valid(content) {
+		return false
+	}
+	t, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(t, "text/") || strings.HasSuffix(t, "+json") || strings.HasSuffix(t, "+xml") {
+		return true
+	}
+	switch t {
+	case "application/json", "application/xml", "application/javascript", "application/x-javascript",
+		"application/yaml", "application/x-yaml", "application/x-sh", "application/sql", "application/csv":
+		return true
+	}
+	return false
+}
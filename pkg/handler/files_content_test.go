@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeContentAPI serves file as the only file, with content as its download
+func fakeContentAPI(t *testing.T, file *slack.File, content string) *fakeSlackAPI {
+	return &fakeSlackAPI{
+		fileInfo: func(fileID string) (*slack.File, error) {
+			if fileID != file.ID {
+				return nil, slack.SlackErrorResponse{Err: "file_not_found"}
+			}
+			return file, nil
+		},
+		file: func(downloadURL string, writer io.Writer) error {
+			assert.Equal(t, file.URLPrivateDownload, downloadURL)
+			_, err := io.Copy(writer, strings.NewReader(content))
+			return err
+		},
+	}
+}
+
+func fakePNG() *slack.File {
+	return &slack.File{
+		ID:                 "F456",
+		Name:               "chart.png",
+		Mimetype:           "image/png",
+		Size:               8,
+		Permalink:          "https://example.slack.com/files/U1/F456/chart.png",
+		URLPrivateDownload: "https://files.slack.com/files-pri/T1-F456/download/chart.png",
+	}
+}
+
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+func TestUnitFilesGetContentText(t *testing.T) {
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", fakeContentAPI(t, fakeFile(5), "hello"), zap.NewNop()), zap.NewNop())
+
+	res, err := ch.FilesGetContentHandler(context.Background(), newToolRequest(map[string]any{"file_id": "F123"}))
+	require.NoError(t, err)
+	require.Len(t, res.Content, 1)
+	assert.Equal(t, "hello", toolResultText(t, res))
+}
+
+func TestUnitFilesGetContentBinary(t *testing.T) {
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", fakeContentAPI(t, fakePNG(), pngHeader), zap.NewNop()), zap.NewNop())
+
+	res, err := ch.FilesGetContentHandler(context.Background(), newToolRequest(map[string]any{"file_id": "F456"}))
+	require.NoError(t, err)
+	require.Len(t, res.Content, 2)
+	assert.Contains(t, toolResultText(t, res), "binary")
+
+	embedded, ok := res.Content[1].(mcp.EmbeddedResource)
+	require.True(t, ok)
+	blob, ok := embedded.Resource.(mcp.BlobResourceContents)
+	require.True(t, ok)
+	assert.Equal(t, "image/png", blob.MIMEType)
+	assert.Equal(t, "https://example.slack.com/files/U1/F456/chart.png", blob.URI)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(pngHeader)), blob.Blob)
+}
+
+func TestUnitFilesGetContentErrors(t *testing.T) {
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", fakeContentAPI(t, fakeFile(5), "hello"), zap.NewNop()), zap.NewNop())
+
+	_, err := ch.FilesGetContentHandler(context.Background(), newToolRequest(map[string]any{}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file_id")
+
+	_, err = ch.FilesGetContentHandler(context.Background(), newToolRequest(map[string]any{"file_id": "F999"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found or not accessible")
+
+	t.Setenv("SLACK_MCP_FILE_MAX_BYTES", "4")
+	ch = NewConversationsHandler(provider.NewWithClient("stdio", fakeContentAPI(t, fakeFile(5), "hello"), zap.NewNop()), zap.NewNop())
+	_, err = ch.FilesGetContentHandler(context.Background(), newToolRequest(map[string]any{"file_id": "F123"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SLACK_MCP_FILE_MAX_BYTES")
+}
+
+func TestUnitFileResource(t *testing.T) {
+	readRequest := func(id string) mcp.ReadResourceRequest {
+		var req mcp.ReadResourceRequest
+		req.Params.URI = "slack://example/files/" + id
+		req.Params.Arguments = map[string]any{"id": []string{id}}
+		return req
+	}
+
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", fakeContentAPI(t, fakeFile(5), "hello"), zap.NewNop()), zap.NewNop())
+	contents, err := ch.FileResource(context.Background(), readRequest("F123"))
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	text, ok := contents[0].(mcp.TextResourceContents)
+	require.True(t, ok)
+	assert.Equal(t, "slack://example/files/F123", text.URI)
+	assert.Equal(t, "text/plain", text.MIMEType)
+	assert.Equal(t, "hello", text.Text)
+
+	ch = NewConversationsHandler(provider.NewWithClient("stdio", fakeContentAPI(t, fakePNG(), pngHeader), zap.NewNop()), zap.NewNop())
+	contents, err = ch.FileResource(context.Background(), readRequest("F456"))
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	blob, ok := contents[0].(mcp.BlobResourceContents)
+	require.True(t, ok)
+	assert.Equal(t, "image/png", blob.MIMEType)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(pngHeader)), blob.Blob)
+}
+
+func TestUnitFileMIMEType(t *testing.T) {
+	// Slack reports the MIME type
+	assert.Equal(t, "image/png", fileMIMEType(&slack.File{Name: "a.bin", Mimetype: "image/png"}, nil))
+	// unknown to Slack, derived from the extension
+	assert.Equal(t, "application/pdf", fileMIMEType(&slack.File{Name: "a.pdf", Mimetype: "application/octet-stream"}, nil))
+	// no extension either, sniffed from the content
+	assert.Equal(t, "text/plain; charset=utf-8", fileMIMEType(&slack.File{Name: "README"}, []byte("hello")))
+
+	assert.True(t, isTextContent("text/csv", []byte("a,b")))
+	assert.True(t, isTextContent("application/json", []byte(`{}`)))
+	assert.True(t, isTextContent("application/vnd.api+json", []byte(`{}`)))
+	assert.False(t, isTextContent("text/plain", []byte{0xff, 0xfe}))
+	assert.False(t, isTextContent("image/png", []byte("hello")))
+}
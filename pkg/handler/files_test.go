@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func fakeFile(size int) *slack.File {
+	return &slack.File{
+		ID:                 "F123",
+		Name:               "notes.txt",
+		Title:              "Notes",
+		Mimetype:           "text/plain",
+		Size:               size,
+		Permalink:          "https://example.slack.com/files/U1/F123/notes.txt",
+		URLPrivateDownload: "https://files.slack.com/files-pri/T1-F123/download/notes.txt",
+	}
+}
+
+func TestUnitFilesGetMetadata(t *testing.T) {
+	api := &fakeSlackAPI{
+		fileInfo: func(fileID string) (*slack.File, error) {
+			assert.Equal(t, "F123", fileID)
+			return fakeFile(5), nil
+		},
+		file: func(downloadURL string, writer io.Writer) error {
+			t.Fatal("content must not be downloaded without include_content")
+			return nil
+		},
+	}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.FilesGetHandler(context.Background(), newToolRequest(map[string]any{"file_id": "F123"}))
+	require.NoError(t, err)
+
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"notes.txt"}, csvColumn(t, out, "Name"))
+	assert.Equal(t, []string{"text/plain"}, csvColumn(t, out, "Mimetype"))
+	assert.Equal(t, []string{"5"}, csvColumn(t, out, "Size"))
+	assert.Equal(t, []string{""}, csvColumn(t, out, "Content"))
+}
+
+func TestUnitFilesGetIncludeLinks(t *testing.T) {
+	api := &fakeSlackAPI{fileInfo: func(fileID string) (*slack.File, error) {
+		return fakeFile(5), nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.FilesGetHandler(context.Background(), newToolRequest(map[string]any{
+		"file_id":       "F123",
+		"include_links": true,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"notes.txt"}, csvColumn(t, toolResultText(t, res), "Name"))
+
+	require.Len(t, res.Content, 2)
+	link, ok := res.Content[1].(mcp.ResourceLink)
+	require.True(t, ok)
+	assert.Equal(t, "https://example.slack.com/files/U1/F123/notes.txt", link.URI)
+	assert.Equal(t, "notes.txt", link.Name)
+	assert.Equal(t, "Notes", link.Description)
+}
+
+func TestUnitFilesGetContent(t *testing.T) {
+	api := &fakeSlackAPI{
+		fileInfo: func(fileID string) (*slack.File, error) {
+			return fakeFile(5), nil
+		},
+		file: func(downloadURL string, writer io.Writer) error {
+			assert.Equal(t, "https://files.slack.com/files-pri/T1-F123/download/notes.txt", downloadURL)
+			_, err := io.Copy(writer, strings.NewReader("hello"))
+			return err
+		},
+	}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.FilesGetHandler(context.Background(), newToolRequest(map[string]any{
+		"file_id":         "F123",
+		"include_content": true,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{base64.StdEncoding.EncodeToString([]byte("hello"))}, csvColumn(t, toolResultText(t, res), "Content"))
+}
+
+func TestUnitFilesGetSizeCap(t *testing.T) {
+	t.Setenv("SLACK_MCP_FILE_MAX_BYTES", "4")
+
+	// files.info already reports the file as too large
+	api := &fakeSlackAPI{
+		fileInfo: func(fileID string) (*slack.File, error) {
+			return fakeFile(5), nil
+		},
+		file: func(downloadURL string, writer io.Writer) error {
+			t.Fatal("oversized file must not be downloaded")
+			return nil
+		},
+	}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+	_, err := ch.FilesGetHandler(context.Background(), newToolRequest(map[string]any{"file_id": "F123", "include_content": true}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SLACK_MCP_FILE_MAX_BYTES")
+
+	// the download turns out larger than files.info reported
+	api = &fakeSlackAPI{
+		fileInfo: func(fileID string) (*slack.File, error) {
+			return fakeFile(3), nil
+		},
+		file: func(downloadURL string, writer io.Writer) error {
+			_, err := io.Copy(writer, strings.NewReader("hello"))
+			return err
+		},
+	}
+	ch = NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+	_, err = ch.FilesGetHandler(context.Background(), newToolRequest(map[string]any{"file_id": "F123", "include_content": true}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "larger than 4 bytes")
+}
+
+func TestUnitFilesGetMissingScope(t *testing.T) {
+	api := &fakeSlackAPI{fileInfo: func(fileID string) (*slack.File, error) {
+		return nil, slack.SlackErrorResponse{Err: "missing_scope"}
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	_, err := ch.FilesGetHandler(context.Background(), newToolRequest(map[string]any{"file_id": "F123"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "files:read")
+}
+
+func TestUnitFilesSearch(t *testing.T) {
+	api := &fakeSlackAPI{search: func(query string, params slack.SearchParameters) (*slack.SearchFiles, error) {
+		assert.Equal(t, "report from:<@U1> after:2023-10-01", query)
+		assert.Equal(t, 2, params.Page)
+		assert.Equal(t, 10, params.Count)
+
+		res := &slack.SearchFiles{Matches: []slack.File{{
+			ID:        "F1",
+			Name:      "report.pdf",
+			Title:     "Q3 report",
+			Filetype:  "pdf",
+			Size:      1024,
+			User:      "U1",
+			Created:   slack.JSONTime(1700000000),
+			Channels:  []string{"C1"},
+			Groups:    []string{"G1"},
+			Permalink: "https://acme.slack.com/files/U1/F1/report.pdf",
+		}}}
+		res.Pagination.Page = 2
+		res.Pagination.PageCount = 3
+		return res, nil
+	}}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	p.ProvideUsersMap().Users["U1"] = slack.User{ID: "U1", Name: "alice"}
+	ch := NewConversationsHandler(p, zap.NewNop())
+
+	res, err := ch.FilesSearchHandler(context.Background(), newToolRequest(map[string]any{
+		"search_query":      "report",
+		"filter_users_from": "U1",
+		"filter_date_after": "2023-10-01",
+		"limit":             float64(10),
+		"cursor":            base64.StdEncoding.EncodeToString([]byte("page:2")),
+	}))
+	require.NoError(t, err)
+
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"report.pdf"}, csvColumn(t, out, "Name"))
+	assert.Equal(t, []string{"pdf"}, csvColumn(t, out, "Filetype"))
+	assert.Equal(t, []string{"C1,G1"}, csvColumn(t, out, "Channels"))
+	assert.Equal(t, []string{"2023-11-14T22:13:20Z"}, csvColumn(t, out, "Time"))
+	assert.Equal(t, []string{"https://acme.slack.com/files/U1/F1/report.pdf"}, csvColumn(t, out, "Permalink"))
+	assert.Equal(t, []string{base64.StdEncoding.EncodeToString([]byte("page:3"))}, csvColumn(t, out, "Cursor"))
+}
+
+func TestUnitFilesSearchNoResults(t *testing.T) {
+	api := &fakeSlackAPI{search: func(query string, params slack.SearchParameters) (*slack.SearchFiles, error) {
+		return &slack.SearchFiles{}, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.FilesSearchHandler(context.Background(), newToolRequest(map[string]any{"search_query": "nothing"}))
+	require.NoError(t, err)
+	assert.Empty(t, csvColumn(t, toolResultText(t, res), "ID"))
+}
+
+func TestUnitFilesSearchErrors(t *testing.T) {
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", &fakeSlackAPI{}, zap.NewNop()), zap.NewNop())
+	_, err := ch.FilesSearchHandler(context.Background(), newToolRequest(map[string]any{}))
+	assert.Error(t, err, "a query or filter is required")
+
+	api := &fakeSlackAPI{search: func(query string, params slack.SearchParameters) (*slack.SearchFiles, error) {
+		return nil, slack.SlackErrorResponse{Err: "not_allowed_token_type"}
+	}}
+	ch = NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+	_, err = ch.FilesSearchHandler(context.Background(), newToolRequest(map[string]any{"search_query": "report"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires a user token")
+}
@@ -0,0 +1,293 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	maxUploadChannels = 10
+	maxFetchRedirects = 5
+)
+
+var errBlockedAddress = errors.New("address is not publicly routable")
+
+// fileFetchClient fetches the content of files_upload given by URL. The URL
+// comes from the tool caller, so only public addresses may be reached.
+var fileFetchClient = newFileFetchClient(isPublicIP)
+
+// cgnatNet is the shared address space of RFC 6598, not covered by net.IP.IsPrivate
+var cgnatNet = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// isPublicIP reports whether ip is neither loopback, private, link-local
+// (e.g. the 169.254.169.254 cloud metadata endpoint) nor unspecified
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() && !cgnatNet.Contains(ip)
+}
+
+// newFileFetchClient returns a client that only connects to IPs accepted by
+// allowed. The check runs on the resolved address at dial time, so DNS
+// rebinding cannot get around it, and again on every redirect.
+func newFileFetchClient(allowed func(net.IP) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !allowed(ip) {
+				return fmt.Errorf("%w: %s", errBlockedAddress, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would be dialed instead of the target, bypassing the check
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxFetchRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxFetchRedirects)
+			}
+			if req.URL.Scheme != "https" && req.URL.Scheme != "http" {
+				return fmt.Errorf("redirect to %s is not an http or https URL", req.URL.Redacted())
+			}
+			if ip := net.ParseIP(req.URL.Hostname()); ip != nil && !allowed(ip) {
+				return fmt.Errorf("redirect to %s: %w", req.URL.Redacted(), errBlockedAddress)
+			}
+			return nil
+		},
+	}
+}
+
+// FileUploaded is a file shared by files_upload, one row per channel. Without
+// channels the file is uploaded privately and ChannelID is empty.
+type FileUploaded struct {
+	FileID    string `json:"fileID"`
+	Title     string `json:"title"`
+	ChannelID string `json:"channelID"`
+	Note      string `json:"note"`
+}
+
+// fileUploadAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type fileUploadAPI interface {
+	UploadFileV2Context(ctx context.Context, params slack.UploadFileV2Parameters) (*slack.FileSummary, error)
+}
+
+// FilesUploadHandler uploads a file with the files.uploadV2 flow and shares it
+// into the given channels, e.g. to attach a generated report. Sharing into a
+// channel is posting into it, so it is subject to the same
+// SLACK_MCP_ADD_MESSAGE_TOOL policy as conversations_add_message.
+func (ch *ConversationsHandler) FilesUploadHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("FilesUploadHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	toolConfig := os.Getenv("SLACK_MCP_ADD_MESSAGE_TOOL")
+	if toolConfig == "" {
+		return nil, errors.New("by default, the files_upload tool is disabled to guard Slack workspaces against accidental spamming. " +
+			"To enable it, set the SLACK_MCP_ADD_MESSAGE_TOOL environment variable to true, 1, or comma separated list of channels " +
+			"to limit where the MCP can share files, the same policy as for conversations_add_message")
+	}
+
+	filename := strings.TrimSpace(request.GetString("filename", ""))
+	if filename == "" {
+		return nil, errors.New("filename must be a string")
+	}
+	if strings.ContainsAny(filename, `/\`) {
+		return nil, fmt.Errorf("filename %q must not contain a path, e.g. %q", filename, path.Base(strings.ReplaceAll(filename, `\`, "/")))
+	}
+
+	var channels []string
+	for _, raw := range strings.Split(request.GetString("channel_ids", ""), ",") {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		channel, err := ch.resolveChannelID(raw)
+		if err != nil {
+			return nil, err
+		}
+		if !isChannelAllowed(channel) {
+			ch.logger.Warn("Files upload not allowed for channel", zap.String("channel", channel), zap.String("policy", toolConfig))
+			return nil, fmt.Errorf("files_upload tool is not allowed for channel %q, applied policy: %s", channel, toolConfig)
+		}
+		channels = append(channels, channel)
+	}
+	if len(channels) > maxUploadChannels {
+		return nil, fmt.Errorf("files can be shared into at most %d channels per call, got %d", maxUploadChannels, len(channels))
+	}
+
+	content, err := ch.uploadContent(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var api fileUploadAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	params := slack.UploadFileV2Parameters{
+		Filename:       filename,
+		FileSize:       len(content),
+		Title:          strings.TrimSpace(request.GetString("title", "")),
+		InitialComment: request.GetString("initial_comment", ""),
+	}
+	if params.Title == "" {
+		params.Title = filename
+	}
+
+	// files.completeUploadExternal shares into one channel, so every channel
+	// gets its own upload; a failed channel does not undo the other ones
+	targets := channels
+	if len(targets) == 0 {
+		targets = []string{""}
+	}
+	var (
+		rows     []FileUploaded
+		shared   int
+		firstErr error
+	)
+	for _, channel := range targets {
+		params.Channel = channel
+		params.Reader = bytes.NewReader(content)
+		file, err := api.UploadFileV2Context(ctx, params)
+		if err != nil {
+			err = uploadError(channel, err)
+			ch.logger.Error("Slack UploadFileV2Context failed", zap.String("channel", channel), zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+			rows = append(rows, FileUploaded{ChannelID: channel, Note: "not shared: " + err.Error()})
+			continue
+		}
+		shared++
+		rows = append(rows, FileUploaded{FileID: file.ID, Title: file.Title, ChannelID: channel})
+	}
+	if shared == 0 {
+		return nil, firstErr
+	}
+
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal uploaded files to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// uploadContent returns the file content of files_upload, decoded from
+// content_base64 or fetched from url, up to the maximum file size
+func (ch *ConversationsHandler) uploadContent(ctx context.Context, request mcp.CallToolRequest) ([]byte, error) {
+	encoded := strings.TrimSpace(request.GetString("content_base64", ""))
+	rawURL := strings.TrimSpace(request.GetString("url", ""))
+	if (encoded == "") == (rawURL == "") {
+		return nil, errors.New("exactly one of content_base64 or url must be provided")
+	}
+
+	var content []byte
+	if encoded != "" {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("content_base64 is not valid base64: %w", err)
+		}
+		if len(decoded) > ch.maxFileSize {
+			return nil, fmt.Errorf("file is %d bytes, files can only be uploaded up to %d bytes (SLACK_MCP_FILE_MAX_BYTES)", len(decoded), ch.maxFileSize)
+		}
+		content = decoded
+	} else {
+		fetched, err := ch.fetchUploadURL(ctx, rawURL)
+		if err != nil {
+			return nil, err
+		}
+		content = fetched
+	}
+
+	if len(content) == 0 {
+		return nil, errors.New("file is empty, Slack does not accept empty files")
+	}
+	return content, nil
+}
+
+// fetchUploadURL downloads the content of an http(s) URL, failing once it
+// exceeds the maximum file size
+func (ch *ConversationsHandler) fetchUploadURL(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("url %q must be an http or https URL", rawURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := fileFetchClient.Do(req)
+	if err != nil {
+		ch.logger.Error("Failed to fetch file to upload", zap.String("url", u.Redacted()), zap.Error(err))
+		if errors.Is(err, errBlockedAddress) {
+			return nil, fmt.Errorf("url %s resolves to a loopback, private or link-local address, files can only be fetched from public addresses: %w", u.Redacted(), err)
+		}
+		return nil, fmt.Errorf("failed to fetch %s: %w", u.Redacted(), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to fetch %s: %s", u.Redacted(), resp.Status)
+	}
+	if resp.ContentLength > int64(ch.maxFileSize) {
+		return nil, fmt.Errorf("file at %s is %d bytes, files can only be uploaded up to %d bytes (SLACK_MCP_FILE_MAX_BYTES)", u.Redacted(), resp.ContentLength, ch.maxFileSize)
+	}
+
+	var buf bytes.Buffer
+	w := &limitedWriter{w: &buf, remaining: ch.maxFileSize}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		if errors.Is(err, errFileTooLarge) {
+			return nil, fmt.Errorf("file at %s is larger than %d bytes (SLACK_MCP_FILE_MAX_BYTES)", u.Redacted(), ch.maxFileSize)
+		}
+		return nil, fmt.Errorf("failed to fetch %s: %w", u.Redacted(), err)
+	}
+	return buf.Bytes(), nil
+}
+
+// uploadError explains the Slack errors of uploading and sharing a file
+func uploadError(channel string, err error) error {
+	switch {
+	case isSlackError(err, "not_in_channel"), isSlackError(err, "channel_not_found"):
+		return fmt.Errorf("cannot share the file into channel %s, the user or bot must be a member of it: %w", channel, err)
+	case isSlackError(err, "is_archived"):
+		return fmt.Errorf("channel %s is archived, files cannot be shared into it: %w", channel, err)
+	case isMissingScope(err):
+		return fmt.Errorf("files_upload requires the files:write scope, add it to the Slack app and reinstall it: %w", err)
+	}
+	return err
+}
@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// allowLoopbackFetch lets files_upload fetch from httptest servers, which
+// only listen on loopback
+func allowLoopbackFetch(t *testing.T) {
+	prev := fileFetchClient
+	fileFetchClient = newFileFetchClient(func(ip net.IP) bool { return ip.IsLoopback() })
+	t.Cleanup(func() { fileFetchClient = prev })
+}
+
+func TestUnitFilesUploadBase64(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "true")
+
+	var shared []string
+	api := &fakeSlackAPI{upload: func(params slack.UploadFileV2Parameters) (*slack.FileSummary, error) {
+		assert.Equal(t, "report.csv", params.Filename)
+		assert.Equal(t, "Weekly report", params.Title)
+		assert.Equal(t, "Here you go", params.InitialComment)
+		assert.Equal(t, 5, params.FileSize)
+		body, err := io.ReadAll(params.Reader)
+		require.NoError(t, err)
+		assert.Equal(t, "a,b\n1", string(body), "every upload reads the whole content")
+		shared = append(shared, params.Channel)
+		return &slack.FileSummary{ID: "F" + params.Channel, Title: params.Title}, nil
+	}}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	maps := p.ProvideChannelsMaps()
+	maps.Channels["C2"] = provider.Channel{ID: "C2", Name: "#reports"}
+	maps.ChannelsInv["#reports"] = "C2"
+	ch := NewConversationsHandler(p, zap.NewNop())
+
+	res, err := ch.FilesUploadHandler(context.Background(), newToolRequest(map[string]any{
+		"filename":        "report.csv",
+		"content_base64":  base64.StdEncoding.EncodeToString([]byte("a,b\n1")),
+		"channel_ids":     "C1, #reports",
+		"title":           "Weekly report",
+		"initial_comment": "Here you go",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C1", "C2"}, shared)
+
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"FC1", "FC2"}, csvColumn(t, out, "FileID"))
+	assert.Equal(t, []string{"C1", "C2"}, csvColumn(t, out, "ChannelID"))
+}
+
+func TestUnitFilesUploadURL(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "true")
+	allowLoopbackFetch(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(strings.Repeat("x", 64)))
+	}))
+	defer srv.Close()
+
+	api := &fakeSlackAPI{upload: func(params slack.UploadFileV2Parameters) (*slack.FileSummary, error) {
+		assert.Empty(t, params.Channel, "without channels the file is not shared")
+		assert.Equal(t, "chart.png", params.Title, "the title defaults to the filename")
+		assert.Equal(t, 64, params.FileSize)
+		return &slack.FileSummary{ID: "F1", Title: params.Title}, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.FilesUploadHandler(context.Background(), newToolRequest(map[string]any{
+		"filename": "chart.png",
+		"url":      srv.URL + "/chart.png",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"F1"}, csvColumn(t, toolResultText(t, res), "FileID"))
+
+	_, err = ch.FilesUploadHandler(context.Background(), newToolRequest(map[string]any{"filename": "chart.png", "url": srv.URL + "/missing"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+
+	ch.maxFileSize = 10
+	_, err = ch.FilesUploadHandler(context.Background(), newToolRequest(map[string]any{"filename": "chart.png", "url": srv.URL + "/chart.png"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SLACK_MCP_FILE_MAX_BYTES")
+}
+
+func TestUnitFilesUploadURLBlocked(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "true")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("secret"))
+	}))
+	defer srv.Close()
+
+	api := &fakeSlackAPI{upload: func(params slack.UploadFileV2Parameters) (*slack.FileSummary, error) {
+		t.Fatal("content of a blocked address must not be uploaded")
+		return nil, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	// rejected when dialing, before any request is sent
+	for _, target := range []string{
+		srv.URL + "/secret",
+		"http://localhost:1/",
+		"http://127.0.0.1:1/",
+		"http://[::1]:1/",
+		"http://0.0.0.0:1/",
+		"http://10.0.0.1:1/",
+		"http://192.168.1.1:1/",
+		"http://169.254.169.254/latest/meta-data/",
+	} {
+		_, err := ch.FilesUploadHandler(context.Background(), newToolRequest(map[string]any{"filename": "a.txt", "url": target}))
+		require.Error(t, err, target)
+		assert.Contains(t, err.Error(), "public addresses", target)
+	}
+}
+
+func TestUnitFilesUploadURLBlockedRedirect(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "true")
+	allowLoopbackFetch(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	api := &fakeSlackAPI{upload: func(params slack.UploadFileV2Parameters) (*slack.FileSummary, error) {
+		t.Fatal("content of a blocked redirect must not be uploaded")
+		return nil, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	_, err := ch.FilesUploadHandler(context.Background(), newToolRequest(map[string]any{"filename": "a.txt", "url": srv.URL}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "public addresses")
+}
+
+func TestUnitIsPublicIP(t *testing.T) {
+	for _, ip := range []string{"127.0.0.1", "::1", "10.1.2.3", "172.16.0.1", "192.168.0.1", "169.254.169.254", "fe80::1", "fd00::1", "0.0.0.0", "::", "100.64.0.1"} {
+		assert.False(t, isPublicIP(net.ParseIP(ip)), ip)
+	}
+	for _, ip := range []string{"8.8.8.8", "1.1.1.1", "2606:4700:4700::1111"} {
+		assert.True(t, isPublicIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestUnitFilesUploadPartialFailure(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "true")
+
+	api := &fakeSlackAPI{upload: func(params slack.UploadFileV2Parameters) (*slack.FileSummary, error) {
+		if params.Channel == "C2" {
+			return nil, slack.SlackErrorResponse{Err: "not_in_channel"}
+		}
+		return &slack.FileSummary{ID: "F1", Title: params.Title}, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+	req := map[string]any{
+		"filename":       "notes.txt",
+		"content_base64": base64.StdEncoding.EncodeToString([]byte("hi")),
+		"channel_ids":    "C1,C2",
+	}
+
+	res, err := ch.FilesUploadHandler(context.Background(), newToolRequest(req))
+	require.NoError(t, err)
+	notes := csvColumn(t, toolResultText(t, res), "Note")
+	require.Len(t, notes, 2)
+	assert.Empty(t, notes[0])
+	assert.Contains(t, notes[1], "must be a member")
+
+	req["channel_ids"] = "C2"
+	_, err = ch.FilesUploadHandler(context.Background(), newToolRequest(req))
+	require.Error(t, err, "nothing was shared")
+	assert.Contains(t, err.Error(), "must be a member")
+}
+
+func TestUnitFilesUploadValidation(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "C1")
+
+	api := &fakeSlackAPI{upload: func(params slack.UploadFileV2Parameters) (*slack.FileSummary, error) {
+		t.Fatal("invalid uploads must not reach Slack")
+		return nil, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+	content := base64.StdEncoding.EncodeToString([]byte("hi"))
+
+	for name, args := range map[string]map[string]any{
+		"no filename":        {"content_base64": content},
+		"path in filename":   {"filename": "../notes.txt", "content_base64": content},
+		"no content":         {"filename": "notes.txt"},
+		"content and url":    {"filename": "notes.txt", "content_base64": content, "url": "https://example.com/a"},
+		"invalid base64":     {"filename": "notes.txt", "content_base64": "!!"},
+		"empty file":         {"filename": "notes.txt", "content_base64": ""},
+		"not an http url":    {"filename": "notes.txt", "url": "file:///etc/passwd"},
+		"channel not listed": {"filename": "notes.txt", "content_base64": content, "channel_ids": "C2"},
+	} {
+		_, err := ch.FilesUploadHandler(context.Background(), newToolRequest(args))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestUnitFilesUploadDisabledByDefault(t *testing.T) {
+	t.Setenv("SLACK_MCP_ADD_MESSAGE_TOOL", "")
+
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", &fakeSlackAPI{}, zap.NewNop()), zap.NewNop())
+	_, err := ch.FilesUploadHandler(context.Background(), newToolRequest(map[string]any{
+		"filename":       "notes.txt",
+		"content_base64": base64.StdEncoding.EncodeToString([]byte("hi")),
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SLACK_MCP_ADD_MESSAGE_TOOL")
+}
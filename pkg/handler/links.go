@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxLinkDescriptionLen caps the text of a message or file shown with its link
+const maxLinkDescriptionLen = 100
+
+// permalinkLink builds the resource link of a Slack permalink
+func permalinkLink(permalink, name, description string) mcp.ResourceLink {
+	return mcp.NewResourceLink(permalink, name, text.TruncateText(description, maxLinkDescriptionLen), "")
+}
+
+// withResourceLinks appends links after the CSV of a tool result, for clients
+// that render them. The CSV stays the first content, so clients reading only
+// the text get the same output as without links. Links without a URI are skipped.
+func withResourceLinks(res *mcp.CallToolResult, links []mcp.ResourceLink) *mcp.CallToolResult {
+	for _, link := range links {
+		if link.URI == "" {
+			continue
+		}
+		res.Content = append(res.Content, link)
+	}
+	return res
+}
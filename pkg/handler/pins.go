@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type PinnedItem struct {
+	Type      string `json:"type"`
+	MsgID     string `json:"msgID"`
+	UserID    string `json:"userID"`
+	UserName  string `json:"userName"`
+	Text      string `json:"text"`
+	Time      string `json:"time"`
+	FileID    string `json:"fileID"`
+	FileName  string `json:"fileName"`
+	Permalink string `json:"permalink"`
+}
+
+// PinsListHandler returns the pinned messages and files of a channel as CSV
+func (ch *ConversationsHandler) PinsListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("PinsListHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	// Get Slack client (OAuth or legacy)
+	var slackClient *slack.Client
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		slackClient = client
+	}
+
+	channel, err := ch.resolveChannelID(request.GetString("channel_id", ""))
+	if err != nil {
+		ch.logger.Error("Failed to resolve channel for pins", zap.Error(err))
+		return nil, err
+	}
+	loc, err := ch.messageLocation(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []slack.Item
+	if ch.oauthEnabled {
+		items, _, err = slackClient.ListPinsContext(ctx, channel)
+	} else {
+		items, _, err = ch.apiProvider.Slack().ListPinsContext(ctx, channel)
+	}
+	if err != nil {
+		ch.logger.Error("Slack ListPinsContext failed", zap.Error(err))
+		if isMissingScope(err) {
+			return nil, fmt.Errorf("pins_list requires the pins:read scope, add it to the Slack app and reinstall it: %w", err)
+		}
+		return nil, err
+	}
+	ch.logger.Debug("Fetched pins", zap.Int("count", len(items)))
+
+	pins := make([]PinnedItem, 0, len(items))
+	for _, item := range items {
+		switch {
+		case item.Message != nil:
+			// render like any other message: resolved user, processed text
+			msgs := ch.convertMessagesFromHistory([]slack.Message{*item.Message}, channel, true)
+			localizeMessageTimes(msgs, loc)
+			if len(msgs) == 0 {
+				continue
+			}
+			msg := msgs[0]
+			pins = append(pins, PinnedItem{
+				Type:      slack.TYPE_MESSAGE,
+				MsgID:     msg.MsgID,
+				UserID:    msg.UserID,
+				UserName:  msg.UserName,
+				Text:      msg.Text,
+				Time:      msg.Time,
+				Permalink: item.Message.Permalink,
+			})
+		case item.File != nil:
+			pins = append(pins, PinnedItem{
+				Type:      slack.TYPE_FILE,
+				UserID:    item.File.User,
+				Text:      item.File.Title,
+				Time:      item.File.Created.Time().In(loc).Format(time.RFC3339),
+				FileID:    item.File.ID,
+				FileName:  item.File.Name,
+				Permalink: item.File.Permalink,
+			})
+		default:
+			ch.logger.Debug("Skipping unsupported pinned item", zap.String("type", item.Type))
+		}
+	}
+
+	csvBytes, err := gocsv.MarshalBytes(&pins)
+	if err != nil {
+		ch.logger.Error("Failed to marshal pins to CSV", zap.Error(err))
+		return nil, err
+	}
+	res := mcp.NewToolResultText(string(csvBytes))
+	if request.GetBool("include_links", false) {
+		links := make([]mcp.ResourceLink, 0, len(pins))
+		for _, pin := range pins {
+			name := "Message " + pin.MsgID
+			if pin.Type == slack.TYPE_FILE {
+				name = pin.FileName
+			}
+			links = append(links, permalinkLink(pin.Permalink, name, pin.Text))
+		}
+		res = withResourceLinks(res, links)
+	}
+	return res, nil
+}
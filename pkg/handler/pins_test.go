@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitPinsList(t *testing.T) {
+	api := &fakeSlackAPI{pins: func(channel string) ([]slack.Item, error) {
+		assert.Equal(t, "C1234567890", channel)
+		msg := slack.Message{Msg: slack.Msg{
+			Timestamp: "1700000000.000100",
+			User:      "U1",
+			Text:      "Release checklist",
+			Permalink: "https://acme.slack.com/archives/C1234567890/p1700000000000100",
+		}}
+		file := &slack.File{
+			ID:        "F1",
+			Name:      "roadmap.pdf",
+			Title:     "Roadmap",
+			User:      "U2",
+			Created:   slack.JSONTime(1700000000),
+			Permalink: "https://acme.slack.com/files/U2/F1/roadmap.pdf",
+		}
+		return []slack.Item{
+			slack.NewMessageItem(channel, &msg),
+			slack.NewFileItem(file),
+		}, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.PinsListHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1234567890"}))
+	require.NoError(t, err)
+
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"message", "file"}, csvColumn(t, out, "Type"))
+	assert.Equal(t, []string{"1700000000.000100", ""}, csvColumn(t, out, "MsgID"))
+	assert.Equal(t, []string{"Release checklist", "Roadmap"}, csvColumn(t, out, "Text"))
+	assert.Equal(t, []string{"", "F1"}, csvColumn(t, out, "FileID"))
+	assert.Equal(t, []string{"2023-11-14T22:13:20Z", "2023-11-14T22:13:20Z"}, csvColumn(t, out, "Time"))
+	assert.Equal(t, []string{
+		"https://acme.slack.com/archives/C1234567890/p1700000000000100",
+		"https://acme.slack.com/files/U2/F1/roadmap.pdf",
+	}, csvColumn(t, out, "Permalink"))
+}
+
+func TestUnitPinsListIncludeLinks(t *testing.T) {
+	api := &fakeSlackAPI{pins: func(channel string) ([]slack.Item, error) {
+		linked := slack.Message{Msg: slack.Msg{
+			Timestamp: "1700000000.000100",
+			Text:      "Release checklist",
+			Permalink: "https://acme.slack.com/archives/C1234567890/p1700000000000100",
+		}}
+		unlinked := slack.Message{Msg: slack.Msg{Timestamp: "1700000000.000200", Text: "No permalink"}}
+		return []slack.Item{
+			slack.NewMessageItem(channel, &linked),
+			slack.NewMessageItem(channel, &unlinked),
+			slack.NewFileItem(&slack.File{ID: "F1", Name: "roadmap.pdf", Permalink: "https://acme.slack.com/files/U2/F1/roadmap.pdf"}),
+		}, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.PinsListHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1234567890"}))
+	require.NoError(t, err)
+	require.Len(t, res.Content, 1, "links must only be added on request")
+
+	res, err = ch.PinsListHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id":    "C1234567890",
+		"include_links": true,
+	}))
+	require.NoError(t, err)
+	assert.Len(t, csvColumn(t, toolResultText(t, res), "MsgID"), 3, "the CSV must stay the first content")
+
+	require.Len(t, res.Content, 3)
+	msgLink, ok := res.Content[1].(mcp.ResourceLink)
+	require.True(t, ok)
+	assert.Equal(t, "https://acme.slack.com/archives/C1234567890/p1700000000000100", msgLink.URI)
+	assert.Equal(t, "Message 1700000000.000100", msgLink.Name)
+	assert.Equal(t, "Release checklist", msgLink.Description)
+
+	fileLink, ok := res.Content[2].(mcp.ResourceLink)
+	require.True(t, ok)
+	assert.Equal(t, "https://acme.slack.com/files/U2/F1/roadmap.pdf", fileLink.URI)
+	assert.Equal(t, "roadmap.pdf", fileLink.Name)
+}
+
+func TestUnitPinsListEmpty(t *testing.T) {
+	api := &fakeSlackAPI{pins: func(channel string) ([]slack.Item, error) {
+		return nil, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.PinsListHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1234567890"}))
+	require.NoError(t, err)
+	assert.Empty(t, csvColumn(t, toolResultText(t, res), "Type"))
+}
+
+func TestUnitPinsListMissingScope(t *testing.T) {
+	api := &fakeSlackAPI{pins: func(channel string) ([]slack.Item, error) {
+		return nil, slack.SlackErrorResponse{Err: "missing_scope"}
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	_, err := ch.PinsListHandler(context.Background(), newToolRequest(map[string]any{"channel_id": "C1234567890"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pins:read")
+}
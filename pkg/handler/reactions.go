@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type Reaction struct {
+	Name      string `json:"name"`
+	Count     int    `json:"count"`
+	UserIDs   string `json:"userIDs"`
+	UserNames string `json:"userNames,omitempty"`
+}
+
+// ReactionsGetHandler returns reactions on a single message as CSV
+func (ch *ConversationsHandler) ReactionsGetHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ReactionsGetHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	// Get Slack client (OAuth or legacy)
+	var slackClient *slack.Client
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		slackClient = client
+	}
+
+	channel, err := ch.resolveChannelID(request.GetString("channel_id", ""))
+	if err != nil {
+		ch.logger.Error("Failed to resolve channel for reactions", zap.Error(err))
+		return nil, err
+	}
+
+	timestamp := request.GetString("timestamp", "")
+	if timestamp == "" || !strings.Contains(timestamp, ".") {
+		ch.logger.Error("Invalid timestamp format", zap.String("timestamp", timestamp))
+		return nil, errors.New("timestamp must be a valid timestamp in format 1234567890.123456")
+	}
+	resolveUsers := request.GetBool("resolve_users", false)
+
+	var name string
+	if raw := request.GetString("name", ""); raw != "" {
+		if name, err = text.NormalizeEmojiName(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	item := slack.NewRefToMessage(channel, timestamp)
+	// Full is required, otherwise Slack truncates the users list of popular reactions
+	params := slack.GetReactionsParameters{Full: true}
+
+	var reactions []slack.ItemReaction
+	if ch.oauthEnabled {
+		reactions, err = slackClient.GetReactionsContext(ctx, item, params)
+	} else {
+		reactions, err = ch.apiProvider.Slack().GetReactionsContext(ctx, item, params)
+	}
+	if err != nil {
+		ch.logger.Error("Slack GetReactionsContext failed", zap.Error(err))
+		if isMissingScope(err) {
+			return nil, fmt.Errorf("reactions_get requires the reactions:read scope, add it to the Slack app and reinstall it: %w", err)
+		}
+		return nil, err
+	}
+	ch.logger.Debug("Fetched reactions", zap.Int("count", len(reactions)))
+
+	if name != "" {
+		reactions = filterReactions(reactions, name)
+	}
+
+	var names map[string]string
+	if resolveUsers {
+		names = ch.resolveUserNames(ctx, slackClient, reactions)
+	}
+
+	reactionList := make([]Reaction, 0, len(reactions))
+	for _, r := range reactions {
+		reaction := Reaction{
+			Name:    r.Name,
+			Count:   r.Count,
+			UserIDs: strings.Join(r.Users, ","),
+		}
+		if resolveUsers {
+			userNames := make([]string, 0, len(r.Users))
+			for _, id := range r.Users {
+				userNames = append(userNames, names[id])
+			}
+			reaction.UserNames = strings.Join(userNames, ",")
+		}
+		reactionList = append(reactionList, reaction)
+	}
+
+	csvBytes, err := gocsv.MarshalBytes(&reactionList)
+	if err != nil {
+		ch.logger.Error("Failed to marshal reactions to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// ReactionUpdate is the result of reactions_add and reactions_remove
+type ReactionUpdate struct {
+	ChannelID string `json:"channelID"`
+	Timestamp string `json:"timestamp"`
+	Name      string `json:"name"`
+	Reacted   bool   `json:"reacted"` // whether the reaction is on the message afterwards
+}
+
+// reactionsWriteAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type reactionsWriteAPI interface {
+	AddReactionContext(ctx context.Context, name string, item slack.ItemRef) error
+	RemoveReactionContext(ctx context.Context, name string, item slack.ItemRef) error
+}
+
+// ReactionsAddHandler adds a reaction to a message as the authenticated user
+func (ch *ConversationsHandler) ReactionsAddHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ReactionsAddHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+	return ch.setReaction(ctx, request, true)
+}
+
+// ReactionsRemoveHandler removes a reaction of the authenticated user from a message
+func (ch *ConversationsHandler) ReactionsRemoveHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("ReactionsRemoveHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+	return ch.setReaction(ctx, request, false)
+}
+
+func (ch *ConversationsHandler) setReaction(ctx context.Context, request mcp.CallToolRequest, add bool) (*mcp.CallToolResult, error) {
+	channel, err := ch.resolveChannelID(request.GetString("channel_id", ""))
+	if err != nil {
+		ch.logger.Error("Failed to resolve channel for reaction", zap.Error(err))
+		return nil, err
+	}
+
+	timestamp := request.GetString("timestamp", "")
+	if timestamp == "" || !strings.Contains(timestamp, ".") {
+		ch.logger.Error("Invalid timestamp format", zap.String("timestamp", timestamp))
+		return nil, errors.New("timestamp must be a valid timestamp in format 1234567890.123456")
+	}
+	name, err := text.NormalizeEmojiName(request.GetString("name", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	var api reactionsWriteAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	item := slack.NewRefToMessage(channel, timestamp)
+	if add {
+		err = api.AddReactionContext(ctx, name, item)
+	} else {
+		err = api.RemoveReactionContext(ctx, name, item)
+	}
+	switch {
+	case err == nil:
+	case isSlackError(err, "already_reacted"):
+		return nil, fmt.Errorf("message %s already has your :%s: reaction: %w", timestamp, name, err)
+	case isSlackError(err, "no_reaction"):
+		return nil, fmt.Errorf("message %s has no :%s: reaction of yours to remove: %w", timestamp, name, err)
+	case isSlackError(err, "invalid_name"):
+		return nil, fmt.Errorf("emoji :%s: does not exist in this workspace: %w", name, err)
+	case isSlackError(err, "message_not_found"):
+		return nil, fmt.Errorf("message %s not found in channel %s: %w", timestamp, channel, err)
+	case isMissingScope(err):
+		return nil, fmt.Errorf("changing reactions requires the reactions:write scope, add it to the Slack app and reinstall it: %w", err)
+	default:
+		if add {
+			ch.logger.Error("Slack AddReactionContext failed", zap.Error(err))
+		} else {
+			ch.logger.Error("Slack RemoveReactionContext failed", zap.Error(err))
+		}
+		return nil, err
+	}
+
+	rows := []ReactionUpdate{{
+		ChannelID: channel,
+		Timestamp: timestamp,
+		Name:      name,
+		Reacted:   add,
+	}}
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal reaction to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// filterReactions keeps the reactions with the given emoji name. A name without
+// a skin tone also matches its skin tone variants.
+func filterReactions(reactions []slack.ItemReaction, name string) []slack.ItemReaction {
+	var filtered []slack.ItemReaction
+	for _, r := range reactions {
+		if r.Name == name || (!strings.Contains(name, "::") && strings.HasPrefix(r.Name, name+"::")) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// resolveUserNames maps reacting user IDs to user names, falling back to the ID
+// when a user is unknown. Legacy mode uses the users cache, OAuth mode asks Slack.
+func (ch *ConversationsHandler) resolveUserNames(ctx context.Context, slackClient *slack.Client, reactions []slack.ItemReaction) map[string]string {
+	names := make(map[string]string)
+	var ids []string
+	for _, r := range reactions {
+		for _, id := range r.Users {
+			if _, ok := names[id]; !ok {
+				names[id] = id
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	if !ch.oauthEnabled {
+		users := ch.apiProvider.ProvideUsersMap().Users
+		for _, id := range ids {
+			if u, ok := users[id]; ok {
+				names[id] = u.Name
+			}
+		}
+		return names
+	}
+
+	if len(ids) == 0 {
+		return names
+	}
+	users, err := slackClient.GetUsersInfoContext(ctx, ids...)
+	if err != nil {
+		ch.logger.Warn("Failed to resolve reacting users, returning IDs", zap.Error(err))
+		return names
+	}
+	for _, u := range *users {
+		names[u.ID] = u.Name
+	}
+	return names
+}
+
+// isMissingScope reports whether Slack rejected a call because the token lacks a scope
+func isMissingScope(err error) bool {
+	return isSlackError(err, "missing_scope")
+}
+
+// isSlackError reports whether Slack rejected a call with the given error code
+func isSlackError(err error, code string) bool {
+	var slackErr slack.SlackErrorResponse
+	if errors.As(err, &slackErr) {
+		return slackErr.Err == code
+	}
+	return err.Error() == code
+}
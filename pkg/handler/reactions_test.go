@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitReactionsGet(t *testing.T) {
+	api := &fakeSlackAPI{reactions: func(item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error) {
+		assert.Equal(t, "C1234567890", item.Channel)
+		assert.Equal(t, "1700000000.000100", item.Timestamp)
+		assert.True(t, params.Full, "full users list must be requested")
+		return []slack.ItemReaction{
+			{Name: "thumbsup", Count: 2, Users: []string{"U1", "U2"}},
+			{Name: "tada", Count: 1, Users: []string{"U3"}},
+		}, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.ReactionsGetHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"timestamp":  "1700000000.000100",
+	}))
+	require.NoError(t, err)
+
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"thumbsup", "tada"}, csvColumn(t, out, "Name"))
+	assert.Equal(t, []string{"2", "1"}, csvColumn(t, out, "Count"))
+	assert.Equal(t, []string{"U1,U2", "U3"}, csvColumn(t, out, "UserIDs"))
+}
+
+func TestUnitReactionsGetNoReactions(t *testing.T) {
+	api := &fakeSlackAPI{reactions: func(item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error) {
+		return nil, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.ReactionsGetHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"timestamp":  "1700000000.000100",
+	}))
+	require.NoError(t, err)
+	assert.Empty(t, csvColumn(t, toolResultText(t, res), "Name"))
+}
+
+func TestUnitReactionsGetMissingScope(t *testing.T) {
+	api := &fakeSlackAPI{reactions: func(item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error) {
+		return nil, slack.SlackErrorResponse{Err: "missing_scope"}
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	_, err := ch.ReactionsGetHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"timestamp":  "1700000000.000100",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reactions:read")
+}
+
+func TestUnitReactionsGetByName(t *testing.T) {
+	api := &fakeSlackAPI{reactions: func(item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error) {
+		return []slack.ItemReaction{
+			{Name: "+1", Count: 2, Users: []string{"U1", "U2"}},
+			{Name: "+1::skin-tone-4", Count: 1, Users: []string{"U4"}},
+			{Name: "tada", Count: 1, Users: []string{"U3"}},
+		}, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	for emoji, want := range map[string][]string{
+		":thumbsup:": {"+1", "+1::skin-tone-4"},
+		"👍🏽":         {"+1::skin-tone-4"},
+		"🎉":          {"tada"},
+		"eyes":       nil,
+	} {
+		res, err := ch.ReactionsGetHandler(context.Background(), newToolRequest(map[string]any{
+			"channel_id": "C1234567890",
+			"timestamp":  "1700000000.000100",
+			"name":       emoji,
+		}))
+		require.NoError(t, err, emoji)
+		assert.Equal(t, want, csvColumn(t, toolResultText(t, res), "Name"), emoji)
+	}
+
+	_, err := ch.ReactionsGetHandler(context.Background(), newToolRequest(map[string]any{
+		"channel_id": "C1234567890",
+		"timestamp":  "1700000000.000100",
+		"name":       "🦩",
+	}))
+	assert.ErrorContains(t, err, "unknown emoji")
+}
+
+func TestUnitReactionsAddRemove(t *testing.T) {
+	reacted := map[string]bool{}
+	api := &fakeSlackAPI{react: func(name string, item slack.ItemRef, add bool) error {
+		key := item.Channel + "/" + item.Timestamp + "/" + name
+		if add && reacted[key] {
+			return slack.SlackErrorResponse{Err: "already_reacted"}
+		}
+		if !add && !reacted[key] {
+			return slack.SlackErrorResponse{Err: "no_reaction"}
+		}
+		reacted[key] = add
+		return nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+	args := func(name string) map[string]any {
+		return map[string]any{"channel_id": "C1234567890", "timestamp": "1700000000.000100", "name": name}
+	}
+
+	res, err := ch.ReactionsAddHandler(context.Background(), newToolRequest(args(":Tada:")))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"tada"}, csvColumn(t, out, "Name"), "colons are stripped")
+	assert.Equal(t, []string{"true"}, csvColumn(t, out, "Reacted"))
+	assert.True(t, reacted["C1234567890/1700000000.000100/tada"])
+
+	_, err = ch.ReactionsAddHandler(context.Background(), newToolRequest(args("tada")))
+	assert.ErrorContains(t, err, "already has your :tada: reaction")
+
+	res, err = ch.ReactionsRemoveHandler(context.Background(), newToolRequest(args("🎉")))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"false"}, csvColumn(t, toolResultText(t, res), "Reacted"))
+
+	_, err = ch.ReactionsRemoveHandler(context.Background(), newToolRequest(args("tada")))
+	assert.ErrorContains(t, err, "no :tada: reaction")
+
+	_, err = ch.ReactionsAddHandler(context.Background(), newToolRequest(args("")))
+	assert.Error(t, err)
+}
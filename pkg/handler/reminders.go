@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type Reminder struct {
+	ID        string `json:"id"`
+	Text      string `json:"text"`
+	Time      string `json:"time"` // empty for recurring reminders, which Slack returns without a time
+	Recurring bool   `json:"recurring"`
+	Completed bool   `json:"completed"`
+	Creator   string `json:"creator"`
+	User      string `json:"user"`
+}
+
+// remindersAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type remindersAPI interface {
+	ListRemindersContext(ctx context.Context) ([]*slack.Reminder, error)
+	AddUserReminderContext(ctx context.Context, userID, text, time string) (*slack.Reminder, error)
+}
+
+// RemindersListHandler lists the reminders created by or for the authenticated user
+func (ch *ConversationsHandler) RemindersListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("RemindersListHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	api, _, err := ch.remindersAPI(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := ch.messageLocation(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders, err := api.ListRemindersContext(ctx)
+	if err != nil {
+		if res := remindersUnavailable(err); res != nil {
+			return res, nil
+		}
+		ch.logger.Error("Slack ListRemindersContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	rows := make([]Reminder, 0, len(reminders))
+	for _, r := range reminders {
+		if r != nil {
+			rows = append(rows, toReminder(r, loc))
+		}
+	}
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal reminders to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// RemindersAddHandler creates a reminder for the authenticated user
+func (ch *ConversationsHandler) RemindersAddHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("RemindersAddHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	reminderText := strings.TrimSpace(request.GetString("text", ""))
+	if reminderText == "" {
+		return nil, errors.New("text must be a string")
+	}
+	at, err := parseReminderTime(request.GetString("time", ""), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	api, userID, err := ch.remindersAPI(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := ch.messageLocation(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder, err := api.AddUserReminderContext(ctx, userID, reminderText, strconv.FormatInt(at.Unix(), 10))
+	if err != nil {
+		if res := remindersUnavailable(err); res != nil {
+			return res, nil
+		}
+		ch.logger.Error("Slack AddUserReminderContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	rows := []Reminder{toReminder(reminder, loc)}
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal reminder to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// remindersAPI returns the client of the user token, reminders belong to the
+// user and are never managed with the bot token. withUser also returns the ID
+// of the authenticated user, who receives created reminders.
+func (ch *ConversationsHandler) remindersAPI(ctx context.Context, withUser bool) (remindersAPI, string, error) {
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+		var userID string
+		if userCtx, ok := auth.FromContext(ctx); ok {
+			userID = userCtx.UserID
+		}
+		return client, userID, nil
+	}
+
+	var userID string
+	if withUser {
+		id, err := ch.apiProvider.Identity(ctx)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to resolve the authenticated user: %w", err)
+		}
+		userID = id.UserID
+	}
+	return ch.apiProvider.Slack(), userID, nil
+}
+
+// remindersUnavailable returns a message instead of an error when Slack does not
+// offer reminders to the token, e.g. a bot token, a missing reminders:read or
+// reminders:write scope or a workspace where the API was retired
+func remindersUnavailable(err error) *mcp.CallToolResult {
+	for code := range unavailableMethodErrors {
+		if isSlackError(err, code) {
+			return mcp.NewToolResultError(fmt.Sprintf("reminders are not available with this token (%s). "+
+				"They require a user token with the reminders:read and reminders:write scopes, "+
+				"and Slack may have retired the reminders API for this workspace", code))
+		}
+	}
+	return nil
+}
+
+// parseReminderTime parses an RFC3339 time or a time relative to now, given
+// as a duration such as 90m or 2h, or as days or weeks such as 3d or 1w,
+// optionally prefixed with "in ". The time must be in the future.
+func parseReminderTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, errors.New("time must be an RFC3339 time or a relative time such as 30m, 2h or 3d")
+	}
+
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		relative := strings.TrimSpace(strings.TrimPrefix(strings.ToLower(value), "in "))
+		d, durErr := parseRelativeDuration(relative)
+		if durErr != nil {
+			return time.Time{}, fmt.Errorf("invalid time %q: must be an RFC3339 time such as 2025-01-02T15:04:05Z or a relative time such as 30m, 2h or 3d", value)
+		}
+		at = now.Add(d)
+	}
+	if !at.After(now) {
+		return time.Time{}, fmt.Errorf("time %q must be in the future", value)
+	}
+	return at, nil
+}
+
+// parseRelativeDuration parses a Go duration or a number of days or weeks
+func parseRelativeDuration(expr string) (time.Duration, error) {
+	if d, err := time.ParseDuration(expr); err == nil {
+		return d, nil
+	}
+	if len(expr) < 2 {
+		return 0, fmt.Errorf("invalid duration %q", expr)
+	}
+	n, err := strconv.Atoi(expr[:len(expr)-1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", expr)
+	}
+	switch expr[len(expr)-1] {
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, nil
+	case 'w':
+		return time.Duration(n) * 7 * 24 * time.Hour, nil
+	default:
+		return 0, fmt.Errorf("invalid duration %q", expr)
+	}
+}
+
+// toReminder converts a reminder, showing its time in loc
+func toReminder(r *slack.Reminder, loc *time.Location) Reminder {
+	row := Reminder{
+		ID:        r.ID,
+		Text:      r.Text,
+		Recurring: r.Recurring,
+		Completed: r.CompleteTS != 0,
+		Creator:   r.Creator,
+		User:      r.User,
+	}
+	if r.Time != 0 {
+		row.Time = time.Unix(int64(r.Time), 0).In(loc).Format(time.RFC3339)
+	}
+	return row
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitParseReminderTime(t *testing.T) {
+	now := time.Date(2025, 1, 2, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{value: "2025-01-03T09:00:00Z", want: time.Date(2025, 1, 3, 9, 0, 0, 0, time.UTC)},
+		{value: "30m", want: now.Add(30 * time.Minute)},
+		{value: "in 2h", want: now.Add(2 * time.Hour)},
+		{value: "3d", want: now.Add(72 * time.Hour)},
+		{value: "1w", want: now.Add(7 * 24 * time.Hour)},
+	}
+	for _, tt := range tests {
+		got, err := parseReminderTime(tt.value, now)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+
+	for _, value := range []string{"", "tomorrow", "-1h", "0s", "2025-01-01T09:00:00Z", "2025-01-02T15:00:00Z"} {
+		_, err := parseReminderTime(value, now)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestUnitRemindersAdd(t *testing.T) {
+	var gotUser, gotText, gotTime string
+	api := &fakeSlackAPI{
+		authTest: func() (*slack.AuthTestResponse, error) {
+			return &slack.AuthTestResponse{URL: "https://acme.slack.com/", UserID: "U1"}, nil
+		},
+		remind: func(userID, text, at string) (*slack.Reminder, error) {
+			gotUser, gotText, gotTime = userID, text, at
+			ts, err := strconv.Atoi(at)
+			require.NoError(t, err)
+			return &slack.Reminder{ID: "Rm1", Creator: userID, User: userID, Text: text, Time: ts}, nil
+		},
+	}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	before := time.Now()
+	res, err := ch.RemindersAddHandler(context.Background(), newToolRequest(map[string]any{"text": "Reply to the launch thread", "time": "2h"}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"Rm1"}, csvColumn(t, out, "ID"))
+	assert.Equal(t, "U1", gotUser, "the reminder is for the authenticated user")
+	assert.Equal(t, "Reply to the launch thread", gotText)
+	ts, err := strconv.ParseInt(gotTime, 10, 64)
+	require.NoError(t, err)
+	assert.WithinDuration(t, before.Add(2*time.Hour), time.Unix(ts, 0), 2*time.Second)
+
+	_, err = ch.RemindersAddHandler(context.Background(), newToolRequest(map[string]any{"text": "too late", "time": "2001-01-01T00:00:00Z"}))
+	assert.ErrorContains(t, err, "must be in the future")
+}
+
+func TestUnitRemindersList(t *testing.T) {
+	api := &fakeSlackAPI{reminders: func() ([]*slack.Reminder, error) {
+		return []*slack.Reminder{
+			{ID: "Rm1", Text: "standup", Time: 1735830000},
+			{ID: "Rm2", Text: "weekly review", Recurring: true},
+			{ID: "Rm3", Text: "done", Time: 1735830000, CompleteTS: 1735830100},
+		}, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.RemindersListHandler(context.Background(), newToolRequest(map[string]any{}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"Rm1", "Rm2", "Rm3"}, csvColumn(t, out, "ID"))
+	assert.Equal(t, []string{"2025-01-02T15:00:00Z", "", "2025-01-02T15:00:00Z"}, csvColumn(t, out, "Time"))
+	assert.Equal(t, []string{"false", "false", "true"}, csvColumn(t, out, "Completed"))
+
+	// a token without reminders gets a message, not a failure
+	api.reminders = func() ([]*slack.Reminder, error) {
+		return nil, slack.SlackErrorResponse{Err: "not_allowed_token_type"}
+	}
+	res, err = ch.RemindersListHandler(context.Background(), newToolRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.True(t, res.IsError)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "not_allowed_token_type")
+}
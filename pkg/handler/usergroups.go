@@ -0,0 +1,337 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// userGroupsPlanErrors are the Slack errors of workspaces whose plan has no usergroups
+var userGroupsPlanErrors = map[string]bool{
+	"paid_teams_only":       true,
+	"plan_upgrade_required": true,
+}
+
+type UserGroup struct {
+	ID          string `json:"id"`
+	Handle      string `json:"handle"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	UserCount   int    `json:"userCount"`
+	Members     string `json:"members"` // comma-separated user IDs, or @names with resolve_users
+}
+
+// userGroupsAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type userGroupsAPI interface {
+	provider.UserGroupsLister
+	GetUserGroupMembersContext(ctx context.Context, userGroup string, options ...slack.GetUserGroupMembersOption) ([]string, error)
+	UpdateUserGroupMembersContext(ctx context.Context, userGroup string, members string, options ...slack.UpdateUserGroupMembersOption) (slack.UserGroup, error)
+}
+
+// UserGroupsListHandler lists the usergroups of the workspace with their members
+func (ch *ConversationsHandler) UserGroupsListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("UserGroupsListHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	api, teamID, err := ch.userGroupsAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := ch.userGroups.Load(ctx, api, teamID)
+	if err != nil {
+		if res := userGroupsUnavailable(err); res != nil {
+			return res, nil
+		}
+		ch.logger.Error("Slack GetUserGroupsContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	resolve := request.GetBool("resolve_users", false)
+	usersMap := ch.userGroupMembersMap(groups, resolve)
+
+	rows := make([]UserGroup, 0, len(groups))
+	for _, g := range groups {
+		members := make([]string, 0, len(g.Users))
+		for _, id := range g.Users {
+			if u, ok := usersMap[id]; ok && resolve {
+				members = append(members, "@"+u.Name)
+			} else {
+				members = append(members, id)
+			}
+		}
+		rows = append(rows, UserGroup{
+			ID:          g.ID,
+			Handle:      "@" + g.Handle,
+			Name:        g.Name,
+			Description: g.Description,
+			UserCount:   g.UserCount,
+			Members:     strings.Join(members, ","),
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Handle < rows[j].Handle
+	})
+
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal usergroups to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// UserGroupsUsersListHandler lists the members of a usergroup given by ID or @handle
+func (ch *ConversationsHandler) UserGroupsUsersListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("UserGroupsUsersListHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	usergroup := strings.TrimSpace(request.GetString("usergroup", ""))
+	if usergroup == "" {
+		return nil, errors.New("usergroup must be a string")
+	}
+
+	api, teamID, err := ch.userGroupsAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usergroup, res, err := ch.userGroupID(ctx, api, teamID, usergroup)
+	if res != nil || err != nil {
+		return res, err
+	}
+
+	members, err := api.GetUserGroupMembersContext(ctx, usergroup)
+	if err != nil {
+		if res := userGroupsUnavailable(err); res != nil {
+			return res, nil
+		}
+		if isSlackError(err, "no_such_subteam") {
+			return nil, fmt.Errorf("usergroup %s not found", usergroup)
+		}
+		ch.logger.Error("Slack GetUserGroupMembersContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	usersMap := map[string]slack.User{}
+	if !ch.oauthEnabled {
+		ch.apiProvider.ResolveUsers(members)
+		usersMap = ch.apiProvider.ProvideUsersMap().Users
+	}
+	users := make([]User, 0, len(members))
+	for _, id := range members {
+		userName, realName, _ := getUserInfo(id, usersMap)
+		users = append(users, User{UserID: id, UserName: userName, RealName: realName})
+	}
+
+	csvBytes, err := gocsv.MarshalBytes(&users)
+	if err != nil {
+		ch.logger.Error("Failed to marshal usergroup members to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// UserGroupsUsersUpdateHandler sets, adds or removes the members of a
+// usergroup with usergroups.users.update, e.g. to hand over an on-call rotation
+func (ch *ConversationsHandler) UserGroupsUsersUpdateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("UserGroupsUsersUpdateHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	if !envEnabled("SLACK_MCP_USERGROUPS_WRITE_TOOL") {
+		return nil, errors.New("by default, the usergroups_users_update tool is disabled to guard Slack workspaces against accidental changes. " +
+			"To enable it, set the SLACK_MCP_USERGROUPS_WRITE_TOOL environment variable to true")
+	}
+
+	usergroup := strings.TrimSpace(request.GetString("usergroup", ""))
+	if usergroup == "" {
+		return nil, errors.New("usergroup must be a string")
+	}
+	mode := request.GetString("mode", "set")
+	if mode != "set" && mode != "add" && mode != "remove" {
+		return nil, fmt.Errorf("mode must be set, add or remove, got %q", mode)
+	}
+	userIDs, err := ch.parseUserIDs(request.GetString("user_ids", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	api, teamID, err := ch.userGroupsAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	usergroup, res, err := ch.userGroupID(ctx, api, teamID, usergroup)
+	if res != nil || err != nil {
+		return res, err
+	}
+
+	members := userIDs
+	if mode != "set" {
+		current, err := api.GetUserGroupMembersContext(ctx, usergroup)
+		if err != nil {
+			if res := userGroupsUnavailable(err); res != nil {
+				return res, nil
+			}
+			if isSlackError(err, "no_such_subteam") {
+				return nil, fmt.Errorf("usergroup %s not found", usergroup)
+			}
+			ch.logger.Error("Slack GetUserGroupMembersContext failed", zap.Error(err))
+			return nil, err
+		}
+		members = updatedMembers(current, userIDs, mode == "add")
+	}
+	if len(members) == 0 {
+		return nil, errors.New("a usergroup must keep at least one member, Slack does not allow removing all of them")
+	}
+
+	group, err := api.UpdateUserGroupMembersContext(ctx, usergroup, strings.Join(members, ","))
+	if err != nil {
+		switch {
+		case isMissingScope(err):
+			return nil, fmt.Errorf("updating usergroups requires the usergroups:write scope, add it to the Slack app and reinstall it: %w", err)
+		case isSlackError(err, "permission_denied"):
+			return nil, fmt.Errorf("the token is not allowed to update usergroup %s, the workspace may restrict usergroup changes to admins: %w", usergroup, err)
+		case isSlackError(err, "no_such_subteam"):
+			return nil, fmt.Errorf("usergroup %s not found", usergroup)
+		}
+		if res := userGroupsUnavailable(err); res != nil {
+			return res, nil
+		}
+		ch.logger.Error("Slack UpdateUserGroupMembersContext failed", zap.Error(err))
+		return nil, err
+	}
+	ch.userGroups.SetGroup(teamID, group)
+
+	rows := []UserGroup{{
+		ID:          group.ID,
+		Handle:      "@" + group.Handle,
+		Name:        group.Name,
+		Description: group.Description,
+		UserCount:   group.UserCount,
+		Members:     strings.Join(group.Users, ","),
+	}}
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal usergroup to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// updatedMembers adds the users to or removes them from the current members,
+// keeping the order of the current members
+func updatedMembers(current, users []string, add bool) []string {
+	given := make(map[string]bool, len(users))
+	for _, id := range users {
+		given[id] = true
+	}
+	members := make([]string, 0, len(current)+len(users))
+	for _, id := range current {
+		if add || !given[id] {
+			members = append(members, id)
+		}
+		delete(given, id)
+	}
+	if add {
+		for _, id := range users {
+			if given[id] {
+				members = append(members, id)
+			}
+		}
+	}
+	return members
+}
+
+// userGroupID resolves a usergroup @handle to its ID. Unavailable usergroups
+// are reported with a result instead of an error, as by the listing tools.
+func (ch *ConversationsHandler) userGroupID(ctx context.Context, api userGroupsAPI, teamID, usergroup string) (string, *mcp.CallToolResult, error) {
+	if !strings.HasPrefix(usergroup, "@") {
+		return usergroup, nil, nil
+	}
+	groups, err := ch.userGroups.Load(ctx, api, teamID)
+	if err != nil {
+		if res := userGroupsUnavailable(err); res != nil {
+			return "", res, nil
+		}
+		ch.logger.Error("Slack GetUserGroupsContext failed", zap.Error(err))
+		return "", nil, err
+	}
+	id, ok := findUserGroup(groups, usergroup)
+	if !ok {
+		return "", nil, fmt.Errorf("usergroup %q not found", usergroup)
+	}
+	return id, nil, nil
+}
+
+// userGroupsAPI returns the client of the request and the team its usergroups are cached for
+func (ch *ConversationsHandler) userGroupsAPI(ctx context.Context) (userGroupsAPI, string, error) {
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+		userCtx, ok := auth.FromContext(ctx)
+		if !ok {
+			return nil, "", fmt.Errorf("user context not found")
+		}
+		return client, userCtx.TeamID, nil
+	}
+	return ch.apiProvider.Slack(), "", nil
+}
+
+// userGroupMembersMap returns the users cache for resolving members, which
+// only legacy mode has. Members missing from it are looked up first.
+func (ch *ConversationsHandler) userGroupMembersMap(groups []slack.UserGroup, resolve bool) map[string]slack.User {
+	if !resolve || ch.oauthEnabled {
+		return map[string]slack.User{}
+	}
+	var ids []string
+	for _, g := range groups {
+		ids = append(ids, g.Users...)
+	}
+	ch.apiProvider.ResolveUsers(ids)
+	return ch.apiProvider.ProvideUsersMap().Users
+}
+
+// findUserGroup returns the ID of the usergroup with the given @handle
+func findUserGroup(groups []slack.UserGroup, handle string) (string, bool) {
+	handle = strings.TrimPrefix(handle, "@")
+	for _, g := range groups {
+		if strings.EqualFold(g.Handle, handle) {
+			return g.ID, true
+		}
+	}
+	return "", false
+}
+
+// userGroupsUnavailable returns a message instead of an error when the
+// workspace or the token has no access to usergroups
+func userGroupsUnavailable(err error) *mcp.CallToolResult {
+	for code := range userGroupsPlanErrors {
+		if isSlackError(err, code) {
+			return mcp.NewToolResultError(fmt.Sprintf("usergroups are not available in this workspace (%s), "+
+				"Slack only offers them on paid plans", code))
+		}
+	}
+	for code := range unavailableMethodErrors {
+		if isSlackError(err, code) {
+			return mcp.NewToolResultError(fmt.Sprintf("usergroups are not available with this token (%s). "+
+				"They require the usergroups:read scope", code))
+		}
+	}
+	return nil
+}
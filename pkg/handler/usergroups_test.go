@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitUserGroupsList(t *testing.T) {
+	calls := 0
+	api := &fakeSlackAPI{groups: func() ([]slack.UserGroup, error) {
+		calls++
+		return []slack.UserGroup{
+			{ID: "S2", Handle: "ops", Name: "Ops", UserCount: 1, Users: []string{"U3"}},
+			{ID: "S1", Handle: "team-eng", Name: "Engineering", UserCount: 2, Users: []string{"U1", "U2"}},
+		}, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.UserGroupsListHandler(context.Background(), newToolRequest(map[string]any{}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"@ops", "@team-eng"}, csvColumn(t, out, "Handle"))
+	assert.Equal(t, []string{"U3", "U1,U2"}, csvColumn(t, out, "Members"))
+
+	_, err = ch.UserGroupsListHandler(context.Background(), newToolRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls, "usergroups are cached")
+}
+
+func TestUnitUserGroupsUsersListByHandle(t *testing.T) {
+	api := &fakeSlackAPI{
+		groups: func() ([]slack.UserGroup, error) {
+			return []slack.UserGroup{{ID: "S1", Handle: "team-eng"}}, nil
+		},
+		members: func(userGroup string) ([]string, error) {
+			assert.Equal(t, "S1", userGroup)
+			return []string{"U1", "U2"}, nil
+		},
+		usersInfo: func(users ...string) (*[]slack.User, error) {
+			resolved := []slack.User{{ID: "U1", Name: "alice", RealName: "Alice"}}
+			return &resolved, nil
+		},
+	}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.UserGroupsUsersListHandler(context.Background(), newToolRequest(map[string]any{"usergroup": "@Team-Eng"}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"U1", "U2"}, csvColumn(t, out, "UserID"))
+	assert.Equal(t, []string{"alice", "U2"}, csvColumn(t, out, "UserName"))
+
+	_, err = ch.UserGroupsUsersListHandler(context.Background(), newToolRequest(map[string]any{"usergroup": "@missing"}))
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestUnitUserGroupsUnavailable(t *testing.T) {
+	for code, want := range map[string]string{
+		"paid_teams_only": "paid plans",
+		"missing_scope":   "usergroups:read",
+	} {
+		api := &fakeSlackAPI{groups: func() ([]slack.UserGroup, error) {
+			return nil, slack.SlackErrorResponse{Err: code}
+		}}
+		ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+		res, err := ch.UserGroupsListHandler(context.Background(), newToolRequest(map[string]any{}))
+		require.NoError(t, err, code)
+		assert.True(t, res.IsError, code)
+		assert.Contains(t, toolResultText(t, res), want)
+	}
+}
+
+func TestUnitUserGroupsUsersUpdate(t *testing.T) {
+	var updated []string
+	api := &fakeSlackAPI{
+		groups: func() ([]slack.UserGroup, error) {
+			return []slack.UserGroup{{ID: "S1", Handle: "oncall", UserCount: 2, Users: []string{"U1", "U2"}}}, nil
+		},
+		members: func(userGroup string) ([]string, error) {
+			return []string{"U1", "U2"}, nil
+		},
+		setGroup: func(userGroup, members string) (slack.UserGroup, error) {
+			assert.Equal(t, "S1", userGroup)
+			updated = append(updated, members)
+			users := strings.Split(members, ",")
+			return slack.UserGroup{ID: "S1", Handle: "oncall", UserCount: len(users), Users: users}, nil
+		},
+	}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	p.ProvideUsersMap().UsersInv["carol"] = "U3"
+	ch := NewConversationsHandler(p, zap.NewNop())
+
+	t.Setenv("SLACK_MCP_USERGROUPS_WRITE_TOOL", "")
+	_, err := ch.UserGroupsUsersUpdateHandler(context.Background(), newToolRequest(map[string]any{"usergroup": "S1", "user_ids": "U3"}))
+	assert.ErrorContains(t, err, "SLACK_MCP_USERGROUPS_WRITE_TOOL", "updates are disabled by default")
+
+	t.Setenv("SLACK_MCP_USERGROUPS_WRITE_TOOL", "true")
+
+	res, err := ch.UserGroupsUsersUpdateHandler(context.Background(), newToolRequest(map[string]any{"usergroup": "@oncall", "user_ids": "@carol"}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"U3"}, csvColumn(t, out, "Members"))
+	assert.Equal(t, []string{"1"}, csvColumn(t, out, "UserCount"))
+
+	res, err = ch.UserGroupsListHandler(context.Background(), newToolRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U3"}, csvColumn(t, toolResultText(t, res), "Members"), "the cached usergroup is updated")
+
+	_, err = ch.UserGroupsUsersUpdateHandler(context.Background(), newToolRequest(map[string]any{"usergroup": "S1", "user_ids": "U3,U1", "mode": "add"}))
+	require.NoError(t, err)
+	_, err = ch.UserGroupsUsersUpdateHandler(context.Background(), newToolRequest(map[string]any{"usergroup": "S1", "user_ids": "U1", "mode": "remove"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U3", "U1,U2,U3", "U2"}, updated)
+
+	_, err = ch.UserGroupsUsersUpdateHandler(context.Background(), newToolRequest(map[string]any{"usergroup": "S1", "user_ids": "U1,U2", "mode": "remove"}))
+	assert.ErrorContains(t, err, "at least one member")
+	assert.Len(t, updated, 3)
+}
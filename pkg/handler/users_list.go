@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultUsersListLimit = 100
+	maxUsersListLimit     = 1000
+)
+
+// UserListEntry is a workspace member of users_list. The last entry of a page
+// carries the cursor of the next page.
+type UserListEntry struct {
+	UserID      string `json:"userID"`
+	UserName    string `json:"userName"`
+	RealName    string `json:"realName"`
+	DisplayName string `json:"displayName"`
+	Email       string `json:"email"`
+	Title       string `json:"title"`
+	TimeZone    string `json:"timeZone"`
+	IsBot       bool   `json:"isBot"`
+	Deleted     bool   `json:"deleted"`
+	Cursor      string `json:"cursor"`
+}
+
+// usersListAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type usersListAPI interface {
+	GetUsersContext(ctx context.Context, options ...slack.GetUsersOption) ([]slack.User, error)
+}
+
+// UsersListHandler lists the members of the workspace with users.list, people
+// only unless include_bots or include_deleted are set. users.list has no
+// order to resume from, so all users are fetched and paged by user ID.
+func (ch *ConversationsHandler) UsersListHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("UsersListHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	limit := request.GetInt("limit", defaultUsersListLimit)
+	if limit < 1 || limit > maxUsersListLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d, got %d", maxUsersListLimit, limit)
+	}
+
+	var afterID string
+	if cursor := request.GetString("cursor", ""); cursor != "" {
+		decoded, err := base64.StdEncoding.DecodeString(cursor)
+		if err != nil || len(decoded) == 0 {
+			ch.logger.Error("Invalid cursor decoding", zap.String("cursor", cursor), zap.Error(err))
+			return nil, fmt.Errorf("invalid cursor: %v", cursor)
+		}
+		afterID = string(decoded)
+	}
+
+	var api usersListAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	users, err := api.GetUsersContext(ctx, slack.GetUsersOptionLimit(maxUsersListLimit))
+	if err != nil {
+		if isMissingScope(err) {
+			return nil, fmt.Errorf("listing users requires the users:read scope, add it to the Slack app and reinstall it: %w", err)
+		}
+		ch.logger.Error("Slack GetUsersContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	includeBots := request.GetBool("include_bots", false)
+	includeDeleted := request.GetBool("include_deleted", false)
+	prefix := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(request.GetString("name_prefix", "")), "@"))
+
+	filtered := make([]slack.User, 0, len(users))
+	for _, user := range users {
+		if isBotUser(user) && !includeBots {
+			continue
+		}
+		if user.Deleted && !includeDeleted {
+			continue
+		}
+		if prefix != "" && !userHasNamePrefix(user, prefix) {
+			continue
+		}
+		filtered = append(filtered, user)
+	}
+
+	// pages follow the user IDs, so that a cursor stays valid while users join
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].ID < filtered[j].ID
+	})
+	start := sort.Search(len(filtered), func(i int) bool {
+		return filtered[i].ID > afterID
+	})
+	end := start + limit
+	if end > len(filtered) {
+		end = len(filtered)
+	}
+
+	entries := make([]UserListEntry, 0, end-start)
+	for _, user := range filtered[start:end] {
+		entries = append(entries, UserListEntry{
+			UserID:      user.ID,
+			UserName:    user.Name,
+			RealName:    user.RealName,
+			DisplayName: user.Profile.DisplayName,
+			Email:       user.Profile.Email,
+			Title:       user.Profile.Title,
+			TimeZone:    user.TZ,
+			IsBot:       isBotUser(user),
+			Deleted:     user.Deleted,
+		})
+	}
+	if end < len(filtered) {
+		entries[len(entries)-1].Cursor = base64.StdEncoding.EncodeToString([]byte(filtered[end-1].ID))
+	}
+
+	csvBytes, err := gocsv.MarshalBytes(&entries)
+	if err != nil {
+		ch.logger.Error("Failed to marshal users to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// userHasNamePrefix reports whether the user name, real name or display name
+// of the user starts with the lowercase prefix
+func userHasNamePrefix(user slack.User, prefix string) bool {
+	for _, name := range []string{user.Name, user.RealName, user.Profile.DisplayName, user.Profile.RealName} {
+		if strings.HasPrefix(strings.ToLower(name), prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitUsersList(t *testing.T) {
+	api := &fakeSlackAPI{users: func() ([]slack.User, error) {
+		return []slack.User{
+			{ID: "U3", Name: "carol", RealName: "Carol Poe"},
+			{ID: "U1", Name: "alice", RealName: "Alice Doe", Profile: slack.UserProfile{DisplayName: "ali", Email: "alice@example.com"}},
+			{ID: "B1", Name: "deploybot", IsBot: true},
+			{ID: "USLACKBOT", Name: "slackbot"},
+			{ID: "U2", Name: "bob", RealName: "Bob Roe"},
+			{ID: "U4", Name: "dave", Deleted: true},
+			{ID: "U5", Name: "jira", IsAppUser: true},
+		}, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.UsersListHandler(context.Background(), newToolRequest(map[string]any{"limit": 2}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"U1", "U2"}, csvColumn(t, out, "UserID"))
+	assert.Equal(t, []string{"ali", ""}, csvColumn(t, out, "DisplayName"))
+	cursor := csvColumn(t, out, "Cursor")[1]
+	require.NotEmpty(t, cursor)
+
+	res, err = ch.UsersListHandler(context.Background(), newToolRequest(map[string]any{"limit": 2, "cursor": cursor}))
+	require.NoError(t, err)
+	out = toolResultText(t, res)
+	assert.Equal(t, []string{"U3"}, csvColumn(t, out, "UserID"), "bots and deleted users are left out by default")
+	assert.Equal(t, []string{""}, csvColumn(t, out, "Cursor"))
+
+	res, err = ch.UsersListHandler(context.Background(), newToolRequest(map[string]any{"include_bots": true, "include_deleted": true}))
+	require.NoError(t, err)
+	out = toolResultText(t, res)
+	assert.Equal(t, []string{"B1", "U1", "U2", "U3", "U4", "U5", "USLACKBOT"}, csvColumn(t, out, "UserID"))
+	assert.Equal(t, []string{"true", "false", "false", "false", "false", "true", "true"}, csvColumn(t, out, "IsBot"), "app users and Slackbot are bots too")
+
+	res, err = ch.UsersListHandler(context.Background(), newToolRequest(map[string]any{"name_prefix": "@Bo"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U2"}, csvColumn(t, toolResultText(t, res), "UserID"))
+
+	res, err = ch.UsersListHandler(context.Background(), newToolRequest(map[string]any{"name_prefix": "ali"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U1"}, csvColumn(t, toolResultText(t, res), "UserID"), "prefixes match the display name too")
+
+	_, err = ch.UsersListHandler(context.Background(), newToolRequest(map[string]any{"cursor": "%%%"}))
+	assert.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type UserProfile struct {
+	UserID      string `json:"userID"`
+	UserName    string `json:"userName"`
+	RealName    string `json:"realName"`
+	DisplayName string `json:"displayName"`
+	Email       string `json:"email"`
+	Title       string `json:"title"`
+	TimeZone    string `json:"timeZone"`
+	IsBot       bool   `json:"isBot"`
+	Deleted     bool   `json:"deleted"`
+}
+
+// UsersLookupByEmailHandler finds the Slack user with an email
+// with users.lookupByEmail, caching the result in legacy mode
+func (ch *ConversationsHandler) UsersLookupByEmailHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("UsersLookupByEmailHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	email := strings.TrimSpace(request.GetString("email", ""))
+	if email == "" || !strings.Contains(email, "@") {
+		return nil, errors.New("email must be an email address, e.g. jane@example.com")
+	}
+
+	var (
+		user *slack.User
+		err  error
+	)
+	if ch.oauthEnabled {
+		client, clientErr := ch.getSlackClient(ctx)
+		if clientErr != nil {
+			return nil, clientErr
+		}
+		user, err = client.GetUserByEmailContext(ctx, email)
+	} else if cached, ok := ch.apiProvider.UserByEmail(email); ok {
+		ch.logger.Debug("User found in cache", zap.String("user", cached.ID))
+		user = &cached
+	} else {
+		user, err = ch.apiProvider.Slack().GetUserByEmailContext(ctx, email)
+		if err == nil {
+			ch.apiProvider.CacheUserByEmail(email, *user)
+		}
+	}
+	if err != nil {
+		switch {
+		case isSlackError(err, "users_not_found"):
+			return nil, fmt.Errorf("no Slack user has the email %s, or the token cannot see the user", email)
+		case isMissingScope(err):
+			return nil, fmt.Errorf("looking up users by email requires the users:read.email scope, add it to the Slack app and reinstall it: %w", err)
+		}
+		ch.logger.Error("Slack GetUserByEmailContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	profiles := []UserProfile{{
+		UserID:      user.ID,
+		UserName:    user.Name,
+		RealName:    user.RealName,
+		DisplayName: user.Profile.DisplayName,
+		Email:       user.Profile.Email,
+		Title:       user.Profile.Title,
+		TimeZone:    user.TZ,
+		IsBot:       user.IsBot,
+		Deleted:     user.Deleted,
+	}}
+	csvBytes, err := gocsv.MarshalBytes(&profiles)
+	if err != nil {
+		ch.logger.Error("Failed to marshal user to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
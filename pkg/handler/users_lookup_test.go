@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitUsersLookupByEmail(t *testing.T) {
+	calls := 0
+	api := &fakeSlackAPI{byEmail: func(email string) (*slack.User, error) {
+		calls++
+		assert.Equal(t, "Jane@Example.com", email)
+		return &slack.User{
+			ID:       "U1",
+			Name:     "jane",
+			RealName: "Jane Doe",
+			TZ:       "Europe/Berlin",
+			Profile:  slack.UserProfile{DisplayName: "janed", Email: "jane@example.com", Title: "Engineer"},
+		}, nil
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	for i := 0; i < 2; i++ {
+		res, err := ch.UsersLookupByEmailHandler(context.Background(), newToolRequest(map[string]any{"email": "Jane@Example.com"}))
+		require.NoError(t, err)
+
+		out := toolResultText(t, res)
+		assert.Equal(t, []string{"U1"}, csvColumn(t, out, "UserID"))
+		assert.Equal(t, []string{"janed"}, csvColumn(t, out, "DisplayName"))
+		assert.Equal(t, []string{"jane@example.com"}, csvColumn(t, out, "Email"))
+		assert.Equal(t, []string{"Europe/Berlin"}, csvColumn(t, out, "TimeZone"))
+	}
+	assert.Equal(t, 1, calls, "the second lookup is served from the users cache")
+
+	res, err := ch.UsersLookupByEmailHandler(context.Background(), newToolRequest(map[string]any{"email": "jane@example.COM"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U1"}, csvColumn(t, toolResultText(t, res), "UserID"), "emails match case-insensitively")
+	assert.Equal(t, 1, calls)
+}
+
+func TestUnitUsersLookupByEmailSyncedUsers(t *testing.T) {
+	api := &fakeSlackAPI{byEmail: func(email string) (*slack.User, error) {
+		t.Fatal("users with an email in the synced cache are not looked up")
+		return nil, nil
+	}}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	p.ProvideUsersMap().Users["U2"] = slack.User{ID: "U2", Name: "bob", Profile: slack.UserProfile{Email: "bob@example.com"}}
+	ch := NewConversationsHandler(p, zap.NewNop())
+
+	res, err := ch.UsersLookupByEmailHandler(context.Background(), newToolRequest(map[string]any{"email": "bob@example.com"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bob"}, csvColumn(t, toolResultText(t, res), "UserName"))
+}
+
+func TestUnitUsersLookupByEmailErrors(t *testing.T) {
+	var slackErr error
+	api := &fakeSlackAPI{byEmail: func(email string) (*slack.User, error) {
+		return nil, slackErr
+	}}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	_, err := ch.UsersLookupByEmailHandler(context.Background(), newToolRequest(map[string]any{"email": "not-an-email"}))
+	assert.ErrorContains(t, err, "email must be an email address")
+
+	slackErr = slack.SlackErrorResponse{Err: "users_not_found"}
+	_, err = ch.UsersLookupByEmailHandler(context.Background(), newToolRequest(map[string]any{"email": "nobody@example.com"}))
+	assert.ErrorContains(t, err, "no Slack user has the email nobody@example.com")
+
+	slackErr = slack.SlackErrorResponse{Err: "missing_scope"}
+	_, err = ch.UsersLookupByEmailHandler(context.Background(), newToolRequest(map[string]any{"email": "nobody@example.com"}))
+	assert.ErrorContains(t, err, "users:read.email")
+}
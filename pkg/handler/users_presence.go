@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// maxPresenceUsers is the number of users users_presence looks up at once,
+// one users.getPresence call each
+const maxPresenceUsers = 20
+
+// UserPresence is the presence of a user. Slack only reports whether others
+// are online, away and active for the authenticated user itself. A row with
+// a Note reports a user that could not be looked up.
+type UserPresence struct {
+	UserID          string `json:"userID"`
+	UserName        string `json:"userName"`
+	Presence        string `json:"presence"`
+	Online          bool   `json:"online"`
+	AutoAway        bool   `json:"autoAway"`
+	ManualAway      bool   `json:"manualAway"`
+	ConnectionCount int    `json:"connectionCount"`
+	LastActivity    string `json:"lastActivity"`
+	Note            string `json:"note"`
+}
+
+// userPresenceAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type userPresenceAPI interface {
+	GetUserPresenceContext(ctx context.Context, user string) (*slack.UserPresence, error)
+}
+
+// UsersPresenceHandler returns whether each given user is active or away with
+// users.getPresence, e.g. to decide between a DM and escalating elsewhere
+func (ch *ConversationsHandler) UsersPresenceHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("UsersPresenceHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	userIDs, err := ch.parseUserIDs(request.GetString("user_ids", ""))
+	if err != nil {
+		return nil, err
+	}
+	if len(userIDs) == 0 {
+		return nil, errors.New("user_ids must contain at least one user ID")
+	}
+	if len(userIDs) > maxPresenceUsers {
+		return nil, fmt.Errorf("too many users: %d, at most %d users can be looked up at once", len(userIDs), maxPresenceUsers)
+	}
+
+	var (
+		api   userPresenceAPI
+		users map[string]slack.User
+	)
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+		users = ch.apiProvider.ProvideUsersMap().Users
+	}
+
+	rows := make([]UserPresence, 0, len(userIDs))
+	for _, id := range userIDs {
+		row := UserPresence{UserID: id}
+		if u, ok := users[id]; ok {
+			row.UserName = u.Name
+		}
+
+		presence, err := api.GetUserPresenceContext(ctx, id)
+		if err != nil {
+			ch.logger.Warn("Slack GetUserPresenceContext failed", zap.String("user", id), zap.Error(err))
+			if isMissingScope(err) {
+				return nil, fmt.Errorf("users_presence requires the users:read scope: %w", err)
+			}
+			row.Note = "error: " + err.Error()
+			rows = append(rows, row)
+			continue
+		}
+
+		row.Presence = presence.Presence
+		row.Online = presence.Online
+		row.AutoAway = presence.AutoAway
+		row.ManualAway = presence.ManualAway
+		row.ConnectionCount = presence.ConnectionCount
+		if presence.LastActivity > 0 {
+			row.LastActivity = presence.LastActivity.Time().UTC().Format(time.RFC3339)
+		}
+		rows = append(rows, row)
+	}
+
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal presence to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitUsersPresence(t *testing.T) {
+	var looked []string
+	api := &fakeSlackAPI{presence: func(user string) (*slack.UserPresence, error) {
+		looked = append(looked, user)
+		switch user {
+		case "U1":
+			return &slack.UserPresence{Presence: "active", Online: true, ConnectionCount: 2, LastActivity: 1700000000}, nil
+		case "U2":
+			return &slack.UserPresence{Presence: "away"}, nil
+		}
+		return nil, slack.SlackErrorResponse{Err: "user_not_found"}
+	}}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	p.ProvideUsersMap().Users["U1"] = slack.User{ID: "U1", Name: "alice"}
+	p.ProvideUsersMap().UsersInv["bob"] = "U2"
+	ch := NewConversationsHandler(p, zap.NewNop())
+
+	res, err := ch.UsersPresenceHandler(context.Background(), newToolRequest(map[string]any{"user_ids": "U1, @bob, U9, U1"}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"U1", "U2", "U9"}, looked)
+	assert.Equal(t, []string{"U1", "U2", "U9"}, csvColumn(t, out, "UserID"))
+	assert.Equal(t, []string{"alice", "", ""}, csvColumn(t, out, "UserName"))
+	assert.Equal(t, []string{"active", "away", ""}, csvColumn(t, out, "Presence"))
+	assert.Equal(t, []string{"true", "false", "false"}, csvColumn(t, out, "Online"))
+	assert.Equal(t, []string{"2023-11-14T22:13:20Z", "", ""}, csvColumn(t, out, "LastActivity"))
+	assert.Equal(t, []string{"", "", "error: user_not_found"}, csvColumn(t, out, "Note"))
+
+	_, err = ch.UsersPresenceHandler(context.Background(), newToolRequest(map[string]any{"user_ids": ""}))
+	assert.Error(t, err)
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// UserProfileDetail is the full profile of a user as returned by users_profile.
+// A Note reports profile details that could not be read.
+type UserProfileDetail struct {
+	UserID           string `json:"userID"`
+	UserName         string `json:"userName"`
+	RealName         string `json:"realName"`
+	DisplayName      string `json:"displayName"`
+	Title            string `json:"title"`
+	Email            string `json:"email"`
+	Phone            string `json:"phone"`
+	StatusText       string `json:"statusText"`
+	StatusEmoji      string `json:"statusEmoji"`
+	StatusExpiration string `json:"statusExpiration"`
+	TimeZone         string `json:"timeZone"`
+	IsBot            bool   `json:"isBot"`
+	Deleted          bool   `json:"deleted"`
+	CustomFields     string `json:"customFields"` // label: value of each custom field, separated by |
+	Note             string `json:"note"`
+}
+
+// userProfileAPI is satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type userProfileAPI interface {
+	GetUserInfoContext(ctx context.Context, user string) (*slack.User, error)
+	GetUserProfileContext(ctx context.Context, params *slack.GetUserProfileParameters) (*slack.UserProfile, error)
+}
+
+// UsersProfileHandler returns the profile of a user by ID or @handle, combining
+// users.info with users.profile.get, which adds the custom profile fields
+func (ch *ConversationsHandler) UsersProfileHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ch.logger.Debug("UsersProfileHandler called",
+		zap.String("request_id", auth.RequestIDFromContext(ctx)),
+		zap.Any("params", request.Params),
+	)
+
+	userIDs, err := ch.parseUserIDs(request.GetString("user", ""))
+	if err != nil {
+		return nil, err
+	}
+	if len(userIDs) != 1 {
+		return nil, errors.New("user must be a single user ID (U...) or @username")
+	}
+	userID := userIDs[0]
+
+	var api userProfileAPI
+	if ch.oauthEnabled {
+		client, err := ch.getSlackClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		api = client
+	} else {
+		api = ch.apiProvider.Slack()
+	}
+
+	user, err := api.GetUserInfoContext(ctx, userID)
+	if err != nil {
+		switch {
+		case isSlackError(err, "user_not_found"):
+			return nil, fmt.Errorf("user %s not found", userID)
+		case isMissingScope(err):
+			return nil, fmt.Errorf("users_profile requires the users:read scope: %w", err)
+		}
+		ch.logger.Error("Slack GetUserInfoContext failed", zap.Error(err))
+		return nil, err
+	}
+
+	row := UserProfileDetail{
+		UserID:   user.ID,
+		UserName: user.Name,
+		RealName: user.RealName,
+		TimeZone: user.TZ,
+		IsBot:    user.IsBot,
+		Deleted:  user.Deleted,
+	}
+
+	profile := &user.Profile
+	full, err := api.GetUserProfileContext(ctx, &slack.GetUserProfileParameters{UserID: user.ID, IncludeLabels: true})
+	switch {
+	case err == nil:
+		profile = full
+	case isMissingScope(err):
+		row.Note = "custom profile fields require the users.profile:read scope"
+	default:
+		ch.logger.Warn("Slack GetUserProfileContext failed", zap.String("user", user.ID), zap.Error(err))
+		row.Note = "custom profile fields unavailable: " + err.Error()
+	}
+
+	row.DisplayName = profile.DisplayName
+	row.Title = profile.Title
+	row.Email = profile.Email
+	row.Phone = profile.Phone
+	row.StatusText = profile.StatusText
+	row.StatusEmoji = profile.StatusEmoji
+	if profile.StatusExpiration > 0 {
+		row.StatusExpiration = time.Unix(int64(profile.StatusExpiration), 0).UTC().Format(time.RFC3339)
+	}
+	row.CustomFields = formatCustomFields(profile.Fields.ToMap())
+
+	rows := []UserProfileDetail{row}
+	csvBytes, err := gocsv.MarshalBytes(&rows)
+	if err != nil {
+		ch.logger.Error("Failed to marshal user profile to CSV", zap.Error(err))
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(csvBytes)), nil
+}
+
+// formatCustomFields lists the custom profile fields with a value as
+// label: value (alt), ordered by label. Fields without a label are listed by ID.
+func formatCustomFields(fields map[string]slack.UserProfileCustomField) string {
+	items := make([]string, 0, len(fields))
+	for id, field := range fields {
+		if field.Value == "" {
+			continue
+		}
+		label := field.Label
+		if label == "" {
+			label = id
+		}
+		item := label + ": " + field.Value
+		if field.Alt != "" {
+			item += " (" + field.Alt + ")"
+		}
+		items = append(items, item)
+	}
+	sort.Strings(items)
+	return strings.Join(items, "|")
+}
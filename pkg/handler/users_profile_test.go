@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitUsersProfile(t *testing.T) {
+	api := &fakeSlackAPI{
+		userInfo: func(user string) (*slack.User, error) {
+			assert.Equal(t, "U1", user)
+			return &slack.User{ID: "U1", Name: "jane", RealName: "Jane Doe", TZ: "Europe/Berlin", Profile: slack.UserProfile{DisplayName: "janed"}}, nil
+		},
+		profile: func(params *slack.GetUserProfileParameters) (*slack.UserProfile, error) {
+			assert.Equal(t, "U1", params.UserID)
+			assert.True(t, params.IncludeLabels)
+			profile := &slack.UserProfile{
+				DisplayName:      "janed",
+				Title:            "Engineer",
+				Email:            "jane@example.com",
+				Phone:            "+49 30 1234",
+				StatusText:       "On call",
+				StatusEmoji:      ":pager:",
+				StatusExpiration: 1700000000,
+			}
+			profile.Fields.SetMap(map[string]slack.UserProfileCustomField{
+				"Xf01": {Value: "Platform", Label: "Team"},
+				"Xf02": {Value: "U2", Alt: "bob", Label: "Manager"},
+				"Xf03": {Label: "Pronouns"},
+			})
+			return profile, nil
+		},
+	}
+	p := provider.NewWithClient("stdio", api, zap.NewNop())
+	p.ProvideUsersMap().UsersInv["jane"] = "U1"
+	ch := NewConversationsHandler(p, zap.NewNop())
+
+	for _, user := range []string{"U1", "@jane"} {
+		res, err := ch.UsersProfileHandler(context.Background(), newToolRequest(map[string]any{"user": user}))
+		require.NoError(t, err)
+		out := toolResultText(t, res)
+		assert.Equal(t, []string{"U1"}, csvColumn(t, out, "UserID"))
+		assert.Equal(t, []string{"Engineer"}, csvColumn(t, out, "Title"))
+		assert.Equal(t, []string{"+49 30 1234"}, csvColumn(t, out, "Phone"))
+		assert.Equal(t, []string{":pager:"}, csvColumn(t, out, "StatusEmoji"))
+		assert.Equal(t, []string{"2023-11-14T22:13:20Z"}, csvColumn(t, out, "StatusExpiration"))
+		assert.Equal(t, []string{"Europe/Berlin"}, csvColumn(t, out, "TimeZone"))
+		assert.Equal(t, []string{"Manager: U2 (bob)|Team: Platform"}, csvColumn(t, out, "CustomFields"))
+		assert.Equal(t, []string{""}, csvColumn(t, out, "Note"))
+	}
+
+	_, err := ch.UsersProfileHandler(context.Background(), newToolRequest(map[string]any{"user": "@nobody"}))
+	assert.Error(t, err)
+}
+
+func TestUnitUsersProfileWithoutProfileScope(t *testing.T) {
+	api := &fakeSlackAPI{
+		userInfo: func(user string) (*slack.User, error) {
+			return &slack.User{ID: "U1", Name: "jane", Profile: slack.UserProfile{Title: "Engineer"}}, nil
+		},
+		profile: func(params *slack.GetUserProfileParameters) (*slack.UserProfile, error) {
+			return nil, slack.SlackErrorResponse{Err: "missing_scope"}
+		},
+	}
+	ch := NewConversationsHandler(provider.NewWithClient("stdio", api, zap.NewNop()), zap.NewNop())
+
+	res, err := ch.UsersProfileHandler(context.Background(), newToolRequest(map[string]any{"user": "U1"}))
+	require.NoError(t, err)
+	out := toolResultText(t, res)
+	assert.Equal(t, []string{"Engineer"}, csvColumn(t, out, "Title"), "the users.info profile is kept")
+	assert.Equal(t, []string{"custom profile fields require the users.profile:read scope"}, csvColumn(t, out, "Note"))
+}
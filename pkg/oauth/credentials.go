@@ -0,0 +1,77 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCredentialsCacheTTL is how long a fetched client secret is reused
+const DefaultCredentialsCacheTTL = time.Minute
+
+// staticCredentials holds a client secret known at startup
+type staticCredentials string
+
+func (s staticCredentials) ClientSecret(ctx context.Context) (string, error) {
+	return string(s), nil
+}
+
+// cachedCredentials keeps a fetched client secret for a short time, so a
+// secret manager is queried once per ttl rather than on every callback
+type cachedCredentials struct {
+	provider CredentialProvider
+	ttl      time.Duration
+
+	mu        sync.Mutex
+	secret    string
+	expiresAt time.Time
+}
+
+func newCachedCredentials(provider CredentialProvider, ttl time.Duration) *cachedCredentials {
+	return &cachedCredentials{provider: provider, ttl: ttl}
+}
+
+func (c *cachedCredentials) ClientSecret(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.secret != "" && time.Now().Before(c.expiresAt) {
+		return c.secret, nil
+	}
+
+	secret, err := c.provider.ClientSecret(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.secret = secret
+	c.expiresAt = time.Now().Add(c.ttl)
+	return secret, nil
+}
+
+// invalidate drops the cached secret, e.g. after Slack rejected it as rotated
+func (c *cachedCredentials) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.secret = ""
+}
+
+// FileCredentials reads the client secret from a file on every fetch, e.g. one
+// kept up to date by a Vault agent or a mounted Kubernetes secret
+type FileCredentials struct {
+	Path string
+}
+
+func (f FileCredentials) ClientSecret(ctx context.Context) (string, error) {
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read client secret file: %w", err)
+	}
+	secret := strings.TrimSpace(string(data))
+	if secret == "" {
+		return "", fmt.Errorf("client secret file %s is empty", f.Path)
+	}
+	return secret, nil
+}
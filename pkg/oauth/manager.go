@@ -1,29 +1,46 @@
 package oauth
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"time"
 )
 
 type Manager struct {
-	clientID     string
-	clientSecret string
-	redirectURI  string
-	storage      TokenStorage
-	httpClient   *http.Client
+	clientID    string
+	credentials CredentialProvider
+	redirectURI string
+	storage     TokenStorage
+	httpClient  *http.Client
 }
 
 // NewManager creates a new OAuth manager
 func NewManager(clientID, clientSecret, redirectURI string, storage TokenStorage) *Manager {
+	return newManager(clientID, staticCredentials(clientSecret), redirectURI, storage)
+}
+
+// NewManagerWithCredentials creates an OAuth manager that fetches the client
+// secret from credentials when exchanging a code, caching it for cacheTTL
+func NewManagerWithCredentials(clientID string, credentials CredentialProvider, cacheTTL time.Duration, redirectURI string, storage TokenStorage) *Manager {
+	return newManager(clientID, newCachedCredentials(credentials, cacheTTL), redirectURI, storage)
+}
+
+func newManager(clientID string, credentials CredentialProvider, redirectURI string, storage TokenStorage) *Manager {
+	// an invalid URI is kept as is, Slack then rejects it with bad_redirect_uri
+	if normalized, err := NormalizeRedirectURI(redirectURI); err == nil {
+		redirectURI = normalized
+	}
+
 	return &Manager{
-		clientID:     clientID,
-		clientSecret: clientSecret,
-		redirectURI:  redirectURI,
-		storage:      storage,
+		clientID:    clientID,
+		credentials: credentials,
+		redirectURI: redirectURI,
+		storage:     storage,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second, // Prevent hanging requests
 		},
@@ -45,8 +62,19 @@ func (m *Manager) GetAuthURL(state string) string {
 		"mpim:read",
 		"mpim:write",
 		"users:read",
+		"users:read.email",
 		"chat:write",
 		"search:read",
+		"reactions:read",
+		"reactions:write",
+		"files:read",
+		"files:write",
+		"pins:read",
+		"channels:write",
+		"groups:write",
+		"reminders:read",
+		"reminders:write",
+		"usergroups:read",
 	}
 
 	// Bot token scopes for OAuth v2
@@ -62,13 +90,17 @@ func (m *Manager) GetAuthURL(state string) string {
 		"mpim:read",
 		"mpim:write",
 		"users:read",
+		"users:read.email",
 		"chat:write", // Critical for posting as bot
+		"reactions:read",
+		"files:read",
+		"pins:read",
 	}
 
 	params := url.Values{
 		"client_id":    {m.clientID},
-		"scope":        {strings.Join(botScopes, ",")},   // Bot scopes
-		"user_scope":   {strings.Join(userScopes, ",")}, // User scopes
+		"scope":        {strings.Join(normalizeScopes(botScopes), ",")},  // Bot scopes
+		"user_scope":   {strings.Join(normalizeScopes(userScopes), ",")}, // User scopes
 		"redirect_uri": {m.redirectURI},
 		"state":        {state},
 	}
@@ -76,41 +108,44 @@ func (m *Manager) GetAuthURL(state string) string {
 	return "https://slack.com/oauth/v2/authorize?" + params.Encode()
 }
 
-// HandleCallback exchanges OAuth code for access token
-func (m *Manager) HandleCallback(code, state string) (*TokenResponse, error) {
-	data := url.Values{
-		"client_id":     {m.clientID},
-		"client_secret": {m.clientSecret},
-		"code":          {code},
-		"redirect_uri":  {m.redirectURI},
+// normalizeScopes trims, de-duplicates and sorts scopes, so that the authorization
+// URL is stable and never repeats a scope, which Slack rejects
+func normalizeScopes(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" || seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		normalized = append(normalized, scope)
 	}
+	sort.Strings(normalized)
+	return normalized
+}
 
-	resp, err := m.httpClient.PostForm("https://slack.com/api/oauth.v2.access", data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to exchange code: %w", err)
-	}
-	defer resp.Body.Close()
+// HandleCallback exchanges OAuth code for access token
+func (m *Manager) HandleCallback(code, state string) (*TokenResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.httpClient.Timeout)
+	defer cancel()
 
-	var result struct {
-		OK          bool   `json:"ok"`
-		Error       string `json:"error"`
-		AccessToken string `json:"access_token"` // Bot token (if bot scopes requested)
-		AuthedUser  struct {
-			ID          string `json:"id"`
-			AccessToken string `json:"access_token"` // User token
-		} `json:"authed_user"`
-		BotUserID string `json:"bot_user_id"` // Bot user ID (if bot installed)
-		Team      struct {
-			ID   string `json:"id"`
-			Name string `json:"name"`
-		} `json:"team"`
+	result, err := m.exchangeCode(ctx, code)
+	if err == nil && !result.OK && result.Error == "bad_client_secret" {
+		// the secret may have been rotated while cached, retry with a fresh one
+		if cached, ok := m.credentials.(*cachedCredentials); ok {
+			cached.invalidate()
+			result, err = m.exchangeCode(ctx, code)
+		}
 	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err != nil {
+		return nil, err
 	}
 
 	if !result.OK {
+		if result.Error == "bad_redirect_uri" {
+			return nil, fmt.Errorf("slack error: bad_redirect_uri: the redirect URI %q must exactly match one of the Redirect URLs registered under OAuth & Permissions of the Slack app, including scheme, host, port, path and trailing slash", m.redirectURI)
+		}
 		return nil, fmt.Errorf("slack error: %s", result.Error)
 	}
 
@@ -120,6 +155,7 @@ func (m *Manager) HandleCallback(code, state string) (*TokenResponse, error) {
 		UserID:      result.AuthedUser.ID,
 		TeamID:      result.Team.ID,
 		BotUserID:   result.BotUserID,
+		BotScopes:   splitScopes(result.Scope),
 		ExpiresAt:   time.Now().Add(365 * 24 * time.Hour), // Slack tokens don't expire by default
 	}
 
@@ -138,6 +174,91 @@ func (m *Manager) HandleCallback(code, state string) (*TokenResponse, error) {
 	return token, nil
 }
 
+// oauthAccessResponse is the response of oauth.v2.access
+type oauthAccessResponse struct {
+	OK          bool   `json:"ok"`
+	Error       string `json:"error"`
+	AccessToken string `json:"access_token"` // Bot token (if bot scopes requested)
+	Scope       string `json:"scope"`        // Bot token scopes
+	AuthedUser  struct {
+		ID          string `json:"id"`
+		AccessToken string `json:"access_token"` // User token
+	} `json:"authed_user"`
+	BotUserID string `json:"bot_user_id"` // Bot user ID (if bot installed)
+	Team      struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"team"`
+}
+
+// exchangeCode calls oauth.v2.access with the current client secret
+func (m *Manager) exchangeCode(ctx context.Context, code string) (*oauthAccessResponse, error) {
+	clientSecret, err := m.credentials.ClientSecret(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client secret: %w", err)
+	}
+
+	data := url.Values{
+		"client_id":     {m.clientID},
+		"client_secret": {clientSecret},
+		"code":          {code},
+		"redirect_uri":  {m.redirectURI},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://slack.com/api/oauth.v2.access", strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result oauthAccessResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// NormalizeRedirectURI checks that a redirect URI is an absolute http(s) URL
+// and returns it trimmed, with the scheme and host lowercased. The path is
+// kept as is, as Slack compares it exactly with the registered Redirect URLs.
+func NormalizeRedirectURI(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid redirect URI %q: %w", raw, err)
+	}
+	if !u.IsAbs() || u.Host == "" {
+		return "", fmt.Errorf("invalid redirect URI %q: must be an absolute URL such as https://example.com/oauth/callback", raw)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", fmt.Errorf("invalid redirect URI %q: scheme must be http or https", raw)
+	}
+	if u.Fragment != "" {
+		return "", fmt.Errorf("invalid redirect URI %q: must not contain a fragment", raw)
+	}
+	u.Host = strings.ToLower(u.Host)
+	return u.String(), nil
+}
+
+// splitScopes parses a comma-separated scope list as returned by Slack
+func splitScopes(scope string) []string {
+	var scopes []string
+	for _, s := range strings.Split(scope, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
 // ValidateToken validates an access token with Slack
 func (m *Manager) ValidateToken(accessToken string) (*TokenInfo, error) {
 	req, err := http.NewRequest("POST", "https://slack.com/api/auth.test", nil)
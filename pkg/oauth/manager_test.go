@@ -0,0 +1,236 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingCredentials struct {
+	secrets []string
+	calls   int
+}
+
+func (c *countingCredentials) ClientSecret(ctx context.Context) (string, error) {
+	secret := c.secrets[c.calls%len(c.secrets)]
+	c.calls++
+	return secret, nil
+}
+
+func TestUnitCachedCredentials(t *testing.T) {
+	provider := &countingCredentials{secrets: []string{"s1", "s2"}}
+	cached := newCachedCredentials(provider, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		secret, err := cached.ClientSecret(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "s1", secret)
+	}
+	assert.Equal(t, 1, provider.calls, "secret is fetched once per ttl")
+
+	cached.invalidate()
+	secret, err := cached.ClientSecret(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "s2", secret)
+
+	expiring := newCachedCredentials(provider, time.Nanosecond)
+	_, _ = expiring.ClientSecret(context.Background())
+	time.Sleep(time.Millisecond)
+	_, _ = expiring.ClientSecret(context.Background())
+	assert.Equal(t, 4, provider.calls, "expired secret is fetched again")
+}
+
+func TestUnitFileCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte("first\n"), 0600))
+
+	creds := FileCredentials{Path: path}
+	secret, err := creds.ClientSecret(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "first", secret)
+
+	require.NoError(t, os.WriteFile(path, []byte("rotated"), 0600))
+	secret, err = creds.ClientSecret(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", secret)
+
+	_, err = FileCredentials{Path: filepath.Join(t.TempDir(), "missing")}.ClientSecret(context.Background())
+	assert.Error(t, err)
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// fakeOAuthAccess answers oauth.v2.access, accepting only the given secret
+func fakeOAuthAccess(t *testing.T, validSecret string, secretsSeen *[]string) *http.Client {
+	return &http.Client{
+		Timeout: time.Second,
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			form, err := url.ParseQuery(string(body))
+			require.NoError(t, err)
+
+			secret := form.Get("client_secret")
+			*secretsSeen = append(*secretsSeen, secret)
+
+			resp := `{"ok":false,"error":"bad_client_secret"}`
+			if secret == validSecret {
+				resp = `{"ok":true,"access_token":"xoxb-bot","authed_user":{"id":"U1","access_token":"xoxp-user"},"team":{"id":"T1"}}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(resp)),
+				Header:     make(http.Header),
+			}, nil
+		}),
+	}
+}
+
+func TestUnitHandleCallbackFetchesSecretLazily(t *testing.T) {
+	provider := &countingCredentials{secrets: []string{"old", "new"}}
+	m := NewManagerWithCredentials("client", provider, time.Hour, "https://example.com/oauth/callback", NewMemoryStorage())
+
+	var seen []string
+	m.httpClient = fakeOAuthAccess(t, "new", &seen)
+
+	// the cached secret was rotated: Slack rejects it and the fresh one is used
+	token, err := m.HandleCallback("code", "state")
+	require.NoError(t, err)
+	assert.Equal(t, "xoxp-user", token.AccessToken)
+	assert.Equal(t, []string{"old", "new"}, seen)
+
+	// the fresh secret is cached for the next callback
+	_, err = m.HandleCallback("code", "state")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"old", "new", "new"}, seen)
+	assert.Equal(t, 2, provider.calls)
+}
+
+func TestUnitHandleCallbackStaticSecret(t *testing.T) {
+	m := NewManager("client", "static", "https://example.com/oauth/callback", NewMemoryStorage())
+
+	var seen []string
+	m.httpClient = fakeOAuthAccess(t, "other", &seen)
+
+	_, err := m.HandleCallback("code", "state")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bad_client_secret")
+	assert.Equal(t, []string{"static"}, seen, "a static secret is not retried")
+}
+
+type failingCredentials struct{}
+
+func (failingCredentials) ClientSecret(ctx context.Context) (string, error) {
+	return "", errors.New("vault unavailable")
+}
+
+func TestUnitHandleCallbackCredentialError(t *testing.T) {
+	m := NewManagerWithCredentials("client", failingCredentials{}, time.Hour, "https://example.com/oauth/callback", NewMemoryStorage())
+
+	_, err := m.HandleCallback("code", "state")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "vault unavailable")
+}
+
+func TestUnitNormalizeRedirectURI(t *testing.T) {
+	normalized, err := NormalizeRedirectURI("  HTTPS://Example.COM/oauth/callback ")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/oauth/callback", normalized)
+
+	normalized, err = NormalizeRedirectURI("https://example.com:8443/Callback/")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com:8443/Callback/", normalized, "path and trailing slash are kept")
+
+	for _, raw := range []string{
+		"",
+		"example.com/oauth/callback",
+		"/oauth/callback",
+		"https://",
+		"ftp://example.com/oauth/callback",
+		"https://example.com/oauth/callback#frag",
+		"https://exa mple.com/oauth/callback",
+	} {
+		_, err := NormalizeRedirectURI(raw)
+		assert.Error(t, err, raw)
+	}
+
+	// plain http is accepted, e.g. for local development
+	normalized, err = NormalizeRedirectURI("http://localhost:13080/oauth/callback")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:13080/oauth/callback", normalized)
+	_, err = NormalizeRedirectURI("ftp://example.com/oauth/callback")
+	assert.ErrorContains(t, err, "scheme must be http or https")
+}
+
+func TestUnitHandleCallbackBadRedirectURI(t *testing.T) {
+	m := NewManager("client", "secret", "HTTPS://Example.com/oauth/callback", NewMemoryStorage())
+	assert.Contains(t, m.GetAuthURL("state"), url.QueryEscape("https://example.com/oauth/callback"))
+
+	var redirectSeen string
+	m.httpClient = &http.Client{
+		Timeout: time.Second,
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			form, err := url.ParseQuery(string(body))
+			require.NoError(t, err)
+			redirectSeen = form.Get("redirect_uri")
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"ok":false,"error":"bad_redirect_uri"}`)),
+				Header:     make(http.Header),
+			}, nil
+		}),
+	}
+
+	_, err := m.HandleCallback("code", "state")
+	require.Error(t, err)
+	assert.Equal(t, "https://example.com/oauth/callback", redirectSeen)
+	assert.Contains(t, err.Error(), "bad_redirect_uri")
+	assert.Contains(t, err.Error(), "must exactly match")
+	assert.Contains(t, err.Error(), `"https://example.com/oauth/callback"`)
+}
+
+func TestUnitNormalizeScopes(t *testing.T) {
+	assert.Equal(t,
+		[]string{"channels:read", "chat:write", "users:read"},
+		normalizeScopes([]string{"users:read", "chat:write", " channels:read", "users:read", "", "chat:write"}),
+	)
+	assert.Empty(t, normalizeScopes(nil))
+}
+
+func TestUnitGetAuthURLStable(t *testing.T) {
+	m := NewManager("client", "secret", "https://example.com/oauth/callback", NewMemoryStorage())
+
+	authURL := m.GetAuthURL("state")
+	assert.Equal(t, "https://slack.com/oauth/v2/authorize?"+
+		"client_id=client"+
+		"&redirect_uri=https%3A%2F%2Fexample.com%2Foauth%2Fcallback"+
+		"&scope=channels%3Ahistory%2Cchannels%3Aread%2Cchat%3Awrite%2Cfiles%3Aread%2Cgroups%3Ahistory%2Cgroups%3Aread"+
+		"%2Cim%3Ahistory%2Cim%3Aread%2Cim%3Awrite%2Cmpim%3Ahistory%2Cmpim%3Aread%2Cmpim%3Awrite%2Cpins%3Aread"+
+		"%2Creactions%3Aread%2Cusers%3Aread%2Cusers%3Aread.email"+
+		"&state=state"+
+		"&user_scope=channels%3Ahistory%2Cchannels%3Aread%2Cchannels%3Awrite%2Cchat%3Awrite%2Cfiles%3Aread%2Cfiles%3Awrite"+
+		"%2Cgroups%3Ahistory%2Cgroups%3Aread%2Cgroups%3Awrite%2Cim%3Ahistory%2Cim%3Aread%2Cim%3Awrite"+
+		"%2Cmpim%3Ahistory%2Cmpim%3Aread%2Cmpim%3Awrite%2Cpins%3Aread%2Creactions%3Aread%2Creactions%3Awrite%2Creminders%3Aread"+
+		"%2Creminders%3Awrite%2Csearch%3Aread%2Cusergroups%3Aread"+
+		"%2Cusers%3Aread%2Cusers%3Aread.email",
+		authURL,
+	)
+	assert.Equal(t, authURL, m.GetAuthURL("state"), "the URL does not change between calls")
+}
@@ -0,0 +1,93 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultMaxStates is the number of pending states a MemoryStateStore holds
+// at most, unless configured otherwise
+const DefaultMaxStates = 10000
+
+// ErrTooManyStates is returned when a state store is full of pending states
+var ErrTooManyStates = errors.New("too many pending OAuth states")
+
+// MemoryStateStore is an in-memory implementation of StateStore,
+// suitable for single-instance deployments
+type MemoryStateStore struct {
+	mu        sync.Mutex
+	states    map[string]time.Time
+	maxStates int
+}
+
+// NewMemoryStateStore creates a new in-memory state store holding at most
+// DefaultMaxStates states and starts a background cleanup of expired states
+func NewMemoryStateStore() *MemoryStateStore {
+	return NewMemoryStateStoreWithLimit(DefaultMaxStates)
+}
+
+// NewMemoryStateStoreWithLimit creates a new in-memory state store holding at
+// most maxStates states, DefaultMaxStates when maxStates is not positive
+func NewMemoryStateStoreWithLimit(maxStates int) *MemoryStateStore {
+	if maxStates <= 0 {
+		maxStates = DefaultMaxStates
+	}
+	s := &MemoryStateStore{
+		states:    make(map[string]time.Time),
+		maxStates: maxStates,
+	}
+	go s.cleanup()
+	return s
+}
+
+// Save registers a state that stays valid for ttl. It fails with
+// ErrTooManyStates when the store is full even after dropping expired states,
+// which bounds the memory used by abandoned authorizations.
+func (s *MemoryStateStore) Save(_ context.Context, state string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.states) >= s.maxStates {
+		s.removeExpired(time.Now())
+		if len(s.states) >= s.maxStates {
+			return ErrTooManyStates
+		}
+	}
+	s.states[state] = time.Now().Add(ttl)
+	return nil
+}
+
+// Consume removes a state, reporting whether it existed and had not expired
+func (s *MemoryStateStore) Consume(_ context.Context, state string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiry, ok := s.states[state]
+	if !ok {
+		return false, nil
+	}
+	delete(s.states, state)
+
+	return time.Now().Before(expiry), nil
+}
+
+func (s *MemoryStateStore) cleanup() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.mu.Lock()
+		s.removeExpired(time.Now())
+		s.mu.Unlock()
+	}
+}
+
+// removeExpired drops the states expired at now, the caller must hold mu
+func (s *MemoryStateStore) removeExpired(now time.Time) {
+	for state, expiry := range s.states {
+		if now.After(expiry) {
+			delete(s.states, state)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package oauth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitMemoryStateStoreSingleUse(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStateStore()
+
+	require.NoError(t, store.Save(ctx, "state-1", time.Minute))
+
+	ok, err := store.Consume(ctx, "state-1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = store.Consume(ctx, "state-1")
+	require.NoError(t, err)
+	assert.False(t, ok, "state must not be usable twice")
+
+	ok, err = store.Consume(ctx, "unknown")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestUnitMemoryStateStoreExpiry(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStateStore()
+
+	require.NoError(t, store.Save(ctx, "state-1", time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	ok, err := store.Consume(ctx, "state-1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestUnitMemoryStateStoreLimit(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStateStoreWithLimit(2)
+
+	require.NoError(t, store.Save(ctx, "state-1", time.Minute))
+	require.NoError(t, store.Save(ctx, "state-2", time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	// the expired state makes room
+	require.NoError(t, store.Save(ctx, "state-3", time.Minute))
+	assert.ErrorIs(t, store.Save(ctx, "state-4", time.Minute), ErrTooManyStates)
+
+	ok, err := store.Consume(ctx, "state-1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	require.NoError(t, store.Save(ctx, "state-4", time.Minute), "consumed states make room")
+}
@@ -2,6 +2,7 @@ package oauth
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 )
 
@@ -39,4 +40,20 @@ func (s *MemoryStorage) Get(userID string) (*TokenResponse, error) {
 	return token, nil
 }
 
+// All returns the tokens of every user, ordered by user ID
+func (s *MemoryStorage) All() ([]*TokenResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	userIDs := make([]string, 0, len(s.tokens))
+	for userID := range s.tokens {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
 
+	tokens := make([]*TokenResponse, 0, len(userIDs))
+	for _, userID := range userIDs {
+		tokens = append(tokens, s.tokens[userID])
+	}
+	return tokens, nil
+}
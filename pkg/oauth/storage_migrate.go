@@ -0,0 +1,39 @@
+package oauth
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MigrateStorage copies every token of from into to and returns how many were
+// copied. A user who already has a token in to keeps it, as it may come from a
+// newer authorization made after a previous run, so the migration is
+// idempotent and can safely be re-run, e.g. after a partial failure.
+// Tokens that cannot be copied do not stop the migration and are reported
+// together in the returned error.
+func MigrateStorage(from, to TokenStorage) (int, error) {
+	tokens, err := from.All()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list tokens: %w", err)
+	}
+
+	var (
+		migrated int
+		errs     []error
+	)
+	for _, token := range tokens {
+		if token == nil || token.UserID == "" {
+			errs = append(errs, errors.New("skipped token without user ID"))
+			continue
+		}
+		if existing, err := to.Get(token.UserID); err == nil && existing != nil {
+			continue
+		}
+		if err := to.Store(token.UserID, token); err != nil {
+			errs = append(errs, fmt.Errorf("failed to store token for user %s: %w", token.UserID, err))
+			continue
+		}
+		migrated++
+	}
+	return migrated, errors.Join(errs...)
+}
@@ -0,0 +1,95 @@
+package oauth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// failingStorage rejects tokens of the given users
+type failingStorage struct {
+	*MemoryStorage
+	reject map[string]bool
+}
+
+func (s *failingStorage) Store(userID string, token *TokenResponse) error {
+	if s.reject[userID] {
+		return errors.New("storage unavailable")
+	}
+	return s.MemoryStorage.Store(userID, token)
+}
+
+func TestUnitMemoryStorageAll(t *testing.T) {
+	s := NewMemoryStorage()
+	tokens, err := s.All()
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+
+	require.NoError(t, s.Store("U2", &TokenResponse{UserID: "U2", AccessToken: "xoxp-2"}))
+	require.NoError(t, s.Store("U1", &TokenResponse{UserID: "U1", AccessToken: "xoxp-1"}))
+
+	tokens, err = s.All()
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	assert.Equal(t, "U1", tokens[0].UserID)
+	assert.Equal(t, "U2", tokens[1].UserID)
+}
+
+func TestUnitMigrateStorage(t *testing.T) {
+	from := NewMemoryStorage()
+	require.NoError(t, from.Store("U1", &TokenResponse{UserID: "U1", TeamID: "T1", AccessToken: "xoxp-1", BotToken: "xoxb-1"}))
+	require.NoError(t, from.Store("U2", &TokenResponse{UserID: "U2", TeamID: "T1", AccessToken: "xoxp-2"}))
+
+	to := NewValidatingStorage(NewMemoryStorage(), true, zap.NewNop())
+	n, err := MigrateStorage(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	token, err := to.Get("U1")
+	require.NoError(t, err)
+	assert.Equal(t, "xoxb-1", token.BotToken)
+
+	n, err = MigrateStorage(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n, "re-running copies nothing")
+
+	all, err := to.All()
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func TestUnitMigrateStorageKeepsDestinationTokens(t *testing.T) {
+	from := NewMemoryStorage()
+	require.NoError(t, from.Store("U1", &TokenResponse{UserID: "U1", AccessToken: "xoxp-old"}))
+
+	to := NewMemoryStorage()
+	require.NoError(t, to.Store("U1", &TokenResponse{UserID: "U1", AccessToken: "xoxp-new"}))
+
+	n, err := MigrateStorage(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	token, err := to.Get("U1")
+	require.NoError(t, err)
+	assert.Equal(t, "xoxp-new", token.AccessToken)
+}
+
+func TestUnitMigrateStoragePartialFailure(t *testing.T) {
+	from := NewMemoryStorage()
+	require.NoError(t, from.Store("U1", &TokenResponse{UserID: "U1", AccessToken: "xoxp-1"}))
+	require.NoError(t, from.Store("U2", &TokenResponse{UserID: "U2", AccessToken: "xoxp-2"}))
+
+	to := &failingStorage{MemoryStorage: NewMemoryStorage(), reject: map[string]bool{"U1": true}}
+	n, err := MigrateStorage(from, to)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "U1")
+	assert.Equal(t, 1, n, "other tokens are still copied")
+
+	to.reject = nil
+	n, err = MigrateStorage(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n, "a re-run copies only the tokens that failed")
+}
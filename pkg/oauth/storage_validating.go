@@ -0,0 +1,95 @@
+package oauth
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+var (
+	userTokenPrefixes = []string{"xoxp-", "xoxe-", "xoxe.xoxp-"} // xoxe.xoxp- is used with token rotation
+	botTokenPrefixes  = []string{"xoxb-"}
+)
+
+// ValidatingStorage wraps a TokenStorage and checks token prefixes before storing,
+// to catch swapped user and bot tokens early instead of at the first API call
+type ValidatingStorage struct {
+	storage TokenStorage
+	strict  bool
+	logger  *zap.Logger
+}
+
+// NewValidatingStorage creates a token storage validating token prefixes.
+// Mismatches are logged as warnings, or rejected when strict is true.
+func NewValidatingStorage(storage TokenStorage, strict bool, logger *zap.Logger) *ValidatingStorage {
+	return &ValidatingStorage{
+		storage: storage,
+		strict:  strict,
+		logger:  logger,
+	}
+}
+
+// Store validates and saves a token for a user
+func (s *ValidatingStorage) Store(userID string, token *TokenResponse) error {
+	if err := ValidateTokenPrefixes(token); err != nil {
+		if s.strict {
+			s.logger.Error("Rejected OAuth token with unexpected prefix",
+				zap.String("userID", userID),
+				zap.Error(err),
+			)
+			return err
+		}
+		s.logger.Warn("OAuth token has unexpected prefix",
+			zap.String("userID", userID),
+			zap.Error(err),
+		)
+	}
+	return s.storage.Store(userID, token)
+}
+
+// Get retrieves a token for a user
+func (s *ValidatingStorage) Get(userID string) (*TokenResponse, error) {
+	return s.storage.Get(userID)
+}
+
+// All returns the tokens of every user
+func (s *ValidatingStorage) All() ([]*TokenResponse, error) {
+	return s.storage.All()
+}
+
+// ValidateTokenPrefixes checks that AccessToken, when present, is a user token
+// (xoxp-/xoxe-) and BotToken, when present, is a bot token (xoxb-)
+func ValidateTokenPrefixes(token *TokenResponse) error {
+	var problems []string
+	if token.AccessToken != "" && !hasAnyPrefix(token.AccessToken, userTokenPrefixes) {
+		problems = append(problems, fmt.Sprintf("access token should start with %s, got %s", strings.Join(userTokenPrefixes, " or "), tokenPrefix(token.AccessToken)))
+	}
+	if token.BotToken != "" && !hasAnyPrefix(token.BotToken, botTokenPrefixes) {
+		problems = append(problems, fmt.Sprintf("bot token should start with %s, got %s", strings.Join(botTokenPrefixes, " or "), tokenPrefix(token.BotToken)))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid token prefix: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenPrefix returns the token type prefix only, never the secret part
+func tokenPrefix(token string) string {
+	if token == "" {
+		return "empty token"
+	}
+	if i := strings.Index(token, "-"); i > 0 && i <= 5 {
+		return token[:i+1]
+	}
+	return "unknown format"
+}
@@ -0,0 +1,57 @@
+package oauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitValidateTokenPrefixes(t *testing.T) {
+	cases := []struct {
+		name    string
+		token   TokenResponse
+		wantErr string
+	}{
+		{"user token only", TokenResponse{AccessToken: "xoxp-1"}, ""},
+		{"rotating user token", TokenResponse{AccessToken: "xoxe.xoxp-1"}, ""},
+		{"bot token as user token", TokenResponse{AccessToken: "xoxb-1"}, "access token should start with xoxp-"},
+		{"expiring user token", TokenResponse{AccessToken: "xoxe-1"}, ""},
+		{"user and bot tokens", TokenResponse{AccessToken: "xoxp-1", BotToken: "xoxb-2"}, ""},
+		{"swapped tokens", TokenResponse{AccessToken: "xoxb-2", BotToken: "xoxp-1"}, "bot token should start with xoxb-, got xoxp-"},
+		{"bot token only", TokenResponse{BotToken: "xoxb-2"}, ""},
+		{"bot token only with a user token as bot token", TokenResponse{BotToken: "xoxp-1"}, "bot token should start with xoxb-, got xoxp-"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateTokenPrefixes(&tc.token)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+			assert.NotContains(t, err.Error(), "-1", "token secrets must not leak into errors")
+		})
+	}
+}
+
+func TestUnitValidatingStorage(t *testing.T) {
+	swapped := &TokenResponse{AccessToken: "xoxb-2", BotToken: "xoxp-1"}
+
+	lenient := NewValidatingStorage(NewMemoryStorage(), false, zap.NewNop())
+	require.NoError(t, lenient.Store("U1", swapped))
+	stored, err := lenient.Get("U1")
+	require.NoError(t, err)
+	assert.Equal(t, swapped, stored)
+
+	strict := NewValidatingStorage(NewMemoryStorage(), true, zap.NewNop())
+	require.Error(t, strict.Store("U1", swapped))
+	_, err = strict.Get("U1")
+	assert.Error(t, err, "rejected token must not be stored")
+
+	botOnly := &TokenResponse{BotToken: "xoxb-2"}
+	require.NoError(t, strict.Store("U2", botOnly), "bot-only token responses have no access token to check")
+}
@@ -1,6 +1,9 @@
 package oauth
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // TokenResponse represents OAuth token response from Slack
 type TokenResponse struct {
@@ -9,6 +12,7 @@ type TokenResponse struct {
 	UserID         string    `json:"user_id"`
 	TeamID         string    `json:"team_id"`
 	BotUserID      string    `json:"bot_user_id"`       // Bot user ID - optional
+	BotScopes      []string  `json:"bot_scopes"`        // Scopes granted to the bot token - optional
 	ExpiresAt      time.Time `json:"expires_at"`
 }
 
@@ -33,6 +37,13 @@ type OAuthManager interface {
 	GetStoredToken(userID string) (*TokenResponse, error)
 }
 
+// CredentialProvider supplies the OAuth client secret on demand, e.g. from
+// Vault or AWS Secrets Manager, so it can rotate without a restart
+type CredentialProvider interface {
+	// ClientSecret returns the current client secret
+	ClientSecret(ctx context.Context) (string, error)
+}
+
 // TokenStorage stores and retrieves OAuth tokens
 type TokenStorage interface {
 	// Store saves a token for a user
@@ -40,5 +51,17 @@ type TokenStorage interface {
 
 	// Get retrieves a token for a user
 	Get(userID string) (*TokenResponse, error)
+
+	// All returns the tokens of every user, e.g. to migrate them to another storage
+	All() ([]*TokenResponse, error)
 }
 
+// StateStore stores OAuth CSRF states between authorize and callback
+type StateStore interface {
+	// Save registers a state that stays valid for ttl
+	Save(ctx context.Context, state string, ttl time.Duration) error
+
+	// Consume atomically removes a state, reporting whether it existed and had not expired.
+	// A state can be consumed only once.
+	Consume(ctx context.Context, state string) (bool, error)
+}
@@ -4,12 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/korotovsky/slack-mcp-server/pkg/limiter"
 	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
 	"github.com/korotovsky/slack-mcp-server/pkg/transport"
 	"github.com/rusq/slackdump/v3/auth"
 	"github.com/slack-go/slack"
@@ -47,6 +53,7 @@ type Channel struct {
 	IsMpIM      bool     `json:"mpim"`
 	IsIM        bool     `json:"im"`
 	IsPrivate   bool     `json:"private"`
+	IsArchived  bool     `json:"archived,omitempty"`
 	User        string   `json:"user,omitempty"` // User ID for IM channels
 	Members     []string `json:"members,omitempty"` // Member IDs for the channel
 }
@@ -56,17 +63,68 @@ type SlackAPI interface {
 	AuthTest() (*slack.AuthTestResponse, error)
 	AuthTestContext(ctx context.Context) (*slack.AuthTestResponse, error)
 	GetUsersContext(ctx context.Context, options ...slack.GetUsersOption) ([]slack.User, error)
+	ListUsersContext(ctx context.Context, pageSize int, page func(users []slack.User) bool) error
 	GetUsersInfo(users ...string) (*[]slack.User, error)
+	GetUserByEmailContext(ctx context.Context, email string) (*slack.User, error)
+	GetUserInfoContext(ctx context.Context, user string) (*slack.User, error)
+	GetUserProfileContext(ctx context.Context, params *slack.GetUserProfileParameters) (*slack.UserProfile, error)
+	GetUserPresenceContext(ctx context.Context, user string) (*slack.UserPresence, error)
 	PostMessageContext(ctx context.Context, channel string, options ...slack.MsgOption) (string, string, error)
+	ScheduleMessageContext(ctx context.Context, channelID, postAt string, options ...slack.MsgOption) (string, string, error)
+	DeleteMessageContext(ctx context.Context, channel, messageTimestamp string) (string, string, error)
+	PostEphemeralContext(ctx context.Context, channelID, userID string, options ...slack.MsgOption) (string, error)
+	GetPermalinkContext(ctx context.Context, params *slack.PermalinkParameters) (string, error)
 	MarkConversationContext(ctx context.Context, channel, ts string) error
+	OpenConversationContext(ctx context.Context, params *slack.OpenConversationParameters) (*slack.Channel, bool, bool, error)
 
 	// Used to get messages
 	GetConversationHistoryContext(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error)
 	GetConversationRepliesContext(ctx context.Context, params *slack.GetConversationRepliesParameters) (msgs []slack.Message, hasMore bool, nextCursor string, err error)
 	SearchContext(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchMessages, *slack.SearchFiles, error)
+	SearchFilesContext(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchFiles, error)
+	GetReactionsContext(ctx context.Context, item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error)
+	AddReactionContext(ctx context.Context, name string, item slack.ItemRef) error
+	RemoveReactionContext(ctx context.Context, name string, item slack.ItemRef) error
+	ListPinsContext(ctx context.Context, channel string) ([]slack.Item, *slack.Paging, error)
+
+	// Used to get files
+	GetFileInfoContext(ctx context.Context, fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error)
+	GetFileContext(ctx context.Context, downloadURL string, writer io.Writer) error
+
+	// Used to upload files
+	UploadFileV2Context(ctx context.Context, params slack.UploadFileV2Parameters) (*slack.FileSummary, error)
 
 	// Used to get channels list from both Slack and Enterprise Grid versions
 	GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error)
+	GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error)
+	GetUsersInConversationContext(ctx context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error)
+
+	// Used to archive and unarchive channels
+	ArchiveConversationContext(ctx context.Context, channelID string) error
+	UnArchiveConversationContext(ctx context.Context, channelID string) error
+
+	// Used to create channels
+	CreateConversationContext(ctx context.Context, params slack.CreateConversationParams) (*slack.Channel, error)
+	SetTopicOfConversationContext(ctx context.Context, channelID, topic string) (*slack.Channel, error)
+	SetPurposeOfConversationContext(ctx context.Context, channelID, purpose string) (*slack.Channel, error)
+	InviteUsersToConversationContext(ctx context.Context, channelID string, users ...string) (*slack.Channel, error)
+
+	// Used to join and leave channels
+	JoinConversationContext(ctx context.Context, channelID string) (*slack.Channel, string, []string, error)
+	LeaveConversationContext(ctx context.Context, channelID string) (bool, error)
+
+	// Used to rename channels
+	RenameConversationContext(ctx context.Context, channelID, channelName string) (*slack.Channel, error)
+
+	// Used to list and create reminders of the authenticated user
+	ListRemindersContext(ctx context.Context) ([]*slack.Reminder, error)
+	AddUserReminderContext(ctx context.Context, userID, text, time string) (*slack.Reminder, error)
+	GetUserGroupsContext(ctx context.Context, options ...slack.GetUserGroupsOption) ([]slack.UserGroup, error)
+	GetUserGroupMembersContext(ctx context.Context, userGroup string, options ...slack.GetUserGroupMembersOption) ([]string, error)
+	UpdateUserGroupMembersContext(ctx context.Context, userGroup string, members string, options ...slack.UpdateUserGroupMembersOption) (slack.UserGroup, error)
+
+	// Used to call allowlisted read methods without a dedicated wrapper
+	CallMethodContext(ctx context.Context, method string, params url.Values) (json.RawMessage, error)
 
 	// Edge API methods
 	ClientUserBoot(ctx context.Context) (*edge.ClientUserBootResponse, error)
@@ -75,6 +133,7 @@ type SlackAPI interface {
 type MCPSlackClient struct {
 	slackClient *slack.Client
 	edgeClient  *edge.Client
+	httpClient  *http.Client
 
 	authResponse *slack.AuthTestResponse
 	authProvider auth.Provider
@@ -90,16 +149,38 @@ type ApiProvider struct {
 	logger    *zap.Logger
 
 	rateLimiter *rate.Limiter
-
-	users      map[string]slack.User
-	usersInv   map[string]string
-	usersCache string
-	usersReady bool
-
+	backoff     BackoffPolicy
+
+	// users and usersInv are replaced as a whole under usersMu, never updated
+	// in place; usersMu also guards usersReady and usersTruncated
+	usersMu        sync.RWMutex
+	users          map[string]slack.User
+	usersInv       map[string]string
+	usersCache     string
+	usersReady     bool
+	usersTruncated bool // users.list had more users than SLACK_MCP_USERS_MAX
+	usersWarm      UsersWarmConfig
+	usersWarmOnce  sync.Once
+
+	// users looked up by email, keyed by lowercase email
+	usersByEmailMu sync.RWMutex
+	usersByEmail   map[string]slack.User
+
+	// channels and channelsInv are replaced as a whole under channelsMu, never
+	// updated in place; channelsMu also guards channelsReady
+	channelsMu    sync.RWMutex
 	channels      map[string]Channel
 	channelsInv   map[string]string
 	channelsCache string
 	channelsReady bool
+
+	// channelsBuild is the channels cache build in progress, if any
+	channelsBuildMu sync.Mutex
+	channelsBuild   *channelsBuild
+
+	// identity of the token from auth.test, nil until resolved or after an auth error
+	identityMu sync.RWMutex
+	identity   *Identity
 }
 
 func NewMCPSlackClient(authProvider auth.Provider, logger *zap.Logger) (*MCPSlackClient, error) {
@@ -141,6 +222,7 @@ func NewMCPSlackClient(authProvider auth.Provider, logger *zap.Logger) (*MCPSlac
 	return &MCPSlackClient{
 		slackClient:  slackClient,
 		edgeClient:   edgeClient,
+		httpClient:   httpClient,
 		authResponse: authResponse,
 		authProvider: authProvider,
 		isEnterprise: isEnterprise,
@@ -149,7 +231,8 @@ func NewMCPSlackClient(authProvider auth.Provider, logger *zap.Logger) (*MCPSlac
 	}, nil
 }
 
-func (c *MCPSlackClient) AuthTest() (*slack.AuthTestResponse, error) {
+// demoAuthResponse is the identity of the demo tokens, nil for real tokens
+func demoAuthResponse() *slack.AuthTestResponse {
 	if os.Getenv("SLACK_MCP_XOXP_TOKEN") == "demo" || (os.Getenv("SLACK_MCP_XOXC_TOKEN") == "demo" && os.Getenv("SLACK_MCP_XOXD_TOKEN") == "demo") {
 		return &slack.AuthTestResponse{
 			URL:          "https://_.slack.com",
@@ -159,7 +242,16 @@ func (c *MCPSlackClient) AuthTest() (*slack.AuthTestResponse, error) {
 			UserID:       "U1234567890",
 			EnterpriseID: "",
 			BotID:        "",
-		}, nil
+		}
+	}
+	return nil
+}
+
+// AuthTest returns the auth.test response of the construction of the client,
+// use AuthTestContext to test the token again
+func (c *MCPSlackClient) AuthTest() (*slack.AuthTestResponse, error) {
+	if demo := demoAuthResponse(); demo != nil {
+		return demo, nil
 	}
 
 	if c.authResponse != nil {
@@ -169,7 +261,11 @@ func (c *MCPSlackClient) AuthTest() (*slack.AuthTestResponse, error) {
 	return c.slackClient.AuthTest()
 }
 
+// AuthTestContext calls auth.test on Slack
 func (c *MCPSlackClient) AuthTestContext(ctx context.Context) (*slack.AuthTestResponse, error) {
+	if demo := demoAuthResponse(); demo != nil {
+		return demo, nil
+	}
 	return c.slackClient.AuthTestContext(ctx)
 }
 
@@ -177,14 +273,62 @@ func (c *MCPSlackClient) GetUsersContext(ctx context.Context, options ...slack.G
 	return c.slackClient.GetUsersContext(ctx, options...)
 }
 
+// ListUsersContext pages through users.list, calling page with every page until
+// it returns false. Rate limited pages are retried after the delay Slack asks for.
+func (c *MCPSlackClient) ListUsersContext(ctx context.Context, pageSize int, page func(users []slack.User) bool) error {
+	p := c.slackClient.GetUsersPaginated(slack.GetUsersOptionLimit(pageSize))
+	for {
+		next, err := p.Next(ctx)
+		var rateLimited *slack.RateLimitedError
+		if errors.As(err, &rateLimited) {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(rateLimited.RetryAfter):
+			}
+			continue
+		}
+		if next.Done(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		p = next
+		if !page(p.Users) {
+			return nil
+		}
+	}
+}
+
 func (c *MCPSlackClient) GetUsersInfo(users ...string) (*[]slack.User, error) {
 	return c.slackClient.GetUsersInfo(users...)
 }
 
+func (c *MCPSlackClient) GetUserByEmailContext(ctx context.Context, email string) (*slack.User, error) {
+	return c.slackClient.GetUserByEmailContext(ctx, email)
+}
+
+func (c *MCPSlackClient) GetUserInfoContext(ctx context.Context, user string) (*slack.User, error) {
+	return c.slackClient.GetUserInfoContext(ctx, user)
+}
+
+func (c *MCPSlackClient) GetUserProfileContext(ctx context.Context, params *slack.GetUserProfileParameters) (*slack.UserProfile, error) {
+	return c.slackClient.GetUserProfileContext(ctx, params)
+}
+
+func (c *MCPSlackClient) GetUserPresenceContext(ctx context.Context, user string) (*slack.UserPresence, error) {
+	return c.slackClient.GetUserPresenceContext(ctx, user)
+}
+
 func (c *MCPSlackClient) MarkConversationContext(ctx context.Context, channel, ts string) error {
 	return c.slackClient.MarkConversationContext(ctx, channel, ts)
 }
 
+func (c *MCPSlackClient) OpenConversationContext(ctx context.Context, params *slack.OpenConversationParameters) (*slack.Channel, bool, bool, error) {
+	return c.slackClient.OpenConversationContext(ctx, params)
+}
+
 func (c *MCPSlackClient) GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
 	// Please see https://github.com/korotovsky/slack-mcp-server/issues/73
 	// It seems that `conversations.list` works with `xoxp` tokens within Enterprise Grid setups
@@ -242,6 +386,50 @@ func (c *MCPSlackClient) GetConversationsContext(ctx context.Context, params *sl
 	return c.slackClient.GetConversationsContext(ctx, params)
 }
 
+func (c *MCPSlackClient) GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	return c.slackClient.GetConversationInfoContext(ctx, input)
+}
+
+func (c *MCPSlackClient) GetUsersInConversationContext(ctx context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+	return c.slackClient.GetUsersInConversationContext(ctx, params)
+}
+
+func (c *MCPSlackClient) ArchiveConversationContext(ctx context.Context, channelID string) error {
+	return c.slackClient.ArchiveConversationContext(ctx, channelID)
+}
+
+func (c *MCPSlackClient) UnArchiveConversationContext(ctx context.Context, channelID string) error {
+	return c.slackClient.UnArchiveConversationContext(ctx, channelID)
+}
+
+func (c *MCPSlackClient) CreateConversationContext(ctx context.Context, params slack.CreateConversationParams) (*slack.Channel, error) {
+	return c.slackClient.CreateConversationContext(ctx, params)
+}
+
+func (c *MCPSlackClient) SetTopicOfConversationContext(ctx context.Context, channelID, topic string) (*slack.Channel, error) {
+	return c.slackClient.SetTopicOfConversationContext(ctx, channelID, topic)
+}
+
+func (c *MCPSlackClient) SetPurposeOfConversationContext(ctx context.Context, channelID, purpose string) (*slack.Channel, error) {
+	return c.slackClient.SetPurposeOfConversationContext(ctx, channelID, purpose)
+}
+
+func (c *MCPSlackClient) InviteUsersToConversationContext(ctx context.Context, channelID string, users ...string) (*slack.Channel, error) {
+	return c.slackClient.InviteUsersToConversationContext(ctx, channelID, users...)
+}
+
+func (c *MCPSlackClient) RenameConversationContext(ctx context.Context, channelID, channelName string) (*slack.Channel, error) {
+	return c.slackClient.RenameConversationContext(ctx, channelID, channelName)
+}
+
+func (c *MCPSlackClient) JoinConversationContext(ctx context.Context, channelID string) (*slack.Channel, string, []string, error) {
+	return c.slackClient.JoinConversationContext(ctx, channelID)
+}
+
+func (c *MCPSlackClient) LeaveConversationContext(ctx context.Context, channelID string) (bool, error) {
+	return c.slackClient.LeaveConversationContext(ctx, channelID)
+}
+
 func (c *MCPSlackClient) GetConversationHistoryContext(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
 	return c.slackClient.GetConversationHistoryContext(ctx, params)
 }
@@ -254,10 +442,78 @@ func (c *MCPSlackClient) SearchContext(ctx context.Context, query string, params
 	return c.slackClient.SearchContext(ctx, query, params)
 }
 
+func (c *MCPSlackClient) SearchFilesContext(ctx context.Context, query string, params slack.SearchParameters) (*slack.SearchFiles, error) {
+	return c.slackClient.SearchFilesContext(ctx, query, params)
+}
+
+func (c *MCPSlackClient) GetReactionsContext(ctx context.Context, item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error) {
+	return c.slackClient.GetReactionsContext(ctx, item, params)
+}
+
+func (c *MCPSlackClient) AddReactionContext(ctx context.Context, name string, item slack.ItemRef) error {
+	return c.slackClient.AddReactionContext(ctx, name, item)
+}
+
+func (c *MCPSlackClient) RemoveReactionContext(ctx context.Context, name string, item slack.ItemRef) error {
+	return c.slackClient.RemoveReactionContext(ctx, name, item)
+}
+
+func (c *MCPSlackClient) ListPinsContext(ctx context.Context, channel string) ([]slack.Item, *slack.Paging, error) {
+	return c.slackClient.ListPinsContext(ctx, channel)
+}
+
+func (c *MCPSlackClient) ListRemindersContext(ctx context.Context) ([]*slack.Reminder, error) {
+	return c.slackClient.ListRemindersContext(ctx)
+}
+
+func (c *MCPSlackClient) AddUserReminderContext(ctx context.Context, userID, text, time string) (*slack.Reminder, error) {
+	return c.slackClient.AddUserReminderContext(ctx, userID, text, time)
+}
+
+func (c *MCPSlackClient) GetUserGroupsContext(ctx context.Context, options ...slack.GetUserGroupsOption) ([]slack.UserGroup, error) {
+	return c.slackClient.GetUserGroupsContext(ctx, options...)
+}
+
+func (c *MCPSlackClient) GetUserGroupMembersContext(ctx context.Context, userGroup string, options ...slack.GetUserGroupMembersOption) ([]string, error) {
+	return c.slackClient.GetUserGroupMembersContext(ctx, userGroup, options...)
+}
+
+func (c *MCPSlackClient) UpdateUserGroupMembersContext(ctx context.Context, userGroup string, members string, options ...slack.UpdateUserGroupMembersOption) (slack.UserGroup, error) {
+	return c.slackClient.UpdateUserGroupMembersContext(ctx, userGroup, members, options...)
+}
+
+func (c *MCPSlackClient) GetFileInfoContext(ctx context.Context, fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error) {
+	return c.slackClient.GetFileInfoContext(ctx, fileID, count, page)
+}
+
+func (c *MCPSlackClient) GetFileContext(ctx context.Context, downloadURL string, writer io.Writer) error {
+	return c.slackClient.GetFileContext(ctx, downloadURL, writer)
+}
+
+func (c *MCPSlackClient) UploadFileV2Context(ctx context.Context, params slack.UploadFileV2Parameters) (*slack.FileSummary, error) {
+	return c.slackClient.UploadFileV2Context(ctx, params)
+}
+
 func (c *MCPSlackClient) PostMessageContext(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
 	return c.slackClient.PostMessageContext(ctx, channelID, options...)
 }
 
+func (c *MCPSlackClient) ScheduleMessageContext(ctx context.Context, channelID, postAt string, options ...slack.MsgOption) (string, string, error) {
+	return c.slackClient.ScheduleMessageContext(ctx, channelID, postAt, options...)
+}
+
+func (c *MCPSlackClient) DeleteMessageContext(ctx context.Context, channel, messageTimestamp string) (string, string, error) {
+	return c.slackClient.DeleteMessageContext(ctx, channel, messageTimestamp)
+}
+
+func (c *MCPSlackClient) PostEphemeralContext(ctx context.Context, channelID, userID string, options ...slack.MsgOption) (string, error) {
+	return c.slackClient.PostEphemeralContext(ctx, channelID, userID, options...)
+}
+
+func (c *MCPSlackClient) GetPermalinkContext(ctx context.Context, params *slack.PermalinkParameters) (string, error) {
+	return c.slackClient.GetPermalinkContext(ctx, params)
+}
+
 func (c *MCPSlackClient) ClientUserBoot(ctx context.Context) (*edge.ClientUserBootResponse, error) {
 	return c.edgeClient.ClientUserBoot(ctx)
 }
@@ -347,10 +603,12 @@ func newWithXOXP(transport string, authProvider auth.ValueAuth, logger *zap.Logg
 		logger:    logger,
 
 		rateLimiter: limiter.Tier2.Limiter(),
+		backoff:     DefaultBackoffPolicy(),
 
 		users:      make(map[string]slack.User),
 		usersInv:   map[string]string{},
 		usersCache: usersCache,
+		usersWarm:  UsersWarmConfigFromEnv(logger),
 
 		channels:      make(map[string]Channel),
 		channelsInv:   map[string]string{},
@@ -389,10 +647,12 @@ func newWithXOXC(transport string, authProvider auth.ValueAuth, logger *zap.Logg
 		logger:    logger,
 
 		rateLimiter: limiter.Tier2.Limiter(),
+		backoff:     DefaultBackoffPolicy(),
 
 		users:      make(map[string]slack.User),
 		usersInv:   map[string]string{},
 		usersCache: usersCache,
+		usersWarm:  UsersWarmConfigFromEnv(logger),
 
 		channels:      make(map[string]Channel),
 		channelsInv:   map[string]string{},
@@ -400,91 +660,160 @@ func newWithXOXC(transport string, authProvider auth.ValueAuth, logger *zap.Logg
 	}
 }
 
-func (ap *ApiProvider) RefreshUsers(ctx context.Context) error {
-	var (
-		list         []slack.User
-		usersCounter = 0
-		optionLimit  = slack.GetUsersOptionLimit(1000)
-	)
+// NewWithClient creates a provider on top of an already constructed Slack API client
+func NewWithClient(transport string, client SlackAPI, logger *zap.Logger) *ApiProvider {
+	return &ApiProvider{
+		transport: transport,
+		client:    client,
+		logger:    logger,
 
-	if data, err := ioutil.ReadFile(ap.usersCache); err == nil {
-		var cachedUsers []slack.User
-		if err := json.Unmarshal(data, &cachedUsers); err != nil {
-			ap.logger.Warn("Failed to unmarshal users cache, will refetch",
-				zap.String("cache_file", ap.usersCache),
-				zap.Error(err))
-		} else {
-			for _, u := range cachedUsers {
-				ap.users[u.ID] = u
-				ap.usersInv[u.Name] = u.ID
+		rateLimiter: limiter.Tier2.Limiter(),
+		backoff:     DefaultBackoffPolicy(),
+
+		users:     make(map[string]slack.User),
+		usersInv:  map[string]string{},
+		usersWarm: DefaultUsersWarmConfig(),
+
+		channels:    make(map[string]Channel),
+		channelsInv: map[string]string{},
+	}
+}
+
+func (ap *ApiProvider) RefreshUsers(ctx context.Context) error {
+	logger := ap.requestLogger(ctx)
+	if ap.usersCacheFresh() {
+		if data, err := ioutil.ReadFile(ap.usersCache); err == nil {
+			var cachedUsers []slack.User
+			if err := json.Unmarshal(data, &cachedUsers); err != nil {
+				logger.Warn("Failed to unmarshal users cache, will refetch",
+					zap.String("cache_file", ap.usersCache),
+					zap.Error(err))
+			} else {
+				ap.setUsers(cachedUsers)
+				logger.Info("Loaded users from cache",
+					zap.Int("count", len(cachedUsers)),
+					zap.String("cache_file", ap.usersCache))
+				ap.setUsersReady(false)
+				return nil
 			}
-			ap.logger.Info("Loaded users from cache",
-				zap.Int("count", len(cachedUsers)),
-				zap.String("cache_file", ap.usersCache))
-			ap.usersReady = true
-			return nil
 		}
 	}
 
-	users, err := ap.client.GetUsersContext(ctx,
-		optionLimit,
-	)
-	if err != nil {
-		ap.logger.Error("Failed to fetch users", zap.Error(err))
-		return err
-	} else {
-		list = append(list, users...)
-	}
+	return ap.fetchUsers(ctx)
+}
 
-	for _, user := range users {
-		ap.users[user.ID] = user
-		ap.usersInv[user.Name] = user.ID
-		usersCounter++
+// usersCacheFresh reports whether the users cache file may be used, which it
+// may until it is older than SLACK_MCP_USERS_CACHE_TTL
+func (ap *ApiProvider) usersCacheFresh() bool {
+	if ap.usersWarm.TTL <= 0 {
+		return true
 	}
+	info, err := os.Stat(ap.usersCache)
+	return err == nil && time.Since(info.ModTime()) < ap.usersWarm.TTL
+}
 
-	users, err = ap.GetSlackConnect(ctx)
+// fetchUsers fetches users from Slack, replaces the users cache and writes
+// the users cache file
+func (ap *ApiProvider) fetchUsers(ctx context.Context) error {
+	logger := ap.requestLogger(ctx)
+	list, truncated, err := ap.listUsers(ctx)
 	if err != nil {
-		ap.logger.Error("Failed to fetch users from Slack Connect", zap.Error(err))
+		logger.Error("Failed to fetch users", zap.Error(err))
 		return err
-	} else {
-		list = append(list, users...)
 	}
+	if truncated {
+		logger.Warn("Users cache is capped by SLACK_MCP_USERS_MAX, other users are resolved on demand",
+			zap.Int("max_users", ap.usersWarm.MaxUsers),
+		)
+	}
+	ap.setUsers(list)
 
-	for _, user := range users {
-		ap.users[user.ID] = user
-		ap.usersInv[user.Name] = user.ID
-		usersCounter++
+	users, err := ap.GetSlackConnect(ctx)
+	if err != nil {
+		logger.Error("Failed to fetch users from Slack Connect", zap.Error(err))
+		return err
 	}
+	ap.addUsers(users)
+	list = append(list, users...)
 
 	if data, err := json.MarshalIndent(list, "", "  "); err != nil {
-		ap.logger.Error("Failed to marshal users for cache", zap.Error(err))
+		logger.Error("Failed to marshal users for cache", zap.Error(err))
 	} else {
 		if err := ioutil.WriteFile(ap.usersCache, data, 0644); err != nil {
-			ap.logger.Error("Failed to write cache file",
+			logger.Error("Failed to write cache file",
 				zap.String("cache_file", ap.usersCache),
 				zap.Error(err))
 		} else {
-			ap.logger.Info("Wrote users to cache",
-				zap.Int("count", usersCounter),
+			logger.Info("Wrote users to cache",
+				zap.Int("count", len(list)),
 				zap.String("cache_file", ap.usersCache))
 		}
 	}
 
-	ap.usersReady = true
+	ap.setUsersReady(truncated)
 
 	return nil
 }
 
+// requestLogger returns the logger with the request ID of ctx, so that Slack
+// calls made while serving a tool call are logged with it
+func (ap *ApiProvider) requestLogger(ctx context.Context) *zap.Logger {
+	if id := requestid.FromContext(ctx); id != "" {
+		return ap.logger.With(zap.String("request_id", id))
+	}
+	return ap.logger
+}
+
+// RefreshChannels builds the channels cache from the cache file or Slack.
+// Concurrent callers share a single build and its result, so that tool calls
+// arriving together at startup do not each fetch every channel from Slack.
+// The build is detached from the caller that started it, every caller stops
+// waiting for it when its own ctx is done.
 func (ap *ApiProvider) RefreshChannels(ctx context.Context) error {
+	ap.channelsBuildMu.Lock()
+	b := ap.channelsBuild
+	if b == nil {
+		b = &channelsBuild{done: make(chan struct{})}
+		ap.channelsBuild = b
+		go ap.runChannelsBuild(context.WithoutCancel(ctx), b)
+	}
+	ap.channelsBuildMu.Unlock()
+
+	select {
+	case <-b.done:
+		return b.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// runChannelsBuild runs a shared build of the channels cache, bounded by
+// channelsBuildTimeout as no caller can cancel it
+func (ap *ApiProvider) runChannelsBuild(ctx context.Context, b *channelsBuild) {
+	ctx, cancel := context.WithTimeout(ctx, channelsBuildTimeout)
+	defer cancel()
+
+	b.err = ap.buildChannels(ctx)
+
+	ap.channelsBuildMu.Lock()
+	ap.channelsBuild = nil
+	ap.channelsBuildMu.Unlock()
+	close(b.done)
+}
+
+func (ap *ApiProvider) buildChannels(ctx context.Context) error {
+	logger := ap.requestLogger(ctx)
 	if data, err := ioutil.ReadFile(ap.channelsCache); err == nil {
 		var cachedChannels []Channel
 		if err := json.Unmarshal(data, &cachedChannels); err != nil {
-			ap.logger.Warn("Failed to unmarshal channels cache, will refetch",
+			logger.Warn("Failed to unmarshal channels cache, will refetch",
 				zap.String("cache_file", ap.channelsCache),
 				zap.Error(err))
 		} else {
 			// Re-map channels with current users cache to ensure DM names are populated
 			usersMap := ap.ProvideUsersMap().Users
+			channels := make(map[string]Channel, len(cachedChannels))
+			channelsInv := make(map[string]string, len(cachedChannels))
 			for _, c := range cachedChannels {
 				// For IM channels, re-generate the name and purpose using current users cache
 				if c.IsIM {
@@ -495,17 +824,19 @@ func (ap *ApiProvider) RefreshChannels(ctx context.Context) error {
 						c.IsIM, c.IsMpIM, c.IsPrivate,
 						usersMap,
 					)
-					ap.channels[c.ID] = remappedChannel
-					ap.channelsInv[remappedChannel.Name] = c.ID
+					remappedChannel.IsArchived = c.IsArchived
+					channels[c.ID] = remappedChannel
+					channelsInv[remappedChannel.Name] = c.ID
 				} else {
-					ap.channels[c.ID] = c
-					ap.channelsInv[c.Name] = c.ID
+					channels[c.ID] = c
+					channelsInv[c.Name] = c.ID
 				}
 			}
-			ap.logger.Info("Loaded channels from cache and re-mapped DM names",
+			ap.setChannels(channels, channelsInv)
+			logger.Info("Loaded channels from cache and re-mapped DM names",
 				zap.Int("count", len(cachedChannels)),
 				zap.String("cache_file", ap.channelsCache))
-			ap.channelsReady = true
+			ap.setChannelsReady()
 			return nil
 		}
 	}
@@ -513,38 +844,90 @@ func (ap *ApiProvider) RefreshChannels(ctx context.Context) error {
 	channels := ap.GetChannels(ctx, AllChanTypes)
 
 	if data, err := json.MarshalIndent(channels, "", "  "); err != nil {
-		ap.logger.Error("Failed to marshal channels for cache", zap.Error(err))
+		logger.Error("Failed to marshal channels for cache", zap.Error(err))
 	} else {
 		if err := ioutil.WriteFile(ap.channelsCache, data, 0644); err != nil {
-			ap.logger.Error("Failed to write cache file",
+			logger.Error("Failed to write cache file",
 				zap.String("cache_file", ap.channelsCache),
 				zap.Error(err))
 		} else {
-			ap.logger.Info("Wrote channels to cache",
+			logger.Info("Wrote channels to cache",
 				zap.Int("count", len(channels)),
 				zap.String("cache_file", ap.channelsCache))
 		}
 	}
 
-	ap.channelsReady = true
+	ap.setChannelsReady()
 
 	return nil
 }
 
+// channelsBuildTimeout bounds a build of the channels cache, listing every
+// channel of a large workspace takes many rate limited pages
+const channelsBuildTimeout = 10 * time.Minute
+
+// channelsBuild is a build of the channels cache that concurrent
+// RefreshChannels callers wait for
+type channelsBuild struct {
+	done chan struct{}
+	err  error
+}
+
+// setChannels replaces the channels cache. The maps are swapped rather than
+// updated so that callers of ProvideChannelsMaps can keep reading the previous ones.
+func (ap *ApiProvider) setChannels(channels map[string]Channel, channelsInv map[string]string) {
+	ap.channelsMu.Lock()
+	defer ap.channelsMu.Unlock()
+	ap.channels = channels
+	ap.channelsInv = channelsInv
+}
+
+// mergeChannels adds fetched channels to the channels cache, replacing cached
+// ones with the same ID. The merge holds channelsMu so that concurrent
+// updateChannel calls are not lost, and swaps the maps as setChannels does.
+func (ap *ApiProvider) mergeChannels(chans []Channel) map[string]Channel {
+	ap.channelsMu.Lock()
+	defer ap.channelsMu.Unlock()
+
+	channels := make(map[string]Channel, len(ap.channels)+len(chans))
+	channelsInv := make(map[string]string, len(ap.channelsInv)+len(chans))
+	for id, ch := range ap.channels {
+		channels[id] = ch
+	}
+	for name, id := range ap.channelsInv {
+		channelsInv[name] = id
+	}
+	for _, ch := range chans {
+		channels[ch.ID] = ch
+		channelsInv[ch.Name] = ch.ID
+	}
+	ap.channels = channels
+	ap.channelsInv = channelsInv
+	return channels
+}
+
+func (ap *ApiProvider) setChannelsReady() {
+	ap.channelsMu.Lock()
+	defer ap.channelsMu.Unlock()
+	ap.channelsReady = true
+}
+
 func (ap *ApiProvider) GetSlackConnect(ctx context.Context) ([]slack.User, error) {
+	logger := ap.requestLogger(ctx)
 	boot, err := ap.client.ClientUserBoot(ctx)
 	if err != nil {
-		ap.logger.Error("Failed to fetch client user boot", zap.Error(err))
+		logger.Error("Failed to fetch client user boot", zap.Error(err))
 		return nil, err
 	}
 
 	var collectedIDs []string
+	users := ap.ProvideUsersMap().Users
 	for _, im := range boot.IMs {
 		if !im.IsShared && !im.IsExtShared {
 			continue
 		}
 
-		_, ok := ap.users[im.User]
+		_, ok := users[im.User]
 		if !ok {
 			collectedIDs = append(collectedIDs, im.User)
 		}
@@ -554,7 +937,7 @@ func (ap *ApiProvider) GetSlackConnect(ctx context.Context) ([]slack.User, error
 	if len(collectedIDs) > 0 {
 		usersInfo, err := ap.client.GetUsersInfo(strings.Join(collectedIDs, ","))
 		if err != nil {
-			ap.logger.Error("Failed to fetch users info for shared IMs", zap.Error(err))
+			logger.Error("Failed to fetch users info for shared IMs", zap.Error(err))
 			return nil, err
 		}
 
@@ -566,11 +949,14 @@ func (ap *ApiProvider) GetSlackConnect(ctx context.Context) ([]slack.User, error
 	return res, nil
 }
 
+// GetChannelsType lists all channels of the given type, archived ones
+// included, so that historical channels can be looked up by name
 func (ap *ApiProvider) GetChannelsType(ctx context.Context, channelType string) []Channel {
+	logger := ap.requestLogger(ctx)
 	params := &slack.GetConversationsParameters{
 		Types:           []string{channelType},
 		Limit:           999,
-		ExcludeArchived: true,
+		ExcludeArchived: false,
 	}
 
 	var (
@@ -583,17 +969,17 @@ func (ap *ApiProvider) GetChannelsType(ctx context.Context, channelType string)
 
 	for {
 		if err := ap.rateLimiter.Wait(ctx); err != nil {
-			ap.logger.Error("Rate limiter wait failed", zap.Error(err))
+			logger.Error("Rate limiter wait failed", zap.Error(err))
 			return nil
 		}
 
-		channels, nextcur, err = ap.client.GetConversationsContext(ctx, params)
-		ap.logger.Debug("Fetched channels for ",
+		channels, nextcur, err = getConversationsWithRetry(ctx, ap.client, params, ap.backoff)
+		logger.Debug("Fetched channels for ",
 			zap.String("channelType", channelType),
 			zap.Int("count", len(channels)),
 		)
 		if err != nil {
-			ap.logger.Error("Failed to fetch channels", zap.Error(err))
+			logger.Error("Failed to fetch channels", zap.Error(err))
 			break
 		}
 
@@ -612,6 +998,7 @@ func (ap *ApiProvider) GetChannelsType(ctx context.Context, channelType string)
 				channel.IsPrivate,
 				ap.ProvideUsersMap().Users,
 			)
+			ch.IsArchived = channel.IsArchived
 			chans = append(chans, ch)
 		}
 
@@ -635,14 +1022,11 @@ func (ap *ApiProvider) GetChannels(ctx context.Context, channelTypes []string) [
 		chans = append(chans, typeChannels...)
 	}
 
-	for _, ch := range chans {
-		ap.channels[ch.ID] = ch
-		ap.channelsInv[ch.Name] = ch.ID
-	}
+	channels := ap.mergeChannels(chans)
 
 	var res []Channel
 	for _, t := range channelTypes {
-		for _, channel := range ap.channels {
+		for _, channel := range channels {
 			if t == "public_channel" && !channel.IsPrivate {
 				res = append(res, channel)
 			}
@@ -662,24 +1046,206 @@ func (ap *ApiProvider) GetChannels(ctx context.Context, channelTypes []string) [
 }
 
 func (ap *ApiProvider) ProvideUsersMap() *UsersCache {
+	ap.usersMu.RLock()
+	defer ap.usersMu.RUnlock()
 	return &UsersCache{
 		Users:    ap.users,
 		UsersInv: ap.usersInv,
 	}
 }
 
+// UserByEmail returns the user with the given email from the users cache,
+// matching emails case-insensitively
+func (ap *ApiProvider) UserByEmail(email string) (slack.User, bool) {
+	email = strings.ToLower(email)
+
+	ap.usersByEmailMu.RLock()
+	user, ok := ap.usersByEmail[email]
+	ap.usersByEmailMu.RUnlock()
+	if ok {
+		return user, true
+	}
+
+	// emails are part of the synced users when the token has users:read.email
+	for _, u := range ap.ProvideUsersMap().Users {
+		if u.Profile.Email != "" && strings.ToLower(u.Profile.Email) == email {
+			return u, true
+		}
+	}
+	return slack.User{}, false
+}
+
+// CacheUserByEmail adds a user looked up by email to the users cache
+func (ap *ApiProvider) CacheUserByEmail(email string, user slack.User) {
+	ap.usersByEmailMu.Lock()
+	defer ap.usersByEmailMu.Unlock()
+	if ap.usersByEmail == nil {
+		ap.usersByEmail = make(map[string]slack.User)
+	}
+	ap.usersByEmail[strings.ToLower(email)] = user
+}
+
 func (ap *ApiProvider) ProvideChannelsMaps() *ChannelsCache {
+	ap.channelsMu.RLock()
+	defer ap.channelsMu.RUnlock()
 	return &ChannelsCache{
 		Channels:    ap.channels,
 		ChannelsInv: ap.channelsInv,
 	}
 }
 
+// SetBackoffPolicy replaces the policy with which failed Slack calls are
+// retried, DefaultBackoffPolicy unless set. It must be called before the
+// provider is used.
+func (ap *ApiProvider) SetBackoffPolicy(policy BackoffPolicy) {
+	ap.backoff = policy
+}
+
+// BackoffPolicy returns the policy with which failed Slack calls are retried
+func (ap *ApiProvider) BackoffPolicy() BackoffPolicy {
+	return ap.backoff
+}
+
+// SetChannelMemberCount updates the member count of a cached channel,
+// reporting whether the channel is in the cache
+func (ap *ApiProvider) SetChannelMemberCount(channelID string, count int) bool {
+	return ap.updateChannel(channelID, func(ch *Channel) {
+		ch.MemberCount = count
+	})
+}
+
+// SetChannelArchived updates the archived flag of a cached channel,
+// reporting whether the channel is in the cache
+func (ap *ApiProvider) SetChannelArchived(channelID string, archived bool) bool {
+	return ap.updateChannel(channelID, func(ch *Channel) {
+		ch.IsArchived = archived
+	})
+}
+
+// SetChannelTopic updates the topic of a cached channel,
+// reporting whether the channel is in the cache
+func (ap *ApiProvider) SetChannelTopic(channelID, topic string) bool {
+	return ap.updateChannel(channelID, func(ch *Channel) {
+		ch.Topic = topic
+	})
+}
+
+// SetChannelPurpose updates the purpose of a cached channel,
+// reporting whether the channel is in the cache
+func (ap *ApiProvider) SetChannelPurpose(channelID, purpose string) bool {
+	return ap.updateChannel(channelID, func(ch *Channel) {
+		ch.Purpose = purpose
+	})
+}
+
+// RenameChannel updates the name of a cached channel and its entry in the
+// name index, reporting whether the channel is in the cache
+func (ap *ApiProvider) RenameChannel(channelID, name string) bool {
+	name = "#" + strings.TrimPrefix(name, "#")
+
+	ap.channelsMu.Lock()
+	defer ap.channelsMu.Unlock()
+	ch, ok := ap.channels[channelID]
+	if !ok {
+		return false
+	}
+	oldName := ch.Name
+	ch.Name = name
+
+	channels := make(map[string]Channel, len(ap.channels))
+	for id, c := range ap.channels {
+		channels[id] = c
+	}
+	channelsInv := make(map[string]string, len(ap.channelsInv))
+	for n, id := range ap.channelsInv {
+		channelsInv[n] = id
+	}
+	channels[channelID] = ch
+	if channelsInv[oldName] == channelID {
+		delete(channelsInv, oldName)
+	}
+	channelsInv[name] = channelID
+	ap.channels = channels
+	ap.channelsInv = channelsInv
+	return true
+}
+
+// AddChannel adds a channel created after the channels cache was synced, so
+// that it can be referred to by name right away, and returns it as cached
+func (ap *ApiProvider) AddChannel(channel slack.Channel) Channel {
+	ch := mapChannel(
+		channel.ID,
+		channel.Name,
+		channel.NameNormalized,
+		channel.Topic.Value,
+		channel.Purpose.Value,
+		channel.User,
+		channel.Members,
+		channel.NumMembers,
+		channel.IsIM,
+		channel.IsMpIM,
+		channel.IsPrivate,
+		ap.ProvideUsersMap().Users,
+	)
+
+	ap.channelsMu.Lock()
+	defer ap.channelsMu.Unlock()
+	channels := make(map[string]Channel, len(ap.channels)+1)
+	for id, c := range ap.channels {
+		channels[id] = c
+	}
+	channelsInv := make(map[string]string, len(ap.channelsInv)+1)
+	for name, id := range ap.channelsInv {
+		channelsInv[name] = id
+	}
+	channels[ch.ID] = ch
+	channelsInv[ch.Name] = ch.ID
+	ap.channels = channels
+	ap.channelsInv = channelsInv
+	return ch
+}
+
+// updateChannel updates a cached channel in a copy of the channels cache, see setChannels
+func (ap *ApiProvider) updateChannel(channelID string, update func(ch *Channel)) bool {
+	ap.channelsMu.Lock()
+	defer ap.channelsMu.Unlock()
+	ch, ok := ap.channels[channelID]
+	if !ok {
+		return false
+	}
+	update(&ch)
+
+	channels := make(map[string]Channel, len(ap.channels))
+	for id, c := range ap.channels {
+		channels[id] = c
+	}
+	channels[channelID] = ch
+	ap.channels = channels
+	return true
+}
+
+// Workspace returns the workspace slug of the token, see Identity
+func (ap *ApiProvider) Workspace(ctx context.Context) (string, error) {
+	id, err := ap.Identity(ctx)
+	if err != nil {
+		return "", err
+	}
+	return id.Workspace, nil
+}
+
+// IsReady reports whether the caches are warm. Users warmed lazily are
+// resolved on demand, so they do not hold back readiness.
 func (ap *ApiProvider) IsReady() (bool, error) {
-	if !ap.usersReady {
+	ap.usersMu.RLock()
+	usersReady := ap.usersReady
+	ap.usersMu.RUnlock()
+	if !usersReady && !ap.usersWarm.Lazy {
 		return false, ErrUsersNotReady
 	}
-	if !ap.channelsReady {
+	ap.channelsMu.RLock()
+	channelsReady := ap.channelsReady
+	ap.channelsMu.RUnlock()
+	if !channelsReady {
 		return false, ErrChannelsNotReady
 	}
 	return true, nil
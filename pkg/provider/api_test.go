@@ -0,0 +1,313 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"golang.org/x/time/rate"
+)
+
+// authTestClient answers auth.test with the queued results, repeating the last one
+type authTestClient struct {
+	SlackAPI
+	errs  []error
+	calls int
+	url   string // workspace URL of the token, https://acme.slack.com/ by default
+}
+
+func (c *authTestClient) AuthTest() (*slack.AuthTestResponse, error) {
+	panic("AuthTest returns the response cached at construction, identities must be tested with AuthTestContext")
+}
+
+func (c *authTestClient) AuthTestContext(_ context.Context) (*slack.AuthTestResponse, error) {
+	err := c.errs[min(c.calls, len(c.errs)-1)]
+	c.calls++
+	if err != nil {
+		return nil, err
+	}
+	if c.url != "" {
+		return &slack.AuthTestResponse{URL: c.url}, nil
+	}
+	return &slack.AuthTestResponse{URL: "https://acme.slack.com/"}, nil
+}
+
+func TestUnitWorkspaceRetriesTransientErrors(t *testing.T) {
+	client := &authTestClient{errs: []error{&slack.RateLimitedError{RetryAfter: time.Millisecond}, nil}}
+	ap := NewWithClient("stdio", client, zap.NewNop())
+
+	ws, err := ap.Workspace(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "acme", ws)
+	assert.Equal(t, 2, client.calls)
+}
+
+func TestUnitWorkspaceFallsBackToLastKnown(t *testing.T) {
+	client := &authTestClient{errs: []error{nil, slack.SlackErrorResponse{Err: "invalid_auth"}}}
+	ap := NewWithClient("stdio", client, zap.NewNop())
+
+	ws, err := ap.Workspace(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "acme", ws)
+
+	ws, err = ap.Workspace(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "acme", ws, "the cached workspace is used while auth.test fails")
+}
+
+func TestUnitRefreshIdentityFallsBackOnTransientErrors(t *testing.T) {
+	limited := &slack.RateLimitedError{RetryAfter: time.Millisecond}
+	client := &authTestClient{errs: []error{nil, limited}}
+	ap := NewWithClient("stdio", client, zap.NewNop())
+
+	_, err := ap.Identity(context.Background())
+	require.NoError(t, err)
+
+	// Slack keeps rate limiting the refresh
+	id, err := ap.RefreshIdentity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "acme", id.Workspace, "the last known identity is used while Slack is unavailable")
+	assert.Equal(t, 1+DefaultBackoffPolicy().MaxAttempts, client.calls, "every attempt calls auth.test again")
+}
+
+func TestUnitWorkspaceFailsWithoutLastKnown(t *testing.T) {
+	client := &authTestClient{errs: []error{slack.SlackErrorResponse{Err: "invalid_auth"}}}
+	ap := NewWithClient("stdio", client, zap.NewNop())
+
+	_, err := ap.Workspace(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, 1, client.calls, "logical errors are not retried")
+}
+
+func TestUnitIdentityIsCached(t *testing.T) {
+	client := &authTestClient{errs: []error{nil}}
+	ap := NewWithClient("stdio", client, zap.NewNop())
+
+	for i := 0; i < 3; i++ {
+		id, err := ap.Identity(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "acme", id.Workspace)
+		assert.Equal(t, "https://acme.slack.com/", id.URL)
+	}
+	assert.Equal(t, 1, client.calls, "auth.test is called once")
+
+	ap.InvalidateIdentity()
+	_, err := ap.Identity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.calls, "an invalidated identity is tested again")
+}
+
+func TestUnitIdentityRotatedToken(t *testing.T) {
+	client := &authTestClient{errs: []error{nil}}
+	ap := NewWithClient("stdio", client, zap.NewNop())
+
+	id, err := ap.Identity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "acme", id.Workspace)
+
+	// the token is replaced by one of another workspace
+	client.url = "https://globex.slack.com/"
+	id, err = ap.Identity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "acme", id.Workspace, "the cached identity is used until it is invalidated")
+
+	ap.InvalidateIdentity()
+	id, err = ap.Identity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "globex", id.Workspace, "auth.test is called again for the rotated token")
+
+	client.url = "https://initech.slack.com/"
+	id, err = ap.RefreshIdentity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "initech", id.Workspace, "a refresh tests the token on demand")
+}
+
+func TestUnitRefreshIdentityAuthError(t *testing.T) {
+	client := &authTestClient{errs: []error{nil, slack.SlackErrorResponse{Err: "token_revoked"}, nil}}
+	ap := NewWithClient("stdio", client, zap.NewNop())
+
+	_, err := ap.Identity(context.Background())
+	require.NoError(t, err)
+
+	_, err = ap.RefreshIdentity(context.Background())
+	require.Error(t, err, "an auth error is not hidden by the cached identity")
+	assert.True(t, IsAuthError(err))
+
+	_, err = ap.Identity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, client.calls, "the cached identity was dropped")
+}
+
+func TestUnitIsAuthError(t *testing.T) {
+	assert.True(t, IsAuthError(slack.SlackErrorResponse{Err: "invalid_auth"}))
+	assert.True(t, IsAuthError(fmt.Errorf("listing channels: %w", slack.SlackErrorResponse{Err: "token_expired"})))
+	assert.True(t, IsAuthError(errors.New("not_authed")))
+	assert.False(t, IsAuthError(slack.SlackErrorResponse{Err: "channel_not_found"}))
+	assert.False(t, IsAuthError(nil))
+}
+
+// blockingChannelsClient serves one channel per conversations.list call,
+// holding the first call until release is closed
+type blockingChannelsClient struct {
+	SlackAPI
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (c *blockingChannelsClient) GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	if c.calls.Add(1) == 1 {
+		close(c.started)
+		<-c.release
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+	channel := slack.Channel{}
+	channel.ID = "C" + params.Types[0]
+	channel.Name = params.Types[0]
+	channel.NameNormalized = params.Types[0]
+	return []slack.Channel{channel}, "", nil
+}
+
+func TestUnitRefreshChannelsSingleFlight(t *testing.T) {
+	client := &blockingChannelsClient{started: make(chan struct{}), release: make(chan struct{})}
+	ap := NewWithClient("stdio", client, zap.NewNop())
+	ap.rateLimiter = rate.NewLimiter(rate.Inf, 1)
+	// the cache file cannot be written, so that every build fetches from Slack
+	ap.channelsCache = filepath.Join(t.TempDir(), "missing", "channels_cache.json")
+
+	const callers = 10
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, callers)
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errs <- ap.RefreshChannels(context.Background())
+	}()
+	<-client.started
+	for i := 1; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- ap.RefreshChannels(context.Background())
+			assert.Len(t, ap.ProvideChannelsMaps().Channels, len(AllChanTypes))
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(client.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(len(AllChanTypes)), client.calls.Load(), "concurrent callers must share one build")
+	assert.Equal(t, "Cpublic_channel", ap.ProvideChannelsMaps().ChannelsInv["#public_channel"])
+}
+
+// archivedChannelsClient lists one active and one archived channel unless
+// archived channels are excluded
+type archivedChannelsClient struct {
+	SlackAPI
+	params *slack.GetConversationsParameters
+}
+
+func (c *archivedChannelsClient) GetConversationsContext(_ context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	c.params = params
+	active, archived := slack.Channel{}, slack.Channel{}
+	active.ID, active.Name, active.NameNormalized = "C1", "general", "general"
+	archived.ID, archived.Name, archived.NameNormalized, archived.IsArchived = "C2", "old-project", "old-project", true
+	if params.ExcludeArchived {
+		return []slack.Channel{active}, "", nil
+	}
+	return []slack.Channel{active, archived}, "", nil
+}
+
+func TestUnitGetChannelsTypeKeepsArchived(t *testing.T) {
+	client := &archivedChannelsClient{}
+	ap := NewWithClient("stdio", client, zap.NewNop())
+	ap.rateLimiter = rate.NewLimiter(rate.Inf, 1)
+
+	chans := ap.GetChannelsType(context.Background(), PubChanType)
+	require.NotNil(t, client.params)
+	assert.False(t, client.params.ExcludeArchived)
+	require.Len(t, chans, 2)
+	assert.False(t, chans[0].IsArchived)
+	assert.Equal(t, "#old-project", chans[1].Name)
+	assert.True(t, chans[1].IsArchived, "archived channels are cached with their state")
+}
+
+func TestUnitGetChannelsTypeLogsRequestID(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	ap := NewWithClient("stdio", &archivedChannelsClient{}, zap.New(core))
+	ap.rateLimiter = rate.NewLimiter(rate.Inf, 1)
+
+	ap.GetChannelsType(requestid.With(context.Background(), "req-42"), PubChanType)
+	entries := logs.FilterMessage("Fetched channels for ").All()
+	require.NotEmpty(t, entries)
+	for _, entry := range entries {
+		assert.Equal(t, "req-42", entry.ContextMap()["request_id"])
+	}
+
+	logs.TakeAll()
+	ap.GetChannelsType(context.Background(), PubChanType)
+	for _, entry := range logs.All() {
+		assert.NotContains(t, entry.ContextMap(), "request_id", "no request ID outside of a tool call")
+	}
+}
+
+func TestUnitRefreshChannelsWaitHonorsContext(t *testing.T) {
+	client := &blockingChannelsClient{started: make(chan struct{}), release: make(chan struct{})}
+	ap := NewWithClient("stdio", client, zap.NewNop())
+	ap.rateLimiter = rate.NewLimiter(rate.Inf, 1)
+	ap.channelsCache = filepath.Join(t.TempDir(), "channels_cache.json")
+
+	done := make(chan error, 1)
+	go func() { done <- ap.RefreshChannels(context.Background()) }()
+	<-client.started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, ap.RefreshChannels(ctx), context.Canceled)
+
+	close(client.release)
+	require.NoError(t, <-done)
+}
+
+func TestUnitRefreshChannelsOutlivesStartingCaller(t *testing.T) {
+	client := &blockingChannelsClient{started: make(chan struct{}), release: make(chan struct{})}
+	ap := NewWithClient("stdio", client, zap.NewNop())
+	ap.rateLimiter = rate.NewLimiter(rate.Inf, 1)
+	ap.channelsCache = filepath.Join(t.TempDir(), "channels_cache.json")
+
+	// the caller starting the build gives up, e.g. on a client timeout
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan error, 1)
+	go func() { started <- ap.RefreshChannels(ctx) }()
+	<-client.started
+
+	waiter := make(chan error, 1)
+	go func() { waiter <- ap.RefreshChannels(context.Background()) }()
+
+	cancel()
+	assert.ErrorIs(t, <-started, context.Canceled)
+
+	close(client.release)
+	require.NoError(t, <-waiter)
+	assert.Len(t, ap.ProvideChannelsMaps().Channels, len(AllChanTypes), "the build is not cancelled with its first caller")
+	assert.Equal(t, int32(len(AllChanTypes)), client.calls.Load())
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// BackoffPolicy describes how long to wait between attempts of a failing call
+type BackoffPolicy struct {
+	// Initial is the delay after the first failed attempt
+	Initial time.Duration
+	// Max caps every delay, 0 leaves delays uncapped
+	Max time.Duration
+	// Multiplier grows the delay after every attempt, values below 1 keep it constant
+	Multiplier float64
+	// Jitter randomizes every delay by up to this fraction in either direction,
+	// e.g. 0.2 for ±20%, so that clients failing together do not retry together
+	Jitter float64
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int
+}
+
+// DefaultBackoffPolicy returns the policy of Slack calls: up to 4 attempts,
+// waiting 1s, 2s and 4s (±20%) in between
+func DefaultBackoffPolicy() BackoffPolicy {
+	return BackoffPolicy{
+		Initial:     time.Second,
+		Max:         30 * time.Second,
+		Multiplier:  2,
+		Jitter:      0.2,
+		MaxAttempts: 4,
+	}
+}
+
+// Next returns the delay after the failed attempt with the given 0-based index
+func (p BackoffPolicy) Next(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	d := float64(p.Initial) * math.Pow(multiplier, float64(max(attempt, 0)))
+	if p.Max > 0 && d > float64(p.Max) {
+		d = float64(p.Max)
+	}
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1)
+	}
+	if d < 0 {
+		return 0
+	}
+	return time.Duration(d)
+}
+
+// ShouldRetry reports whether another attempt follows the failed attempt with
+// the given 0-based index
+func (p BackoffPolicy) ShouldRetry(attempt int) bool {
+	return attempt+1 < p.MaxAttempts
+}
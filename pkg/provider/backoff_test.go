@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitBackoffPolicyNext(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  BackoffPolicy
+		attempt int
+		want    time.Duration
+	}{
+		{"first attempt", BackoffPolicy{Initial: time.Second, Multiplier: 2}, 0, time.Second},
+		{"second attempt", BackoffPolicy{Initial: time.Second, Multiplier: 2}, 1, 2 * time.Second},
+		{"third attempt", BackoffPolicy{Initial: time.Second, Multiplier: 2}, 2, 4 * time.Second},
+		{"fractional multiplier", BackoffPolicy{Initial: 100 * time.Millisecond, Multiplier: 1.5}, 2, 225 * time.Millisecond},
+		{"capped", BackoffPolicy{Initial: time.Second, Max: 5 * time.Second, Multiplier: 2}, 3, 5 * time.Second},
+		{"uncapped", BackoffPolicy{Initial: time.Second, Multiplier: 2}, 6, 64 * time.Second},
+		{"constant without multiplier", BackoffPolicy{Initial: time.Second}, 4, time.Second},
+		{"negative attempt", BackoffPolicy{Initial: time.Second, Multiplier: 2}, -1, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.Next(tt.attempt))
+		})
+	}
+}
+
+func TestUnitBackoffPolicyJitter(t *testing.T) {
+	policy := BackoffPolicy{Initial: time.Second, Multiplier: 2, Jitter: 0.2}
+	for i := 0; i < 100; i++ {
+		d := policy.Next(1)
+		assert.GreaterOrEqual(t, d, 1600*time.Millisecond)
+		assert.LessOrEqual(t, d, 2400*time.Millisecond)
+	}
+}
+
+func TestUnitBackoffPolicyShouldRetry(t *testing.T) {
+	policy := DefaultBackoffPolicy()
+	assert.True(t, policy.ShouldRetry(0))
+	assert.True(t, policy.ShouldRetry(2))
+	assert.False(t, policy.ShouldRetry(3), "the default policy makes 4 attempts")
+	assert.False(t, BackoffPolicy{}.ShouldRetry(0), "a zero policy never retries")
+}
+
+func TestUnitProviderUsesBackoffPolicy(t *testing.T) {
+	client := &authTestClient{errs: []error{&slack.RateLimitedError{RetryAfter: time.Millisecond}, nil}}
+	ap := NewWithClient("stdio", client, zap.NewNop())
+	ap.SetBackoffPolicy(BackoffPolicy{MaxAttempts: 1})
+	assert.Equal(t, 1, ap.BackoffPolicy().MaxAttempts)
+
+	_, err := ap.RefreshIdentity(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, 1, client.calls, "the injected policy allows a single attempt")
+}
+
+// limitedLister rate limits the first failures listings, then serves a channel
+type limitedLister struct {
+	failures int
+	calls    int
+}
+
+func (f *limitedLister) GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, "", &slack.RateLimitedError{RetryAfter: time.Millisecond}
+	}
+	return []slack.Channel{fakeChannel("C1", "alpha")}, "", nil
+}
+
+func TestUnitTeamChannelsCacheUsesBackoffPolicy(t *testing.T) {
+	cache := NewTeamChannelsCache(time.Minute)
+	cache.SetBackoffPolicy(BackoffPolicy{MaxAttempts: 1})
+	lister := &limitedLister{failures: 1}
+	_, err := cache.Load(context.Background(), lister, "T1", "U1", PubChanType)
+	require.Error(t, err)
+	assert.Equal(t, 1, lister.calls, "the injected policy allows a single attempt")
+
+	cache = NewTeamChannelsCache(time.Minute)
+	cache.SetBackoffPolicy(BackoffPolicy{Initial: time.Millisecond, MaxAttempts: 3})
+	lister = &limitedLister{failures: 2}
+	chans, err := cache.Load(context.Background(), lister, "T1", "U1", PubChanType)
+	require.NoError(t, err)
+	assert.Len(t, chans, 1)
+	assert.Equal(t, 3, lister.calls)
+}
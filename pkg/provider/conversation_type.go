@@ -0,0 +1,35 @@
+package provider
+
+import "strings"
+
+// LikelyChannelTypes infers which conversation types a channel ID can belong to
+// from its prefix: C is a public or private channel, D a direct message and G
+// a legacy private channel or group DM. It returns nil for IDs that do not look
+// like conversation IDs at all.
+//
+// This is a heuristic to reject obviously mismatched input before calling
+// Slack, not a lookup: Slack does not guarantee ID prefixes, so callers must
+// still handle Slack's own error for the actual call.
+func LikelyChannelTypes(id string) []string {
+	switch {
+	case strings.HasPrefix(id, "C"):
+		return []string{PubChanType, PrivateChanType}
+	case strings.HasPrefix(id, "D"):
+		return []string{"im"}
+	case strings.HasPrefix(id, "G"):
+		return []string{PrivateChanType, "mpim"}
+	default:
+		return nil
+	}
+}
+
+// IsLikelyChannelType reports whether a channel ID can belong to the given type,
+// see LikelyChannelTypes for the heuristic used
+func IsLikelyChannelType(id, channelType string) bool {
+	for _, t := range LikelyChannelTypes(id) {
+		if t == channelType {
+			return true
+		}
+	}
+	return false
+}
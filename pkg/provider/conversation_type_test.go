@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitLikelyChannelTypes(t *testing.T) {
+	tests := []struct {
+		id   string
+		want []string
+	}{
+		{"C0123456789", []string{"public_channel", "private_channel"}},
+		{"D0123456789", []string{"im"}},
+		{"G0123456789", []string{"private_channel", "mpim"}},
+		{"U0123456789", nil},
+		{"general", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			assert.Equal(t, tt.want, LikelyChannelTypes(tt.id))
+		})
+	}
+
+	assert.True(t, IsLikelyChannelType("D0123456789", "im"))
+	assert.False(t, IsLikelyChannelType("D0123456789", PubChanType))
+	assert.False(t, IsLikelyChannelType("U0123456789", "im"))
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// Identity is who the token of the provider authenticates as, from auth.test
+type Identity struct {
+	URL          string
+	Workspace    string // workspace slug of URL, e.g. acme for https://acme.slack.com/
+	Team         string
+	TeamID       string
+	User         string
+	UserID       string
+	BotID        string
+	EnterpriseID string
+}
+
+// authErrorCodes are the Slack errors telling that the token itself is not valid anymore
+var authErrorCodes = map[string]bool{
+	"invalid_auth":     true,
+	"not_authed":       true,
+	"account_inactive": true,
+	"token_revoked":    true,
+	"token_expired":    true,
+}
+
+// IsAuthError reports whether Slack rejected a call because the token is not valid anymore
+func IsAuthError(err error) bool {
+	var slackErr slack.SlackErrorResponse
+	if errors.As(err, &slackErr) {
+		return authErrorCodes[slackErr.Err]
+	}
+	return err != nil && authErrorCodes[err.Error()]
+}
+
+// Identity returns the identity of the token. auth.test is only called the
+// first time and after InvalidateIdentity, the result is cached otherwise.
+func (ap *ApiProvider) Identity(ctx context.Context) (Identity, error) {
+	ap.identityMu.RLock()
+	id := ap.identity
+	ap.identityMu.RUnlock()
+	if id != nil {
+		return *id, nil
+	}
+	return ap.RefreshIdentity(ctx)
+}
+
+// RefreshIdentity calls auth.test, retrying transient failures, and caches the
+// result. When Slack stays unavailable, the cached identity is returned instead
+// of failing; an auth error drops it so that a replaced token is tested again.
+func (ap *ApiProvider) RefreshIdentity(ctx context.Context) (Identity, error) {
+	var (
+		ar  *slack.AuthTestResponse
+		err error
+	)
+	for attempt := 0; ; attempt++ {
+		ar, err = ap.client.AuthTestContext(ctx)
+		if err == nil || !ap.backoff.ShouldRetry(attempt) || !isRetryable(err) {
+			break
+		}
+		if sleepErr := sleepContext(ctx, retryAfter(err, ap.backoff, attempt)); sleepErr != nil {
+			break
+		}
+	}
+
+	if err == nil {
+		ws, parseErr := text.Workspace(ar.URL)
+		if parseErr != nil {
+			return Identity{}, fmt.Errorf("failed to parse workspace from URL %q: %w", ar.URL, parseErr)
+		}
+		id := Identity{
+			URL:          ar.URL,
+			Workspace:    ws,
+			Team:         ar.Team,
+			TeamID:       ar.TeamID,
+			User:         ar.User,
+			UserID:       ar.UserID,
+			BotID:        ar.BotID,
+			EnterpriseID: ar.EnterpriseID,
+		}
+		ap.identityMu.Lock()
+		ap.identity = &id
+		ap.identityMu.Unlock()
+		return id, nil
+	}
+
+	if IsAuthError(err) {
+		ap.InvalidateIdentity()
+		return Identity{}, err
+	}
+
+	ap.identityMu.RLock()
+	id := ap.identity
+	ap.identityMu.RUnlock()
+	if id == nil {
+		return Identity{}, err
+	}
+	ap.logger.Warn("Slack AuthTest failed, using the last known identity",
+		zap.String("workspace", id.Workspace),
+		zap.Error(err),
+	)
+	return *id, nil
+}
+
+// InvalidateIdentity drops the cached identity, the next Identity call tests the token again
+func (ap *ApiProvider) InvalidateIdentity() {
+	ap.identityMu.Lock()
+	ap.identity = nil
+	ap.identityMu.Unlock()
+}
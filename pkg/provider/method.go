@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const maxMethodResponseSize = 4 << 20 // 4 MiB
+
+// CallMethod calls a Slack Web API method with form parameters and returns the
+// raw JSON response, whether or not Slack reports it as ok
+func CallMethod(ctx context.Context, client *http.Client, apiURL, token, method string, params url.Values) (json.RawMessage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL+method, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxMethodResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxMethodResponseSize {
+		return nil, fmt.Errorf("%s response exceeds %d bytes", method, maxMethodResponseSize)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s failed with HTTP status %d", method, resp.StatusCode)
+	}
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("%s returned an invalid JSON response", method)
+	}
+	return body, nil
+}
+
+func (c *MCPSlackClient) CallMethodContext(ctx context.Context, method string, params url.Values) (json.RawMessage, error) {
+	return CallMethod(ctx, c.httpClient, c.teamEndpoint+"api/", c.authProvider.SlackToken(), method, params)
+}
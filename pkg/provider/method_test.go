@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitCallMethod(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/team.info", r.URL.Path)
+		assert.Equal(t, "Bearer xoxp-1", r.Header.Get("Authorization"))
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "T1", r.PostForm.Get("team"))
+		assert.Empty(t, r.PostForm.Get("token"))
+		w.Write([]byte(`{"ok":true,"team":{"id":"T1"}}`))
+	}))
+	defer srv.Close()
+
+	body, err := CallMethod(context.Background(), srv.Client(), srv.URL+"/api/", "xoxp-1", "team.info", url.Values{"team": {"T1"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ok":true,"team":{"id":"T1"}}`, string(body))
+}
+
+func TestUnitCallMethodErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/users.list":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/api/users.info":
+			w.Write([]byte(`<html>not json</html>`))
+		}
+	}))
+	defer srv.Close()
+
+	_, err := CallMethod(context.Background(), srv.Client(), srv.URL+"/api/", "xoxp-1", "users.list", nil)
+	assert.ErrorContains(t, err, "HTTP status 429")
+
+	_, err = CallMethod(context.Background(), srv.Client(), srv.URL+"/api/", "xoxp-1", "users.info", nil)
+	assert.ErrorContains(t, err, "invalid JSON")
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// Slack API error codes that describe a transient server-side condition
+var retryableSlackErrors = map[string]bool{
+	"ratelimited":         true,
+	"internal_error":      true,
+	"fatal_error":         true,
+	"service_unavailable": true,
+	"request_timeout":     true,
+}
+
+// isRetryable reports whether a Slack call that failed with err may succeed
+// when repeated: rate limits, network failures and 5xx responses are retryable,
+// logical errors such as invalid_auth or channel_not_found are not.
+func isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// the caller gave up, repeating the call would fail the same way
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var rateLimited *slack.RateLimitedError
+	if errors.As(err, &rateLimited) {
+		return true
+	}
+
+	var statusErr slack.StatusCodeError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code == http.StatusTooManyRequests || statusErr.Code >= http.StatusInternalServerError
+	}
+
+	var slackErr slack.SlackErrorResponse
+	if errors.As(err, &slackErr) {
+		return retryableSlackErrors[slackErr.Err]
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// retryAfter returns how long to wait before repeating a call that failed with
+// err, honoring the delay Slack asks for on rate limits
+func retryAfter(err error, policy BackoffPolicy, attempt int) time.Duration {
+	var rateLimited *slack.RateLimitedError
+	if errors.As(err, &rateLimited) && rateLimited.RetryAfter > 0 {
+		return rateLimited.RetryAfter
+	}
+	return policy.Next(attempt)
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// getConversationsWithRetry fetches one page of conversations, repeating the
+// call as the policy allows while it fails with a retryable error
+func getConversationsWithRetry(ctx context.Context, client ConversationsLister, params *slack.GetConversationsParameters, policy BackoffPolicy) ([]slack.Channel, string, error) {
+	for attempt := 0; ; attempt++ {
+		channels, nextcur, err := client.GetConversationsContext(ctx, params)
+		if err == nil || !policy.ShouldRetry(attempt) || !isRetryable(err) {
+			return channels, nextcur, err
+		}
+		if err := sleepContext(ctx, retryAfter(err, policy, attempt)); err != nil {
+			return nil, "", err
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestUnitIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"rate limited", &slack.RateLimitedError{RetryAfter: time.Second}, true},
+		{"wrapped rate limited", fmt.Errorf("fetch: %w", &slack.RateLimitedError{}), true},
+		{"http 429", slack.StatusCodeError{Code: 429}, true},
+		{"http 500", slack.StatusCodeError{Code: 500}, true},
+		{"http 503", slack.StatusCodeError{Code: 503}, true},
+		{"http 404", slack.StatusCodeError{Code: 404}, false},
+		{"network timeout", &url.Error{Op: "Post", URL: "https://slack.com/api", Err: timeoutError{}}, true},
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+		{"slack internal_error", slack.SlackErrorResponse{Err: "internal_error"}, true},
+		{"slack ratelimited", slack.SlackErrorResponse{Err: "ratelimited"}, true},
+		{"invalid_auth", slack.SlackErrorResponse{Err: "invalid_auth"}, false},
+		{"channel_not_found", slack.SlackErrorResponse{Err: "channel_not_found"}, false},
+		{"context canceled", context.Canceled, false},
+		{"context deadline", fmt.Errorf("fetch: %w", context.DeadlineExceeded), false},
+		{"plain error", io.ErrUnexpectedEOF, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRetryable(tt.err))
+		})
+	}
+}
+
+type flakyLister struct {
+	errs  []error
+	calls int
+}
+
+func (f *flakyLister) GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	f.calls++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return nil, "", err
+	}
+	return []slack.Channel{fakeChannel("C1", "alpha")}, "", nil
+}
+
+func TestUnitGetConversationsWithRetry(t *testing.T) {
+	ctx := context.Background()
+
+	flaky := &flakyLister{errs: []error{&slack.RateLimitedError{RetryAfter: time.Millisecond}}}
+	channels, _, err := getConversationsWithRetry(ctx, flaky, &slack.GetConversationsParameters{}, DefaultBackoffPolicy())
+	require.NoError(t, err)
+	assert.Len(t, channels, 1)
+	assert.Equal(t, 2, flaky.calls)
+
+	denied := &flakyLister{errs: []error{slack.SlackErrorResponse{Err: "invalid_auth"}}}
+	_, _, err = getConversationsWithRetry(ctx, denied, &slack.GetConversationsParameters{}, DefaultBackoffPolicy())
+	require.Error(t, err)
+	assert.Equal(t, 1, denied.calls)
+}
@@ -0,0 +1,407 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/limiter"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+const DefaultTeamChannelsCacheTTL = 5 * time.Minute
+
+// ConversationsLister is the subset of the Slack API needed to list conversations,
+// satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type ConversationsLister interface {
+	GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error)
+}
+
+// UsersInfoGetter is the subset of the Slack API needed to resolve the users
+// of IMs and MPIMs, satisfied by *slack.Client (OAuth mode)
+type UsersInfoGetter interface {
+	GetUsersInfoContext(ctx context.Context, users ...string) (*[]slack.User, error)
+}
+
+// usersInfoBatch is the number of users resolved with one users.info call
+const usersInfoBatch = 100
+
+type teamChannelsEntry struct {
+	channels  []Channel
+	expiresAt time.Time
+}
+
+type teamUsersEntry struct {
+	users     map[string]slack.User
+	expiresAt time.Time
+}
+
+// TeamChannelsCache caches channel listings per team for OAuth mode, where
+// every request carries its own token and there is no global channels cache.
+//
+// Public channels are shared by everyone in a team and are cached per TeamID.
+// Private channels, IMs and MPIMs depend on the membership of the requesting
+// user, so they are cached per TeamID and UserID to never leak between users.
+//
+// The users of IMs and MPIMs are resolved with users.info and kept per team
+// for the same TTL. Listings of a team are paced by a Tier2 limiter of that
+// team, conversations.list being a Tier2 method.
+type TeamChannelsCache struct {
+	mu         sync.RWMutex
+	ttl        time.Duration
+	entries    map[string]teamChannelsEntry
+	users      map[string]teamUsersEntry
+	limiters   map[string]*rate.Limiter
+	newLimiter func() *rate.Limiter
+	backoff    BackoffPolicy
+}
+
+// NewTeamChannelsCache creates a per-team channels cache with the given TTL
+func NewTeamChannelsCache(ttl time.Duration) *TeamChannelsCache {
+	if ttl <= 0 {
+		ttl = DefaultTeamChannelsCacheTTL
+	}
+
+	return &TeamChannelsCache{
+		ttl:        ttl,
+		entries:    make(map[string]teamChannelsEntry),
+		users:      make(map[string]teamUsersEntry),
+		limiters:   make(map[string]*rate.Limiter),
+		newLimiter: limiter.Tier2.Limiter,
+		backoff:    DefaultBackoffPolicy(),
+	}
+}
+
+// SetBackoffPolicy replaces the policy with which failed listings are retried,
+// DefaultBackoffPolicy unless set. It must be called before the cache is used.
+func (c *TeamChannelsCache) SetBackoffPolicy(policy BackoffPolicy) {
+	c.backoff = policy
+}
+
+// NewTeamChannelsCacheFromEnv creates a per-team channels cache with the TTL
+// taken from SLACK_MCP_OAUTH_CHANNELS_CACHE_TTL
+func NewTeamChannelsCacheFromEnv(logger *zap.Logger) *TeamChannelsCache {
+	ttl := DefaultTeamChannelsCacheTTL
+	if v := os.Getenv("SLACK_MCP_OAUTH_CHANNELS_CACHE_TTL"); v != "" {
+		parsed, err := time.ParseDuration(v)
+		if err != nil || parsed <= 0 {
+			logger.Warn("Invalid SLACK_MCP_OAUTH_CHANNELS_CACHE_TTL, using default",
+				zap.String("value", v),
+				zap.Duration("default", DefaultTeamChannelsCacheTTL),
+			)
+		} else {
+			ttl = parsed
+		}
+	}
+
+	return NewTeamChannelsCache(ttl)
+}
+
+// TTL returns how long a cached listing is served before it is refreshed
+func (c *TeamChannelsCache) TTL() time.Duration {
+	return c.ttl
+}
+
+// Get returns cached channels of the given type if present and not expired
+func (c *TeamChannelsCache) Get(teamID, userID, channelType string) ([]Channel, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[teamChannelsKey(teamID, userID, channelType)]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+
+	return entry.channels, true
+}
+
+// Set stores channels of the given type and drops expired entries
+func (c *TeamChannelsCache) Set(teamID, userID, channelType string, channels []Channel) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+
+	c.entries[teamChannelsKey(teamID, userID, channelType)] = teamChannelsEntry{
+		channels:  channels,
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+// SetMemberCount updates the member count of a cached channel in the listings
+// visible to the given user, keeping their expiry
+func (c *TeamChannelsCache) SetMemberCount(teamID, userID, channelID string, count int) {
+	c.update(teamID, userID, channelID, func(ch *Channel) {
+		ch.MemberCount = count
+	})
+}
+
+// SetArchived updates the archived flag of a cached channel in the listings
+// visible to the given user, keeping their expiry
+func (c *TeamChannelsCache) SetArchived(teamID, userID, channelID string, archived bool) {
+	c.update(teamID, userID, channelID, func(ch *Channel) {
+		ch.IsArchived = archived
+	})
+}
+
+// SetTopic updates the topic of a cached channel in the listings visible to
+// the given user, keeping their expiry
+func (c *TeamChannelsCache) SetTopic(teamID, userID, channelID, topic string) {
+	c.update(teamID, userID, channelID, func(ch *Channel) {
+		ch.Topic = topic
+	})
+}
+
+// SetPurpose updates the purpose of a cached channel in the listings visible
+// to the given user, keeping their expiry
+func (c *TeamChannelsCache) SetPurpose(teamID, userID, channelID, purpose string) {
+	c.update(teamID, userID, channelID, func(ch *Channel) {
+		ch.Purpose = purpose
+	})
+}
+
+// Rename updates the name of a cached channel in the listings visible to the
+// given user, keeping their expiry
+func (c *TeamChannelsCache) Rename(teamID, userID, channelID, name string) {
+	c.update(teamID, userID, channelID, func(ch *Channel) {
+		ch.Name = "#" + strings.TrimPrefix(name, "#")
+	})
+}
+
+// Add adds a channel created by the given user to the cached listing of its
+// type, keeping its expiry. Listings that are not cached are left alone, they
+// include the channel once fetched.
+func (c *TeamChannelsCache) Add(teamID, userID string, channel slack.Channel) {
+	channelType := PubChanType
+	if channel.IsPrivate {
+		channelType = PrivateChanType
+	}
+	ch := mapChannel(
+		channel.ID,
+		channel.Name,
+		channel.NameNormalized,
+		channel.Topic.Value,
+		channel.Purpose.Value,
+		channel.User,
+		channel.Members,
+		channel.NumMembers,
+		channel.IsIM,
+		channel.IsMpIM,
+		channel.IsPrivate,
+		map[string]slack.User{},
+	)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := teamChannelsKey(teamID, userID, channelType)
+	entry, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	for _, cached := range entry.channels {
+		if cached.ID == ch.ID {
+			return
+		}
+	}
+	// copy, the previous slice may still be read by other requests
+	entry.channels = append(append([]Channel(nil), entry.channels...), ch)
+	c.entries[key] = entry
+}
+
+func (c *TeamChannelsCache) update(teamID, userID, channelID string, apply func(ch *Channel)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, channelType := range AllChanTypes {
+		key := teamChannelsKey(teamID, userID, channelType)
+		entry, ok := c.entries[key]
+		if !ok {
+			continue
+		}
+		for i, ch := range entry.channels {
+			if ch.ID != channelID {
+				continue
+			}
+			// copy, the previous slice may still be read by other requests
+			channels := append([]Channel(nil), entry.channels...)
+			apply(&channels[i])
+			entry.channels = channels
+			c.entries[key] = entry
+			break
+		}
+	}
+}
+
+// Load returns channels of the given type from the cache, archived ones
+// included, fetching all pages with the requesting user's client when the
+// entry is missing or expired. IMs and MPIMs are named after their users when
+// the client can resolve them, and after the user IDs when it cannot.
+func (c *TeamChannelsCache) Load(ctx context.Context, client ConversationsLister, teamID, userID, channelType string) ([]Channel, error) {
+	if channels, ok := c.Get(teamID, userID, channelType); ok {
+		return channels, nil
+	}
+
+	channels, err := listChannels(ctx, client, channelType, true, c.backoff, c.teamLimiter(teamID))
+	if err != nil {
+		return nil, err
+	}
+
+	usersMap := map[string]slack.User{}
+	if getter, ok := client.(UsersInfoGetter); ok && (channelType == "im" || channelType == "mpim") {
+		usersMap = c.dmUsers(ctx, getter, teamID, channels)
+	}
+	chans := mapChannels(channels, usersMap)
+
+	c.Set(teamID, userID, channelType, chans)
+
+	return chans, nil
+}
+
+// teamLimiter returns the limiter pacing the listings of a team
+func (c *TeamChannelsCache) teamLimiter(teamID string) *rate.Limiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rl, ok := c.limiters[teamID]
+	if !ok {
+		rl = c.newLimiter()
+		c.limiters[teamID] = rl
+	}
+	return rl
+}
+
+// dmUsers returns the users of a team that IMs and MPIMs are with, looking up
+// those not known to the team yet with users.info. Users that fail to resolve,
+// e.g. without the users:read scope, are left out and named by their IDs.
+func (c *TeamChannelsCache) dmUsers(ctx context.Context, getter UsersInfoGetter, teamID string, channels []slack.Channel) map[string]slack.User {
+	c.mu.RLock()
+	entry, ok := c.users[teamID]
+	c.mu.RUnlock()
+	if !ok || time.Now().After(entry.expiresAt) {
+		entry = teamUsersEntry{users: map[string]slack.User{}, expiresAt: time.Now().Add(c.ttl)}
+	}
+
+	var missing []string
+	seen := map[string]bool{}
+	for _, channel := range channels {
+		ids := channel.Members
+		if channel.IsIM {
+			ids = []string{channel.User}
+		}
+		for _, id := range ids {
+			if _, known := entry.users[id]; !known && id != "" && !seen[id] {
+				seen[id] = true
+				missing = append(missing, id)
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return entry.users
+	}
+
+	// copy, the previous map may still be read by other requests
+	users := make(map[string]slack.User, len(entry.users)+len(missing))
+	for id, u := range entry.users {
+		users[id] = u
+	}
+	for start := 0; start < len(missing); start += usersInfoBatch {
+		batch := missing[start:min(start+usersInfoBatch, len(missing))]
+		info, err := getter.GetUsersInfoContext(ctx, batch...)
+		if err != nil {
+			break
+		}
+		for _, u := range *info {
+			users[u.ID] = u
+		}
+	}
+
+	c.mu.Lock()
+	c.users[teamID] = teamUsersEntry{users: users, expiresAt: entry.expiresAt}
+	c.mu.Unlock()
+
+	return users
+}
+
+func teamChannelsKey(teamID, userID, channelType string) string {
+	if channelType == PubChanType {
+		return teamID + "/" + channelType
+	}
+	return teamID + "/" + userID + "/" + channelType
+}
+
+// FetchChannels lists all channels of the given type directly from Slack,
+// bypassing any cache, retrying with the given policy. Archived channels are
+// only returned with includeArchived.
+func FetchChannels(ctx context.Context, client ConversationsLister, channelType string, includeArchived bool, usersMap map[string]slack.User, policy BackoffPolicy) ([]Channel, error) {
+	channels, err := listChannels(ctx, client, channelType, includeArchived, policy, nil)
+	if err != nil {
+		return nil, err
+	}
+	return mapChannels(channels, usersMap), nil
+}
+
+// listChannels lists all conversations of the given type, waiting for rl
+// before every page unless it is nil
+func listChannels(ctx context.Context, client ConversationsLister, channelType string, includeArchived bool, policy BackoffPolicy, rl *rate.Limiter) ([]slack.Channel, error) {
+	params := &slack.GetConversationsParameters{
+		Types:           []string{channelType},
+		Limit:           999,
+		ExcludeArchived: !includeArchived,
+	}
+
+	var all []slack.Channel
+	for {
+		if rl != nil {
+			if err := rl.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		channels, nextcur, err := getConversationsWithRetry(ctx, client, params, policy)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, channels...)
+
+		if nextcur == "" {
+			break
+		}
+		params.Cursor = nextcur
+	}
+
+	return all, nil
+}
+
+// mapChannels maps listed conversations to cached channels, naming IMs and
+// MPIMs after the users in usersMap
+func mapChannels(channels []slack.Channel, usersMap map[string]slack.User) []Channel {
+	var chans []Channel
+	for _, channel := range channels {
+		ch := mapChannel(
+			channel.ID,
+			channel.Name,
+			channel.NameNormalized,
+			channel.Topic.Value,
+			channel.Purpose.Value,
+			channel.User,
+			channel.Members,
+			channel.NumMembers,
+			channel.IsIM,
+			channel.IsMpIM,
+			channel.IsPrivate,
+			usersMap,
+		)
+		ch.IsArchived = channel.IsArchived
+		chans = append(chans, ch)
+	}
+	return chans
+}
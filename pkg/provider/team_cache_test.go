@@ -0,0 +1,253 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+type fakeLister struct {
+	calls    int
+	channels []slack.Channel
+}
+
+func (f *fakeLister) GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	f.calls++
+	return f.channels, "", nil
+}
+
+func fakeChannel(id, name string) slack.Channel {
+	var c slack.Channel
+	c.ID = id
+	c.Name = name
+	c.NameNormalized = name
+	return c
+}
+
+func TestUnitTeamChannelsCacheIsolation(t *testing.T) {
+	ctx := context.Background()
+	cache := NewTeamChannelsCache(time.Minute)
+
+	teamA := &fakeLister{channels: []slack.Channel{fakeChannel("C1", "alpha")}}
+	teamB := &fakeLister{channels: []slack.Channel{fakeChannel("C2", "beta")}}
+
+	chans, err := cache.Load(ctx, teamA, "T1", "U1", PubChanType)
+	require.NoError(t, err)
+	require.Len(t, chans, 1)
+	assert.Equal(t, "#alpha", chans[0].Name)
+
+	chans, err = cache.Load(ctx, teamB, "T2", "U2", PubChanType)
+	require.NoError(t, err)
+	require.Len(t, chans, 1)
+	assert.Equal(t, "C2", chans[0].ID, "team T2 must not be served team T1 channels")
+
+	// Public channels are shared within a team
+	_, err = cache.Load(ctx, teamA, "T1", "U3", PubChanType)
+	require.NoError(t, err)
+	assert.Equal(t, 1, teamA.calls)
+
+	// Private channels are scoped to the requesting user
+	_, err = cache.Load(ctx, teamA, "T1", "U1", PrivateChanType)
+	require.NoError(t, err)
+	_, err = cache.Load(ctx, teamA, "T1", "U3", PrivateChanType)
+	require.NoError(t, err)
+	assert.Equal(t, 3, teamA.calls)
+}
+
+func TestUnitTeamChannelsCacheExpiry(t *testing.T) {
+	ctx := context.Background()
+	cache := NewTeamChannelsCache(time.Millisecond)
+	lister := &fakeLister{channels: []slack.Channel{fakeChannel("C1", "alpha")}}
+
+	_, err := cache.Load(ctx, lister, "T1", "U1", PubChanType)
+	require.NoError(t, err)
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok := cache.Get("T1", "U1", PubChanType)
+	assert.False(t, ok)
+
+	_, err = cache.Load(ctx, lister, "T1", "U1", PubChanType)
+	require.NoError(t, err)
+	assert.Equal(t, 2, lister.calls)
+}
+
+func TestUnitTeamChannelsCacheSetMemberCount(t *testing.T) {
+	cache := NewTeamChannelsCache(time.Minute)
+	original := []Channel{{ID: "C1", MemberCount: 3}}
+	cache.Set("T1", "U1", PubChanType, original)
+
+	cache.SetMemberCount("T1", "U1", "C1", 42)
+
+	chans, ok := cache.Get("T1", "U1", PubChanType)
+	require.True(t, ok)
+	assert.Equal(t, 42, chans[0].MemberCount)
+	assert.Equal(t, 3, original[0].MemberCount, "slices handed out before are not modified")
+
+	cache.SetMemberCount("T2", "U1", "C1", 7)
+	chans, _ = cache.Get("T1", "U1", PubChanType)
+	assert.Equal(t, 42, chans[0].MemberCount, "other teams are not touched")
+}
+
+func TestUnitTeamChannelsCacheSetArchived(t *testing.T) {
+	cache := NewTeamChannelsCache(time.Minute)
+	cache.Set("T1", "U1", PrivateChanType, []Channel{{ID: "G1"}, {ID: "G2"}})
+
+	cache.SetArchived("T1", "U1", "G2", true)
+
+	chans, ok := cache.Get("T1", "U1", PrivateChanType)
+	require.True(t, ok)
+	assert.False(t, chans[0].IsArchived)
+	assert.True(t, chans[1].IsArchived)
+}
+
+func TestUnitTeamChannelsCacheSetTopicAndPurpose(t *testing.T) {
+	cache := NewTeamChannelsCache(time.Minute)
+	cache.Set("T1", "U1", PubChanType, []Channel{{ID: "C1", Topic: "old", Purpose: "old"}})
+
+	cache.SetTopic("T1", "U2", "C1", "Status: resolved")
+	cache.SetPurpose("T1", "U2", "C1", "Checkout outage")
+
+	chans, ok := cache.Get("T1", "U1", PubChanType)
+	require.True(t, ok)
+	assert.Equal(t, "Status: resolved", chans[0].Topic, "public listings are shared by the team")
+	assert.Equal(t, "Checkout outage", chans[0].Purpose)
+}
+
+func TestUnitTeamChannelsCacheRename(t *testing.T) {
+	cache := NewTeamChannelsCache(time.Minute)
+	cache.Set("T1", "U1", PubChanType, []Channel{{ID: "C1", Name: "#incident-42"}})
+
+	cache.Rename("T1", "U1", "C1", "incident-42-resolved")
+
+	chans, ok := cache.Get("T1", "U1", PubChanType)
+	require.True(t, ok)
+	assert.Equal(t, "#incident-42-resolved", chans[0].Name)
+}
+
+func TestUnitTeamChannelsCacheAdd(t *testing.T) {
+	cache := NewTeamChannelsCache(time.Minute)
+	cache.Set("T1", "U1", PrivateChanType, []Channel{{ID: "G1", Name: "#secret"}})
+
+	created := slack.Channel{GroupConversation: slack.GroupConversation{
+		Name:         "incident-42",
+		Conversation: slack.Conversation{ID: "G2", NameNormalized: "incident-42", IsPrivate: true},
+	}}
+	cache.Add("T1", "U1", created)
+	cache.Add("T1", "U1", created)
+
+	chans, ok := cache.Get("T1", "U1", PrivateChanType)
+	require.True(t, ok)
+	require.Len(t, chans, 2, "a channel is added once")
+	assert.Equal(t, "G2", chans[1].ID)
+	assert.Equal(t, "#incident-42", chans[1].Name)
+	assert.True(t, chans[1].IsPrivate)
+
+	cache.Add("T1", "U1", slack.Channel{GroupConversation: slack.GroupConversation{Conversation: slack.Conversation{ID: "C1"}}})
+	_, ok = cache.Get("T1", "U1", PubChanType)
+	assert.False(t, ok, "listings that are not cached stay uncached")
+}
+
+// usersLister lists IMs and resolves their users with users.info
+type usersLister struct {
+	fakeLister
+	users   map[string]slack.User
+	lookups [][]string
+	err     error
+}
+
+func (f *usersLister) GetUsersInfoContext(ctx context.Context, users ...string) (*[]slack.User, error) {
+	f.lookups = append(f.lookups, users)
+	if f.err != nil {
+		return nil, f.err
+	}
+	var res []slack.User
+	for _, id := range users {
+		if u, ok := f.users[id]; ok {
+			res = append(res, u)
+		}
+	}
+	return &res, nil
+}
+
+func fakeIM(id, user string) slack.Channel {
+	var c slack.Channel
+	c.ID = id
+	c.IsIM = true
+	c.User = user
+	return c
+}
+
+func TestUnitTeamChannelsCacheResolvesIMUsers(t *testing.T) {
+	ctx := context.Background()
+	cache := NewTeamChannelsCache(time.Minute)
+	lister := &usersLister{
+		fakeLister: fakeLister{channels: []slack.Channel{fakeIM("D1", "U9"), fakeIM("D2", "U8")}},
+		users: map[string]slack.User{
+			"U9": {ID: "U9", Name: "alice", RealName: "Alice Doe"},
+		},
+	}
+
+	chans, err := cache.Load(ctx, lister, "T1", "U1", "im")
+	require.NoError(t, err)
+	require.Len(t, chans, 2)
+	assert.Equal(t, "@alice", chans[0].Name)
+	assert.Equal(t, "DM with Alice Doe", chans[0].Purpose)
+	assert.Equal(t, "@U8", chans[1].Name, "users that cannot be resolved are named by their IDs")
+	assert.Equal(t, [][]string{{"U9", "U8"}}, lister.lookups)
+
+	// another user of the team reuses the resolved users
+	lister.channels = []slack.Channel{fakeIM("D3", "U9")}
+	chans, err = cache.Load(ctx, lister, "T1", "U2", "im")
+	require.NoError(t, err)
+	assert.Equal(t, "@alice", chans[0].Name)
+	assert.Len(t, lister.lookups, 1)
+
+	// a failing users.info leaves the names as IDs instead of failing the listing
+	failing := &usersLister{
+		fakeLister: fakeLister{channels: []slack.Channel{fakeIM("D1", "U9")}},
+		err:        errors.New("missing_scope"),
+	}
+	chans, err = cache.Load(ctx, failing, "T2", "U1", "im")
+	require.NoError(t, err)
+	assert.Equal(t, "@U9", chans[0].Name)
+}
+
+// pagedLister lists one channel per page
+type pagedLister struct {
+	pages int
+	calls int
+}
+
+func (f *pagedLister) GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	f.calls++
+	next := ""
+	if f.calls < f.pages {
+		next = strconv.Itoa(f.calls)
+	}
+	return []slack.Channel{fakeChannel("C"+strconv.Itoa(f.calls), "page")}, next, nil
+}
+
+func TestUnitTeamChannelsCachePacesListings(t *testing.T) {
+	cache := NewTeamChannelsCache(time.Minute)
+	cache.newLimiter = func() *rate.Limiter { return rate.NewLimiter(rate.Every(time.Hour), 1) }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	lister := &pagedLister{pages: 2}
+	_, err := cache.Load(ctx, lister, "T1", "U1", PubChanType)
+	require.Error(t, err, "the second page waits for the limiter of the team")
+	assert.Equal(t, 1, lister.calls)
+
+	// teams are paced independently
+	chans, err := cache.Load(context.Background(), &pagedLister{pages: 1}, "T2", "U1", PubChanType)
+	require.NoError(t, err)
+	assert.Len(t, chans, 1)
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const DefaultUserGroupsCacheTTL = time.Hour
+
+// UserGroupsLister is the subset of the Slack API needed to list usergroups,
+// satisfied by both *slack.Client (OAuth mode) and SlackAPI (legacy mode)
+type UserGroupsLister interface {
+	GetUserGroupsContext(ctx context.Context, options ...slack.GetUserGroupsOption) ([]slack.UserGroup, error)
+}
+
+type userGroupsEntry struct {
+	groups    []slack.UserGroup
+	expiresAt time.Time
+}
+
+// UserGroupsCache caches the usergroups of a team with their members. They
+// change rarely, and every team member sees the same usergroups, so they are
+// cached per TeamID. Legacy mode has a single team and uses an empty TeamID.
+type UserGroupsCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[string]userGroupsEntry
+}
+
+// NewUserGroupsCache creates a usergroups cache with the given TTL
+func NewUserGroupsCache(ttl time.Duration) *UserGroupsCache {
+	if ttl <= 0 {
+		ttl = DefaultUserGroupsCacheTTL
+	}
+
+	return &UserGroupsCache{
+		ttl:     ttl,
+		entries: make(map[string]userGroupsEntry),
+	}
+}
+
+// NewUserGroupsCacheFromEnv creates a usergroups cache with the TTL taken
+// from SLACK_MCP_USERGROUPS_CACHE_TTL
+func NewUserGroupsCacheFromEnv(logger *zap.Logger) *UserGroupsCache {
+	ttl := DefaultUserGroupsCacheTTL
+	if v := os.Getenv("SLACK_MCP_USERGROUPS_CACHE_TTL"); v != "" {
+		parsed, err := time.ParseDuration(v)
+		if err != nil || parsed <= 0 {
+			logger.Warn("Invalid SLACK_MCP_USERGROUPS_CACHE_TTL, using default",
+				zap.String("value", v),
+				zap.Duration("default", DefaultUserGroupsCacheTTL),
+			)
+		} else {
+			ttl = parsed
+		}
+	}
+
+	return NewUserGroupsCache(ttl)
+}
+
+// Get returns the cached usergroups of a team if present and not expired
+func (c *UserGroupsCache) Get(teamID string) ([]slack.UserGroup, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[teamID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+
+	return entry.groups, true
+}
+
+// Set stores the usergroups of a team
+func (c *UserGroupsCache) Set(teamID string, groups []slack.UserGroup) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[teamID] = userGroupsEntry{
+		groups:    groups,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+// SetGroup replaces a usergroup of a cached team, e.g. after its members
+// were updated. Teams that are not cached are left alone.
+func (c *UserGroupsCache) SetGroup(teamID string, group slack.UserGroup) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[teamID]
+	if !ok {
+		return
+	}
+	groups := make([]slack.UserGroup, 0, len(entry.groups))
+	for _, g := range entry.groups {
+		if g.ID == group.ID {
+			g = group
+		}
+		groups = append(groups, g)
+	}
+	entry.groups = groups
+	c.entries[teamID] = entry
+}
+
+// Load returns the usergroups of a team from the cache, listing them with
+// their members when the entry is missing or expired
+func (c *UserGroupsCache) Load(ctx context.Context, client UserGroupsLister, teamID string) ([]slack.UserGroup, error) {
+	if groups, ok := c.Get(teamID); ok {
+		return groups, nil
+	}
+
+	groups, err := client.GetUserGroupsContext(ctx,
+		slack.GetUserGroupsOptionIncludeUsers(true),
+		slack.GetUserGroupsOptionIncludeCount(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Set(teamID, groups)
+
+	return groups, nil
+}
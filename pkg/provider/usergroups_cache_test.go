@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeUserGroupsLister struct {
+	calls  int
+	groups []slack.UserGroup
+	err    error
+}
+
+func (f *fakeUserGroupsLister) GetUserGroupsContext(ctx context.Context, options ...slack.GetUserGroupsOption) ([]slack.UserGroup, error) {
+	f.calls++
+	return f.groups, f.err
+}
+
+func TestUnitUserGroupsCache(t *testing.T) {
+	ctx := context.Background()
+	cache := NewUserGroupsCache(time.Minute)
+
+	teamA := &fakeUserGroupsLister{groups: []slack.UserGroup{{ID: "S1", Handle: "eng"}}}
+	teamB := &fakeUserGroupsLister{groups: []slack.UserGroup{{ID: "S2", Handle: "ops"}}}
+
+	groups, err := cache.Load(ctx, teamA, "T1")
+	require.NoError(t, err)
+	assert.Equal(t, "S1", groups[0].ID)
+
+	groups, err = cache.Load(ctx, teamB, "T2")
+	require.NoError(t, err)
+	assert.Equal(t, "S2", groups[0].ID, "team T2 must not be served team T1 usergroups")
+
+	_, err = cache.Load(ctx, teamA, "T1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, teamA.calls, "usergroups are served from the cache")
+}
+
+func TestUnitUserGroupsCacheSetGroup(t *testing.T) {
+	ctx := context.Background()
+	cache := NewUserGroupsCache(time.Minute)
+
+	lister := &fakeUserGroupsLister{groups: []slack.UserGroup{{ID: "S1", Users: []string{"U1"}}, {ID: "S2"}}}
+	_, err := cache.Load(ctx, lister, "T1")
+	require.NoError(t, err)
+
+	cache.SetGroup("T1", slack.UserGroup{ID: "S1", Users: []string{"U2", "U3"}})
+	cache.SetGroup("T2", slack.UserGroup{ID: "S1"})
+
+	groups, ok := cache.Get("T1")
+	require.True(t, ok)
+	assert.Equal(t, []string{"U2", "U3"}, groups[0].Users)
+	assert.Equal(t, "S2", groups[1].ID)
+	assert.Equal(t, []string{"U1"}, lister.groups[0].Users, "the listed usergroups are not modified")
+	_, ok = cache.Get("T2")
+	assert.False(t, ok, "uncached teams stay uncached")
+}
+
+func TestUnitUserGroupsCacheExpiryAndErrors(t *testing.T) {
+	ctx := context.Background()
+	cache := NewUserGroupsCache(time.Millisecond)
+
+	failing := &fakeUserGroupsLister{err: errors.New("paid_teams_only")}
+	_, err := cache.Load(ctx, failing, "T1")
+	require.Error(t, err)
+	_, ok := cache.Get("T1")
+	assert.False(t, ok, "errors are not cached")
+
+	lister := &fakeUserGroupsLister{groups: []slack.UserGroup{{ID: "S1"}}}
+	_, err = cache.Load(ctx, lister, "T1")
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	_, err = cache.Load(ctx, lister, "T1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, lister.calls, "expired entries are refetched")
+}
+
+func TestUnitUserGroupsCacheFromEnv(t *testing.T) {
+	t.Setenv("SLACK_MCP_USERGROUPS_CACHE_TTL", "10m")
+	assert.Equal(t, 10*time.Minute, NewUserGroupsCacheFromEnv(zap.NewNop()).ttl)
+
+	t.Setenv("SLACK_MCP_USERGROUPS_CACHE_TTL", "bogus")
+	assert.Equal(t, DefaultUserGroupsCacheTTL, NewUserGroupsCacheFromEnv(zap.NewNop()).ttl)
+}
@@ -0,0 +1,273 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultUsersPageSize is the number of users fetched per users.list page
+	DefaultUsersPageSize = 1000
+
+	maxUsersPageSize   = 1000
+	usersInfoBatchSize = 50
+)
+
+// UsersWarmConfig describes how the users cache is warmed from users.list
+type UsersWarmConfig struct {
+	// PageSize is the users.list page size
+	PageSize int
+	// MaxUsers caps the number of cached users, 0 caches all users
+	MaxUsers int
+	// TTL is how often the users cache is refreshed, 0 never refreshes it.
+	// The users cache file is only used while it is younger than TTL.
+	TTL time.Duration
+	// Lazy defers warming until users are first resolved instead of at startup
+	Lazy bool
+}
+
+// DefaultUsersWarmConfig returns the configuration used when no SLACK_MCP_USERS_* variables are set
+func DefaultUsersWarmConfig() UsersWarmConfig {
+	return UsersWarmConfig{PageSize: DefaultUsersPageSize}
+}
+
+// UsersWarmConfigFromEnv reads the users cache warm configuration from
+// SLACK_MCP_USERS_PAGE_SIZE, SLACK_MCP_USERS_MAX, SLACK_MCP_USERS_CACHE_TTL and
+// SLACK_MCP_USERS_WARM, falling back to defaults for invalid values
+func UsersWarmConfigFromEnv(logger *zap.Logger) UsersWarmConfig {
+	cfg := DefaultUsersWarmConfig()
+
+	if v := os.Getenv("SLACK_MCP_USERS_PAGE_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxUsersPageSize {
+			logger.Warn("Invalid SLACK_MCP_USERS_PAGE_SIZE, using default",
+				zap.String("value", v),
+				zap.Int("default", DefaultUsersPageSize),
+			)
+		} else {
+			cfg.PageSize = n
+		}
+	}
+
+	if v := os.Getenv("SLACK_MCP_USERS_MAX"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			logger.Warn("Invalid SLACK_MCP_USERS_MAX, caching all users",
+				zap.String("value", v),
+			)
+		} else {
+			cfg.MaxUsers = n
+		}
+	}
+
+	if v := os.Getenv("SLACK_MCP_USERS_CACHE_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			logger.Warn("Invalid SLACK_MCP_USERS_CACHE_TTL, the users cache is not refreshed",
+				zap.String("value", v),
+			)
+		} else {
+			cfg.TTL = d
+		}
+	}
+
+	switch v := os.Getenv("SLACK_MCP_USERS_WARM"); v {
+	case "", "startup":
+	case "lazy":
+		cfg.Lazy = true
+	default:
+		logger.Warn("Invalid SLACK_MCP_USERS_WARM, warming users at startup",
+			zap.String("value", v),
+		)
+	}
+
+	return cfg
+}
+
+// UsersWarmConfig returns how the users cache of the provider is warmed
+func (ap *ApiProvider) UsersWarmConfig() UsersWarmConfig {
+	return ap.usersWarm
+}
+
+// setUsers replaces the users cache. The maps are swapped rather than updated
+// so that callers of ProvideUsersMap can keep reading the previous ones.
+func (ap *ApiProvider) setUsers(list []slack.User) {
+	users := make(map[string]slack.User, len(list))
+	usersInv := make(map[string]string, len(list))
+	for _, u := range list {
+		users[u.ID] = u
+		usersInv[u.Name] = u.ID
+	}
+
+	ap.usersMu.Lock()
+	defer ap.usersMu.Unlock()
+	ap.users = users
+	ap.usersInv = usersInv
+}
+
+// setUsersReady marks the users cache as warm, truncated when users were left out
+func (ap *ApiProvider) setUsersReady(truncated bool) {
+	ap.usersMu.Lock()
+	defer ap.usersMu.Unlock()
+	ap.usersReady = true
+	ap.usersTruncated = truncated
+}
+
+// addUsers adds users to a copy of the users cache, see setUsers
+func (ap *ApiProvider) addUsers(list []slack.User) {
+	if len(list) == 0 {
+		return
+	}
+
+	ap.usersMu.Lock()
+	defer ap.usersMu.Unlock()
+	users := make(map[string]slack.User, len(ap.users)+len(list))
+	usersInv := make(map[string]string, len(ap.usersInv)+len(list))
+	for id, u := range ap.users {
+		users[id] = u
+	}
+	for name, id := range ap.usersInv {
+		usersInv[name] = id
+	}
+	for _, u := range list {
+		users[u.ID] = u
+		usersInv[u.Name] = u.ID
+	}
+	ap.users = users
+	ap.usersInv = usersInv
+}
+
+// listUsers pages through users.list with the provider's rate limiter, stopping
+// at MaxUsers. The second result reports whether users were left out.
+func (ap *ApiProvider) listUsers(ctx context.Context) ([]slack.User, bool, error) {
+	pageSize := ap.usersWarm.PageSize
+	if pageSize < 1 {
+		pageSize = DefaultUsersPageSize
+	}
+
+	var (
+		list      []slack.User
+		truncated bool
+		waitErr   error
+	)
+	err := ap.client.ListUsersContext(ctx, pageSize, func(users []slack.User) bool {
+		list = append(list, users...)
+		ap.logger.Debug("Fetched users page",
+			zap.Int("count", len(users)),
+			zap.Int("total", len(list)),
+		)
+		if max := ap.usersWarm.MaxUsers; max > 0 && len(list) >= max {
+			// more users may exist beyond the cap
+			truncated = true
+			list = list[:max]
+			return false
+		}
+		if waitErr = ap.rateLimiter.Wait(ctx); waitErr != nil {
+			return false
+		}
+		return true
+	})
+	if err == nil {
+		err = waitErr
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return list, truncated, nil
+}
+
+// EnsureUsersWarm starts warming the users cache in the background when it is
+// warmed lazily and has not been warmed yet. It never blocks the caller.
+func (ap *ApiProvider) EnsureUsersWarm() {
+	if !ap.usersWarm.Lazy || !ap.hasClient() {
+		return
+	}
+	ap.usersWarmOnce.Do(func() {
+		go func() {
+			ctx := context.Background()
+			ap.logger.Info("Warming users cache on first use", zap.String("context", "console"))
+			if err := ap.RefreshUsers(ctx); err != nil {
+				ap.logger.Error("Failed to warm users cache", zap.Error(err))
+				return
+			}
+			ap.StartUsersRefresh(ctx)
+		}()
+	})
+}
+
+// StartUsersRefresh refetches the users cache every TTL in the background
+// until ctx is done. It does nothing without a TTL.
+func (ap *ApiProvider) StartUsersRefresh(ctx context.Context) {
+	if ap.usersWarm.TTL <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(ap.usersWarm.TTL)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := ap.fetchUsers(ctx); err != nil {
+					ap.logger.Warn("Failed to refresh users cache, keeping the cached users", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// ResolveUsers makes sure that users are in the users cache, looking up the
+// missing ones directly with users.info. Lookups only happen while the users
+// cache is incomplete (not warmed yet or capped by SLACK_MCP_USERS_MAX), and
+// a lazy warm is started in the background without waiting for it.
+func (ap *ApiProvider) ResolveUsers(ids []string) {
+	ap.EnsureUsersWarm()
+	ap.usersMu.RLock()
+	complete := ap.usersReady && !ap.usersTruncated
+	ap.usersMu.RUnlock()
+	if !ap.hasClient() || complete {
+		return
+	}
+
+	users := ap.ProvideUsersMap().Users
+	var (
+		missing []string
+		seen    = make(map[string]bool)
+	)
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		if _, ok := users[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	for start := 0; start < len(missing); start += usersInfoBatchSize {
+		end := min(start+usersInfoBatchSize, len(missing))
+		resolved, err := ap.client.GetUsersInfo(missing[start:end]...)
+		if err != nil {
+			ap.logger.Debug("Failed to resolve users", zap.Strings("users", missing[start:end]), zap.Error(err))
+			return
+		}
+		if resolved != nil {
+			ap.addUsers(*resolved)
+		}
+	}
+}
+
+// hasClient reports whether the provider has a Slack client, which it has not
+// with demo credentials
+func (ap *ApiProvider) hasClient() bool {
+	if c, ok := ap.client.(*MCPSlackClient); ok {
+		return c != nil
+	}
+	return ap.client != nil
+}
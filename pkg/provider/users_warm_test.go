@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/provider/edge"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// usersClient serves users.list in pages of the requested size and users.info
+// for any user ID
+type usersClient struct {
+	SlackAPI
+	total     int
+	pageSizes []int
+	infoCalls [][]string
+}
+
+func (c *usersClient) ListUsersContext(_ context.Context, pageSize int, page func(users []slack.User) bool) error {
+	for start := 0; start < c.total; start += pageSize {
+		c.pageSizes = append(c.pageSizes, pageSize)
+		var users []slack.User
+		for i := start; i < min(start+pageSize, c.total); i++ {
+			users = append(users, slack.User{ID: fmt.Sprintf("U%d", i), Name: fmt.Sprintf("user%d", i)})
+		}
+		if !page(users) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (c *usersClient) ClientUserBoot(context.Context) (*edge.ClientUserBootResponse, error) {
+	return &edge.ClientUserBootResponse{}, nil
+}
+
+func (c *usersClient) GetUsersInfo(users ...string) (*[]slack.User, error) {
+	c.infoCalls = append(c.infoCalls, users)
+	resolved := make([]slack.User, 0, len(users))
+	for _, id := range users {
+		resolved = append(resolved, slack.User{ID: id, Name: strings.ToLower(id)})
+	}
+	return &resolved, nil
+}
+
+func newUsersTestProvider(t *testing.T, client *usersClient, cfg UsersWarmConfig) *ApiProvider {
+	ap := NewWithClient("stdio", client, zap.NewNop())
+	ap.rateLimiter = rate.NewLimiter(rate.Inf, 1)
+	ap.usersCache = filepath.Join(t.TempDir(), "users_cache.json")
+	ap.usersWarm = cfg
+	return ap
+}
+
+func TestUnitRefreshUsersPaginates(t *testing.T) {
+	client := &usersClient{total: 25}
+	ap := newUsersTestProvider(t, client, UsersWarmConfig{PageSize: 10})
+
+	require.NoError(t, ap.RefreshUsers(context.Background()))
+	assert.Equal(t, []int{10, 10, 10}, client.pageSizes)
+	assert.Len(t, ap.ProvideUsersMap().Users, 25)
+	assert.Equal(t, "U7", ap.ProvideUsersMap().UsersInv["user7"])
+
+	ready, _ := ap.IsReady()
+	assert.False(t, ready, "channels are not cached yet")
+	assert.True(t, ap.usersReady)
+	assert.False(t, ap.usersTruncated)
+}
+
+func TestUnitRefreshUsersCapped(t *testing.T) {
+	client := &usersClient{total: 100}
+	ap := newUsersTestProvider(t, client, UsersWarmConfig{PageSize: 10, MaxUsers: 25})
+
+	require.NoError(t, ap.RefreshUsers(context.Background()))
+	assert.Len(t, client.pageSizes, 3, "paging stops once the cap is reached")
+	assert.Len(t, ap.ProvideUsersMap().Users, 25)
+	assert.True(t, ap.usersTruncated)
+
+	// users beyond the cap are resolved on demand
+	ap.ResolveUsers([]string{"U1", "U99", "U99", ""})
+	assert.Equal(t, [][]string{{"U99"}}, client.infoCalls)
+	assert.Contains(t, ap.ProvideUsersMap().Users, "U99")
+}
+
+func TestUnitRefreshUsersCacheTTL(t *testing.T) {
+	client := &usersClient{total: 3}
+	ap := newUsersTestProvider(t, client, UsersWarmConfig{PageSize: 10, TTL: time.Hour})
+	require.NoError(t, os.WriteFile(ap.usersCache, []byte(`[{"id":"U_CACHED","name":"cached"}]`), 0644))
+
+	require.NoError(t, ap.RefreshUsers(context.Background()))
+	assert.Empty(t, client.pageSizes, "a fresh cache file is used")
+	assert.Contains(t, ap.ProvideUsersMap().Users, "U_CACHED")
+
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(ap.usersCache, old, old))
+	require.NoError(t, ap.RefreshUsers(context.Background()))
+	assert.Len(t, client.pageSizes, 1, "a stale cache file is refetched")
+	assert.NotContains(t, ap.ProvideUsersMap().Users, "U_CACHED")
+	assert.Len(t, ap.ProvideUsersMap().Users, 3)
+}
+
+func TestUnitResolveUsersBeforeWarm(t *testing.T) {
+	client := &usersClient{}
+	ap := newUsersTestProvider(t, client, DefaultUsersWarmConfig())
+
+	before := ap.ProvideUsersMap().Users
+	ids := make([]string, 0, usersInfoBatchSize+1)
+	for i := 0; i <= usersInfoBatchSize; i++ {
+		ids = append(ids, fmt.Sprintf("U%d", i))
+	}
+	ap.ResolveUsers(ids)
+	require.Len(t, client.infoCalls, 2, "users are looked up in batches")
+	assert.Len(t, ap.ProvideUsersMap().Users, usersInfoBatchSize+1)
+	assert.Empty(t, before, "maps handed out before are never modified")
+
+	ap.ResolveUsers(ids)
+	assert.Len(t, client.infoCalls, 2, "cached users are not looked up again")
+
+	ap.usersReady = true
+	ap.ResolveUsers([]string{"U_UNKNOWN"})
+	assert.Len(t, client.infoCalls, 2, "a complete users cache is not extended")
+}
+
+func TestUnitUsersWarmConfigFromEnv(t *testing.T) {
+	assert.Equal(t, DefaultUsersWarmConfig(), UsersWarmConfigFromEnv(zap.NewNop()))
+
+	t.Setenv("SLACK_MCP_USERS_PAGE_SIZE", "200")
+	t.Setenv("SLACK_MCP_USERS_MAX", "50000")
+	t.Setenv("SLACK_MCP_USERS_CACHE_TTL", "6h")
+	t.Setenv("SLACK_MCP_USERS_WARM", "lazy")
+	assert.Equal(t, UsersWarmConfig{PageSize: 200, MaxUsers: 50000, TTL: 6 * time.Hour, Lazy: true}, UsersWarmConfigFromEnv(zap.NewNop()))
+
+	t.Setenv("SLACK_MCP_USERS_PAGE_SIZE", "5000")
+	t.Setenv("SLACK_MCP_USERS_MAX", "-1")
+	t.Setenv("SLACK_MCP_USERS_CACHE_TTL", "daily")
+	t.Setenv("SLACK_MCP_USERS_WARM", "eager")
+	assert.Equal(t, DefaultUsersWarmConfig(), UsersWarmConfigFromEnv(zap.NewNop()), "invalid values fall back to defaults")
+}
+
+func TestUnitLazyUsersWarm(t *testing.T) {
+	client := &usersClient{total: 3}
+	ap := newUsersTestProvider(t, client, UsersWarmConfig{PageSize: 10, Lazy: true})
+	ap.channelsReady = true
+
+	ready, err := ap.IsReady()
+	assert.True(t, ready, "lazily warmed users do not hold back readiness")
+	assert.NoError(t, err)
+
+	ap.ResolveUsers([]string{"U1"})
+	assert.Contains(t, ap.ProvideUsersMap().Users, "U1", "users are resolved without waiting for the warm")
+	assert.Eventually(t, func() bool {
+		ap.usersMu.RLock()
+		defer ap.usersMu.RUnlock()
+		return len(ap.users) == 3 && ap.usersReady
+	}, time.Second, 10*time.Millisecond)
+}
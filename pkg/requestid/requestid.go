@@ -0,0 +1,16 @@
+package requestid
+
+import "context"
+
+type requestIDKey struct{}
+
+// With adds a request ID to the context
+func With(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// FromContext extracts the request ID from the context, empty string if not set
+func FromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
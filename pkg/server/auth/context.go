@@ -1,6 +1,10 @@
 package auth
 
-import "context"
+import (
+	"context"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
+)
 
 type userContextKey struct{}
 type userTokenKey struct{}
@@ -9,9 +13,11 @@ type userTokenKey struct{}
 type UserContext struct {
 	UserID      string
 	TeamID      string
-	AccessToken string // User token (xoxp-...) for per-request client creation
-	BotToken    string // Bot token (xoxb-...) if available - for posting as bot
-	BotUserID   string // Bot user ID if available
+	AccessToken string   // User token (xoxp-...) for per-request client creation
+	BotToken    string   // Bot token (xoxb-...) if available - for posting as bot
+	BotUserID   string   // Bot user ID if available
+	BotScopes   []string // Scopes granted to the bot token if known
+	UserName    string   // Display name, set only when user name resolution is enabled
 }
 
 // WithUserContext adds user context to the context
@@ -25,4 +31,12 @@ func FromContext(ctx context.Context) (*UserContext, bool) {
 	return user, ok
 }
 
+// WithRequestID adds a request ID to the context
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return requestid.With(ctx, requestID)
+}
 
+// RequestIDFromContext extracts the request ID from the context, empty string if not set
+func RequestIDFromContext(ctx context.Context) string {
+	return requestid.FromContext(ctx)
+}
@@ -18,7 +18,10 @@ func OAuthMiddleware(oauthMgr oauth.OAuthManager, logger *zap.Logger) server.Too
 			// Extract token from context
 			token, ok := ctx.Value(authKey{}).(string)
 			if !ok {
-				logger.Warn("Missing auth token in OAuth mode")
+				logger.Warn("Missing auth token in OAuth mode",
+					zap.String("tool", req.Params.Name),
+					zap.String("request_id", RequestIDFromContext(ctx)),
+				)
 				return nil, fmt.Errorf("missing authentication token")
 			}
 
@@ -28,14 +31,21 @@ func OAuthMiddleware(oauthMgr oauth.OAuthManager, logger *zap.Logger) server.Too
 			// Validate token
 			tokenInfo, err := oauthMgr.ValidateToken(token)
 			if err != nil {
-				logger.Warn("Invalid token", zap.Error(err))
+				logger.Warn("Invalid token",
+					zap.String("tool", req.Params.Name),
+					zap.String("request_id", RequestIDFromContext(ctx)),
+					zap.Error(err),
+				)
 				return nil, fmt.Errorf("invalid authentication token: %w", err)
 			}
 
 			// Get full token response to access bot token if available
 			storedToken, err := oauthMgr.GetStoredToken(tokenInfo.UserID)
 			if err != nil {
-				logger.Warn("Failed to retrieve stored token", zap.Error(err))
+				logger.Warn("Failed to retrieve stored token",
+					zap.String("request_id", RequestIDFromContext(ctx)),
+					zap.Error(err),
+				)
 				// Fallback: use validated token without bot token
 				storedToken = &oauth.TokenResponse{
 					AccessToken: token,
@@ -50,6 +60,7 @@ func OAuthMiddleware(oauthMgr oauth.OAuthManager, logger *zap.Logger) server.Too
 				AccessToken: token,                  // User token for per-request client
 				BotToken:    storedToken.BotToken,   // Bot token if available
 				BotUserID:   storedToken.BotUserID,  // Bot user ID if available
+				BotScopes:   storedToken.BotScopes,  // Bot token scopes if known
 			}
 
 			// Inject user context
@@ -58,6 +69,7 @@ func OAuthMiddleware(oauthMgr oauth.OAuthManager, logger *zap.Logger) server.Too
 			logger.Debug("Authenticated user",
 				zap.String("userID", userCtx.UserID),
 				zap.String("teamID", userCtx.TeamID),
+				zap.String("request_id", RequestIDFromContext(ctx)),
 			)
 
 			return next(ctx, req)
@@ -90,6 +90,7 @@ func BuildMiddleware(transport string, logger *zap.Logger) server.ToolHandlerMid
 				zap.String("context", "http"),
 				zap.String("transport", transport),
 				zap.String("tool", req.Params.Name),
+				zap.String("request_id", RequestIDFromContext(ctx)),
 			)
 
 			if authenticated, err := IsAuthenticated(ctx, transport, logger); !authenticated {
@@ -97,6 +98,7 @@ func BuildMiddleware(transport string, logger *zap.Logger) server.ToolHandlerMid
 					zap.String("context", "http"),
 					zap.String("transport", transport),
 					zap.String("tool", req.Params.Name),
+					zap.String("request_id", RequestIDFromContext(ctx)),
 					zap.Error(err),
 				)
 				return nil, err
@@ -106,6 +108,7 @@ func BuildMiddleware(transport string, logger *zap.Logger) server.ToolHandlerMid
 				zap.String("context", "http"),
 				zap.String("transport", transport),
 				zap.String("tool", req.Params.Name),
+				zap.String("request_id", RequestIDFromContext(ctx)),
 			)
 
 			return next(ctx, req)
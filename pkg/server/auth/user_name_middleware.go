@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+const (
+	userNameCacheTTL = 24 * time.Hour
+	// userNameFailureTTL keeps a failed resolution from calling users.info on every request
+	userNameFailureTTL = 5 * time.Minute
+)
+
+// UserNameResolver resolves the authenticated user to a display name
+type UserNameResolver func(ctx context.Context, userCtx *UserContext) (string, error)
+
+// SlackUserNameResolver resolves the display name with users.info using the user's own token
+func SlackUserNameResolver(ctx context.Context, userCtx *UserContext) (string, error) {
+	user, err := slack.New(userCtx.AccessToken).GetUserInfoContext(ctx, userCtx.UserID)
+	if err != nil {
+		return "", err
+	}
+	if user.Profile.DisplayName != "" {
+		return user.Profile.DisplayName, nil
+	}
+	if user.RealName != "" {
+		return user.RealName, nil
+	}
+	return user.Name, nil
+}
+
+type userNameEntry struct {
+	name      string // empty when the resolution failed
+	expiresAt time.Time
+}
+
+// UserNameMiddleware fills UserContext.UserName for authenticated requests, it must
+// be registered after OAuthMiddleware. Names are cached per team and user, failed
+// resolutions for a short while, and a failed resolution never fails the request.
+func UserNameMiddleware(resolve UserNameResolver, logger *zap.Logger) server.ToolHandlerMiddleware {
+	var (
+		mu    sync.RWMutex
+		cache = make(map[string]userNameEntry)
+	)
+
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			userCtx, ok := FromContext(ctx)
+			if !ok || userCtx.UserName != "" {
+				return next(ctx, req)
+			}
+
+			key := userCtx.TeamID + "/" + userCtx.UserID
+
+			mu.RLock()
+			entry, found := cache[key]
+			mu.RUnlock()
+
+			if !found || time.Now().After(entry.expiresAt) {
+				name, err := resolve(ctx, userCtx)
+				if err != nil || name == "" {
+					logger.Warn("Failed to resolve user name",
+						zap.String("userID", userCtx.UserID),
+						zap.String("request_id", RequestIDFromContext(ctx)),
+						zap.Error(err),
+					)
+					entry = userNameEntry{expiresAt: time.Now().Add(userNameFailureTTL)}
+				} else {
+					entry = userNameEntry{name: name, expiresAt: time.Now().Add(userNameCacheTTL)}
+				}
+				mu.Lock()
+				cache[key] = entry
+				mu.Unlock()
+			}
+			if entry.name == "" {
+				return next(ctx, req)
+			}
+
+			// Copy to keep the context value immutable for other requests
+			enriched := *userCtx
+			enriched.UserName = entry.name
+			ctx = WithUserContext(ctx, &enriched)
+
+			logger.Debug("Resolved user name",
+				zap.String("userID", enriched.UserID),
+				zap.String("userName", enriched.UserName),
+				zap.String("request_id", RequestIDFromContext(ctx)),
+			)
+
+			return next(ctx, req)
+		}
+	}
+}
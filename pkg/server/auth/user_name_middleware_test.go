@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitUserNameMiddleware(t *testing.T) {
+	calls := 0
+	resolve := func(ctx context.Context, userCtx *UserContext) (string, error) {
+		calls++
+		if userCtx.UserID == "UFAIL" {
+			return "", errors.New("users_not_found")
+		}
+		return "name-of-" + userCtx.UserID, nil
+	}
+
+	var seen string
+	handler := UserNameMiddleware(resolve, zap.NewNop())(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		userCtx, _ := FromContext(ctx)
+		seen = userCtx.UserName
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	ctx := WithUserContext(context.Background(), &UserContext{UserID: "U1", TeamID: "T1"})
+	for i := 0; i < 3; i++ {
+		_, err := handler(ctx, mcp.CallToolRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, "name-of-U1", seen)
+	}
+	assert.Equal(t, 1, calls, "resolved names must be cached")
+
+	original, _ := FromContext(ctx)
+	assert.Empty(t, original.UserName, "the original user context must not be mutated")
+
+	failing := WithUserContext(context.Background(), &UserContext{UserID: "UFAIL", TeamID: "T1"})
+	for i := 0; i < 3; i++ {
+		res, err := handler(failing, mcp.CallToolRequest{})
+		require.NoError(t, err, "resolution failures must not fail the request")
+		require.NotNil(t, res)
+		assert.Empty(t, seen)
+	}
+	assert.Equal(t, 2, calls, "failed resolutions must be cached too")
+}
@@ -0,0 +1,233 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/handler"
+	"github.com/korotovsky/slack-mcp-server/pkg/provider"
+	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
+	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+// ChannelResolver turns a #channel or @user_dm name into a channel ID
+type ChannelResolver func(name string) (string, bool)
+
+// ParseChannelAllowlist splits SLACK_MCP_CHANNEL_ALLOWLIST into channel IDs and names
+func ParseChannelAllowlist(config string) []string {
+	var entries []string
+	for _, item := range strings.Split(config, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			entries = append(entries, item)
+		}
+	}
+	return entries
+}
+
+// ProviderChannelResolver resolves names from the channels cache of the provider
+func ProviderChannelResolver(ap *provider.ApiProvider) ChannelResolver {
+	return func(name string) (string, bool) {
+		id, ok := ap.ProvideChannelsMaps().ChannelsInv[name]
+		return id, ok
+	}
+}
+
+// isChannelName reports whether a channel reference is a name rather than an ID
+func isChannelName(channel string) bool {
+	return strings.HasPrefix(channel, "#") || strings.HasPrefix(channel, "@")
+}
+
+// searchTools search across all channels unless a channel filter narrows them down
+var searchTools = map[string]bool{
+	"conversations_search_messages": true,
+	"files_search":                  true,
+}
+
+// unverifiedReadMethods are the prefixes of the Slack methods reaching channels
+// that cannot be checked from their params, e.g. files.info and search.messages,
+// so slack_api_read refuses them while an allowlist is set
+var unverifiedReadMethods = []string{
+	"files.",
+	"reactions.list",
+	"search.",
+	"stars.",
+}
+
+// apiReadChannelParams are the params in which read methods take channels,
+// e.g. channel for conversations.history and channel_id for bookmarks.list
+var apiReadChannelParams = []string{"channel", "channel_id", "channels"}
+
+// apiReadFileParams are the params with which read methods reach a file rather
+// than a channel, e.g. reactions.get by file
+var apiReadFileParams = []string{"file", "file_comment"}
+
+// requestChannels returns the channels a tool call reaches: channel_id,
+// channel_ids, the channel of a permalink, the channel filters of searches,
+// including in: filters written into search_query, and the channels in the
+// params of slack_api_read.
+// Searches without a channel filter reach every channel and are refused.
+func requestChannels(req mcp.CallToolRequest) ([]string, error) {
+	var channels []string
+	for _, key := range []string{"channel_id", "filter_in_channel", "filter_in_im_or_mpim"} {
+		if channel := strings.TrimSpace(req.GetString(key, "")); channel != "" {
+			channels = append(channels, channel)
+		}
+	}
+	for _, channel := range strings.Split(req.GetString("channel_ids", ""), ",") {
+		if channel = strings.TrimSpace(channel); channel != "" {
+			channels = append(channels, channel)
+		}
+	}
+	if permalink := strings.TrimSpace(req.GetString("permalink", "")); permalink != "" {
+		link, err := text.ParsePermalink(permalink)
+		if err != nil {
+			return nil, fmt.Errorf("channel not permitted: the channel of the permalink cannot be checked against the channel allowlist of this server: %w", err)
+		}
+		channels = append(channels, link.ChannelID)
+	}
+	if req.Params.Name == "slack_api_read" {
+		apiChannels, err := apiReadChannels(strings.TrimSpace(req.GetString("method", "")), req.GetArguments()["params"])
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, apiChannels...)
+	}
+	if searchTools[req.Params.Name] {
+		channels = append(channels, handler.SearchQueryChannels(req.GetString("search_query", ""))...)
+	}
+	if searchTools[req.Params.Name] && len(channels) == 0 {
+		return nil, fmt.Errorf("channel not permitted: %s searches all channels, set filter_in_channel or filter_in_im_or_mpim to a channel in the channel allowlist of this server", req.Params.Name)
+	}
+	return channels, nil
+}
+
+// apiReadChannels returns the channels in the params of slack_api_read, given
+// as an object or a JSON string, as read methods such as conversations.history
+// and bookmarks.list take. Methods reaching files or channels that cannot be
+// checked, see unverifiedReadMethods and apiReadFileParams, are refused.
+func apiReadChannels(method string, raw any) ([]string, error) {
+	for _, prefix := range unverifiedReadMethods {
+		if strings.HasPrefix(method, prefix) {
+			return nil, fmt.Errorf("channel not permitted: %s reaches channels that cannot be checked against the channel allowlist of this server", method)
+		}
+	}
+
+	params, _ := raw.(map[string]any)
+	if s, isString := raw.(string); isString && strings.TrimSpace(s) != "" {
+		if err := json.Unmarshal([]byte(s), &params); err != nil {
+			return nil, fmt.Errorf("channel not permitted: params must be a JSON object to be checked against the channel allowlist of this server: %w", err)
+		}
+	}
+	for _, key := range apiReadFileParams {
+		if params[key] != nil {
+			return nil, fmt.Errorf("channel not permitted: %s by params.%s reaches a file whose channels cannot be checked against the channel allowlist of this server", method, key)
+		}
+	}
+
+	var channels []string
+	for _, key := range apiReadChannelParams {
+		switch v := params[key].(type) {
+		case nil:
+		case string:
+			for _, channel := range strings.Split(v, ",") {
+				if channel = strings.TrimSpace(channel); channel != "" {
+					channels = append(channels, channel)
+				}
+			}
+		case []any:
+			if key != "channels" {
+				return nil, fmt.Errorf("channel not permitted: params.%s must be a channel ID to be checked against the channel allowlist of this server", key)
+			}
+			for _, item := range v {
+				channel, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("channel not permitted: params.channels must hold channel IDs to be checked against the channel allowlist of this server")
+				}
+				channels = append(channels, strings.TrimSpace(channel))
+			}
+		default:
+			return nil, fmt.Errorf("channel not permitted: params.%s must be a channel ID to be checked against the channel allowlist of this server", key)
+		}
+	}
+	return channels, nil
+}
+
+// buildChannelAllowlistMiddleware rejects tool calls reaching a channel that is
+// not in the allowlist, see requestChannels. Names are resolved on every call,
+// both in the allowlist and in the request, as the channels cache is loaded in
+// the background. An empty allowlist allows all channels.
+func buildChannelAllowlistMiddleware(entries []string, resolve ChannelResolver, logger *zap.Logger) server.ToolHandlerMiddleware {
+	var (
+		ids   = make(map[string]bool)
+		names []string
+	)
+	for _, entry := range entries {
+		if isChannelName(entry) {
+			names = append(names, entry)
+		} else {
+			ids[entry] = true
+		}
+	}
+
+	allowed := func(channel string) bool {
+		channel = strings.TrimSpace(channel)
+		if isChannelName(channel) {
+			if resolve == nil {
+				return false
+			}
+			id, ok := resolve(channel)
+			if !ok {
+				return false
+			}
+			channel = id
+		}
+		if ids[channel] {
+			return true
+		}
+		for _, name := range names {
+			if resolve == nil {
+				break
+			}
+			if id, ok := resolve(name); ok && id == channel {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		if len(entries) == 0 {
+			return next
+		}
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			channels, err := requestChannels(req)
+			if err != nil {
+				logger.Warn("Channel not permitted by SLACK_MCP_CHANNEL_ALLOWLIST",
+					zap.String("tool", req.Params.Name),
+					zap.String("request_id", auth.RequestIDFromContext(ctx)),
+					zap.Error(err),
+				)
+				return nil, err
+			}
+
+			for _, channel := range channels {
+				if allowed(channel) {
+					continue
+				}
+				logger.Warn("Channel not permitted by SLACK_MCP_CHANNEL_ALLOWLIST",
+					zap.String("tool", req.Params.Name),
+					zap.String("request_id", auth.RequestIDFromContext(ctx)),
+					zap.String("channel", channel),
+				)
+				return nil, fmt.Errorf("channel not permitted: %s is not in the channel allowlist of this server", channel)
+			}
+
+			return next(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func callWithAllowlist(t *testing.T, entries []string, resolve ChannelResolver, args map[string]any) (bool, error) {
+	t.Helper()
+	return callToolWithAllowlist(t, "conversations_history", entries, resolve, args)
+}
+
+func callToolWithAllowlist(t *testing.T, tool string, entries []string, resolve ChannelResolver, args map[string]any) (bool, error) {
+	t.Helper()
+	called := false
+	next := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		called = true
+		return mcp.NewToolResultText("ok"), nil
+	}
+
+	var req mcp.CallToolRequest
+	req.Params.Name = tool
+	req.Params.Arguments = args
+	_, err := buildChannelAllowlistMiddleware(entries, resolve, zap.NewNop())(next)(context.Background(), req)
+	return called, err
+}
+
+func TestUnitParseChannelAllowlist(t *testing.T) {
+	assert.Empty(t, ParseChannelAllowlist(""))
+	assert.Equal(t, []string{"C1", "#general"}, ParseChannelAllowlist(" C1, ,#general ,"))
+}
+
+func TestUnitChannelAllowlistAllowed(t *testing.T) {
+	resolve := func(name string) (string, bool) {
+		id, ok := map[string]string{"#general": "C2", "@alice": "D1"}[name]
+		return id, ok
+	}
+	entries := []string{"C1", "#general"}
+
+	for _, channel := range []string{"C1", "C2", "#general"} {
+		called, err := callWithAllowlist(t, entries, resolve, map[string]any{"channel_id": channel})
+		require.NoError(t, err, channel)
+		assert.True(t, called, channel)
+	}
+
+	called, err := callWithAllowlist(t, entries, resolve, map[string]any{"channel_ids": "C1, #general"})
+	require.NoError(t, err)
+	assert.True(t, called)
+
+	called, err = callWithAllowlist(t, entries, resolve, map[string]any{"query": "no channel"})
+	require.NoError(t, err)
+	assert.True(t, called, "tools without a channel are not restricted")
+}
+
+func TestUnitChannelAllowlistDenied(t *testing.T) {
+	resolve := func(name string) (string, bool) {
+		id, ok := map[string]string{"#general": "C2", "@alice": "D1"}[name]
+		return id, ok
+	}
+	entries := []string{"C1", "#general"}
+
+	for _, args := range []map[string]any{
+		{"channel_id": "C3"},
+		{"channel_id": "@alice"},
+		{"channel_id": "#unknown"},
+		{"channel_ids": "C1,C3"},
+	} {
+		called, err := callWithAllowlist(t, entries, resolve, args)
+		require.Error(t, err, args)
+		assert.Contains(t, err.Error(), "channel not permitted")
+		assert.False(t, called, args)
+	}
+
+	// names cannot be checked without a resolver
+	called, err := callWithAllowlist(t, []string{"C1"}, nil, map[string]any{"channel_id": "#general"})
+	require.Error(t, err)
+	assert.False(t, called)
+}
+
+func TestUnitChannelAllowlistEmpty(t *testing.T) {
+	called, err := callWithAllowlist(t, nil, nil, map[string]any{"channel_id": "C3"})
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestUnitChannelAllowlistPermalink(t *testing.T) {
+	entries := []string{"C1"}
+
+	called, err := callToolWithAllowlist(t, "conversations_get_message", entries, nil, map[string]any{
+		"permalink": "https://acme.slack.com/archives/C1/p1700000000000100",
+	})
+	require.NoError(t, err)
+	assert.True(t, called)
+
+	for _, permalink := range []string{
+		"https://acme.slack.com/archives/C3/p1700000000000100",
+		"https://acme.slack.com/archives/C3/p1700000000000100?thread_ts=1700000000.000050",
+		"not a permalink",
+	} {
+		called, err := callToolWithAllowlist(t, "conversations_get_message", entries, nil, map[string]any{"permalink": permalink})
+		require.Error(t, err, permalink)
+		assert.Contains(t, err.Error(), "channel not permitted")
+		assert.False(t, called, permalink)
+	}
+}
+
+func TestUnitChannelAllowlistSearch(t *testing.T) {
+	resolve := func(name string) (string, bool) {
+		id, ok := map[string]string{"#general": "C2", "@alice": "D1"}[name]
+		return id, ok
+	}
+	entries := []string{"C1", "#general"}
+
+	for _, tool := range []string{"conversations_search_messages", "files_search"} {
+		called, err := callToolWithAllowlist(t, tool, entries, resolve, map[string]any{"search_query": "report", "filter_in_channel": "#general"})
+		require.NoError(t, err, tool)
+		assert.True(t, called, tool)
+
+		for _, args := range []map[string]any{
+			{"search_query": "report"},
+			{"search_query": "report", "filter_in_channel": "C3"},
+			{"search_query": "report", "filter_in_im_or_mpim": "@alice"},
+			{"search_query": "report", "filter_in_channel": "C1", "filter_in_im_or_mpim": "@alice"},
+		} {
+			called, err := callToolWithAllowlist(t, tool, entries, resolve, args)
+			require.Error(t, err, tool, args)
+			assert.Contains(t, err.Error(), "channel not permitted")
+			assert.False(t, called, tool, args)
+		}
+	}
+
+	called, err := callToolWithAllowlist(t, "files_search", nil, resolve, map[string]any{"search_query": "report"})
+	require.NoError(t, err)
+	assert.True(t, called, "searches are not restricted without an allowlist")
+}
+
+func TestUnitChannelAllowlistSearchQueryFilters(t *testing.T) {
+	resolve := func(name string) (string, bool) {
+		id, ok := map[string]string{"#general": "C2", "#secret": "C3"}[name]
+		return id, ok
+	}
+	entries := []string{"C1", "#general"}
+
+	for _, tool := range []string{"conversations_search_messages", "files_search"} {
+		// Slack ORs in: filters, so one written into the query widens the search
+		for _, args := range []map[string]any{
+			{"search_query": "in:#secret report", "filter_in_channel": "#general"},
+			{"search_query": "report IN:C3", "filter_in_channel": "C1"},
+			{"search_query": "in:<#C3|secret> report", "filter_in_channel": "C1"},
+			{"search_query": "in:#general in:#secret report"},
+			{"search_query": "in:#unknown report"},
+		} {
+			called, err := callToolWithAllowlist(t, tool, entries, resolve, args)
+			require.Error(t, err, tool, args)
+			assert.Contains(t, err.Error(), "channel not permitted")
+			assert.False(t, called, tool, args)
+		}
+
+		for _, args := range []map[string]any{
+			{"search_query": "in:#general report"},
+			{"search_query": "in:<#C1> report", "filter_in_channel": "#general"},
+		} {
+			called, err := callToolWithAllowlist(t, tool, entries, resolve, args)
+			require.NoError(t, err, tool, args)
+			assert.True(t, called, tool, args)
+		}
+	}
+}
+
+func TestUnitChannelAllowlistAPIRead(t *testing.T) {
+	entries := []string{"C1"}
+
+	for _, params := range []any{
+		map[string]any{"channel": "C1", "limit": float64(10)},
+		`{"channel": "C1"}`,
+		map[string]any{"user": "U1"},
+		nil,
+	} {
+		called, err := callToolWithAllowlist(t, "slack_api_read", entries, nil, map[string]any{"method": "conversations.history", "params": params})
+		require.NoError(t, err, params)
+		assert.True(t, called, params)
+	}
+
+	for _, params := range []any{
+		map[string]any{"channel": "C3"},
+		`{"channel": "C3"}`,
+		map[string]any{"channel": []any{"C3"}},
+		`not json`,
+	} {
+		called, err := callToolWithAllowlist(t, "slack_api_read", entries, nil, map[string]any{"method": "conversations.history", "params": params})
+		require.Error(t, err, params)
+		assert.Contains(t, err.Error(), "channel not permitted")
+		assert.False(t, called, params)
+	}
+}
+
+func TestUnitChannelAllowlistAPIReadChannelParams(t *testing.T) {
+	entries := []string{"C1"}
+
+	for _, args := range []map[string]any{
+		{"method": "bookmarks.list", "params": map[string]any{"channel_id": "C1"}},
+		{"method": "bookmarks.list", "params": `{"channel_id": "C1"}`},
+		{"method": "conversations.history", "params": map[string]any{"channels": "C1"}},
+		{"method": "conversations.history", "params": map[string]any{"channels": []any{"C1"}}},
+		{"method": "reactions.get", "params": map[string]any{"channel": "C1", "timestamp": "1700000000.000100"}},
+		{"method": "users.info", "params": map[string]any{"user": "U1"}},
+	} {
+		called, err := callToolWithAllowlist(t, "slack_api_read", entries, nil, args)
+		require.NoError(t, err, args)
+		assert.True(t, called, args)
+	}
+
+	for _, args := range []map[string]any{
+		{"method": "bookmarks.list", "params": map[string]any{"channel_id": "C3"}},
+		{"method": "bookmarks.list", "params": `{"channel_id": "C3"}`},
+		{"method": "conversations.history", "params": map[string]any{"channels": "C1,C3"}},
+		{"method": "conversations.history", "params": map[string]any{"channels": []any{"C1", "C3"}}},
+		{"method": "conversations.history", "params": map[string]any{"channel": "C1", "channel_id": "C3"}},
+	} {
+		called, err := callToolWithAllowlist(t, "slack_api_read", entries, nil, args)
+		require.Error(t, err, args)
+		assert.Contains(t, err.Error(), "channel not permitted")
+		assert.False(t, called, args)
+	}
+}
+
+func TestUnitChannelAllowlistAPIReadUnverifiedMethods(t *testing.T) {
+	entries := []string{"C1"}
+
+	for _, args := range []map[string]any{
+		{"method": "files.info", "params": map[string]any{"file": "F1"}},
+		{"method": "files.info", "params": map[string]any{"file": "F1", "channel": "C1"}},
+		{"method": "files.list", "params": nil},
+		{"method": "search.messages", "params": map[string]any{"query": "hello"}},
+		{"method": "reactions.get", "params": map[string]any{"file": "F1"}},
+		{"method": "reactions.get", "params": map[string]any{"file_comment": "Fc1", "channel": "C1"}},
+	} {
+		called, err := callToolWithAllowlist(t, "slack_api_read", entries, nil, args)
+		require.Error(t, err, args)
+		assert.Contains(t, err.Error(), "channel not permitted")
+		assert.Contains(t, err.Error(), "cannot be checked")
+		assert.False(t, called, args)
+	}
+
+	called, err := callToolWithAllowlist(t, "slack_api_read", nil, nil, map[string]any{"method": "files.info", "params": map[string]any{"file": "F1"}})
+	require.NoError(t, err)
+	assert.True(t, called)
+}
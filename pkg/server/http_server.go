@@ -0,0 +1,121 @@
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	defaultReadHeaderTimeout = 10 * time.Second
+	defaultReadTimeout       = 30 * time.Second
+	defaultIdleTimeout       = 120 * time.Second
+)
+
+// HTTPServerConfig describes the timeouts and TLS settings of the HTTP server
+// exposing the MCP and OAuth endpoints
+type HTTPServerConfig struct {
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	// WriteTimeout is disabled by default, as it would also cut off SSE and
+	// streamable HTTP responses that stay open while the client is connected
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+
+	// TLS is served when both files are set
+	TLSCertFile   string
+	TLSKeyFile    string
+	TLSMinVersion uint16
+}
+
+// DefaultHTTPServerConfig returns the safe defaults used when no SLACK_MCP_HTTP_*
+// or SLACK_MCP_TLS_* variables are set
+func DefaultHTTPServerConfig() HTTPServerConfig {
+	return HTTPServerConfig{
+		ReadHeaderTimeout: defaultReadHeaderTimeout,
+		ReadTimeout:       defaultReadTimeout,
+		IdleTimeout:       defaultIdleTimeout,
+		TLSMinVersion:     tls.VersionTLS12,
+	}
+}
+
+// HTTPServerConfigFromEnv reads the HTTP server configuration from SLACK_MCP_HTTP_*
+// and SLACK_MCP_TLS_* variables
+func HTTPServerConfigFromEnv() (HTTPServerConfig, error) {
+	cfg := DefaultHTTPServerConfig()
+
+	timeouts := []struct {
+		env   string
+		value *time.Duration
+	}{
+		{"SLACK_MCP_HTTP_READ_HEADER_TIMEOUT", &cfg.ReadHeaderTimeout},
+		{"SLACK_MCP_HTTP_READ_TIMEOUT", &cfg.ReadTimeout},
+		{"SLACK_MCP_HTTP_WRITE_TIMEOUT", &cfg.WriteTimeout},
+		{"SLACK_MCP_HTTP_IDLE_TIMEOUT", &cfg.IdleTimeout},
+	}
+	for _, timeout := range timeouts {
+		v := os.Getenv(timeout.env)
+		if v == "" {
+			continue
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return cfg, fmt.Errorf("%s must be a non-negative duration such as 30s or 2m, got %q", timeout.env, v)
+		}
+		*timeout.value = d
+	}
+
+	if v := os.Getenv("SLACK_MCP_TLS_MIN_VERSION"); v != "" {
+		switch v {
+		case "1.2":
+			cfg.TLSMinVersion = tls.VersionTLS12
+		case "1.3":
+			cfg.TLSMinVersion = tls.VersionTLS13
+		default:
+			return cfg, fmt.Errorf("SLACK_MCP_TLS_MIN_VERSION must be 1.2 or 1.3, got %q", v)
+		}
+	}
+
+	cfg.TLSCertFile = os.Getenv("SLACK_MCP_TLS_CERT_FILE")
+	cfg.TLSKeyFile = os.Getenv("SLACK_MCP_TLS_KEY_FILE")
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return cfg, errors.New("SLACK_MCP_TLS_CERT_FILE and SLACK_MCP_TLS_KEY_FILE must be set together")
+	}
+
+	return cfg, nil
+}
+
+// TLSEnabled reports whether the server is served over TLS
+func (cfg HTTPServerConfig) TLSEnabled() bool {
+	return cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
+}
+
+// NewHTTPServer builds the http.Server for a handler, such as the combined
+// MCP and OAuth handler of ServeSSEWithOAuth or ServeHTTPWithOAuth
+func NewHTTPServer(addr string, handler http.Handler, cfg HTTPServerConfig) *http.Server {
+	minVersion := cfg.TLSMinVersion
+	if minVersion == 0 {
+		minVersion = tls.VersionTLS12
+	}
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		TLSConfig:         &tls.Config{MinVersion: minVersion},
+	}
+}
+
+// ListenAndServe serves over TLS when the configuration has a certificate
+// and over plain HTTP otherwise
+func ListenAndServe(srv *http.Server, cfg HTTPServerConfig) error {
+	if cfg.TLSEnabled() {
+		return srv.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile)
+	}
+	return srv.ListenAndServe()
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitHTTPServerConfigDefaults(t *testing.T) {
+	cfg, err := HTTPServerConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultHTTPServerConfig(), cfg)
+	assert.False(t, cfg.TLSEnabled())
+
+	srv := NewHTTPServer(":13080", http.NotFoundHandler(), cfg)
+	assert.Equal(t, 10*time.Second, srv.ReadHeaderTimeout)
+	assert.Equal(t, 30*time.Second, srv.ReadTimeout)
+	assert.Zero(t, srv.WriteTimeout, "a write timeout would cut off SSE streams")
+	assert.Equal(t, 120*time.Second, srv.IdleTimeout)
+	assert.Equal(t, uint16(tls.VersionTLS12), srv.TLSConfig.MinVersion)
+
+	srv = NewHTTPServer(":13080", http.NotFoundHandler(), HTTPServerConfig{})
+	assert.Equal(t, uint16(tls.VersionTLS12), srv.TLSConfig.MinVersion, "TLS 1.2 is the floor even without a configured version")
+}
+
+func TestUnitHTTPServerConfigFromEnv(t *testing.T) {
+	t.Setenv("SLACK_MCP_HTTP_READ_HEADER_TIMEOUT", "5s")
+	t.Setenv("SLACK_MCP_HTTP_READ_TIMEOUT", "1m")
+	t.Setenv("SLACK_MCP_HTTP_WRITE_TIMEOUT", "2m")
+	t.Setenv("SLACK_MCP_HTTP_IDLE_TIMEOUT", "0s")
+	t.Setenv("SLACK_MCP_TLS_MIN_VERSION", "1.3")
+	t.Setenv("SLACK_MCP_TLS_CERT_FILE", "/etc/tls/cert.pem")
+	t.Setenv("SLACK_MCP_TLS_KEY_FILE", "/etc/tls/key.pem")
+
+	cfg, err := HTTPServerConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, cfg.ReadHeaderTimeout)
+	assert.Equal(t, time.Minute, cfg.ReadTimeout)
+	assert.Equal(t, 2*time.Minute, cfg.WriteTimeout)
+	assert.Zero(t, cfg.IdleTimeout)
+	assert.Equal(t, uint16(tls.VersionTLS13), cfg.TLSMinVersion)
+	assert.True(t, cfg.TLSEnabled())
+}
+
+func TestUnitHTTPServerConfigFromEnvErrors(t *testing.T) {
+	for name, env := range map[string]map[string]string{
+		"invalid timeout":  {"SLACK_MCP_HTTP_READ_TIMEOUT": "soon"},
+		"negative timeout": {"SLACK_MCP_HTTP_IDLE_TIMEOUT": "-1s"},
+		"legacy TLS":       {"SLACK_MCP_TLS_MIN_VERSION": "1.0"},
+		"cert without key": {"SLACK_MCP_TLS_CERT_FILE": "/etc/tls/cert.pem"},
+		"key without cert": {"SLACK_MCP_TLS_KEY_FILE": "/etc/tls/key.pem"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range env {
+				t.Setenv(k, v)
+			}
+			_, err := HTTPServerConfigFromEnv()
+			assert.Error(t, err)
+		})
+	}
+}
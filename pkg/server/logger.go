@@ -0,0 +1,212 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const defaultLogSampleInitial = 100
+
+// LoggerConfig describes how the server logger is built
+type LoggerConfig struct {
+	Level      zapcore.Level
+	JSON       bool
+	Colors     bool
+	OutputPath string
+
+	// Debug entries with the same message are logged SampleInitial times per
+	// second and then every SampleThereafter-th time. Zero disables sampling.
+	SampleInitial    int
+	SampleThereafter int
+}
+
+// LoggerConfigFromEnv reads the logger configuration from SLACK_MCP_LOG_* variables
+func LoggerConfigFromEnv(transport string) (LoggerConfig, error) {
+	cfg := LoggerConfig{
+		Level:         zapcore.InfoLevel,
+		JSON:          shouldUseJSONFormat(),
+		OutputPath:    "stdout",
+		SampleInitial: defaultLogSampleInitial,
+	}
+	cfg.Colors = shouldUseColors() && !cfg.JSON
+
+	if transport == "stdio" {
+		cfg.OutputPath = "stderr"
+	}
+
+	if envLevel := os.Getenv("SLACK_MCP_LOG_LEVEL"); envLevel != "" {
+		if err := cfg.Level.UnmarshalText([]byte(envLevel)); err != nil {
+			fmt.Printf("Invalid log level '%s': %v, using 'info'\n", envLevel, err)
+			cfg.Level = zapcore.InfoLevel
+		}
+	}
+
+	if v := os.Getenv("SLACK_MCP_LOG_SAMPLE_INITIAL"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return cfg, fmt.Errorf("SLACK_MCP_LOG_SAMPLE_INITIAL must be a positive integer, got %q", v)
+		}
+		cfg.SampleInitial = n
+	}
+	if v := os.Getenv("SLACK_MCP_LOG_SAMPLE_THEREAFTER"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return cfg, fmt.Errorf("SLACK_MCP_LOG_SAMPLE_THEREAFTER must be a non-negative integer, got %q", v)
+		}
+		cfg.SampleThereafter = n
+	}
+
+	return cfg, nil
+}
+
+// NewLogger builds the zap logger shared by the server and all handlers
+func NewLogger(cfg LoggerConfig) (*zap.Logger, error) {
+	atomicLevel := zap.NewAtomicLevelAt(cfg.Level)
+
+	var config zap.Config
+
+	if cfg.JSON {
+		config = zap.Config{
+			Level:            atomicLevel,
+			Development:      false,
+			Encoding:         "json",
+			OutputPaths:      []string{cfg.OutputPath},
+			ErrorOutputPaths: []string{"stderr"},
+			EncoderConfig: zapcore.EncoderConfig{
+				TimeKey:       "timestamp",
+				LevelKey:      "level",
+				NameKey:       "logger",
+				MessageKey:    "message",
+				StacktraceKey: "stacktrace",
+				EncodeLevel:   zapcore.LowercaseLevelEncoder,
+				EncodeTime:    zapcore.RFC3339TimeEncoder,
+				EncodeCaller:  zapcore.ShortCallerEncoder,
+			},
+		}
+	} else {
+		config = zap.Config{
+			Level:            atomicLevel,
+			Development:      true,
+			Encoding:         "console",
+			OutputPaths:      []string{cfg.OutputPath},
+			ErrorOutputPaths: []string{"stderr"},
+			EncoderConfig: zapcore.EncoderConfig{
+				TimeKey:          "timestamp",
+				LevelKey:         "level",
+				NameKey:          "logger",
+				MessageKey:       "msg",
+				StacktraceKey:    "stacktrace",
+				EncodeLevel:      getConsoleLevelEncoder(cfg.Colors),
+				EncodeTime:       zapcore.ISO8601TimeEncoder,
+				EncodeCaller:     zapcore.ShortCallerEncoder,
+				ConsoleSeparator: " | ",
+			},
+		}
+	}
+
+	opts := []zap.Option{zap.AddCaller()}
+	if cfg.SampleThereafter > 0 {
+		opts = append(opts, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return newDebugSamplingCore(core, cfg.SampleInitial, cfg.SampleThereafter)
+		}))
+	}
+
+	logger, err := config.Build(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	logger = logger.With(zap.String("app", "slack-mcp-server"))
+
+	return logger, err
+}
+
+// debugSamplingCore samples debug entries only, so that noisy debug lines
+// can be thinned out under load without ever dropping info and above
+type debugSamplingCore struct {
+	zapcore.Core
+	sampled zapcore.Core
+}
+
+func newDebugSamplingCore(core zapcore.Core, initial, thereafter int) zapcore.Core {
+	return &debugSamplingCore{
+		Core:    core,
+		sampled: zapcore.NewSamplerWithOptions(core, time.Second, initial, thereafter),
+	}
+}
+
+func (c *debugSamplingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &debugSamplingCore{
+		Core:    c.Core.With(fields),
+		sampled: c.sampled.With(fields),
+	}
+}
+
+func (c *debugSamplingCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if entry.Level == zapcore.DebugLevel {
+		return c.sampled.Check(entry, ce)
+	}
+	return c.Core.Check(entry, ce)
+}
+
+// shouldUseJSONFormat determines if JSON format should be used
+func shouldUseJSONFormat() bool {
+	if format := os.Getenv("SLACK_MCP_LOG_FORMAT"); format != "" {
+		return strings.ToLower(format) == "json"
+	}
+
+	if env := os.Getenv("ENVIRONMENT"); env != "" {
+		switch strings.ToLower(env) {
+		case "production", "prod", "staging":
+			return true
+		case "development", "dev", "local":
+			return false
+		}
+	}
+
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" ||
+		os.Getenv("DOCKER_CONTAINER") != "" ||
+		os.Getenv("container") != "" {
+		return true
+	}
+
+	if !isatty.IsTerminal(os.Stdout.Fd()) {
+		return true
+	}
+
+	return false
+}
+
+func shouldUseColors() bool {
+	if colorEnv := os.Getenv("SLACK_MCP_LOG_COLOR"); colorEnv != "" {
+		return colorEnv == "true" || colorEnv == "1"
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	if os.Getenv("FORCE_COLOR") != "" {
+		return true
+	}
+
+	if env := os.Getenv("ENVIRONMENT"); env == "development" || env == "dev" {
+		return isatty.IsTerminal(os.Stdout.Fd())
+	}
+
+	return isatty.IsTerminal(os.Stdout.Fd())
+}
+
+func getConsoleLevelEncoder(useColors bool) zapcore.LevelEncoder {
+	if useColors {
+		return zapcore.CapitalColorLevelEncoder
+	}
+	return zapcore.CapitalLevelEncoder
+}
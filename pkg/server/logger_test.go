@@ -0,0 +1,44 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestUnitDebugSamplingCore(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(newDebugSamplingCore(core, 2, 0)).With(zap.String("app", "test"))
+
+	for i := 0; i < 10; i++ {
+		logger.Debug("noisy")
+		logger.Info("important")
+	}
+
+	assert.Equal(t, 2, logs.FilterMessage("noisy").Len(), "debug entries are sampled")
+	assert.Equal(t, 10, logs.FilterMessage("important").Len(), "info entries are never sampled")
+}
+
+func TestUnitLoggerConfigFromEnv(t *testing.T) {
+	t.Setenv("SLACK_MCP_LOG_LEVEL", "debug")
+	t.Setenv("SLACK_MCP_LOG_FORMAT", "json")
+	t.Setenv("SLACK_MCP_LOG_SAMPLE_INITIAL", "10")
+	t.Setenv("SLACK_MCP_LOG_SAMPLE_THEREAFTER", "50")
+
+	cfg, err := LoggerConfigFromEnv("stdio")
+	require.NoError(t, err)
+	assert.Equal(t, zapcore.DebugLevel, cfg.Level)
+	assert.True(t, cfg.JSON)
+	assert.False(t, cfg.Colors)
+	assert.Equal(t, "stderr", cfg.OutputPath)
+	assert.Equal(t, 10, cfg.SampleInitial)
+	assert.Equal(t, 50, cfg.SampleThereafter)
+
+	t.Setenv("SLACK_MCP_LOG_SAMPLE_THEREAFTER", "often")
+	_, err = LoggerConfigFromEnv("stdio")
+	assert.Error(t, err)
+}
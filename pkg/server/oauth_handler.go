@@ -1,45 +1,85 @@
 package server
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"sync"
 	"time"
 
 	"github.com/korotovsky/slack-mcp-server/pkg/oauth"
 	"go.uber.org/zap"
 )
 
+const (
+	stateTTL = 10 * time.Minute
+
+	// authCallbackTimeout bounds an AuthCallback, which outlives the callback request
+	authCallbackTimeout = 30 * time.Second
+)
+
+// AuthSummary describes a completed OAuth authentication without its tokens
+type AuthSummary struct {
+	UserID      string
+	TeamID      string
+	BotUserID   string
+	HasBotToken bool
+	BotScopes   []string
+	ExpiresAt   time.Time
+}
+
+// AuthCallback is invoked after a user completed OAuth, e.g. to provision
+// resources or send a welcome message. Its error is only logged.
+type AuthCallback func(ctx context.Context, summary AuthSummary) error
+
 // OAuthHandler handles OAuth authorization flow
 type OAuthHandler struct {
-	manager  oauth.OAuthManager
-	logger   *zap.Logger
-	states   map[string]time.Time
-	statesMu sync.RWMutex
+	manager   oauth.OAuthManager
+	states    oauth.StateStore
+	authorize *ipRateLimiter // nil when authorize calls are not limited
+	onAuth    AuthCallback
+	logger    *zap.Logger
 }
 
-// NewOAuthHandler creates a new OAuth handler
-func NewOAuthHandler(mgr oauth.OAuthManager, logger *zap.Logger) *OAuthHandler {
-	h := &OAuthHandler{
-		manager: mgr,
-		logger:  logger,
-		states:  make(map[string]time.Time),
+// NewOAuthHandler creates a new OAuth handler, limiting authorize calls per
+// client IP as configured. onAuth is optional and runs asynchronously after
+// each successful callback, see AuthCallback.
+func NewOAuthHandler(mgr oauth.OAuthManager, states oauth.StateStore, limits AuthorizeLimitConfig, onAuth AuthCallback, logger *zap.Logger) *OAuthHandler {
+	return &OAuthHandler{
+		manager:   mgr,
+		states:    states,
+		authorize: newIPRateLimiter(limits),
+		onAuth:    onAuth,
+		logger:    logger,
 	}
-	go h.cleanupStates()
-	return h
 }
 
 // HandleAuthorize initiates the OAuth flow
 func (h *OAuthHandler) HandleAuthorize(w http.ResponseWriter, r *http.Request) {
+	if h.authorize != nil && !h.authorize.Allow(r) {
+		h.logger.Warn("OAuth authorize rate limit exceeded", zap.String("remote_addr", r.RemoteAddr))
+		w.Header().Set("Retry-After", "60")
+		http.Error(w, "Too many authorization requests", http.StatusTooManyRequests)
+		return
+	}
+
 	// Generate CSRF state
 	state := generateState()
 
-	h.statesMu.Lock()
-	h.states[state] = time.Now().Add(10 * time.Minute)
-	h.statesMu.Unlock()
+	if err := h.states.Save(r.Context(), state, stateTTL); err != nil {
+		if errors.Is(err, oauth.ErrTooManyStates) {
+			h.logger.Warn("OAuth state store is full", zap.Error(err))
+			w.Header().Set("Retry-After", "60")
+			http.Error(w, "Too many pending authentications, try again later", http.StatusServiceUnavailable)
+			return
+		}
+		h.logger.Error("Failed to save OAuth state", zap.Error(err))
+		http.Error(w, "Failed to initiate authentication", http.StatusInternalServerError)
+		return
+	}
 
 	// Generate OAuth URL
 	authURL := h.manager.GetAuthURL(state)
@@ -66,21 +106,18 @@ func (h *OAuthHandler) HandleCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Verify state
-	h.statesMu.RLock()
-	expiry, ok := h.states[state]
-	h.statesMu.RUnlock()
-
-	if !ok || time.Now().After(expiry) {
+	// Verify and consume state, it is single-use
+	ok, err := h.states.Consume(r.Context(), state)
+	if err != nil {
+		h.logger.Error("Failed to verify OAuth state", zap.Error(err))
+		http.Error(w, "Authentication failed", http.StatusInternalServerError)
+		return
+	}
+	if !ok {
 		http.Error(w, "Invalid or expired state", http.StatusBadRequest)
 		return
 	}
 
-	// Clean up state
-	h.statesMu.Lock()
-	delete(h.states, state)
-	h.statesMu.Unlock()
-
 	// Exchange code for token
 	token, err := h.manager.HandleCallback(code, state)
 	if err != nil {
@@ -94,6 +131,17 @@ func (h *OAuthHandler) HandleCallback(w http.ResponseWriter, r *http.Request) {
 		zap.String("teamID", token.TeamID),
 	)
 
+	if h.onAuth != nil {
+		go h.runAuthCallback(AuthSummary{
+			UserID:      token.UserID,
+			TeamID:      token.TeamID,
+			BotUserID:   token.BotUserID,
+			HasBotToken: token.BotToken != "",
+			BotScopes:   token.BotScopes,
+			ExpiresAt:   token.ExpiresAt,
+		})
+	}
+
 	// Security headers
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("X-Content-Type-Options", "nosniff")
@@ -120,19 +168,28 @@ func (h *OAuthHandler) HandleCallback(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-func (h *OAuthHandler) cleanupStates() {
-	ticker := time.NewTicker(time.Minute)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		h.statesMu.Lock()
-		now := time.Now()
-		for state, expiry := range h.states {
-			if now.After(expiry) {
-				delete(h.states, state)
-			}
+// runAuthCallback invokes the AuthCallback, logging its failure. It must not
+// break the authentication, which has already succeeded, so panics are recovered.
+func (h *OAuthHandler) runAuthCallback(summary AuthSummary) {
+	defer func() {
+		if r := recover(); r != nil {
+			h.logger.Error("OAuth callback hook panicked",
+				zap.String("userID", summary.UserID),
+				zap.String("teamID", summary.TeamID),
+				zap.Any("panic", r),
+			)
 		}
-		h.statesMu.Unlock()
+	}()
+
+	// the request context ends with the response, which does not wait for the hook
+	ctx, cancel := context.WithTimeout(context.Background(), authCallbackTimeout)
+	defer cancel()
+	if err := h.onAuth(ctx, summary); err != nil {
+		h.logger.Error("OAuth callback hook failed",
+			zap.String("userID", summary.UserID),
+			zap.String("teamID", summary.TeamID),
+			zap.Error(err),
+		)
 	}
 }
 
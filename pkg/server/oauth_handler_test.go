@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/oauth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// callbackManager completes every OAuth callback with the same token
+type callbackManager struct {
+	oauth.OAuthManager
+	token *oauth.TokenResponse
+}
+
+func (m *callbackManager) HandleCallback(code, state string) (*oauth.TokenResponse, error) {
+	return m.token, nil
+}
+
+func oauthCallback(t *testing.T, h *OAuthHandler, states oauth.StateStore) *httptest.ResponseRecorder {
+	t.Helper()
+	require.NoError(t, states.Save(context.Background(), "state1", time.Minute))
+	w := httptest.NewRecorder()
+	h.HandleCallback(w, httptest.NewRequest(http.MethodGet, "/oauth/callback?code=c&state=state1", nil))
+	return w
+}
+
+func TestUnitOAuthCallbackHookAsync(t *testing.T) {
+	mgr := &callbackManager{token: &oauth.TokenResponse{
+		AccessToken: "xoxp-secret",
+		BotToken:    "xoxb-secret",
+		UserID:      "U1",
+		TeamID:      "T1",
+		BotUserID:   "B1",
+		BotScopes:   []string{"chat:write"},
+	}}
+	release := make(chan struct{})
+	got := make(chan AuthSummary, 1)
+	onAuth := func(ctx context.Context, summary AuthSummary) error {
+		<-release
+		got <- summary
+		return nil
+	}
+	states := oauth.NewMemoryStateStore()
+	h := NewOAuthHandler(mgr, states, AuthorizeLimitConfig{}, onAuth, zap.NewNop())
+
+	// the response is written while the hook is still blocked
+	w := oauthCallback(t, h, states)
+	assert.Equal(t, http.StatusOK, w.Code)
+	close(release)
+
+	select {
+	case summary := <-got:
+		assert.Equal(t, AuthSummary{
+			UserID:      "U1",
+			TeamID:      "T1",
+			BotUserID:   "B1",
+			HasBotToken: true,
+			BotScopes:   []string{"chat:write"},
+		}, summary)
+	case <-time.After(5 * time.Second):
+		t.Fatal("hook was not called")
+	}
+}
+
+func TestUnitOAuthCallbackHookFailureKeepsAuth(t *testing.T) {
+	mgr := &callbackManager{token: &oauth.TokenResponse{AccessToken: "xoxp-1", UserID: "U1", TeamID: "T1"}}
+	called := make(chan struct{})
+	for _, onAuth := range []AuthCallback{
+		func(context.Context, AuthSummary) error {
+			defer close(called)
+			return errors.New("provisioning failed")
+		},
+		func(context.Context, AuthSummary) error {
+			panic("hook bug")
+		},
+	} {
+		states := oauth.NewMemoryStateStore()
+		w := oauthCallback(t, NewOAuthHandler(mgr, states, AuthorizeLimitConfig{}, onAuth, zap.NewNop()), states)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "xoxp-1", body["access_token"])
+	}
+	<-called
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const defaultAuthorizePerMinute = 10
+
+// AuthorizeLimitConfig bounds how many OAuth flows clients can start, as every
+// /oauth/authorize call stores a new state until it expires
+type AuthorizeLimitConfig struct {
+	// PerMinute is the number of authorize calls allowed per client IP and
+	// minute, 0 disables the limit
+	PerMinute int
+	// TrustedNets are exempt from PerMinute, e.g. internal networks
+	TrustedNets []*net.IPNet
+	// MaxStates caps the pending states of the in-memory state store
+	MaxStates int
+}
+
+// DefaultAuthorizeLimitConfig returns the limits used when no
+// SLACK_MCP_OAUTH_AUTHORIZE_* variables are set
+func DefaultAuthorizeLimitConfig() AuthorizeLimitConfig {
+	return AuthorizeLimitConfig{
+		PerMinute: defaultAuthorizePerMinute,
+	}
+}
+
+// AuthorizeLimitConfigFromEnv reads the authorize limits from
+// SLACK_MCP_OAUTH_AUTHORIZE_PER_MINUTE, SLACK_MCP_OAUTH_AUTHORIZE_TRUSTED_CIDRS
+// and SLACK_MCP_OAUTH_MAX_STATES
+func AuthorizeLimitConfigFromEnv() (AuthorizeLimitConfig, error) {
+	cfg := DefaultAuthorizeLimitConfig()
+
+	if v := os.Getenv("SLACK_MCP_OAUTH_AUTHORIZE_PER_MINUTE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return cfg, fmt.Errorf("SLACK_MCP_OAUTH_AUTHORIZE_PER_MINUTE must be a non-negative integer, got %q", v)
+		}
+		cfg.PerMinute = n
+	}
+
+	if v := os.Getenv("SLACK_MCP_OAUTH_AUTHORIZE_TRUSTED_CIDRS"); v != "" {
+		for _, item := range strings.Split(v, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			ipNet, err := parseTrustedNet(item)
+			if err != nil {
+				return cfg, fmt.Errorf("SLACK_MCP_OAUTH_AUTHORIZE_TRUSTED_CIDRS: %w", err)
+			}
+			cfg.TrustedNets = append(cfg.TrustedNets, ipNet)
+		}
+	}
+
+	if v := os.Getenv("SLACK_MCP_OAUTH_MAX_STATES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return cfg, fmt.Errorf("SLACK_MCP_OAUTH_MAX_STATES must be a positive integer, got %q", v)
+		}
+		cfg.MaxStates = n
+	}
+
+	return cfg, nil
+}
+
+// parseTrustedNet parses a CIDR such as 10.0.0.0/8, or a single IP address
+func parseTrustedNet(s string) (*net.IPNet, error) {
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address or CIDR %q", s)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IP address or CIDR %q", s)
+	}
+	return ipNet, nil
+}
+
+type ipLimiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// ipRateLimiter limits requests per client IP. Clients are identified by the
+// remote address of the connection, so behind a reverse proxy all clients
+// share the limit of the proxy unless it is trusted.
+type ipRateLimiter struct {
+	mu        sync.Mutex
+	perMinute int
+	trusted   []*net.IPNet
+	entries   map[string]*ipLimiterEntry
+	lastPrune time.Time
+}
+
+// newIPRateLimiter creates a limiter for the given config, nil when disabled
+func newIPRateLimiter(cfg AuthorizeLimitConfig) *ipRateLimiter {
+	if cfg.PerMinute <= 0 {
+		return nil
+	}
+	return &ipRateLimiter{
+		perMinute: cfg.PerMinute,
+		trusted:   cfg.TrustedNets,
+		entries:   make(map[string]*ipLimiterEntry),
+		lastPrune: time.Now(),
+	}
+}
+
+// Allow reports whether the client of r may make another request
+func (l *ipRateLimiter) Allow(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	for _, ipNet := range l.trusted {
+		if ip != nil && ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	// a limiter idle for a minute is full again, so it can be dropped
+	if now.Sub(l.lastPrune) > time.Minute {
+		for key, entry := range l.entries {
+			if now.Sub(entry.lastSeen) > time.Minute {
+				delete(l.entries, key)
+			}
+		}
+		l.lastPrune = now
+	}
+
+	entry, ok := l.entries[host]
+	if !ok {
+		entry = &ipLimiterEntry{
+			limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(l.perMinute)), l.perMinute),
+		}
+		l.entries[host] = entry
+	}
+	entry.lastSeen = now
+	return entry.limiter.AllowN(now, 1)
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/oauth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// authorizeManager returns a fixed authorization URL
+type authorizeManager struct {
+	oauth.OAuthManager
+}
+
+func (authorizeManager) GetAuthURL(state string) string {
+	return "https://slack.com/oauth/v2/authorize?state=" + state
+}
+
+func authorize(h *OAuthHandler, remoteAddr string) int {
+	req := httptest.NewRequest(http.MethodGet, "/oauth/authorize", nil)
+	req.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+	h.HandleAuthorize(w, req)
+	return w.Code
+}
+
+func TestUnitAuthorizeLimitConfigFromEnv(t *testing.T) {
+	cfg, err := AuthorizeLimitConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultAuthorizeLimitConfig(), cfg)
+
+	t.Setenv("SLACK_MCP_OAUTH_AUTHORIZE_PER_MINUTE", "3")
+	t.Setenv("SLACK_MCP_OAUTH_AUTHORIZE_TRUSTED_CIDRS", "10.0.0.0/8, 192.168.1.7,fd00::/8")
+	t.Setenv("SLACK_MCP_OAUTH_MAX_STATES", "500")
+	cfg, err = AuthorizeLimitConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, 3, cfg.PerMinute)
+	assert.Equal(t, 500, cfg.MaxStates)
+	require.Len(t, cfg.TrustedNets, 3)
+	assert.Equal(t, "192.168.1.7/32", cfg.TrustedNets[1].String())
+
+	for env, value := range map[string]string{
+		"SLACK_MCP_OAUTH_AUTHORIZE_PER_MINUTE":    "-1",
+		"SLACK_MCP_OAUTH_AUTHORIZE_TRUSTED_CIDRS": "10.0.0.0/33",
+		"SLACK_MCP_OAUTH_MAX_STATES":              "0",
+	} {
+		t.Run(env, func(t *testing.T) {
+			t.Setenv(env, value)
+			_, err := AuthorizeLimitConfigFromEnv()
+			assert.ErrorContains(t, err, env)
+		})
+	}
+}
+
+func TestUnitAuthorizeRateLimit(t *testing.T) {
+	cfg := AuthorizeLimitConfig{PerMinute: 2}
+	trusted, err := parseTrustedNet("10.0.0.0/8")
+	require.NoError(t, err)
+	cfg.TrustedNets = append(cfg.TrustedNets, trusted)
+	h := NewOAuthHandler(authorizeManager{}, oauth.NewMemoryStateStore(), cfg, nil, zap.NewNop())
+
+	assert.Equal(t, http.StatusOK, authorize(h, "203.0.113.1:1000"))
+	assert.Equal(t, http.StatusOK, authorize(h, "203.0.113.1:1001"))
+	assert.Equal(t, http.StatusTooManyRequests, authorize(h, "203.0.113.1:1002"), "the limit is per IP, not per connection")
+	assert.Equal(t, http.StatusOK, authorize(h, "203.0.113.2:1000"), "other clients have their own limit")
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, authorize(h, "10.1.2.3:1000"), "trusted networks are not limited")
+	}
+
+	unlimited := NewOAuthHandler(authorizeManager{}, oauth.NewMemoryStateStore(), AuthorizeLimitConfig{}, nil, zap.NewNop())
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, authorize(unlimited, "203.0.113.1:1000"))
+	}
+}
+
+func TestUnitAuthorizeStateStoreFull(t *testing.T) {
+	states := oauth.NewMemoryStateStoreWithLimit(1)
+	require.NoError(t, states.Save(context.Background(), "pending", time.Minute))
+	h := NewOAuthHandler(authorizeManager{}, states, AuthorizeLimitConfig{}, nil, zap.NewNop())
+
+	assert.Equal(t, http.StatusServiceUnavailable, authorize(h, "203.0.113.1:1000"))
+}
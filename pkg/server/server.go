@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/korotovsky/slack-mcp-server/pkg/handler"
 	"github.com/korotovsky/slack-mcp-server/pkg/oauth"
 	"github.com/korotovsky/slack-mcp-server/pkg/provider"
 	"github.com/korotovsky/slack-mcp-server/pkg/server/auth"
-	"github.com/korotovsky/slack-mcp-server/pkg/text"
+	"github.com/korotovsky/slack-mcp-server/pkg/transport"
 	"github.com/korotovsky/slack-mcp-server/pkg/version"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -22,144 +25,37 @@ type MCPServer struct {
 	logger *zap.Logger
 }
 
-func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer {
+func NewMCPServer(provider *provider.ApiProvider, toolFlags map[string]bool, logger *zap.Logger) *MCPServer {
 	s := server.NewMCPServer(
 		"Slack MCP Server",
 		version.Version,
 		server.WithLogging(),
-		server.WithRecovery(),
+		server.WithToolHandlerMiddleware(buildRequestIDMiddleware()),
+		server.WithToolHandlerMiddleware(buildRecoveryMiddleware(logger)),
 		server.WithToolHandlerMiddleware(buildLoggerMiddleware(logger)),
+		server.WithToolHandlerMiddleware(buildWarningsMiddleware()),
 		server.WithToolHandlerMiddleware(auth.BuildMiddleware(provider.ServerTransport(), logger)),
+		server.WithToolHandlerMiddleware(buildAuthErrorMiddleware(provider, logger)),
+		server.WithToolHandlerMiddleware(buildChannelAllowlistMiddleware(
+			ParseChannelAllowlist(os.Getenv("SLACK_MCP_CHANNEL_ALLOWLIST")), ProviderChannelResolver(provider), logger)),
 	)
 
 	conversationsHandler := handler.NewConversationsHandler(provider, logger)
-
-	s.AddTool(mcp.NewTool("conversations_history",
-		mcp.WithDescription("Get messages from the channel (or DM) by channel_id, the last row/column in the response is used as 'cursor' parameter for pagination if not empty"),
-		mcp.WithString("channel_id",
-			mcp.Required(),
-			mcp.Description("    - `channel_id` (string): ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
-		),
-		mcp.WithBoolean("include_activity_messages",
-			mcp.Description("If true, the response will include activity messages such as 'channel_join' or 'channel_leave'. Default is boolean false."),
-			mcp.DefaultBool(false),
-		),
-		mcp.WithString("cursor",
-			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
-		),
-		mcp.WithString("limit",
-			mcp.DefaultString("1d"),
-			mcp.Description("Limit of messages to fetch in format of maximum ranges of time (e.g. 1d - 1 day, 1w - 1 week, 30d - 30 days, 90d - 90 days which is a default limit for free tier history) or number of messages (e.g. 50). Must be empty when 'cursor' is provided."),
-		),
-	), conversationsHandler.ConversationsHistoryHandler)
-
-	s.AddTool(mcp.NewTool("conversations_replies",
-		mcp.WithDescription("Get a thread of messages posted to a conversation by channelID and thread_ts, the last row/column in the response is used as 'cursor' parameter for pagination if not empty"),
-		mcp.WithString("channel_id",
-			mcp.Required(),
-			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
-		),
-		mcp.WithString("thread_ts",
-			mcp.Required(),
-			mcp.Description("Unique identifier of either a thread's parent message or a message in the thread. ts must be the timestamp in format 1234567890.123456 of an existing message with 0 or more replies."),
-		),
-		mcp.WithBoolean("include_activity_messages",
-			mcp.Description("If true, the response will include activity messages such as 'channel_join' or 'channel_leave'. Default is boolean false."),
-			mcp.DefaultBool(false),
-		),
-		mcp.WithString("cursor",
-			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
-		),
-		mcp.WithString("limit",
-			mcp.DefaultString("1d"),
-			mcp.Description("Limit of messages to fetch in format of maximum ranges of time (e.g. 1d - 1 day, 30d - 30 days, 90d - 90 days which is a default limit for free tier history) or number of messages (e.g. 50). Must be empty when 'cursor' is provided."),
-		),
-	), conversationsHandler.ConversationsRepliesHandler)
-
-	s.AddTool(mcp.NewTool("conversations_add_message",
-		mcp.WithDescription("Add a message to a public channel, private channel, or direct message (DM, or IM) conversation by channel_id and thread_ts."),
-		mcp.WithString("channel_id",
-			mcp.Required(),
-			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
-		),
-		mcp.WithString("thread_ts",
-			mcp.Description("Unique identifier of either a thread's parent message or a message in the thread_ts must be the timestamp in format 1234567890.123456 of an existing message with 0 or more replies. Optional, if not provided the message will be added to the channel itself, otherwise it will be added to the thread."),
-		),
-		mcp.WithString("payload",
-			mcp.Description("Message payload in specified content_type format. Example: 'Hello, world!' for text/plain or '# Hello, world!' for text/markdown."),
-		),
-		mcp.WithString("content_type",
-			mcp.DefaultString("text/markdown"),
-			mcp.Description("Content type of the message. Default is 'text/markdown'. Allowed values: 'text/markdown', 'text/plain'."),
-		),
-	), conversationsHandler.ConversationsAddMessageHandler)
-
-	s.AddTool(mcp.NewTool("conversations_search_messages",
-		mcp.WithDescription("Search messages in a public channel, private channel, or direct message (DM, or IM) conversation using filters. All filters are optional, if not provided then search_query is required."),
-		mcp.WithString("search_query",
-			mcp.Description("Search query to filter messages. Example: 'marketing report' or full URL of Slack message e.g. 'https://slack.com/archives/C1234567890/p1234567890123456', then the tool will return a single message matching given URL, herewith all other parameters will be ignored."),
-		),
-		mcp.WithString("filter_in_channel",
-			mcp.Description("Filter messages in a specific public/private channel by its ID or name. Example: 'C1234567890', 'G1234567890', or '#general'. If not provided, all channels will be searched."),
-		),
-		mcp.WithString("filter_in_im_or_mpim",
-			mcp.Description("Filter messages in a direct message (DM) or multi-person direct message (MPIM) conversation by its ID or name. Example: 'D1234567890' or '@username_dm'. If not provided, all DMs and MPIMs will be searched."),
-		),
-		mcp.WithString("filter_users_with",
-			mcp.Description("Filter messages with a specific user by their ID or display name in threads and DMs. Example: 'U1234567890' or '@username'. If not provided, all threads and DMs will be searched."),
-		),
-		mcp.WithString("filter_users_from",
-			mcp.Description("Filter messages from a specific user by their ID or display name. Example: 'U1234567890' or '@username'. If not provided, all users will be searched."),
-		),
-		mcp.WithString("filter_date_before",
-			mcp.Description("Filter messages sent before a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
-		),
-		mcp.WithString("filter_date_after",
-			mcp.Description("Filter messages sent after a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
-		),
-		mcp.WithString("filter_date_on",
-			mcp.Description("Filter messages sent on a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
-		),
-		mcp.WithString("filter_date_during",
-			mcp.Description("Filter messages sent during a specific period in format 'YYYY-MM-DD'. Example: 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
-		),
-		mcp.WithBoolean("filter_threads_only",
-			mcp.Description("If true, the response will include only messages from threads. Default is boolean false."),
-		),
-		mcp.WithString("cursor",
-			mcp.DefaultString(""),
-			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
-		),
-		mcp.WithNumber("limit",
-			mcp.DefaultNumber(20),
-			mcp.Description("The maximum number of items to return. Must be an integer between 1 and 100."),
-		),
-	), conversationsHandler.ConversationsSearchHandler)
-
 	channelsHandler := handler.NewChannelsHandler(provider, logger)
 
-	s.AddTool(mcp.NewTool("channels_list",
-		mcp.WithDescription("Get list of channels"),
-		mcp.WithString("channel_types",
-			mcp.Required(),
-			mcp.Description("Comma-separated channel types. Allowed values: 'mpim', 'im', 'public_channel', 'private_channel'. Example: 'public_channel,private_channel,im'"),
-		),
-		mcp.WithString("sort",
-			mcp.Description("Type of sorting. Allowed values: 'popularity' - sort by number of members/participants in each channel."),
-		),
-		mcp.WithNumber("limit",
-			mcp.DefaultNumber(100),
-			mcp.Description("The maximum number of items to return. Must be an integer between 1 and 1000 (maximum 999)."), // context fix for cursor: https://github.com/korotovsky/slack-mcp-server/issues/7
-		),
-		mcp.WithString("cursor",
-			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
-		),
-	), channelsHandler.ChannelsHandler)
+	r := newToolRegistry(s, toolFlags, logger)
+	registerTools(r, conversationsHandler, channelsHandler, false)
+	if err := r.validate(); err != nil {
+		logger.Fatal("error in SLACK_MCP_TOOLS",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
 
 	logger.Info("Authenticating with Slack API...",
 		zap.String("context", "console"),
 	)
-	ar, err := provider.Slack().AuthTest()
+	id, err := provider.Identity(context.Background())
 	if err != nil {
 		logger.Fatal("Failed to authenticate with Slack",
 			zap.String("context", "console"),
@@ -169,20 +65,12 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 
 	logger.Info("Successfully authenticated with Slack",
 		zap.String("context", "console"),
-		zap.String("team", ar.Team),
-		zap.String("user", ar.User),
-		zap.String("enterprise", ar.EnterpriseID),
-		zap.String("url", ar.URL),
+		zap.String("team", id.Team),
+		zap.String("user", id.User),
+		zap.String("enterprise", id.EnterpriseID),
+		zap.String("url", id.URL),
 	)
-
-	ws, err := text.Workspace(ar.URL)
-	if err != nil {
-		logger.Fatal("Failed to parse workspace from URL",
-			zap.String("context", "console"),
-			zap.String("url", ar.URL),
-			zap.Error(err),
-		)
-	}
+	ws := id.Workspace
 
 	s.AddResource(mcp.NewResource(
 		"slack://"+ws+"/channels",
@@ -198,6 +86,12 @@ func NewMCPServer(provider *provider.ApiProvider, logger *zap.Logger) *MCPServer
 		mcp.WithMIMEType("text/csv"),
 	), conversationsHandler.UsersResource)
 
+	s.AddResourceTemplate(mcp.NewResourceTemplate(
+		"slack://"+ws+"/files/{id}",
+		"Content of a Slack file",
+		mcp.WithTemplateDescription("This resource provides the content of a Slack file by its ID, as text for text files and base64 encoded otherwise."),
+	), conversationsHandler.FileResource)
+
 	return &MCPServer{
 		server: s,
 		logger: logger,
@@ -209,142 +103,55 @@ func NewMCPServerWithOAuth(
 	conversationsHandler *handler.ConversationsHandler,
 	channelsHandler *handler.ChannelsHandler,
 	oauthManager oauth.OAuthManager,
+	toolFlags map[string]bool,
 	logger *zap.Logger,
 ) *MCPServer {
+	opts := []server.ServerOption{
+		server.WithLogging(),
+		server.WithToolHandlerMiddleware(buildRequestIDMiddleware()),
+		server.WithToolHandlerMiddleware(buildRecoveryMiddleware(logger)),
+		server.WithToolHandlerMiddleware(buildWarningsMiddleware()),
+		server.WithToolHandlerMiddleware(auth.OAuthMiddleware(oauthManager, logger)),
+	}
+
+	// Opt-in as it costs a users.info call per user (cached)
+	if os.Getenv("SLACK_MCP_OAUTH_RESOLVE_USER_NAME") == "true" {
+		opts = append(opts, server.WithToolHandlerMiddleware(auth.UserNameMiddleware(auth.SlackUserNameResolver, logger)))
+	}
+	// after authentication so that request logs carry the user, rejected
+	// requests are logged by OAuthMiddleware
+	opts = append(opts, server.WithToolHandlerMiddleware(buildLoggerMiddleware(logger)))
+
+	// There is no shared channels cache to resolve names from in OAuth mode
+	allowlist := ParseChannelAllowlist(os.Getenv("SLACK_MCP_CHANNEL_ALLOWLIST"))
+	for _, entry := range allowlist {
+		if isChannelName(entry) {
+			logger.Fatal("In OAuth mode, SLACK_MCP_CHANNEL_ALLOWLIST must contain channel IDs (C...) instead of names",
+				zap.String("context", "console"),
+				zap.String("channel", entry),
+			)
+		}
+	}
+	opts = append(opts, server.WithToolHandlerMiddleware(buildChannelAllowlistMiddleware(allowlist, nil, logger)))
+
 	s := server.NewMCPServer(
 		"Slack MCP Server",
 		version.Version,
-		server.WithLogging(),
-		server.WithRecovery(),
-		server.WithToolHandlerMiddleware(buildLoggerMiddleware(logger)),
-		server.WithToolHandlerMiddleware(auth.OAuthMiddleware(oauthManager, logger)),
+		opts...,
 	)
 
-	// Add conversation tools
-	s.AddTool(mcp.NewTool("conversations_history",
-		mcp.WithDescription("Get messages from the channel (or DM) by channel_id, the last row/column in the response is used as 'cursor' parameter for pagination if not empty"),
-		mcp.WithString("channel_id",
-			mcp.Required(),
-			mcp.Description("    - `channel_id` (string): ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
-		),
-		mcp.WithBoolean("include_activity_messages",
-			mcp.Description("If true, the response will include activity messages such as 'channel_join' or 'channel_leave'. Default is boolean false."),
-			mcp.DefaultBool(false),
-		),
-		mcp.WithString("cursor",
-			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
-		),
-		mcp.WithString("limit",
-			mcp.DefaultString("1d"),
-			mcp.Description("Limit of messages to fetch in format of maximum ranges of time (e.g. 1d - 1 day, 1w - 1 week, 30d - 30 days, 90d - 90 days which is a default limit for free tier history) or number of messages (e.g. 50). Must be empty when 'cursor' is provided."),
-		),
-	), conversationsHandler.ConversationsHistoryHandler)
-
-	s.AddTool(mcp.NewTool("conversations_replies",
-		mcp.WithDescription("Get a thread of messages posted to a conversation by channelID and thread_ts, the last row/column in the response is used as 'cursor' parameter for pagination if not empty"),
-		mcp.WithString("channel_id",
-			mcp.Required(),
-			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
-		),
-		mcp.WithString("thread_ts",
-			mcp.Required(),
-			mcp.Description("Unique identifier of either a thread's parent message or a message in the thread. ts must be the timestamp in format 1234567890.123456 of an existing message with 0 or more replies."),
-		),
-		mcp.WithBoolean("include_activity_messages",
-			mcp.Description("If true, the response will include activity messages such as 'channel_join' or 'channel_leave'. Default is boolean false."),
-			mcp.DefaultBool(false),
-		),
-		mcp.WithString("cursor",
-			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
-		),
-		mcp.WithString("limit",
-			mcp.DefaultString("1d"),
-			mcp.Description("Limit of messages to fetch in format of maximum ranges of time (e.g. 1d - 1 day, 30d - 30 days, 90d - 90 days which is a default limit for free tier history) or number of messages (e.g. 50). Must be empty when 'cursor' is provided."),
-		),
-	), conversationsHandler.ConversationsRepliesHandler)
-
-	s.AddTool(mcp.NewTool("conversations_add_message",
-		mcp.WithDescription("Add a message to a public channel, private channel, or direct message (DM, or IM) conversation by channel_id and thread_ts."),
-		mcp.WithString("channel_id",
-			mcp.Required(),
-			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
-		),
-		mcp.WithString("thread_ts",
-			mcp.Description("Unique identifier of either a thread's parent message or a message in the thread_ts must be the timestamp in format 1234567890.123456 of an existing message with 0 or more replies. Optional, if not provided the message will be added to the channel itself, otherwise it will be added to the thread."),
-		),
-		mcp.WithString("payload",
-			mcp.Description("Message payload in specified content_type format. Example: 'Hello, world!' for text/plain or '# Hello, world!' for text/markdown."),
-		),
-		mcp.WithString("content_type",
-			mcp.DefaultString("text/markdown"),
-			mcp.Description("Content type of the message. Default is 'text/markdown'. Allowed values: 'text/markdown', 'text/plain'."),
-		),
-	), conversationsHandler.ConversationsAddMessageHandler)
-
-	s.AddTool(mcp.NewTool("conversations_search_messages",
-		mcp.WithDescription("Search messages in a public channel, private channel, or direct message (DM, or IM) conversation using filters. All filters are optional, if not provided then search_query is required."),
-		mcp.WithString("search_query",
-			mcp.Description("Search query to filter messages. Example: 'marketing report' or full URL of Slack message e.g. 'https://slack.com/archives/C1234567890/p1234567890123456', then the tool will return a single message matching given URL, herewith all other parameters will be ignored."),
-		),
-		mcp.WithString("filter_in_channel",
-			mcp.Description("Filter messages in a specific public/private channel by its ID or name. Example: 'C1234567890', 'G1234567890', or '#general'. If not provided, all channels will be searched."),
-		),
-		mcp.WithString("filter_in_im_or_mpim",
-			mcp.Description("Filter messages in a direct message (DM) or multi-person direct message (MPIM) conversation by its ID or name. Example: 'D1234567890' or '@username_dm'. If not provided, all DMs and MPIMs will be searched."),
-		),
-		mcp.WithString("filter_users_with",
-			mcp.Description("Filter messages with a specific user by their ID or display name in threads and DMs. Example: 'U1234567890' or '@username'. If not provided, all threads and DMs will be searched."),
-		),
-		mcp.WithString("filter_users_from",
-			mcp.Description("Filter messages from a specific user by their ID or display name. Example: 'U1234567890' or '@username'. If not provided, all users will be searched."),
-		),
-		mcp.WithString("filter_date_before",
-			mcp.Description("Filter messages sent before a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
-		),
-		mcp.WithString("filter_date_after",
-			mcp.Description("Filter messages sent after a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
-		),
-		mcp.WithString("filter_date_on",
-			mcp.Description("Filter messages sent on a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
-		),
-		mcp.WithString("filter_date_during",
-			mcp.Description("Filter messages sent during a specific period in format 'YYYY-MM-DD'. Example: 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
-		),
-		mcp.WithBoolean("filter_threads_only",
-			mcp.Description("If true, the response will include only messages from threads. Default is boolean false."),
-		),
-		mcp.WithString("cursor",
-			mcp.DefaultString(""),
-			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
-		),
-		mcp.WithNumber("limit",
-			mcp.DefaultNumber(20),
-			mcp.Description("The maximum number of items to return. Must be an integer between 1 and 100."),
-		),
-	), conversationsHandler.ConversationsSearchHandler)
-
-	// Add channels tool
-	s.AddTool(mcp.NewTool("channels_list",
-		mcp.WithDescription("Get list of channels"),
-		mcp.WithString("channel_types",
-			mcp.Required(),
-			mcp.Description("Comma-separated channel types. Allowed values: 'mpim', 'im', 'public_channel', 'private_channel'. Example: 'public_channel,private_channel,im'"),
-		),
-		mcp.WithString("sort",
-			mcp.Description("Type of sorting. Allowed values: 'popularity' - sort by number of members/participants in each channel."),
-		),
-		mcp.WithNumber("limit",
-			mcp.DefaultNumber(100),
-			mcp.Description("The maximum number of items to return. Must be an integer between 1 and 1000 (maximum 999)."),
-		),
-		mcp.WithString("cursor",
-			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
-		),
-	), channelsHandler.ChannelsHandler)
+	r := newToolRegistry(s, toolFlags, logger)
+	registerTools(r, conversationsHandler, channelsHandler, true)
+	if err := r.validate(); err != nil {
+		logger.Fatal("error in SLACK_MCP_TOOLS",
+			zap.String("context", "console"),
+			zap.Error(err),
+		)
+	}
 
 	logger.Info("OAuth MCP Server initialized",
 		zap.String("context", "console"),
-		zap.Int("tools_count", 5),
+		zap.Int("tools_count", r.count),
 	)
 
 	return &MCPServer{
@@ -458,13 +265,27 @@ func (s *MCPServer) ServeStdio() error {
 	return err
 }
 
+// buildLoggerMiddleware logs every request and its duration. In OAuth mode it is
+// registered after the OAuth and user name middlewares, so that the lines name
+// the authenticated user.
 func buildLoggerMiddleware(logger *zap.Logger) server.ToolHandlerMiddleware {
 	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
 		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			logger.Info("Request received",
+			fields := []zap.Field{
 				zap.String("tool", req.Params.Name),
-				zap.Any("params", req.Params),
-			)
+				zap.String("request_id", auth.RequestIDFromContext(ctx)),
+			}
+			if userCtx, ok := auth.FromContext(ctx); ok {
+				fields = append(fields,
+					zap.String("user_id", userCtx.UserID),
+					zap.String("team_id", userCtx.TeamID),
+				)
+				if userCtx.UserName != "" {
+					fields = append(fields, zap.String("user_name", userCtx.UserName))
+				}
+			}
+
+			logger.Info("Request received", append(fields, zap.Any("params", req.Params))...)
 
 			startTime := time.Now()
 
@@ -472,12 +293,102 @@ func buildLoggerMiddleware(logger *zap.Logger) server.ToolHandlerMiddleware {
 
 			duration := time.Since(startTime)
 
-			logger.Info("Request finished",
-				zap.String("tool", req.Params.Name),
-				zap.Duration("duration", duration),
-			)
+			logger.Info("Request finished", append(fields, zap.Duration("duration", duration))...)
+
+			return res, err
+		}
+	}
+}
+
+// buildRecoveryMiddleware turns a panic in a tool handler, or in any middleware
+// registered after it, into a generic tool error so that one faulty request
+// cannot take the whole server down. The panic value is only logged, with the
+// stack, as it may contain details not meant for the client.
+func buildRecoveryMiddleware(logger *zap.Logger) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (res *mcp.CallToolResult, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					requestID := auth.RequestIDFromContext(ctx)
+					logger.Error("Panic recovered in tool handler",
+						zap.String("tool", req.Params.Name),
+						zap.String("request_id", requestID),
+						zap.Any("panic", r),
+						zap.Stack("stack"),
+					)
+					res = mcp.NewToolResultError(fmt.Sprintf("internal error in %s tool (request_id %s)", req.Params.Name, requestID))
+					err = nil
+				}
+			}()
+			return next(ctx, req)
+		}
+	}
+}
 
+// buildAuthErrorMiddleware drops the cached identity of the provider when a tool
+// fails because the token is not valid anymore, so that it is tested again
+func buildAuthErrorMiddleware(ap *provider.ApiProvider, logger *zap.Logger) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			res, err := next(ctx, req)
+			if provider.IsAuthError(err) {
+				logger.Warn("Slack rejected the token, dropping the cached identity",
+					zap.String("tool", req.Params.Name),
+					zap.String("request_id", auth.RequestIDFromContext(ctx)),
+					zap.Error(err),
+				)
+				ap.InvalidateIdentity()
+			}
 			return res, err
 		}
 	}
 }
+
+// buildWarningsMiddleware appends the warnings Slack returned with its responses,
+// e.g. missing_charset, as a trailing note of the result when a tool is called
+// with debug_warnings=true. Only warning codes are kept, see transport.Warnings.
+func buildWarningsMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !req.GetBool(debugWarningsParam, false) {
+				return next(ctx, req)
+			}
+
+			ctx, warnings := transport.WithWarnings(ctx)
+			res, err := next(ctx, req)
+			if res != nil {
+				if codes := warnings.List(); len(codes) > 0 {
+					res.Content = append(res.Content, mcp.NewTextContent("Slack warnings: "+strings.Join(codes, ", ")))
+				}
+			}
+			return res, err
+		}
+	}
+}
+
+// buildRequestIDMiddleware injects a request ID into the context, reusing the one
+// supplied by the client in the MCP request metadata if present.
+func buildRequestIDMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			requestID := requestIDFromMeta(req.Params.Meta)
+			if requestID == "" {
+				requestID = uuid.New().String()
+			}
+
+			return next(auth.WithRequestID(ctx, requestID), req)
+		}
+	}
+}
+
+func requestIDFromMeta(meta *mcp.Meta) string {
+	if meta == nil {
+		return ""
+	}
+	for _, key := range []string{"requestId", "request_id"} {
+		if v, ok := meta.AdditionalFields[key].(string); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,987 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/korotovsky/slack-mcp-server/pkg/handler"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
+)
+
+// ParseToolFlags parses SLACK_MCP_TOOLS, a comma-separated list of tool=true|false
+// pairs. Tools not listed stay enabled.
+func ParseToolFlags(config string) (map[string]bool, error) {
+	flags := make(map[string]bool)
+	for _, entry := range strings.Split(config, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid tool flag %q: expected format tool_name=true|false", entry)
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for tool flag %q: expected true or false", name)
+		}
+		flags[name] = enabled
+	}
+	return flags, nil
+}
+
+// toolRegistry registers tools with the MCP server unless they are disabled by a flag
+type toolRegistry struct {
+	server *server.MCPServer
+	flags  map[string]bool
+	known  map[string]bool
+	count  int
+	logger *zap.Logger
+}
+
+func newToolRegistry(s *server.MCPServer, flags map[string]bool, logger *zap.Logger) *toolRegistry {
+	return &toolRegistry{
+		server: s,
+		flags:  flags,
+		known:  make(map[string]bool),
+		logger: logger,
+	}
+}
+
+// debugWarningsParam is accepted by every tool, see buildWarningsMiddleware
+const debugWarningsParam = "debug_warnings"
+
+func (r *toolRegistry) addTool(tool mcp.Tool, h server.ToolHandlerFunc) {
+	r.known[tool.Name] = true
+	mcp.WithBoolean(debugWarningsParam,
+		mcp.Description("If true, warnings Slack returned with its responses, e.g. missing_charset, are appended to the result as a note. For debugging, default is boolean false."),
+	)(&tool)
+	if enabled, ok := r.flags[tool.Name]; ok && !enabled {
+		r.logger.Info("Tool disabled by SLACK_MCP_TOOLS",
+			zap.String("context", "console"),
+			zap.String("tool", tool.Name),
+		)
+		return
+	}
+	r.server.AddTool(tool, h)
+	r.count++
+}
+
+// validate reports flags that do not name any tool known to this server
+func (r *toolRegistry) validate() error {
+	var unknown []string
+	for name := range r.flags {
+		if !r.known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+
+	known := make([]string, 0, len(r.known))
+	for name := range r.known {
+		known = append(known, name)
+	}
+	sort.Strings(known)
+
+	return fmt.Errorf("unknown tool name(s) %s, known tools are: %s",
+		strings.Join(unknown, ", "), strings.Join(known, ", "))
+}
+
+// withTimezone adds the timezone parameter of the tools with a time column
+func withTimezone() mcp.ToolOption {
+	return mcp.WithString("timezone",
+		mcp.Description("IANA timezone the time column is shown in, e.g. 'Europe/Berlin' or 'UTC'. Defaults to the timezone of the authenticated user, UTC when it is unknown."),
+	)
+}
+
+// registerTools adds every tool of the server; bot_info only exists in OAuth mode
+func registerTools(r *toolRegistry, conversationsHandler *handler.ConversationsHandler, channelsHandler *handler.ChannelsHandler, oauthEnabled bool) {
+	r.addTool(mcp.NewTool("conversations_history",
+		mcp.WithDescription("Get messages from the channel (or DM) by channel_id, the last row/column in the response is used as 'cursor' parameter for pagination if not empty"),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("    - `channel_id` (string): ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithBoolean("include_activity_messages",
+			mcp.Description("If true, the response will include activity messages such as 'channel_join' or 'channel_leave'. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("exclude_bots",
+			mcp.Description("If true, messages posted by bots and apps are left out: bot messages, messages with a bot_id or the app_id of a bot profile, and messages of users flagged is_bot or is_app_user. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithString("limit",
+			mcp.DefaultString("1d"),
+			mcp.Description("Limit of messages to fetch in format of maximum ranges of time (e.g. 1d - 1 day, 1w - 1 week, 30d - 30 days, 90d - 90 days which is a default limit for free tier history) or number of messages (e.g. 50). Must be empty when 'cursor' is provided."),
+		),
+		mcp.WithString("date_range",
+			mcp.Description("Date range instead of the time range of limit: 7d, 2w or 3m, today, yesterday, a date such as 2024-01-31, or two of them joined by .. such as 2024-01-01..2024-02-01, both days included. Dates are in the local time of the server. A numeric limit still caps the number of messages per page. Cannot be combined with oldest or latest, pass the same value on every page."),
+		),
+		mcp.WithString("oldest",
+			mcp.Description("Only messages after this timestamp in format 1234567890.123456, overriding the start of a time range limit. Pass the same value on every page. Optional."),
+		),
+		mcp.WithString("latest",
+			mcp.Description("Only messages before this timestamp in format 1234567890.123456, overriding the end of a time range limit. Pass the same value on every page. Optional."),
+		),
+		mcp.WithBoolean("inclusive",
+			mcp.Description("If true, messages with a timestamp exactly on the oldest/latest boundary of a time range limit are included. Pass the same value on every page: the cursor never repeats a message, so a boundary message is returned once when true and skipped when false. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithNumber("max_text_len",
+			mcp.Description("Truncate the text of each message to this many characters, marking cut text with an ellipsis (…). Default is 0, no truncation."),
+		),
+		mcp.WithBoolean("include_metadata",
+			mcp.Description("If true, Subtype, EditedTs and EditedUser columns are added before the cursor column. Slack only keeps the latest edit, so a message edited several times reports its last edit only. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("include_edit_events",
+			mcp.Description("If true, edit and deletion records (message_changed, message_deleted and tombstone subtypes) are included where Slack returns them, without the other activity messages. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithString("format",
+			mcp.DefaultString("text"),
+			mcp.Description("Format of the message text: 'text' removes Slack markup and formatting, 'markdown' converts Slack mrkdwn to standard Markdown, with **bold**, [label](url) links and @name/#channel mentions. Default is 'text'."),
+		),
+		withTimezone(),
+	), conversationsHandler.ConversationsHistoryHandler)
+
+	r.addTool(mcp.NewTool("conversations_bulk_history",
+		mcp.WithDescription("Get recent messages from several channels (or DMs) over the same time range as one CSV with a channelID column. Rows with a 'note' report channels that failed and messages left out by the limits."),
+		mcp.WithString("channel_ids",
+			mcp.Required(),
+			mcp.Description("Comma-separated list of up to 20 channels, each an ID in format Cxxxxxxxxxx or a name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("limit",
+			mcp.DefaultString("1d"),
+			mcp.Description("Time range shared by all channels (e.g. 1d - 1 day, 1w - 1 week, 30d - 30 days)."),
+		),
+		mcp.WithString("date_range",
+			mcp.Description("Date range instead of the time range of limit: 7d, 2w or 3m, today, yesterday, a date such as 2024-01-31, or two of them joined by .. such as 2024-01-01..2024-02-01, both days included. Dates are in the local time of the server. Optional."),
+		),
+		mcp.WithNumber("per_channel_limit",
+			mcp.DefaultNumber(50),
+			mcp.Description("Maximum number of messages fetched per channel, between 1 and 200. Default is 50."),
+		),
+		mcp.WithNumber("max_messages",
+			mcp.DefaultNumber(300),
+			mcp.Description("Maximum number of messages in the whole response, between 1 and 1000, shared fairly between channels. Default is 300."),
+		),
+		mcp.WithBoolean("include_activity_messages",
+			mcp.Description("If true, the response will include activity messages such as 'channel_join' or 'channel_leave'. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("exclude_bots",
+			mcp.Description("If true, messages posted by bots and apps are left out: bot messages, messages with a bot_id or the app_id of a bot profile, and messages of users flagged is_bot or is_app_user. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithNumber("max_text_len",
+			mcp.Description("Truncate the text of each message to this many characters, marking cut text with an ellipsis (…). Default is 0, no truncation."),
+		),
+		mcp.WithString("format",
+			mcp.DefaultString("text"),
+			mcp.Description("Format of the message text: 'text' removes Slack markup and formatting, 'markdown' converts Slack mrkdwn to standard Markdown, with **bold**, [label](url) links and @name/#channel mentions. Default is 'text'."),
+		),
+		withTimezone(),
+	), conversationsHandler.ConversationsBulkHistoryHandler)
+
+	r.addTool(mcp.NewTool("conversations_replies",
+		mcp.WithDescription("Get a thread of messages posted to a conversation by channelID and thread_ts, the last row/column in the response is used as 'cursor' parameter for pagination if not empty"),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Required(),
+			mcp.Description("Unique identifier of either a thread's parent message or a message in the thread. ts must be the timestamp in format 1234567890.123456 of an existing message with 0 or more replies."),
+		),
+		mcp.WithBoolean("include_activity_messages",
+			mcp.Description("If true, the response will include activity messages such as 'channel_join' or 'channel_leave'. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("exclude_bots",
+			mcp.Description("If true, messages posted by bots and apps are left out: bot messages, messages with a bot_id or the app_id of a bot profile, and messages of users flagged is_bot or is_app_user. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithString("limit",
+			mcp.DefaultString("1d"),
+			mcp.Description("Limit of messages to fetch in format of maximum ranges of time (e.g. 1d - 1 day, 30d - 30 days, 90d - 90 days which is a default limit for free tier history) or number of messages (e.g. 50). Must be empty when 'cursor' is provided."),
+		),
+		mcp.WithBoolean("fetch_all",
+			mcp.Description("If true, the whole thread is fetched in one call, following Slack pagination internally and ignoring limit. Up to max_items messages are returned, a longer thread returns a cursor to continue with and a last row whose note says the thread was truncated. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithNumber("max_items",
+			mcp.Description("Maximum number of messages fetch_all returns, at most and by default the server limit SLACK_MCP_THREAD_MAX_MESSAGES (1000 unless configured)."),
+		),
+		mcp.WithNumber("max_text_len",
+			mcp.Description("Truncate the text of each message to this many characters, marking cut text with an ellipsis (…). Default is 0, no truncation."),
+		),
+		mcp.WithString("format",
+			mcp.DefaultString("text"),
+			mcp.Description("Format of the message text: 'text' removes Slack markup and formatting, 'markdown' converts Slack mrkdwn to standard Markdown, with **bold**, [label](url) links and @name/#channel mentions. Default is 'text'."),
+		),
+		withTimezone(),
+	), conversationsHandler.ConversationsRepliesHandler)
+
+	r.addTool(mcp.NewTool("conversations_thread_root",
+		mcp.WithDescription("Get the root message of the thread a message belongs to by channel_id and the ts of any message in the thread, with flags telling whether ts is a reply and whether the message is in a thread at all. Use the returned MsgID as thread_ts of conversations_replies to fetch the whole thread."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("ts",
+			mcp.Required(),
+			mcp.Description("Timestamp of a thread reply or root message in format 1234567890.123456."),
+		),
+		withTimezone(),
+	), conversationsHandler.ConversationsThreadRootHandler)
+
+	r.addTool(mcp.NewTool("conversations_get_message",
+		mcp.WithDescription("Get a single message, including thread replies, by its permalink or by channel_id and ts. Returns the message as CSV with its reactions and the id, name, mimetype and size of its files."),
+		mcp.WithString("permalink",
+			mcp.Description("Permalink of the message, e.g. 'https://acme.slack.com/archives/C1234567890/p1234567890123456'. Required unless channel_id and ts are given."),
+		),
+		mcp.WithString("channel_id",
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm. Required together with ts unless permalink is given."),
+		),
+		mcp.WithString("ts",
+			mcp.Description("Timestamp of the message in format 1234567890.123456. Required together with channel_id unless permalink is given."),
+		),
+		withTimezone(),
+	), conversationsHandler.ConversationsGetMessageHandler)
+
+	r.addTool(mcp.NewTool("conversations_get_permalink",
+		mcp.WithDescription("Get the permalink of a message by channel_id and ts, a shareable URL to cite the message. Thread replies link to their thread, whose ts is returned as ThreadTs."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("ts",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message in format 1234567890.123456."),
+		),
+	), conversationsHandler.ConversationsGetPermalinkHandler)
+
+	r.addTool(mcp.NewTool("conversations_context",
+		mcp.WithDescription("Get the messages surrounding a message in a channel (or DM) by channel_id and ts, in chronological order. This returns channel-level context: if ts is a thread reply, the surrounding channel messages are returned, not the thread, use conversations_replies for thread context."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("ts",
+			mcp.Required(),
+			mcp.Description("Timestamp of the center message in format 1234567890.123456."),
+		),
+		mcp.WithNumber("context",
+			mcp.DefaultNumber(5),
+			mcp.Description("Number of messages to fetch before and after the center message. Must be an integer between 1 and 100."),
+		),
+		withTimezone(),
+	), conversationsHandler.ConversationsContextHandler)
+
+	r.addTool(mcp.NewTool("conversations_channel_events",
+		mcp.WithDescription("List what changed in a channel within a time range: members joining or leaving, topic, purpose and name changes, archiving. Returns a CSV timeline, oldest first, built from the system messages of the channel's history, so no admin scopes are needed."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("limit",
+			mcp.DefaultString("30d"),
+			mcp.Description("Time range to scan, e.g. 1d (today), 1w or 30d. Default is 30d."),
+		),
+		mcp.WithString("date_range",
+			mcp.Description("Date range instead of the time range of limit: 7d, 2w or 3m, today, yesterday, a date such as 2024-01-31, or two of them joined by .. such as 2024-01-01..2024-02-01, both days included. Dates are in the local time of the server. Optional."),
+		),
+		mcp.WithNumber("max_items",
+			mcp.DefaultNumber(1000),
+			mcp.Description("Maximum number of history messages scanned for events, between 1 and 5000. Default is 1000. A note row tells when the scan stopped before the start of the time range."),
+		),
+		withTimezone(),
+	), conversationsHandler.ConversationsChannelEventsHandler)
+
+	r.addTool(mcp.NewTool("conversations_add_message",
+		mcp.WithDescription("Add a message to a public channel, private channel, or direct message (DM, or IM) conversation by channel_id and thread_ts."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Unique identifier of either a thread's parent message or a message in the thread_ts must be the timestamp in format 1234567890.123456 of an existing message with 0 or more replies. Optional, if not provided the message will be added to the channel itself, otherwise it will be added to the thread. The message must exist in the channel, it is looked up before posting and a reply to a message in a thread is added to the thread of its root."),
+		),
+		mcp.WithBoolean("reply_broadcast",
+			mcp.Description("If true, a thread reply is also posted to the channel. Only allowed together with thread_ts. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithString("payload",
+			mcp.Description("Message payload in specified content_type format. Example: 'Hello, world!' for text/plain or '# Hello, world!' for text/markdown."),
+		),
+		mcp.WithString("content_type",
+			mcp.DefaultString("text/markdown"),
+			mcp.Description("Content type of the message. Default is 'text/markdown'. Allowed values: 'text/markdown', 'text/plain'."),
+		),
+		mcp.WithString("blocks",
+			mcp.Description("Block Kit blocks as a JSON array, or an object with a blocks array as exported by the Block Kit Builder, for structured messages with sections, fields or buttons. At most 50 blocks. The payload is then the notification fallback text and optional, content_type is ignored. Optional."),
+		),
+		mcp.WithString("mentions",
+			mcp.Description("Comma-separated users and channels to mention, e.g. '@john,#general'. Their occurrences in the payload become Slack mentions, mentions missing from the payload are prepended to it. IDs such as @U1234567890 are used as is. @here, @channel and @everyone, also as <!here>, <!channel> or <!everyone> markup in the payload or blocks and rich_text broadcast elements, require SLACK_MCP_ADD_MESSAGE_BROADCAST. Optional."),
+		),
+		mcp.WithBoolean("unfurl_links",
+			mcp.Description("Set to false to disable link previews. Optional, Slack's default is used when not provided. Previews are never shown for links that SLACK_MCP_ADD_MESSAGE_UNFURLING does not allow."),
+		),
+		mcp.WithBoolean("unfurl_media",
+			mcp.Description("Set to false to disable media previews (images, videos). Optional, Slack's default is used when not provided. Previews are never shown for links that SLACK_MCP_ADD_MESSAGE_UNFURLING does not allow."),
+		),
+	), conversationsHandler.ConversationsAddMessageHandler)
+
+	r.addTool(mcp.NewTool("conversations_schedule_message",
+		mcp.WithDescription("Schedule a message to be posted later to a public channel, private channel, or direct message (DM, or IM) conversation, returning the scheduled message ID. Subject to the same SLACK_MCP_ADD_MESSAGE_TOOL policy as conversations_add_message."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("post_at",
+			mcp.Required(),
+			mcp.Description("When to post the message: unix seconds, an RFC3339 time such as 2025-01-02T15:04:05Z, or a time without UTC offset such as 2025-01-02 15:04, taken in the timezone of the authenticated user. Must be in the future and at most 120 days ahead."),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Timestamp in format 1234567890.123456 of an existing message to reply to. Optional, if not provided the message will be added to the channel itself."),
+		),
+		mcp.WithBoolean("reply_broadcast",
+			mcp.Description("If true, the thread reply is also posted to the channel. Only allowed together with thread_ts. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithString("payload",
+			mcp.Description("Message payload in specified content_type format. Example: 'Hello, world!' for text/plain or '# Hello, world!' for text/markdown."),
+		),
+		mcp.WithString("content_type",
+			mcp.DefaultString("text/markdown"),
+			mcp.Description("Content type of the message. Default is 'text/markdown'. Allowed values: 'text/markdown', 'text/plain'."),
+		),
+		mcp.WithString("blocks",
+			mcp.Description("Block Kit blocks as a JSON array, or an object with a blocks array as exported by the Block Kit Builder, for structured messages with sections, fields or buttons. At most 50 blocks. The payload is then the notification fallback text and optional, content_type is ignored. Optional."),
+		),
+		mcp.WithString("mentions",
+			mcp.Description("Comma-separated users and channels to mention, e.g. '@john,#general', as with conversations_add_message. Optional."),
+		),
+		mcp.WithBoolean("unfurl_links",
+			mcp.Description("Set to false to disable link previews. Optional, Slack's default is used when not provided."),
+		),
+		mcp.WithBoolean("unfurl_media",
+			mcp.Description("Set to false to disable media previews (images, videos). Optional, Slack's default is used when not provided."),
+		),
+	), conversationsHandler.ConversationsScheduleMessageHandler)
+
+	r.addTool(mcp.NewTool("conversations_post_ephemeral",
+		mcp.WithDescription("Post a message to a channel that only the given user sees, e.g. to answer one user without notifying everyone. Ephemeral messages are not stored in the history. In OAuth mode the bot token is used. Subject to the same SLACK_MCP_ADD_MESSAGE_TOOL policy as conversations_add_message."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("user_id",
+			mcp.Required(),
+			mcp.Description("ID of the user who sees the message, in format Uxxxxxxxxxx, or @username in non-OAuth mode. The user must be a member of the channel."),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Timestamp in format 1234567890.123456 of an existing message, to show the message in its thread. Optional."),
+		),
+		mcp.WithString("payload",
+			mcp.Description("Message payload in specified content_type format. Example: 'Hello, world!' for text/plain or '# Hello, world!' for text/markdown."),
+		),
+		mcp.WithString("content_type",
+			mcp.DefaultString("text/markdown"),
+			mcp.Description("Content type of the message. Default is 'text/markdown'. Allowed values: 'text/markdown', 'text/plain'."),
+		),
+		mcp.WithString("blocks",
+			mcp.Description("Block Kit blocks as a JSON array, or an object with a blocks array as exported by the Block Kit Builder, for structured messages with sections, fields or buttons. At most 50 blocks. The payload is then the notification fallback text and optional, content_type is ignored. Optional."),
+		),
+		mcp.WithString("mentions",
+			mcp.Description("Comma-separated users and channels to mention, e.g. '@john,#general', as with conversations_add_message. Optional."),
+		),
+	), conversationsHandler.ConversationsPostEphemeralHandler)
+
+	r.addTool(mcp.NewTool("conversations_delete_message",
+		mcp.WithDescription("Delete a message by channel_id and ts. Deleted messages cannot be restored. Disabled unless SLACK_MCP_ALLOW_DESTRUCTIVE is set to true."),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("ts",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message to delete in format 1234567890.123456."),
+		),
+	), conversationsHandler.ConversationsDeleteMessageHandler)
+
+	r.addTool(mcp.NewTool("conversations_open",
+		mcp.WithDescription("Open a direct message (DM) with one user or a group DM (MPIM) with several users, returning the channel ID to post to. Re-opening returns the existing conversation."),
+		mcp.WithString("user_ids",
+			mcp.Required(),
+			mcp.Description("Comma-separated user IDs, or @username in non-OAuth mode. One user opens a DM, several users open a group DM. At most 8 users besides yourself. Example: 'U1234567890,U0987654321'"),
+		),
+	), conversationsHandler.ConversationsOpenHandler)
+
+	r.addTool(mcp.NewTool("conversations_search_messages",
+		mcp.WithDescription("Search messages in a public channel, private channel, or direct message (DM, or IM) conversation using filters. All filters are optional, if not provided then search_query is required."),
+		mcp.WithString("search_query",
+			mcp.Description("Search query to filter messages. Example: 'marketing report' or full URL of Slack message e.g. 'https://slack.com/archives/C1234567890/p1234567890123456', then the tool will return a single message matching given URL, herewith all other parameters will be ignored."),
+		),
+		mcp.WithString("filter_in_channel",
+			mcp.Description("Filter messages in a specific public/private channel by its ID or name. Example: 'C1234567890', 'G1234567890', or '#general'. If not provided, all channels will be searched."),
+		),
+		mcp.WithString("filter_in_im_or_mpim",
+			mcp.Description("Filter messages in a direct message (DM) or multi-person direct message (MPIM) conversation by its ID or name. Example: 'D1234567890' or '@username_dm'. If not provided, all DMs and MPIMs will be searched."),
+		),
+		mcp.WithString("filter_users_with",
+			mcp.Description("Filter messages with a specific user by their ID or display name in threads and DMs. Example: 'U1234567890' or '@username'. If not provided, all threads and DMs will be searched."),
+		),
+		mcp.WithString("filter_users_from",
+			mcp.Description("Filter messages from a specific user by their ID or display name. Example: 'U1234567890' or '@username'. If not provided, all users will be searched."),
+		),
+		mcp.WithString("filter_date_before",
+			mcp.Description("Filter messages sent before a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
+		),
+		mcp.WithString("filter_date_after",
+			mcp.Description("Filter messages sent after a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
+		),
+		mcp.WithString("filter_date_on",
+			mcp.Description("Filter messages sent on a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
+		),
+		mcp.WithString("filter_date_during",
+			mcp.Description("Filter messages sent during a specific period in format 'YYYY-MM-DD'. Example: 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
+		),
+		mcp.WithBoolean("filter_threads_only",
+			mcp.Description("If true, the response will include only messages from threads. Default is boolean false."),
+		),
+		mcp.WithBoolean("exclude_bots",
+			mcp.Description("If true, matches posted by bots and apps are left out: matches without a user and matches of users flagged is_bot or is_app_user. A page may then hold fewer matches than limit. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithString("cursor",
+			mcp.DefaultString(""),
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(20),
+			mcp.Description("The maximum number of items to return. Must be an integer between 1 and 100."),
+		),
+		mcp.WithNumber("max_text_len",
+			mcp.Description("Truncate the text of each message to this many characters, marking cut text with an ellipsis (…). Default is 0, no truncation."),
+		),
+		mcp.WithBoolean("highlight",
+			mcp.DefaultBool(false),
+			mcp.Description("If true, adds a Highlight column with the part of each message that matched, with every match wrapped in ** markers. The Text column is unchanged."),
+		),
+		mcp.WithNumber("highlight_context",
+			mcp.DefaultNumber(50),
+			mcp.Description("Number of characters of context kept on either side of the matches in the Highlight column, cut text is marked with an ellipsis (…). 0 keeps the whole message. Must be an integer between 0 and 1000."),
+		),
+		mcp.WithBoolean("include_permalink",
+			mcp.DefaultBool(false),
+			mcp.Description("If true, adds a Permalink column with the shareable URL of each message, taken from the search results without extra calls. The Highlight column is then present too, empty unless highlight is true."),
+		),
+		withTimezone(),
+	), conversationsHandler.ConversationsSearchHandler)
+
+	r.addTool(mcp.NewTool("reactions_get",
+		mcp.WithDescription("Get reactions on a message by channel_id and timestamp. Returns each reaction's name, count and reacting users, or an empty result if the message has no reactions. Requires the reactions:read scope."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message in format 1234567890.123456, as returned in the msgID column of other tools."),
+		),
+		mcp.WithBoolean("resolve_users",
+			mcp.Description("If true, reacting user IDs are also resolved to user names. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithString("name",
+			mcp.Description("Only return this reaction, as a Slack emoji name with or without colons or as an emoji glyph, e.g. ':thumbsup:', 'tada' or '🎉'. Without a skin tone, its skin tone variants are returned too."),
+		),
+	), conversationsHandler.ReactionsGetHandler)
+
+	r.addTool(mcp.NewTool("reactions_add",
+		mcp.WithDescription("Add an emoji reaction to a message by channel_id and timestamp as the authenticated user. Fails if the message already has this reaction of the user. Requires the reactions:write scope."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message in format 1234567890.123456, as returned in the msgID column of other tools."),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Emoji name with or without colons or as an emoji glyph, e.g. ':thumbsup:', 'tada' or '🎉'."),
+		),
+	), conversationsHandler.ReactionsAddHandler)
+
+	r.addTool(mcp.NewTool("reactions_remove",
+		mcp.WithDescription("Remove an emoji reaction of the authenticated user from a message by channel_id and timestamp. Fails if the user has not reacted with it. Requires the reactions:write scope."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message in format 1234567890.123456, as returned in the msgID column of other tools."),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Emoji name with or without colons or as an emoji glyph, e.g. ':thumbsup:', 'tada' or '🎉'."),
+		),
+	), conversationsHandler.ReactionsRemoveHandler)
+
+	r.addTool(mcp.NewTool("pins_list",
+		mcp.WithDescription("Get the pinned messages and files of a channel by channel_id as CSV, e.g. to summarize what a channel considers important. Returns an empty result if nothing is pinned. Requires the pins:read scope."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithBoolean("include_links",
+			mcp.Description("If true, the permalinks are also returned as resource links after the CSV, for clients that render links. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		withTimezone(),
+	), conversationsHandler.PinsListHandler)
+
+	r.addTool(mcp.NewTool("files_get",
+		mcp.WithDescription("Get metadata of a file shared in Slack by file_id, e.g. from the attachments of a message, and optionally its content. Requires the files:read scope."),
+		mcp.WithString("file_id",
+			mcp.Required(),
+			mcp.Description("ID of the file in format Fxxxxxxxxxx."),
+		),
+		mcp.WithBoolean("include_content",
+			mcp.Description("If true, the file content is downloaded and returned base64 encoded in the Content column. Fails for files larger than the configured maximum download size. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("include_links",
+			mcp.Description("If true, the permalinks are also returned as resource links after the CSV, for clients that render links. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+	), conversationsHandler.FilesGetHandler)
+
+	r.addTool(mcp.NewTool("files_get_content",
+		mcp.WithDescription("Download the content of a file shared in Slack by file_id. Text files are returned inline as text, binary files such as images or PDFs as an embedded base64 resource with their MIME type. Fails for files larger than the configured maximum download size. Requires the files:read scope."),
+		mcp.WithString("file_id",
+			mcp.Required(),
+			mcp.Description("ID of the file in format Fxxxxxxxxxx."),
+		),
+	), conversationsHandler.FilesGetContentHandler)
+
+	r.addTool(mcp.NewTool("files_search",
+		mcp.WithDescription("Search files shared in channels and conversations using search.files and filters. All filters are optional, if not provided then search_query is required. Requires a user token with the search:read scope."),
+		mcp.WithString("search_query",
+			mcp.Description("Search query matched against file names, titles and contents. Example: 'quarterly report'."),
+		),
+		mcp.WithString("filter_in_channel",
+			mcp.Description("Filter files shared in a specific public/private channel by its ID or name. Example: 'C1234567890', 'G1234567890', or '#general'. If not provided, all channels will be searched."),
+		),
+		mcp.WithString("filter_in_im_or_mpim",
+			mcp.Description("Filter files shared in a direct message (DM) or multi-person direct message (MPIM) conversation by its ID or name. Example: 'D1234567890' or '@username_dm'. If not provided, all DMs and MPIMs will be searched."),
+		),
+		mcp.WithString("filter_users_from",
+			mcp.Description("Filter files shared by a specific user by their ID or display name. Example: 'U1234567890' or '@username'. If not provided, all users will be searched."),
+		),
+		mcp.WithString("filter_date_before",
+			mcp.Description("Filter files shared before a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
+		),
+		mcp.WithString("filter_date_after",
+			mcp.Description("Filter files shared after a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
+		),
+		mcp.WithString("filter_date_on",
+			mcp.Description("Filter files shared on a specific date in format 'YYYY-MM-DD'. Example: '2023-10-01', 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
+		),
+		mcp.WithString("filter_date_during",
+			mcp.Description("Filter files shared during a specific period in format 'YYYY-MM-DD'. Example: 'July', 'Yesterday' or 'Today'. If not provided, all dates will be searched."),
+		),
+		mcp.WithString("cursor",
+			mcp.DefaultString(""),
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(20),
+			mcp.Description("The maximum number of items to return. Must be an integer between 1 and 100."),
+		),
+		mcp.WithBoolean("include_links",
+			mcp.Description("If true, the permalinks are also returned as resource links after the CSV, for clients that render links. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		withTimezone(),
+	), conversationsHandler.FilesSearchHandler)
+
+	r.addTool(mcp.NewTool("files_upload",
+		mcp.WithDescription("Upload a file with files.uploadV2 and share it into channels, e.g. to attach a generated report. Returns one CSV row per channel with the file ID. Subject to the same SLACK_MCP_ADD_MESSAGE_TOOL policy as conversations_add_message. Requires the files:write scope."),
+		mcp.WithString("filename",
+			mcp.Required(),
+			mcp.Description("Name of the file including its extension, e.g. 'report.csv'. Slack derives the file type from it."),
+		),
+		mcp.WithString("content_base64",
+			mcp.Description("Content of the file, base64 encoded. Either content_base64 or url is required."),
+		),
+		mcp.WithString("url",
+			mcp.Description("http or https URL to fetch the content of the file from, only public addresses can be fetched. Either content_base64 or url is required."),
+		),
+		mcp.WithString("channel_ids",
+			mcp.Description("Comma-separated list of up to 10 channels to share the file into, each an ID in format Cxxxxxxxxxx or a name starting with #... or @... aka #general or @username_dm. Every channel gets its own copy of the file. Optional, if not provided the file is uploaded without being shared."),
+		),
+		mcp.WithString("title",
+			mcp.Description("Title of the file. Optional, defaults to the filename."),
+		),
+		mcp.WithString("initial_comment",
+			mcp.Description("Message posted together with the file in each channel. Optional."),
+		),
+	), conversationsHandler.FilesUploadHandler)
+
+	r.addTool(mcp.NewTool("users_lookup_by_email",
+		mcp.WithDescription("Find the Slack user with an email address, e.g. to map a CRM contact to a Slack user. Returns the user ID and profile as CSV. Requires the users:read.email scope."),
+		mcp.WithString("email",
+			mcp.Required(),
+			mcp.Description("Email address of the user, e.g. 'jane@example.com'."),
+		),
+	), conversationsHandler.UsersLookupByEmailHandler)
+
+	r.addTool(mcp.NewTool("users_list",
+		mcp.WithDescription("List the members of the workspace as CSV, people only by default, ordered by user ID. Requires the users:read scope."),
+		mcp.WithString("name_prefix",
+			mcp.Description("Only list users whose user name, real name or display name starts with this prefix, case-insensitive, e.g. 'jan' or '@jan'."),
+		),
+		mcp.WithBoolean("include_bots",
+			mcp.Description("If true, bots and apps are listed too. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("include_deleted",
+			mcp.Description("If true, deactivated users are listed too. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(100),
+			mcp.Description("The maximum number of users to return, between 1 and 1000."),
+		),
+	), conversationsHandler.UsersListHandler)
+
+	r.addTool(mcp.NewTool("users_profile",
+		mcp.WithDescription("Get the profile of a Slack user: title, email, phone, status, time zone and custom profile fields, as CSV. Requires the users:read scope, users:read.email for the email and users.profile:read for custom fields."),
+		mcp.WithString("user",
+			mcp.Required(),
+			mcp.Description("ID of the user in format Uxxxxxxxxxx, or its @handle, e.g. '@jane'. Handles are not supported in OAuth mode."),
+		),
+	), conversationsHandler.UsersProfileHandler)
+
+	r.addTool(mcp.NewTool("users_presence",
+		mcp.WithDescription("Check whether Slack users are active or away, e.g. before deciding to DM someone or escalate. Returns the presence of each user as CSV. Requires the users:read scope."),
+		mcp.WithString("user_ids",
+			mcp.Required(),
+			mcp.Description("Comma-separated user IDs in format Uxxxxxxxxxx, or @usernames, at most 20, e.g. 'U1234567890,@jane'. Usernames are not supported in OAuth mode."),
+		),
+	), conversationsHandler.UsersPresenceHandler)
+
+	r.addTool(mcp.NewTool("reminders_list",
+		mcp.WithDescription("List the reminders created by or for the authenticated user as CSV. Requires a user token with the reminders:read scope."),
+		withTimezone(),
+	), conversationsHandler.RemindersListHandler)
+
+	r.addTool(mcp.NewTool("reminders_add",
+		mcp.WithDescription("Create a reminder for the authenticated user, e.g. to follow up on a thread. Requires a user token with the reminders:write scope."),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("What to be reminded about, e.g. 'Reply to the launch thread'."),
+		),
+		mcp.WithString("time",
+			mcp.Required(),
+			mcp.Description("When to be reminded, in the future. Either an RFC3339 time such as 2025-01-02T15:04:05Z or a time relative to now such as 30m, 2h, 3d or 1w."),
+		),
+		withTimezone(),
+	), conversationsHandler.RemindersAddHandler)
+
+	r.addTool(mcp.NewTool("usergroups_list",
+		mcp.WithDescription("List the usergroups of the workspace, such as @team-eng, with their handle, name, description and member IDs. Usergroups are cached as they rarely change. Requires the usergroups:read scope."),
+		mcp.WithBoolean("resolve_users",
+			mcp.DefaultBool(false),
+			mcp.Description("If true, members are listed by @name instead of user ID where the name is known."),
+		),
+	), conversationsHandler.UserGroupsListHandler)
+
+	r.addTool(mcp.NewTool("usergroups_users_list",
+		mcp.WithDescription("List the current members of a usergroup with their user names. Requires the usergroups:read scope."),
+		mcp.WithString("usergroup",
+			mcp.Required(),
+			mcp.Description("ID of the usergroup in format Sxxxxxxxxxx or its handle starting with @, aka @team-eng."),
+		),
+	), conversationsHandler.UserGroupsUsersListHandler)
+
+	r.addTool(mcp.NewTool("usergroups_users_update",
+		mcp.WithDescription("Set, add or remove the members of a usergroup, e.g. to hand over an on-call rotation, and return the updated usergroup as CSV. Disabled unless SLACK_MCP_USERGROUPS_WRITE_TOOL is set. Requires the usergroups:write scope."),
+		mcp.WithString("usergroup",
+			mcp.Required(),
+			mcp.Description("ID of the usergroup in format Sxxxxxxxxxx or its handle starting with @, aka @team-eng."),
+		),
+		mcp.WithString("user_ids",
+			mcp.Required(),
+			mcp.Description("Comma-separated user IDs in format Uxxxxxxxxxx, or @usernames, e.g. 'U1234567890,@jane'. Usernames are not supported in OAuth mode."),
+		),
+		mcp.WithString("mode",
+			mcp.DefaultString("set"),
+			mcp.Description("How user_ids change the members. Allowed values: 'set' - user_ids become the members, 'add' - user_ids are added, 'remove' - user_ids are removed. A usergroup must keep at least one member."),
+		),
+	), conversationsHandler.UserGroupsUsersUpdateHandler)
+
+	r.addTool(mcp.NewTool("slack_api_read",
+		mcp.WithDescription("Call a read-only Slack Web API method that has no dedicated tool and return its raw JSON response. Only methods in the server's allowlist can be called, the error lists them."),
+		mcp.WithString("method",
+			mcp.Required(),
+			mcp.Description("Slack Web API method name, e.g. 'users.profile.get' or 'bookmarks.list'."),
+		),
+		mcp.WithObject("params",
+			mcp.Description("Arguments of the method as a JSON object, e.g. {\"channel_id\": \"C1234567890\"}. The token is supplied by the server."),
+		),
+	), conversationsHandler.SlackAPIReadHandler)
+
+	if oauthEnabled {
+		r.addTool(mcp.NewTool("bot_info",
+			mcp.WithDescription("Get the identity and scopes of the bot token installed with the Slack app, e.g. to avoid self-mentions or reply loops. Says so plainly if no bot token is available."),
+		), conversationsHandler.BotInfoHandler)
+	}
+
+	r.addTool(mcp.NewTool("channels_list",
+		mcp.WithDescription("Get list of channels"),
+		mcp.WithString("channel_types",
+			mcp.Required(),
+			mcp.Description("Comma-separated channel types. Allowed values: 'mpim', 'im', 'public_channel', 'private_channel'. Example: 'public_channel,private_channel,im'"),
+		),
+		mcp.WithString("sort",
+			mcp.Description("Type of sorting. Allowed values: 'popularity' - sort by number of members/participants in each channel, ties ordered by channel ID."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(100),
+			mcp.Description("The maximum number of items to return. Must be an integer between 1 and 1000 (maximum 999)."), // context fix for cursor: https://github.com/korotovsky/slack-mcp-server/issues/7
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithString("query",
+			mcp.Description("Only list channels whose name, topic or purpose match, case-insensitively. A substring such as 'incident', or a glob with *, ? or [...] matched against the whole name, topic or purpose such as 'incident-*'. Applied before pagination. Optional."),
+		),
+		mcp.WithBoolean("include_archived",
+			mcp.Description("If true, archived channels are listed too, e.g. to find historical channels and read their history. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+	), channelsHandler.ChannelsHandler)
+
+	r.addTool(mcp.NewTool("channels_member_count",
+		mcp.WithDescription("Get the number of members of a channel by channel_id from conversations.info, without listing the members. Faster than fetching the member list for large channels."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+	), channelsHandler.ChannelMemberCountHandler)
+
+	r.addTool(mcp.NewTool("channels_members",
+		mcp.WithDescription("List the members of a channel by channel_id from conversations.members as CSV, e.g. to answer who is in a channel. The last row/column in the response is used as 'cursor' parameter for pagination if not empty. Use channels_member_count when only the number is needed."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+		mcp.WithBoolean("resolve_users",
+			mcp.Description("If true, the user name and real name of each member are filled from the users cache. Not supported in OAuth mode. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Cursor for pagination. Use the value of the last row and column in the response as next_cursor field returned from the previous request."),
+		),
+		mcp.WithNumber("limit",
+			mcp.DefaultNumber(100),
+			mcp.Description("The maximum number of members to return, between 1 and 1000. Slack may return fewer members per page."),
+		),
+	), channelsHandler.ChannelsMembersHandler)
+
+	r.addTool(mcp.NewTool("channels_info",
+		mcp.WithDescription("Get the full detail of a channel by channel_id from conversations.info as CSV: creation date, creator, whether it is private, archived, the general channel or shared with other workspaces, member count, topic, purpose and the timestamp of the latest message."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... or @... aka #general or @username_dm."),
+		),
+	), channelsHandler.ChannelsInfoHandler)
+
+	r.addTool(mcp.NewTool("channels_unreads",
+		mcp.WithDescription("Get the unread message count and last read position of the authenticated user in channels and DMs, from conversations.info, to triage which conversations need attention. Channels with the most unread messages come first."),
+		mcp.WithString("channel_ids",
+			mcp.Required(),
+			mcp.Description("Comma-separated list of up to 50 channels, each an ID in format Cxxxxxxxxxx or a name starting with #... or @... aka #general or @username_dm."),
+		),
+	), channelsHandler.ChannelsUnreadsHandler)
+
+	r.addTool(mcp.NewTool("channels_stats",
+		mcp.WithDescription("Count channels per conversation type (public_channel, private_channel, im, mpim) with active and archived counts and a total row, without listing the channels. Answers from the channel cache."),
+		mcp.WithBoolean("include_archived",
+			mcp.DefaultBool(false),
+			mcp.Description("Count archived channels too. Default is boolean false."),
+		),
+	), channelsHandler.ChannelsStatsHandler)
+
+	r.addTool(mcp.NewTool("channels_export",
+		mcp.WithDescription("Export channels matching all of the given filters as CSV, e.g. to audit a workspace for small, archived or inactive channels. Every filter is optional."),
+		mcp.WithString("channel_types",
+			mcp.Description("Comma-separated channel types. Allowed values: 'mpim', 'im', 'public_channel', 'private_channel'. Default is 'public_channel,private_channel'."),
+		),
+		mcp.WithNumber("min_members",
+			mcp.Description("Only channels with at least this many members."),
+		),
+		mcp.WithNumber("max_members",
+			mcp.Description("Only channels with at most this many members."),
+		),
+		mcp.WithString("archived",
+			mcp.DefaultString("exclude"),
+			mcp.Description("Archived state. Allowed values: 'exclude' - only active channels, 'include' - both, 'only' - only archived channels. "),
+		),
+		mcp.WithString("active_within",
+			mcp.Description("Only channels with a message within this period, e.g. 7d - 7 days, 2w - 2 weeks, 3m - 3 months. Looks up each matching channel with conversations.info; channels whose last activity is unknown are left out."),
+		),
+		mcp.WithString("inactive_for",
+			mcp.Description("Only channels without a message for at least this period, in the same format as active_within. Looks up each matching channel with conversations.info; channels whose last activity is unknown are left out."),
+		),
+		mcp.WithBoolean("include_activity",
+			mcp.Description("If true, fills the LastActivity column even without an activity filter. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithNumber("max_info_calls",
+			mcp.DefaultNumber(100),
+			mcp.Description("Maximum number of conversations.info calls for activity lookups, between 1 and 500. The call fails if more channels match the other filters."),
+		),
+		mcp.WithNumber("max_text_len",
+			mcp.Description("Truncate the topic and purpose of each channel to this many characters, marking cut text with an ellipsis (…). Default is 0, no truncation."),
+		),
+	), channelsHandler.ChannelsExportHandler)
+
+	r.addTool(mcp.NewTool("channels_create",
+		mcp.WithDescription("Create a public or private channel, optionally with a topic, a purpose and invited users, and return it as CSV. A Note reports settings that could not be applied to the created channel. Disabled unless SLACK_MCP_CHANNELS_WRITE_TOOL is set. Requires the channels:write scope, groups:write for private channels."),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the channel, with or without a leading #: lowercase letters, numbers, hyphens and underscores, at most 80 characters, e.g. 'incident-42'."),
+		),
+		mcp.WithBoolean("is_private",
+			mcp.Description("If true, a private channel is created. Default is boolean false."),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithString("topic",
+			mcp.Description("Initial topic of the channel. Optional."),
+		),
+		mcp.WithString("purpose",
+			mcp.Description("Initial purpose of the channel. Optional."),
+		),
+		mcp.WithString("invitees",
+			mcp.Description("Comma-separated user IDs or @handles to invite, e.g. 'U1234567890,@jane'. Handles are not supported in OAuth mode. Optional."),
+		),
+	), channelsHandler.ChannelsCreateHandler)
+
+	r.addTool(mcp.NewTool("channels_join",
+		mcp.WithDescription("Join a public channel as the authenticated user or bot, e.g. before reading or posting into a channel that fails with not_in_channel. Joining a channel twice succeeds without changes. Disabled unless SLACK_MCP_CHANNELS_WRITE_TOOL is set. Requires the channels:join scope for bot tokens, channels:write for user tokens."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+	), channelsHandler.ChannelsJoinHandler)
+
+	r.addTool(mcp.NewTool("channels_leave",
+		mcp.WithDescription("Leave a channel as the authenticated user or bot. Leaving a channel that is not joined succeeds without changes. Disabled unless SLACK_MCP_CHANNELS_WRITE_TOOL is set. Requires the channels:write scope, groups:write for private channels."),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+	), channelsHandler.ChannelsLeaveHandler)
+
+	r.addTool(mcp.NewTool("channels_set_topic",
+		mcp.WithDescription("Set the topic of a channel, e.g. to keep the status of an incident channel up to date and return its topic and purpose as CSV. An empty topic clears it. Disabled unless SLACK_MCP_CHANNELS_WRITE_TOOL is set. Requires the channels:write scope, groups:write for private channels."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+		mcp.WithString("topic",
+			mcp.Required(),
+			mcp.Description("New topic of the channel, at most 250 characters. An empty string clears it."),
+		),
+	), channelsHandler.ChannelsSetTopicHandler)
+
+	r.addTool(mcp.NewTool("channels_set_purpose",
+		mcp.WithDescription("Set the purpose of a channel and return its topic and purpose as CSV. An empty purpose clears it. Disabled unless SLACK_MCP_CHANNELS_WRITE_TOOL is set. Requires the channels:write scope, groups:write for private channels."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+		mcp.WithString("purpose",
+			mcp.Required(),
+			mcp.Description("New purpose of the channel, at most 250 characters. An empty string clears it."),
+		),
+	), channelsHandler.ChannelsSetPurposeHandler)
+
+	r.addTool(mcp.NewTool("channels_rename",
+		mcp.WithDescription("Rename a channel and return its new name as CSV. The name is checked against Slack's channel name rules before renaming. Disabled unless SLACK_MCP_CHANNELS_WRITE_TOOL is set. Requires the channels:write scope, groups:write for private channels."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("New name of the channel, with or without a leading #: lowercase letters, numbers, hyphens and underscores, at most 80 characters, e.g. 'incident-42-resolved'."),
+		),
+	), channelsHandler.ChannelsRenameHandler)
+
+	r.addTool(mcp.NewTool("channels_archive",
+		mcp.WithDescription("Archive a channel. Archiving an already archived channel succeeds without changes. Disabled unless SLACK_MCP_CHANNELS_WRITE_TOOL is set. Requires the channels:write scope, groups:write for private channels."),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+	), channelsHandler.ChannelsArchiveHandler)
+
+	r.addTool(mcp.NewTool("channels_unarchive",
+		mcp.WithDescription("Unarchive a channel. Unarchiving a channel that is not archived succeeds without changes. Disabled unless SLACK_MCP_CHANNELS_WRITE_TOOL is set. Requires the channels:write scope, groups:write for private channels."),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx."),
+		),
+	), channelsHandler.ChannelsUnarchiveHandler)
+
+	r.addTool(mcp.NewTool("channels_set_muted",
+		mcp.WithDescription("Mute or unmute a channel for the authenticated user. Requires SLACK_MCP_CHANNELS_WRITE_TOOL. Uses users.prefs.setNotifications, which no OAuth scope unlocks: it only works with user tokens that Slack allows to change notification preferences, such as browser session tokens (xoxc/xoxd)."),
+		mcp.WithString("channel_id",
+			mcp.Required(),
+			mcp.Description("ID of the channel in format Cxxxxxxxxxx or its name starting with #... aka #general."),
+		),
+		mcp.WithBoolean("mute",
+			mcp.Required(),
+			mcp.Description("true to mute the channel, false to unmute it."),
+		),
+	), channelsHandler.ChannelsSetMutedHandler)
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnitParseToolFlags(t *testing.T) {
+	flags, err := ParseToolFlags("")
+	require.NoError(t, err)
+	assert.Empty(t, flags)
+
+	flags, err = ParseToolFlags(" reactions_get=false, channels_list=true ,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"reactions_get": false, "channels_list": true}, flags)
+
+	_, err = ParseToolFlags("reactions_get")
+	assert.Error(t, err)
+
+	_, err = ParseToolFlags("reactions_get=maybe")
+	assert.Error(t, err)
+}
+
+func TestUnitToolRegistry(t *testing.T) {
+	s := server.NewMCPServer("test", "0.0.0")
+	r := newToolRegistry(s, map[string]bool{"disabled": false, "enabled": true}, zap.NewNop())
+
+	r.addTool(mcp.NewTool("enabled"), nil)
+	r.addTool(mcp.NewTool("disabled"), nil)
+	r.addTool(mcp.NewTool("default"), nil)
+
+	assert.Equal(t, 2, r.count)
+	require.NoError(t, r.validate())
+
+	tools := s.ListTools()
+	assert.Contains(t, tools, "enabled")
+	assert.Contains(t, tools, "default")
+	assert.NotContains(t, tools, "disabled")
+
+	r = newToolRegistry(s, map[string]bool{"nope": false}, zap.NewNop())
+	r.addTool(mcp.NewTool("enabled"), nil)
+	err := r.validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nope")
+}
+
+func TestUnitToolAnnotations(t *testing.T) {
+	s := server.NewMCPServer("test", "0.0.0")
+	registerTools(newToolRegistry(s, nil, zap.NewNop()), nil, nil, false)
+	tools := s.ListTools()
+
+	archive := tools["channels_archive"].Tool.Annotations
+	assert.True(t, *archive.DestructiveHint)
+	assert.True(t, *archive.IdempotentHint, "archiving an archived channel succeeds")
+
+	unarchive := tools["channels_unarchive"].Tool.Annotations
+	assert.False(t, *unarchive.DestructiveHint)
+	assert.True(t, *unarchive.IdempotentHint)
+}
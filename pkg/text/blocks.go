@@ -0,0 +1,213 @@
+package text
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// FlattenMessage renders the blocks and attachments of a message as readable
+// text, one line per block or attachment. It is meant for messages without a
+// top-level text, such as those of apps and integrations. Interactive elements
+// degrade to their labels or placeholders, images to their alt text.
+func FlattenMessage(blocks slack.Blocks, attachments []slack.Attachment) string {
+	lines := flattenBlocks(blocks.BlockSet)
+	for _, att := range attachments {
+		if line := attachmentLine(att); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// attachmentLine returns the text of an attachment, its fields and blocks.
+// The fallback is only used when the attachment has nothing else to show.
+func attachmentLine(att slack.Attachment) string {
+	var parts []string
+	if s := AttachmentToText(att); s != "" {
+		parts = append(parts, s)
+	}
+	for _, f := range att.Fields {
+		switch {
+		case f.Title != "" && f.Value != "":
+			parts = append(parts, fmt.Sprintf("%s: %s", f.Title, f.Value))
+		case f.Value != "":
+			parts = append(parts, f.Value)
+		}
+	}
+	parts = append(parts, flattenBlocks(att.Blocks.BlockSet)...)
+	if len(parts) == 0 && att.Fallback != "" {
+		parts = append(parts, att.Fallback)
+	}
+	return oneLine(strings.Join(parts, "; "))
+}
+
+func flattenBlocks(blocks []slack.Block) []string {
+	var lines []string
+	for _, block := range blocks {
+		if line := oneLine(blockText(block)); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func blockText(block slack.Block) string {
+	switch b := block.(type) {
+	case *slack.SectionBlock:
+		parts := []string{textObject(b.Text)}
+		for _, f := range b.Fields {
+			parts = append(parts, textObject(f))
+		}
+		if b.Accessory != nil {
+			parts = append(parts, accessoryText(b.Accessory))
+		}
+		return joinNonEmpty(parts, "; ")
+	case *slack.HeaderBlock:
+		return textObject(b.Text)
+	case *slack.MarkdownBlock:
+		return b.Text
+	case *slack.ContextBlock:
+		var parts []string
+		for _, el := range b.ContextElements.Elements {
+			switch e := el.(type) {
+			case *slack.TextBlockObject:
+				parts = append(parts, textObject(e))
+			case *slack.ImageBlockElement:
+				parts = append(parts, e.AltText)
+			}
+		}
+		return joinNonEmpty(parts, " ")
+	case *slack.ImageBlock:
+		if title := textObject(b.Title); title != "" {
+			return title
+		}
+		return b.AltText
+	case *slack.ActionBlock:
+		if b.Elements == nil {
+			return ""
+		}
+		var parts []string
+		for _, el := range b.Elements.ElementSet {
+			parts = append(parts, elementText(el))
+		}
+		return joinNonEmpty(parts, " | ")
+	case *slack.InputBlock:
+		return joinNonEmpty([]string{textObject(b.Label), elementText(b.Element)}, ": ")
+	case *slack.RichTextBlock:
+		var parts []string
+		for _, el := range b.Elements {
+			parts = append(parts, richTextElement(el))
+		}
+		return joinNonEmpty(parts, " ")
+	}
+	return ""
+}
+
+func accessoryText(a *slack.Accessory) string {
+	switch {
+	case a.ButtonElement != nil:
+		return elementText(a.ButtonElement)
+	case a.SelectElement != nil:
+		return elementText(a.SelectElement)
+	case a.MultiSelectElement != nil:
+		return elementText(a.MultiSelectElement)
+	case a.DatePickerElement != nil:
+		return elementText(a.DatePickerElement)
+	case a.ImageElement != nil:
+		return a.ImageElement.AltText
+	}
+	return ""
+}
+
+// elementText returns the label of an interactive element
+func elementText(el slack.BlockElement) string {
+	switch e := el.(type) {
+	case *slack.ButtonBlockElement:
+		return textObject(e.Text)
+	case *slack.SelectBlockElement:
+		return textObject(e.Placeholder)
+	case *slack.MultiSelectBlockElement:
+		return textObject(e.Placeholder)
+	case *slack.DatePickerBlockElement:
+		return textObject(e.Placeholder)
+	case *slack.PlainTextInputBlockElement:
+		return textObject(e.Placeholder)
+	case *slack.ImageBlockElement:
+		return e.AltText
+	}
+	return ""
+}
+
+func richTextElement(el slack.RichTextElement) string {
+	switch e := el.(type) {
+	case *slack.RichTextSection:
+		return richTextSection(e.Elements)
+	case *slack.RichTextPreformatted:
+		return richTextSection(e.Elements)
+	case *slack.RichTextQuote:
+		return richTextSection(e.Elements)
+	case *slack.RichTextList:
+		var items []string
+		for _, item := range e.Elements {
+			items = append(items, richTextElement(item))
+		}
+		return joinNonEmpty(items, " ")
+	}
+	return ""
+}
+
+func richTextSection(elements []slack.RichTextSectionElement) string {
+	var b strings.Builder
+	for _, el := range elements {
+		switch e := el.(type) {
+		case *slack.RichTextSectionTextElement:
+			b.WriteString(e.Text)
+		case *slack.RichTextSectionLinkElement:
+			if e.Text != "" {
+				b.WriteString(e.Text)
+			} else {
+				b.WriteString(e.URL)
+			}
+		case *slack.RichTextSectionUserElement:
+			b.WriteString("@" + e.UserID)
+		case *slack.RichTextSectionChannelElement:
+			b.WriteString("#" + e.ChannelID)
+		case *slack.RichTextSectionUserGroupElement:
+			b.WriteString("@" + e.UsergroupID)
+		case *slack.RichTextSectionBroadcastElement:
+			b.WriteString("@" + e.Range)
+		case *slack.RichTextSectionEmojiElement:
+			b.WriteString(":" + e.Name + ":")
+		case *slack.RichTextSectionDateElement:
+			if e.Fallback != nil {
+				b.WriteString(*e.Fallback)
+			}
+		}
+	}
+	return b.String()
+}
+
+func textObject(t *slack.TextBlockObject) string {
+	if t == nil {
+		return ""
+	}
+	return t.Text
+}
+
+func joinNonEmpty(parts []string, sep string) string {
+	var kept []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, sep)
+}
+
+// oneLine collapses the line breaks of a block, so that every block or
+// attachment stays on a line of its own
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,126 @@
+package text
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+const blocksFixture = `[
+  {
+    "type": "message",
+    "ts": "1700000000.000100",
+    "text": "",
+    "blocks": [
+      {"type": "header", "text": {"type": "plain_text", "text": "Deploy finished"}},
+      {
+        "type": "section",
+        "text": {"type": "mrkdwn", "text": "Service *api* is live"},
+        "fields": [
+          {"type": "mrkdwn", "text": "Env: prod"},
+          {"type": "mrkdwn", "text": "Version:\n1.2.3"}
+        ],
+        "accessory": {"type": "button", "text": {"type": "plain_text", "text": "View logs"}, "action_id": "logs"}
+      },
+      {"type": "divider"},
+      {
+        "type": "actions",
+        "elements": [
+          {"type": "button", "text": {"type": "plain_text", "text": "Rollback"}, "action_id": "rollback"},
+          {"type": "static_select", "placeholder": {"type": "plain_text", "text": "Pick a region"}, "action_id": "region"}
+        ]
+      },
+      {
+        "type": "context",
+        "elements": [
+          {"type": "image", "image_url": "https://example.com/a.png", "alt_text": "ci"},
+          {"type": "mrkdwn", "text": "by the CI bot"}
+        ]
+      },
+      {
+        "type": "rich_text",
+        "elements": [
+          {
+            "type": "rich_text_section",
+            "elements": [
+              {"type": "text", "text": "ping "},
+              {"type": "user", "user_id": "U123"},
+              {"type": "text", "text": " in "},
+              {"type": "channel", "channel_id": "C456"},
+              {"type": "text", "text": " see "},
+              {"type": "link", "url": "https://example.com/docs"}
+            ]
+          },
+          {
+            "type": "rich_text_list",
+            "style": "bullet",
+            "elements": [
+              {"type": "rich_text_section", "elements": [{"type": "text", "text": "first"}]},
+              {"type": "rich_text_section", "elements": [{"type": "text", "text": "second"}]}
+            ]
+          }
+        ]
+      }
+    ]
+  },
+  {
+    "type": "message",
+    "ts": "1700000000.000200",
+    "text": "",
+    "attachments": [
+      {
+        "fallback": "Build #42 failed",
+        "title": "Build #42",
+        "fields": [
+          {"title": "Branch", "value": "main", "short": true},
+          {"title": "Status", "value": "failed", "short": true}
+        ]
+      },
+      {"fallback": "Only a fallback"}
+    ]
+  }
+]`
+
+func TestFlattenMessage(t *testing.T) {
+	var msgs []slack.Message
+	if err := json.Unmarshal([]byte(blocksFixture), &msgs); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		msg  slack.Message
+		want string
+	}{
+		{
+			name: "blocks only",
+			msg:  msgs[0],
+			want: "Deploy finished\n" +
+				"Service *api* is live; Env: prod; Version: 1.2.3; View logs\n" +
+				"Rollback | Pick a region\n" +
+				"ci by the CI bot\n" +
+				"ping @U123 in #C456 see https://example.com/docs first second",
+		},
+		{
+			name: "attachments only",
+			msg:  msgs[1],
+			want: "Title: Build #42; Branch: main; Status: failed\n" +
+				"Only a fallback",
+		},
+		{
+			name: "nothing to render",
+			msg:  slack.Message{},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FlattenMessage(tt.msg.Blocks, tt.msg.Attachments)
+			if got != tt.want {
+				t.Errorf("FlattenMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package text
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// MaxChannelNameLen is the number of characters Slack allows in a channel name
+const MaxChannelNameLen = 80
+
+// ValidateChannelName checks a channel name against Slack's rules before it is
+// sent to conversations.create or conversations.rename: at most 80 characters,
+// lowercase letters, numbers, hyphens and underscores only. A leading # is
+// ignored. It returns the name without the #.
+func ValidateChannelName(name string) (string, error) {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "#")
+	if name == "" {
+		return "", errors.New("channel name must not be empty")
+	}
+	if n := len([]rune(name)); n > MaxChannelNameLen {
+		return "", fmt.Errorf("invalid channel name %q: it is %d characters long, Slack allows at most %d", name, n, MaxChannelNameLen)
+	}
+	for _, r := range name {
+		switch {
+		case r == '-' || r == '_':
+		case unicode.IsUpper(r):
+			return "", fmt.Errorf("invalid channel name %q: names must be lowercase, e.g. %q", name, strings.ToLower(name))
+		case unicode.IsSpace(r):
+			return "", fmt.Errorf("invalid channel name %q: names cannot contain spaces, use hyphens instead", name)
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			return "", fmt.Errorf("invalid channel name %q: %q is not allowed, names may contain letters, numbers, hyphens and underscores only", name, r)
+		}
+	}
+	return name, nil
+}
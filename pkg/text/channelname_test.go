@@ -0,0 +1,43 @@
+package text
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateChannelName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain", input: "incident-42", want: "incident-42"},
+		{name: "hash and spaces trimmed", input: " #team_ops ", want: "team_ops"},
+		{name: "non-latin lowercase", input: "général", want: "général"},
+		{name: "max length", input: strings.Repeat("a", MaxChannelNameLen), want: strings.Repeat("a", MaxChannelNameLen)},
+		{name: "empty", input: "#", wantErr: true},
+		{name: "too long", input: strings.Repeat("a", MaxChannelNameLen+1), wantErr: true},
+		{name: "uppercase", input: "Incident", wantErr: true},
+		{name: "space", input: "my channel", wantErr: true},
+		{name: "punctuation", input: "v1.2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateChannelName(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ValidateChannelName(%q) = %q, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateChannelName(%q) error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ValidateChannelName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package text
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayouts are the calendar dates accepted in date ranges
+var dateLayouts = []string{"2006-01-02", "2006/01/02"}
+
+// ParseDateRange converts a human-friendly date expression into the time range
+// [oldest, latest) it covers, in the location of now:
+//   - a relative range such as 7d, 2w or 3m, from the start of the day that
+//     many days, weeks or months ago until now, where 1d is today
+//   - today, yesterday or a date such as 2024-01-31, the whole day
+//   - two of the above joined by .., e.g. 2024-01-01..2024-02-01, from the
+//     start of the first until the end of the second. Either side can be left
+//     out, 2024-01-01.. runs until now and ..2024-02-01 has no start.
+//
+// A zero oldest means there is no lower bound.
+func ParseDateRange(expr string, now time.Time) (oldest, latest time.Time, err error) {
+	expr = strings.ToLower(strings.TrimSpace(expr))
+	if expr == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("empty date range")
+	}
+
+	from, to, isRange := strings.Cut(expr, "..")
+	if !isRange {
+		return parseDateTerm(expr, now)
+	}
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if from == "" && to == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid date range %q: at least one side of .. is required", expr)
+	}
+
+	latest = now
+	if from != "" {
+		if oldest, _, err = parseDateTerm(from, now); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if to != "" {
+		if _, latest, err = parseDateTerm(to, now); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if !oldest.Before(latest) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid date range %q: the start must be before the end", expr)
+	}
+	return oldest, latest, nil
+}
+
+func parseDateTerm(term string, now time.Time) (oldest, latest time.Time, err error) {
+	startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch term {
+	case "today":
+		return startOfToday, now, nil
+	case "yesterday":
+		return startOfToday.AddDate(0, 0, -1), startOfToday, nil
+	}
+
+	for _, layout := range dateLayouts {
+		if day, err := time.ParseInLocation(layout, term, now.Location()); err == nil {
+			return day, day.AddDate(0, 0, 1), nil
+		}
+	}
+
+	if len(term) >= 2 {
+		n, err := strconv.Atoi(term[:len(term)-1])
+		if err == nil && n > 0 {
+			switch term[len(term)-1] {
+			case 'd':
+				return startOfToday.AddDate(0, 0, -n+1), now, nil
+			case 'w':
+				return startOfToday.AddDate(0, 0, -n*7+1), now, nil
+			case 'm':
+				return startOfToday.AddDate(0, -n, 0), now, nil
+			}
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q: expected a range such as 7d, 2w or 3m, today, yesterday or a date such as 2024-01-31", term)
+}
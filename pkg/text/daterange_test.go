@@ -0,0 +1,47 @@
+package text
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateRange(t *testing.T) {
+	loc := time.FixedZone("CET", 3600)
+	now := time.Date(2024, 3, 15, 13, 30, 0, 0, loc)
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, loc) }
+
+	tests := []struct {
+		expr   string
+		oldest time.Time
+		latest time.Time
+	}{
+		{expr: "1d", oldest: day(2024, 3, 15), latest: now},
+		{expr: "7d", oldest: day(2024, 3, 9), latest: now},
+		{expr: "2w", oldest: day(2024, 3, 2), latest: now},
+		{expr: "1m", oldest: day(2024, 2, 15), latest: now},
+		{expr: "today", oldest: day(2024, 3, 15), latest: now},
+		{expr: " Yesterday ", oldest: day(2024, 3, 14), latest: day(2024, 3, 15)},
+		{expr: "2024-01-31", oldest: day(2024, 1, 31), latest: day(2024, 2, 1)},
+		{expr: "2024/01/31", oldest: day(2024, 1, 31), latest: day(2024, 2, 1)},
+		{expr: "2024-01-01..2024-02-01", oldest: day(2024, 1, 1), latest: day(2024, 2, 2)},
+		{expr: "2024-03-01 .. yesterday", oldest: day(2024, 3, 1), latest: day(2024, 3, 15)},
+		{expr: "2024-03-01..", oldest: day(2024, 3, 1), latest: now},
+		{expr: "..2024-02-01", latest: day(2024, 2, 2)},
+	}
+	for _, tt := range tests {
+		oldest, latest, err := ParseDateRange(tt.expr, now)
+		if err != nil {
+			t.Errorf("ParseDateRange(%q) error: %v", tt.expr, err)
+			continue
+		}
+		if !oldest.Equal(tt.oldest) || !latest.Equal(tt.latest) {
+			t.Errorf("ParseDateRange(%q) = [%s, %s), want [%s, %s)", tt.expr, oldest, latest, tt.oldest, tt.latest)
+		}
+	}
+
+	for _, expr := range []string{"", "..", "0d", "-3d", "7y", "last week", "2024-02-30", "2024-02-01..2024-01-01", "2024-01-01..2024-02-01..2024-03-01"} {
+		if oldest, latest, err := ParseDateRange(expr, now); err == nil {
+			t.Errorf("ParseDateRange(%q) = [%s, %s), want an error", expr, oldest, latest)
+		}
+	}
+}
@@ -0,0 +1,371 @@
+package text
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// emojiNameRe matches Slack emoji names, optionally with a skin tone, e.g. +1::skin-tone-3
+var emojiNameRe = regexp.MustCompile(`^[a-z0-9_+'-]+(::skin-tone-[2-6])?$`)
+
+// emojiAliases maps alternative names to the name Slack reports in reactions
+var emojiAliases = map[string]string{
+	"thumbsup":    "+1",
+	"thumbs_up":   "+1",
+	"thumbsdown":  "-1",
+	"thumbs_down": "-1",
+	"poop":        "hankey",
+	"shit":        "hankey",
+	"punch":       "facepunch",
+	"raised_hand": "hand",
+	"satisfied":   "laughing",
+	"red_heart":   "heart",
+}
+
+// skinTones maps the unicode skin tone modifiers to Slack's skin tone suffixes
+var skinTones = map[rune]string{
+	'\U0001F3FB': "skin-tone-2",
+	'\U0001F3FC': "skin-tone-3",
+	'\U0001F3FD': "skin-tone-4",
+	'\U0001F3FE': "skin-tone-5",
+	'\U0001F3FF': "skin-tone-6",
+}
+
+// unicodeEmoji maps common emoji glyphs, without variation selectors, to their Slack names
+var unicodeEmoji = map[string]string{
+	// Faces
+	"😀": "grinning",
+	"😃": "smiley",
+	"😄": "smile",
+	"😁": "grin",
+	"😆": "laughing",
+	"😅": "sweat_smile",
+	"🤣": "rolling_on_the_floor_laughing",
+	"😂": "joy",
+	"🙂": "slightly_smiling_face",
+	"🙃": "upside_down_face",
+	"😉": "wink",
+	"😊": "blush",
+	"😇": "innocent",
+	"🥰": "smiling_face_with_3_hearts",
+	"😍": "heart_eyes",
+	"🤩": "star-struck",
+	"😘": "kissing_heart",
+	"😋": "yum",
+	"😛": "stuck_out_tongue",
+	"😜": "stuck_out_tongue_winking_eye",
+	"🤪": "zany_face",
+	"😝": "stuck_out_tongue_closed_eyes",
+	"🤑": "money_mouth_face",
+	"🤗": "hugging_face",
+	"🤭": "face_with_hand_over_mouth",
+	"🤫": "shushing_face",
+	"🤔": "thinking_face",
+	"🤐": "zipper_mouth_face",
+	"🤨": "face_with_raised_eyebrow",
+	"😐": "neutral_face",
+	"😑": "expressionless",
+	"😶": "no_mouth",
+	"😏": "smirk",
+	"😒": "unamused",
+	"🙄": "face_with_rolling_eyes",
+	"😬": "grimacing",
+	"😌": "relieved",
+	"😔": "pensive",
+	"😪": "sleepy",
+	"😴": "sleeping",
+	"😷": "mask",
+	"🤒": "face_with_thermometer",
+	"🤢": "nauseated_face",
+	"🤮": "face_vomiting",
+	"🥵": "hot_face",
+	"🥶": "cold_face",
+	"🥴": "woozy_face",
+	"😵": "dizzy_face",
+	"🤯": "exploding_head",
+	"🤠": "face_with_cowboy_hat",
+	"🥳": "partying_face",
+	"😎": "sunglasses",
+	"🤓": "nerd_face",
+	"🧐": "face_with_monocle",
+	"😕": "confused",
+	"😟": "worried",
+	"🙁": "slightly_frowning_face",
+	"😮": "open_mouth",
+	"😯": "hushed",
+	"😲": "astonished",
+	"😳": "flushed",
+	"🥺": "pleading_face",
+	"😦": "frowning",
+	"😧": "anguished",
+	"😨": "fearful",
+	"😰": "cold_sweat",
+	"😥": "disappointed_relieved",
+	"😢": "cry",
+	"😭": "sob",
+	"😱": "scream",
+	"😖": "confounded",
+	"😣": "persevere",
+	"😞": "disappointed",
+	"😓": "sweat",
+	"😩": "weary",
+	"😫": "tired_face",
+	"🥱": "yawning_face",
+	"😤": "triumph",
+	"😡": "rage",
+	"😠": "angry",
+	"🤬": "face_with_symbols_on_mouth",
+	"😈": "smiling_imp",
+	"💀": "skull",
+	"💩": "hankey",
+	"🤡": "clown_face",
+	"👻": "ghost",
+	"👽": "alien",
+	"🤖": "robot_face",
+	"🫡": "saluting_face",
+	// Hands and people
+	"👍": "+1",
+	"👎": "-1",
+	"👌": "ok_hand",
+	"✌": "v",
+	"🤞": "crossed_fingers",
+	"🤟": "i_love_you_hand_sign",
+	"🤘": "the_horns",
+	"🤙": "call_me_hand",
+	"👈": "point_left",
+	"👉": "point_right",
+	"👆": "point_up_2",
+	"👇": "point_down",
+	"☝": "point_up",
+	"✋": "hand",
+	"🤚": "raised_back_of_hand",
+	"🖐": "raised_hand_with_fingers_splayed",
+	"🖖": "spock-hand",
+	"👋": "wave",
+	"👏": "clap",
+	"🙌": "raised_hands",
+	"👐": "open_hands",
+	"🤲": "palms_up_together",
+	"🤝": "handshake",
+	"🙏": "pray",
+	"✍": "writing_hand",
+	"💪": "muscle",
+	"👊": "facepunch",
+	"✊": "fist",
+	"🤛": "left-facing_fist",
+	"🤜": "right-facing_fist",
+	"👀": "eyes",
+	"🧠": "brain",
+	"🤷": "shrug",
+	"🤦": "face_palm",
+	"🙋": "raising_hand",
+	"🙇": "bow",
+	// Hearts and symbols
+	"❤": "heart",
+	"🧡": "orange_heart",
+	"💛": "yellow_heart",
+	"💚": "green_heart",
+	"💙": "blue_heart",
+	"💜": "purple_heart",
+	"🖤": "black_heart",
+	"🤍": "white_heart",
+	"🤎": "brown_heart",
+	"💔": "broken_heart",
+	"💕": "two_hearts",
+	"💖": "sparkling_heart",
+	"💯": "100",
+	"💥": "boom",
+	"💫": "dizzy",
+	"💦": "sweat_drops",
+	"💤": "zzz",
+	"💬": "speech_balloon",
+	"💭": "thought_balloon",
+	"✅": "white_check_mark",
+	"✔": "heavy_check_mark",
+	"☑": "ballot_box_with_check",
+	"❌": "x",
+	"❎": "negative_squared_cross_mark",
+	"❓": "question",
+	"❔": "grey_question",
+	"❗": "exclamation",
+	"❕": "grey_exclamation",
+	"‼": "bangbang",
+	"⁉": "interrobang",
+	"⚠": "warning",
+	"🚫": "no_entry_sign",
+	"⛔": "no_entry",
+	"🔴": "red_circle",
+	"🟢": "large_green_circle",
+	"🟡": "large_yellow_circle",
+	"🔵": "large_blue_circle",
+	"⭐": "star",
+	"🌟": "star2",
+	"✨": "sparkles",
+	"⚡": "zap",
+	"🔥": "fire",
+	"➕": "heavy_plus_sign",
+	"➖": "heavy_minus_sign",
+	"➡": "arrow_right",
+	"⬅": "arrow_left",
+	"⬆": "arrow_up",
+	"⬇": "arrow_down",
+	"🔄": "arrows_counterclockwise",
+	"🆗": "ok",
+	"🆕": "new",
+	"🆒": "cool",
+	// Objects
+	"🎉": "tada",
+	"🎊": "confetti_ball",
+	"🎈": "balloon",
+	"🎁": "gift",
+	"🏆": "trophy",
+	"🥇": "first_place_medal",
+	"🏅": "sports_medal",
+	"🚀": "rocket",
+	"🛠": "hammer_and_wrench",
+	"🔧": "wrench",
+	"🔨": "hammer",
+	"⚙": "gear",
+	"🐛": "bug",
+	"💡": "bulb",
+	"📌": "pushpin",
+	"📎": "paperclip",
+	"🔗": "link",
+	"🔒": "lock",
+	"🔓": "unlock",
+	"🔑": "key",
+	"🔔": "bell",
+	"📣": "mega",
+	"📢": "loudspeaker",
+	"📝": "memo",
+	"📅": "date",
+	"📆": "calendar",
+	"📈": "chart_with_upwards_trend",
+	"📉": "chart_with_downwards_trend",
+	"📊": "bar_chart",
+	"📦": "package",
+	"📷": "camera",
+	"💻": "computer",
+	"📱": "iphone",
+	"☎": "phone",
+	"✉": "email",
+	"📚": "books",
+	"📖": "book",
+	"🔍": "mag",
+	"🔎": "mag_right",
+	"⏰": "alarm_clock",
+	"⏳": "hourglass_flowing_sand",
+	"⌛": "hourglass",
+	"💰": "moneybag",
+	"💸": "money_with_wings",
+	"🧪": "test_tube",
+	"🚧": "construction",
+	"🚨": "rotating_light",
+	"🏁": "checkered_flag",
+	"🚩": "triangular_flag_on_post",
+	"🎯": "dart",
+	"🧩": "jigsaw",
+	// Food and nature
+	"🍕": "pizza",
+	"☕": "coffee",
+	"🍺": "beer",
+	"🍻": "beers",
+	"🥂": "clinking_glasses",
+	"🍰": "cake",
+	"🎂": "birthday",
+	"🌮": "taco",
+	"🍩": "doughnut",
+	"🍪": "cookie",
+	"☀": "sunny",
+	"🌈": "rainbow",
+	"☁": "cloud",
+	"❄": "snowflake",
+	"🌊": "ocean",
+	"🌱": "seedling",
+	"🌵": "cactus",
+	"🌸": "cherry_blossom",
+	"🌹": "rose",
+	"🌻": "sunflower",
+	"🍀": "four_leaf_clover",
+	"🐶": "dog",
+	"🐱": "cat",
+	"🦄": "unicorn_face",
+	"🐢": "turtle",
+	"🐍": "snake",
+	"🦀": "crab",
+	"🐙": "octopus",
+	"🦊": "fox_face",
+	"🐼": "panda_face",
+	"🐧": "penguin",
+	"🐝": "bee",
+	"🦋": "butterfly",
+	"🐸": "frog",
+	"🌍": "earth_africa",
+	"🌎": "earth_americas",
+	"🌏": "earth_asia",
+	"🌙": "crescent_moon",
+}
+
+// NormalizeEmojiName turns an emoji given as :name:, name or a unicode glyph into
+// the name used by Slack's reactions API, e.g. :thumbsup: and 👍 both become +1.
+// Glyphs missing from the built-in table are rejected as Slack would reject them.
+func NormalizeEmojiName(emoji string) (string, error) {
+	s := strings.TrimSpace(emoji)
+	if s == "" {
+		return "", fmt.Errorf("emoji name must not be empty")
+	}
+
+	if !isASCII(s) {
+		return glyphToName(emoji, s)
+	}
+
+	s = strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(s, ":"), ":"))
+	base, tone, hasTone := strings.Cut(s, "::")
+	if alias, ok := emojiAliases[base]; ok {
+		base = alias
+	}
+	name := base
+	if hasTone {
+		name += "::" + tone
+	}
+	if len(name) > 100 || !emojiNameRe.MatchString(name) {
+		return "", fmt.Errorf("invalid emoji name %q, expected a Slack emoji name such as thumbsup or :tada:", emoji)
+	}
+	return name, nil
+}
+
+// glyphToName looks up a unicode emoji, keeping its skin tone if any
+func glyphToName(emoji, s string) (string, error) {
+	var tone string
+	var b strings.Builder
+	for _, r := range s {
+		if t, ok := skinTones[r]; ok {
+			tone = t
+			continue
+		}
+		if r == '\uFE0F' {
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	name, ok := unicodeEmoji[b.String()]
+	if !ok {
+		return "", fmt.Errorf("unknown emoji %q, pass its Slack name such as thumbsup or :tada: instead", emoji)
+	}
+	if tone != "" {
+		name += "::" + tone
+	}
+	return name, nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestNormalizeEmojiName(t *testing.T) {
+	tests := []struct {
+		name    string
+		emoji   string
+		want    string
+		wantErr bool
+	}{
+		{name: "bare name", emoji: "tada", want: "tada"},
+		{name: "colons", emoji: ":tada:", want: "tada"},
+		{name: "whitespace", emoji: "  :eyes: ", want: "eyes"},
+		{name: "upper case", emoji: ":White_Check_Mark:", want: "white_check_mark"},
+		{name: "alias", emoji: ":thumbsup:", want: "+1"},
+		{name: "canonical plus one", emoji: "+1", want: "+1"},
+		{name: "minus one alias", emoji: "thumbsdown", want: "-1"},
+		{name: "custom emoji", emoji: ":party-parrot:", want: "party-parrot"},
+		{name: "skin tone name", emoji: ":thumbsup::skin-tone-3:", want: "+1::skin-tone-3"},
+		{name: "glyph", emoji: "👍", want: "+1"},
+		{name: "glyph with skin tone", emoji: "👍🏽", want: "+1::skin-tone-4"},
+		{name: "glyph with variation selector", emoji: "❤️", want: "heart"},
+		{name: "glyph without variation selector", emoji: "❤", want: "heart"},
+		{name: "check mark", emoji: "✅", want: "white_check_mark"},
+		{name: "party", emoji: "🎉", want: "tada"},
+		{name: "rocket", emoji: "🚀", want: "rocket"},
+		{name: "eyes glyph", emoji: "👀", want: "eyes"},
+		{name: "hundred", emoji: "💯", want: "100"},
+		{name: "warning", emoji: "⚠️", want: "warning"},
+		{name: "empty", emoji: "", wantErr: true},
+		{name: "only colons", emoji: "::", wantErr: true},
+		{name: "spaces in name", emoji: "thumbs up", wantErr: true},
+		{name: "invalid skin tone", emoji: "+1::skin-tone-9", wantErr: true},
+		{name: "unknown glyph", emoji: "🦩", wantErr: true},
+		{name: "two glyphs", emoji: "👍👍", wantErr: true},
+		{name: "non-emoji text", emoji: "привет", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeEmojiName(tt.emoji)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NormalizeEmojiName(%q) = %q, want error", tt.emoji, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeEmojiName(%q) unexpected error: %v", tt.emoji, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeEmojiName(%q) = %q, want %q", tt.emoji, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnicodeEmojiNamesAreValid(t *testing.T) {
+	for glyph, name := range unicodeEmoji {
+		if !emojiNameRe.MatchString(name) {
+			t.Errorf("unicodeEmoji[%q] = %q is not a valid emoji name", glyph, name)
+		}
+		if got, err := NormalizeEmojiName(name); err != nil || got != name {
+			t.Errorf("NormalizeEmojiName(%q) = %q, %v, want the name unchanged", name, got, err)
+		}
+	}
+}
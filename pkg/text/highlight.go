@@ -0,0 +1,93 @@
+package text
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Slack wraps the matches of highlighted search results in these private use
+// characters, see the highlight argument of search.messages
+const (
+	HighlightStart = "\ue000"
+	HighlightEnd   = "\ue001"
+)
+
+// HighlightMarker wraps the matches of a highlight fragment. ProcessText
+// removes * from message text, so it never occurs in a fragment otherwise.
+const HighlightMarker = "**"
+
+// placeholders for the delimiters that survive ProcessText, which removes
+// private use characters along with other punctuation
+const (
+	highlightStartPlaceholder = "HIGHLIGHTSTARTPLACEHOLDER"
+	highlightEndPlaceholder   = "HIGHLIGHTENDPLACEHOLDER"
+)
+
+// StripHighlights removes the highlight delimiters of Slack from s
+func StripHighlights(s string) string {
+	return strings.NewReplacer(HighlightStart, "", HighlightEnd, "").Replace(s)
+}
+
+// HighlightFragment returns the part of the highlighted text s from the first
+// to the last match, processed like message text with ProcessText and with
+// every match wrapped in HighlightMarker. The fragment extends by up to
+// context characters on either side, and cut text is marked with an ellipsis
+// (…). A context of 0 or less returns the whole text. Text without matches has no
+// fragment.
+func HighlightFragment(s string, context int) string {
+	if !strings.Contains(s, HighlightStart) {
+		return ""
+	}
+	s = strings.NewReplacer(
+		HighlightStart, highlightStartPlaceholder,
+		HighlightEnd, highlightEndPlaceholder,
+	).Replace(s)
+	s = ProcessText(s)
+
+	first := strings.Index(s, highlightStartPlaceholder)
+	if first < 0 {
+		return ""
+	}
+	// the fragment must not cut a delimiter, even an unbalanced one
+	last := max(
+		strings.LastIndex(s, highlightStartPlaceholder)+len(highlightStartPlaceholder),
+		strings.LastIndex(s, highlightEndPlaceholder)+len(highlightEndPlaceholder),
+	)
+
+	start, end := 0, len(s)
+	if context > 0 {
+		start = runesBefore(s, first, context)
+		end = runesAfter(s, last, context)
+	}
+	fragment := strings.TrimSpace(s[start:end])
+	fragment = strings.NewReplacer(
+		highlightStartPlaceholder, HighlightMarker,
+		highlightEndPlaceholder, HighlightMarker,
+	).Replace(fragment)
+
+	if start > 0 {
+		fragment = "…" + fragment
+	}
+	if end < len(s) {
+		fragment += "…"
+	}
+	return fragment
+}
+
+// runesBefore returns the byte offset n characters before offset i of s
+func runesBefore(s string, i, n int) int {
+	for ; n > 0 && i > 0; n-- {
+		_, size := utf8.DecodeLastRuneInString(s[:i])
+		i -= size
+	}
+	return i
+}
+
+// runesAfter returns the byte offset n characters after offset i of s
+func runesAfter(s string, i, n int) int {
+	for ; n > 0 && i < len(s); n-- {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return i
+}
@@ -0,0 +1,76 @@
+package text
+
+import "testing"
+
+func TestStripHighlights(t *testing.T) {
+	got := StripHighlights("the \ue000deploy\ue001 of \ue000api\ue001")
+	if want := "the deploy of api"; got != want {
+		t.Errorf("StripHighlights() = %q, want %q", got, want)
+	}
+}
+
+func TestHighlightFragment(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		context int
+		want    string
+	}{
+		{
+			name:    "no matches",
+			text:    "nothing matched here",
+			context: 10,
+			want:    "",
+		},
+		{
+			name:    "whole text",
+			text:    "the \ue000deploy\ue001 failed",
+			context: 0,
+			want:    "the **deploy** failed",
+		},
+		{
+			name:    "context fits",
+			text:    "the \ue000deploy\ue001 failed",
+			context: 20,
+			want:    "the **deploy** failed",
+		},
+		{
+			name:    "cut on both sides",
+			text:    "yesterday the \ue000deploy\ue001 failed again",
+			context: 4,
+			want:    "…the **deploy** fai…",
+		},
+		{
+			name:    "spans from first to last match",
+			text:    "a long preamble \ue000api\ue001 then \ue000deploy\ue001 and a long epilogue",
+			context: 1,
+			want:    "…**api** then **deploy**…",
+		},
+		{
+			name:    "processed like message text",
+			text:    "*bold* \ue000match\ue001 with <https://example.com|link>",
+			context: 0,
+			want:    "bold **match** with https://example.com - link",
+		},
+		{
+			name:    "multi-byte context",
+			text:    "héllo wörld \ue000mätch\ue001 ünd more",
+			context: 3,
+			want:    "…ld **mätch** ün…",
+		},
+		{
+			name:    "unbalanced delimiter",
+			text:    "\ue000open match never closed",
+			context: 1,
+			want:    "**o…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HighlightFragment(tt.text, tt.context); got != tt.want {
+				t.Errorf("HighlightFragment() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
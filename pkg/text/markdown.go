@@ -0,0 +1,92 @@
+package text
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	mrkdwnLabeledLinkRe = regexp.MustCompile(`<((?:https?|mailto):[^>|]+)\|([^>]+)>`)
+	mrkdwnLinkRe        = regexp.MustCompile(`<((?:https?|mailto):[^>|]+)>`)
+	mrkdwnEntities      = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+)
+
+// MrkdwnToMarkdown rewrites Slack mrkdwn as standard Markdown: mentions are
+// resolved as with ResolveMentions, <url|label> becomes [label](url), *bold*
+// becomes **bold**, ~strike~ becomes ~~strike~~ and • bullets become - items.
+// _italic_, > quotes and code are the same in both and kept, and the text of
+// code spans and blocks is never rewritten.
+func MrkdwnToMarkdown(s string, names MentionNames) string {
+	var b strings.Builder
+	for i, block := range strings.Split(s, "```") {
+		if i > 0 {
+			b.WriteString("```")
+		}
+		if i%2 == 1 {
+			b.WriteString(mrkdwnEntities.Replace(block))
+			continue
+		}
+		for j, span := range strings.Split(block, "`") {
+			if j > 0 {
+				b.WriteString("`")
+			}
+			if j%2 == 1 {
+				b.WriteString(mrkdwnEntities.Replace(span))
+				continue
+			}
+			b.WriteString(mrkdwnProse(span, names))
+		}
+	}
+	return b.String()
+}
+
+// mrkdwnProse converts mrkdwn outside of code
+func mrkdwnProse(s string, names MentionNames) string {
+	s = ResolveMentions(s, names)
+	s = mrkdwnLabeledLinkRe.ReplaceAllString(s, "[$2]($1)")
+	s = mrkdwnLinkRe.ReplaceAllString(s, "$1")
+	s = replaceDelimited(s, '*', "**")
+	s = replaceDelimited(s, '~', "~~")
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		if rest, ok := strings.CutPrefix(trimmed, "• "); ok {
+			lines[i] = line[:len(line)-len(trimmed)] + "- " + rest
+		}
+	}
+	return mrkdwnEntities.Replace(strings.Join(lines, "\n"))
+}
+
+// replaceDelimited replaces the delim around the emphasized words of a line
+// with marker, e.g. *bold* with **bold**. As in Slack, the delimiters must be
+// at word boundaries and hug the emphasized text, so 2*3*4 is left alone.
+func replaceDelimited(s string, delim byte, marker string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != delim || (i > 0 && isWordByte(s[i-1])) || i+1 >= len(s) || s[i+1] == ' ' || s[i+1] == delim {
+			b.WriteByte(s[i])
+			continue
+		}
+		end := -1
+		for j := i + 1; j < len(s) && s[j] != '\n'; j++ {
+			if s[j] == delim && s[j-1] != ' ' && (j+1 == len(s) || !isWordByte(s[j+1])) {
+				end = j
+				break
+			}
+		}
+		if end < 0 {
+			b.WriteByte(s[i])
+			continue
+		}
+		b.WriteString(marker)
+		b.WriteString(s[i+1 : end])
+		b.WriteString(marker)
+		i = end
+	}
+	return b.String()
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,39 @@
+package text
+
+import "testing"
+
+func TestMrkdwnToMarkdown(t *testing.T) {
+	names := MentionNames{
+		User: func(id string) (string, bool) {
+			name, ok := map[string]string{"U123": "john"}[id]
+			return name, ok
+		},
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "mentions", text: "<@U123> see <#C123|general>", want: "@john see #general"},
+		{name: "labeled link", text: "read <https://example.com/doc?a=1&amp;b=2|the doc>", want: "read [the doc](https://example.com/doc?a=1&b=2)"},
+		{name: "bare link", text: "at <https://example.com>", want: "at https://example.com"},
+		{name: "mailto", text: "mail <mailto:a@example.com|a@example.com>", want: "mail [a@example.com](mailto:a@example.com)"},
+		{name: "bold", text: "*deploy* done, *all green*", want: "**deploy** done, **all green**"},
+		{name: "italic kept", text: "_maybe_ later", want: "_maybe_ later"},
+		{name: "strike", text: "~old~ new", want: "~~old~~ new"},
+		{name: "not emphasis", text: "2*3*4 and a * b", want: "2*3*4 and a * b"},
+		{name: "no emphasis across lines", text: "*one\ntwo*", want: "*one\ntwo*"},
+		{name: "quote and entities", text: "&gt; a &lt; b &amp;&amp; c", want: "> a < b && c"},
+		{name: "bullets", text: "• one\n  • two", want: "- one\n  - two"},
+		{name: "inline code kept", text: "run `*not bold* &lt;x&gt;` *now*", want: "run `*not bold* <x>` **now**"},
+		{name: "code block kept", text: "```\n*x* <@U123>\n```\n*y*", want: "```\n*x* <@U123>\n```\n**y**"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MrkdwnToMarkdown(tt.text, names); got != tt.want {
+				t.Errorf("MrkdwnToMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
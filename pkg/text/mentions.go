@@ -0,0 +1,212 @@
+package text
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrBroadcastMention is returned when @here, @channel or @everyone is
+// mentioned while broadcasts are not allowed
+var ErrBroadcastMention = errors.New("broadcast mentions are not allowed")
+
+var (
+	userIDRe    = regexp.MustCompile(`^[UW][A-Z0-9]{2,}$`)
+	channelIDRe = regexp.MustCompile(`^[CG][A-Z0-9]{2,}$`)
+	// broadcastMarkupRe matches broadcast markup, with an optional label as in <!here|here>
+	broadcastMarkupRe = regexp.MustCompile(`(?i)<!(here|channel|everyone)(\|[^>]*)?>`)
+)
+
+// broadcastMentions maps the broadcast mentions to their Slack markup
+var broadcastMentions = map[string]string{
+	"@here":     "<!here>",
+	"@channel":  "<!channel>",
+	"@everyone": "<!everyone>",
+}
+
+// BroadcastMarkup returns the first broadcast written as Slack markup in s,
+// e.g. <!channel>, or "" when s notifies no whole channel
+func BroadcastMarkup(s string) string {
+	return broadcastMarkupRe.FindString(s)
+}
+
+// MentionDirectory resolves the names of users and channels to their IDs
+type MentionDirectory struct {
+	Users    map[string]string // user name without the leading @ to user ID
+	Channels map[string]string // channel name with the leading # to channel ID
+}
+
+// MentionMarkup returns the Slack markup of a mention given as @name, #name,
+// or as @U.../#C... IDs which need no lookup, e.g. @john becomes <@U123>
+func MentionMarkup(mention string, dir MentionDirectory, allowBroadcast bool) (string, error) {
+	mention = strings.TrimSpace(mention)
+	if markup, ok := broadcastMentions[strings.ToLower(mention)]; ok {
+		if !allowBroadcast {
+			return "", fmt.Errorf("%w: %s", ErrBroadcastMention, mention)
+		}
+		return markup, nil
+	}
+
+	switch {
+	case strings.HasPrefix(mention, "@"):
+		name := mention[1:]
+		if userIDRe.MatchString(name) {
+			return "<@" + name + ">", nil
+		}
+		if id, ok := dir.Users[name]; ok {
+			return "<@" + id + ">", nil
+		}
+	case strings.HasPrefix(mention, "#"):
+		if channelIDRe.MatchString(mention[1:]) {
+			return "<#" + mention[1:] + ">", nil
+		}
+		if id, ok := dir.Channels[mention]; ok {
+			return "<#" + id + ">", nil
+		}
+	default:
+		return "", fmt.Errorf("invalid mention %q, expected @user or #channel", mention)
+	}
+	return "", fmt.Errorf("cannot resolve mention %q", mention)
+}
+
+// ApplyMentions replaces the given mentions in s with their Slack markup.
+// A mention that does not occur as a whole word in s is prepended to it, so
+// that every requested mention renders. Mentions that cannot be resolved are
+// reported together instead of being left as plain text.
+func ApplyMentions(s string, mentions []string, dir MentionDirectory, allowBroadcast bool) (string, error) {
+	var (
+		prefix     []string
+		unresolved []string
+	)
+	for _, mention := range mentions {
+		mention = strings.TrimSpace(mention)
+		if mention == "" {
+			continue
+		}
+		markup, err := MentionMarkup(mention, dir, allowBroadcast)
+		if errors.Is(err, ErrBroadcastMention) {
+			return "", err
+		}
+		if err != nil {
+			unresolved = append(unresolved, mention)
+			continue
+		}
+
+		replaced, found := replaceWord(s, mention, markup)
+		if found {
+			s = replaced
+		} else {
+			prefix = append(prefix, markup)
+		}
+	}
+	if len(unresolved) > 0 {
+		return "", fmt.Errorf("cannot resolve mentions: %s", strings.Join(unresolved, ", "))
+	}
+	if len(prefix) > 0 {
+		s = strings.Join(prefix, " ") + " " + s
+	}
+	return s, nil
+}
+
+// replaceWord replaces the occurrences of word in s that are not part of a
+// longer name, e.g. @jo is replaced in "hi @jo!" but not in "hi @john"
+func replaceWord(s, word, replacement string) (string, bool) {
+	var (
+		b     strings.Builder
+		found bool
+	)
+	for {
+		i := strings.Index(s, word)
+		if i < 0 {
+			b.WriteString(s)
+			break
+		}
+		end := i + len(word)
+		if (i > 0 && isNameByte(s[i-1])) || (end < len(s) && isNameByte(s[end])) {
+			b.WriteString(s[:end])
+			s = s[end:]
+			continue
+		}
+		b.WriteString(s[:i])
+		b.WriteString(replacement)
+		s = s[end:]
+		found = true
+	}
+	return b.String(), found
+}
+
+func isNameByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.'
+}
+
+// mentionMarkupRe matches the Slack markup of user, channel and special
+// mentions, e.g. <@U123>, <#C123|general>, <!here> or <!subteam^S123|@team>
+var mentionMarkupRe = regexp.MustCompile(`<([@#!])([^>|]+)(?:\|([^>]*))?>`)
+
+// MentionNames resolves the IDs in mention markup to names, either func may
+// be nil when no names are known
+type MentionNames struct {
+	User    func(id string) (name string, ok bool) // user name without the leading @
+	Channel func(id string) (name string, ok bool) // channel name with the leading #
+}
+
+// ResolveMentions replaces the mention markup in message text with readable
+// mentions: <@U123> becomes @john, <#C123> becomes #general and <!here>
+// becomes @here. IDs without a known name are kept, e.g. @U123.
+func ResolveMentions(s string, names MentionNames) string {
+	return mentionMarkupRe.ReplaceAllStringFunc(s, func(markup string) string {
+		m := mentionMarkupRe.FindStringSubmatch(markup)
+		id, label := m[2], strings.TrimSpace(m[3])
+		switch m[1] {
+		case "@":
+			if names.User != nil {
+				if name, ok := names.User(id); ok && name != "" {
+					return "@" + name
+				}
+			}
+			if label != "" {
+				return "@" + strings.TrimPrefix(label, "@")
+			}
+			return "@" + id
+		case "#":
+			if label != "" {
+				return "#" + strings.TrimPrefix(label, "#")
+			}
+			if names.Channel != nil {
+				if name, ok := names.Channel(id); ok && name != "" {
+					return "#" + strings.TrimPrefix(name, "#")
+				}
+			}
+			return "#" + id
+		}
+
+		switch {
+		case id == "here" || id == "channel" || id == "everyone":
+			return "@" + id
+		case strings.HasPrefix(id, "subteam^"):
+			if label != "" {
+				return "@" + strings.TrimPrefix(label, "@")
+			}
+			return "@" + strings.TrimPrefix(id, "subteam^")
+		case label != "":
+			// e.g. dates, <!date^1700000000^{date}|Nov 14> renders its fallback
+			return label
+		}
+		return markup
+	})
+}
+
+// MentionedUserIDs returns the IDs of the users mentioned in message text,
+// in order of appearance and without duplicates
+func MentionedUserIDs(s string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, m := range mentionMarkupRe.FindAllStringSubmatch(s, -1) {
+		if m[1] == "@" && !seen[m[2]] {
+			seen[m[2]] = true
+			ids = append(ids, m[2])
+		}
+	}
+	return ids
+}
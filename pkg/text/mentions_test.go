@@ -0,0 +1,128 @@
+package text
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestApplyMentions(t *testing.T) {
+	dir := MentionDirectory{
+		Users:    map[string]string{"jo": "U1", "john": "U2"},
+		Channels: map[string]string{"#general": "C1"},
+	}
+
+	tests := []struct {
+		name      string
+		text      string
+		mentions  []string
+		broadcast bool
+		want      string
+		wantErr   bool
+	}{
+		{name: "user and channel", text: "@jo see #general", mentions: []string{"@jo", "#general"}, want: "<@U1> see <#C1>"},
+		{name: "whole words only", text: "@john and @jo!", mentions: []string{"@jo"}, want: "@john and <@U1>!"},
+		{name: "every occurrence", text: "@jo, @jo", mentions: []string{"@jo"}, want: "<@U1>, <@U1>"},
+		{name: "prepended when missing", text: "done", mentions: []string{"@john", "#general"}, want: "<@U2> <#C1> done"},
+		{name: "ids need no lookup", text: "ping @U999", mentions: []string{"@U999", "#C999"}, want: "<#C999> ping <@U999>"},
+		{name: "blank mentions ignored", text: "hi", mentions: []string{" ", ""}, want: "hi"},
+		{name: "unresolved", text: "hi @bob", mentions: []string{"@bob", "#random"}, wantErr: true},
+		{name: "no prefix", text: "hi", mentions: []string{"jo"}, wantErr: true},
+		{name: "broadcast allowed", text: "@channel deploy", mentions: []string{"@channel"}, broadcast: true, want: "<!channel> deploy"},
+		{name: "broadcast denied", text: "@here deploy", mentions: []string{"@here"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyMentions(tt.text, tt.mentions, dir, tt.broadcast)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ApplyMentions() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApplyMentions() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ApplyMentions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyMentionsReportsAllUnresolved(t *testing.T) {
+	_, err := ApplyMentions("hi", []string{"@bob", "#random"}, MentionDirectory{}, false)
+	if err == nil || err.Error() != "cannot resolve mentions: @bob, #random" {
+		t.Fatalf("ApplyMentions() error = %v", err)
+	}
+
+	_, err = ApplyMentions("hi", []string{"@everyone"}, MentionDirectory{}, false)
+	if !errors.Is(err, ErrBroadcastMention) {
+		t.Fatalf("ApplyMentions() error = %v, want ErrBroadcastMention", err)
+	}
+}
+
+func TestBroadcastMarkup(t *testing.T) {
+	tests := map[string]string{
+		"<!channel> deploy":          "<!channel>",
+		"deploy <!here|here> now":    "<!here|here>",
+		"<!Everyone>":                "<!Everyone>",
+		"@channel is plain text":     "",
+		"<!subteam^S123> on call":    "",
+		"<@U123> and <#C123|ops> ok": "",
+	}
+	for s, want := range tests {
+		if got := BroadcastMarkup(s); got != want {
+			t.Errorf("BroadcastMarkup(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func TestResolveMentions(t *testing.T) {
+	names := MentionNames{
+		User: func(id string) (string, bool) {
+			name, ok := map[string]string{"U1": "john"}[id]
+			return name, ok
+		},
+		Channel: func(id string) (string, bool) {
+			name, ok := map[string]string{"C1": "#general"}[id]
+			return name, ok
+		},
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "user", text: "<@U1> please review", want: "@john please review"},
+		{name: "unknown user keeps id", text: "cc <@U2>", want: "cc @U2"},
+		{name: "user label", text: "cc <@U2|bob>", want: "cc @bob"},
+		{name: "cached name over label", text: "cc <@U1|old>", want: "cc @john"},
+		{name: "channel", text: "see <#C1>", want: "see #general"},
+		{name: "channel label", text: "see <#C2|random>", want: "see #random"},
+		{name: "unknown channel keeps id", text: "see <#C2>", want: "see #C2"},
+		{name: "broadcast", text: "<!here> deploy", want: "@here deploy"},
+		{name: "user group", text: "<!subteam^S1|@oncall> and <!subteam^S2>", want: "@oncall and @S2"},
+		{name: "date fallback", text: "due <!date^1700000000^{date}|Nov 14>", want: "due Nov 14"},
+		{name: "links untouched", text: "<https://example.com|site> by <@U1>", want: "<https://example.com|site> by @john"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveMentions(tt.text, names); got != tt.want {
+				t.Errorf("ResolveMentions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got, want := ResolveMentions("<@U1> in <#C1>", MentionNames{}), "@U1 in #C1"; got != want {
+		t.Errorf("ResolveMentions() without names = %q, want %q", got, want)
+	}
+}
+
+func TestMentionedUserIDs(t *testing.T) {
+	got := MentionedUserIDs("<@U1> and <@U2|bob> in <#C1>, thanks <@U1>")
+	if len(got) != 2 || got[0] != "U1" || got[1] != "U2" {
+		t.Errorf("MentionedUserIDs() = %v, want [U1 U2]", got)
+	}
+}
@@ -0,0 +1,51 @@
+package text
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// permalinkPathRe matches the path of a message permalink, /archives/<channel>/p<ts
+// without the dot>, e.g. /archives/C1234567890/p1700000000000100
+var permalinkPathRe = regexp.MustCompile(`^/archives/([A-Z0-9]+)/p(\d{10})(\d{6})/?$`)
+
+// Permalink is a Slack message permalink parsed into its parts
+type Permalink struct {
+	Domain    string // workspace host, e.g. acme.slack.com
+	ChannelID string
+	Ts        string // ts of the message, e.g. 1700000000.000100
+	ThreadTs  string // ts of the thread root, only set for replies
+}
+
+// ParsePermalink parses a message permalink as returned by chat.getPermalink,
+// e.g. https://acme.slack.com/archives/C1234567890/p1700000000000100?thread_ts=1700000000.000050
+func ParsePermalink(rawURL string) (Permalink, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return Permalink{}, fmt.Errorf("invalid permalink %q: %w", rawURL, err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return Permalink{}, fmt.Errorf("invalid permalink %q: not a URL", rawURL)
+	}
+	domain, err := WorkspaceDomain(u.String())
+	if err != nil {
+		return Permalink{}, fmt.Errorf("invalid permalink %q: %w", rawURL, err)
+	}
+	m := permalinkPathRe.FindStringSubmatch(u.Path)
+	if m == nil {
+		return Permalink{}, fmt.Errorf("invalid permalink %q: expected a path such as /archives/C1234567890/p1700000000000100", rawURL)
+	}
+
+	link := Permalink{
+		Domain:    domain,
+		ChannelID: m[1],
+		Ts:        m[2] + "." + m[3],
+		ThreadTs:  u.Query().Get("thread_ts"),
+	}
+	if link.ThreadTs == link.Ts {
+		link.ThreadTs = ""
+	}
+	return link, nil
+}
@@ -0,0 +1,46 @@
+package text
+
+import "testing"
+
+func TestParsePermalink(t *testing.T) {
+	tests := []struct {
+		url  string
+		want Permalink
+	}{
+		{
+			url:  "https://acme.slack.com/archives/C1234567890/p1700000000000100",
+			want: Permalink{Domain: "acme.slack.com", ChannelID: "C1234567890", Ts: "1700000000.000100"},
+		},
+		{
+			url:  "https://acme.enterprise.slack.com/archives/D0123ABCD/p1700000000000200?thread_ts=1700000000.000100&cid=D0123ABCD",
+			want: Permalink{Domain: "acme.enterprise.slack.com", ChannelID: "D0123ABCD", Ts: "1700000000.000200", ThreadTs: "1700000000.000100"},
+		},
+		{
+			url:  " https://Acme.Slack.com/archives/G0123ABCD/p1700000000000100/?thread_ts=1700000000.000100 ",
+			want: Permalink{Domain: "acme.slack.com", ChannelID: "G0123ABCD", Ts: "1700000000.000100"},
+		},
+	}
+	for _, tt := range tests {
+		got, err := ParsePermalink(tt.url)
+		if err != nil {
+			t.Errorf("ParsePermalink(%q) error: %v", tt.url, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePermalink(%q) = %+v, want %+v", tt.url, got, tt.want)
+		}
+	}
+
+	for _, url := range []string{
+		"",
+		"C1234567890/p1700000000000100",
+		"https://slack.com/archives/C1234567890/p1700000000000100",
+		"https://acme.slack.com/archives/C1234567890",
+		"https://acme.slack.com/archives/C1234567890/p170000000000010",
+		"https://acme.slack.com/files/U1/F1/report.pdf",
+	} {
+		if got, err := ParsePermalink(url); err == nil {
+			t.Errorf("ParsePermalink(%q) = %+v, want an error", url, got)
+		}
+	}
+}
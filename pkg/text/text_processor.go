@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/slack-go/slack"
 	"go.uber.org/zap"
@@ -152,6 +153,26 @@ func Workspace(rawURL string) (string, error) {
 	return parts[0], nil
 }
 
+// WorkspaceDomain returns the full host of a workspace URL as used in permalinks,
+// e.g. acme.slack.com, acme.enterprise.slack.com or agency.slack-gov.com
+func WorkspaceDomain(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	host := strings.ToLower(u.Hostname())
+	parts := strings.Split(host, ".")
+	if len(parts) < 3 {
+		return "", fmt.Errorf("invalid Slack URL: %q", rawURL)
+	}
+	for _, p := range parts {
+		if p == "" {
+			return "", fmt.Errorf("invalid Slack URL: %q", rawURL)
+		}
+	}
+	return host, nil
+}
+
 func TimestampToIsoRFC3339(slackTS string) (string, error) {
 	parts := strings.Split(slackTS, ".")
 	if len(parts) != 2 {
@@ -179,6 +200,23 @@ func ProcessText(s string) string {
 	return s
 }
 
+// TruncateText cuts s to at most n characters, never splitting a multi-byte
+// character, and appends an ellipsis to mark that the text was cut.
+// n <= 0 disables truncation.
+func TruncateText(s string, n int) string {
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	i := 0
+	for pos := range s {
+		if i == n {
+			return s[:pos] + "…"
+		}
+		i++
+	}
+	return s
+}
+
 func HumanizeCertificates(certs []*x509.Certificate) string {
 	var descriptions []string
 	for _, cert := range certs {
@@ -266,7 +304,8 @@ func filterSpecialChars(text string) string {
 		protected = strings.Replace(protected, url, placeholder, 1)
 	}
 
-	cleanRegex := regexp.MustCompile(`[^0-9\p{L}\p{M}\s\.\,\-_:/\?=&%]`)
+	// @ and # are kept for the mentions of ResolveMentions
+	cleanRegex := regexp.MustCompile(`[^0-9\p{L}\p{M}\s\.\,\-_:/\?=&%@#]`)
 	cleaned := cleanRegex.ReplaceAllString(protected, "")
 
 	// Restore the URLs
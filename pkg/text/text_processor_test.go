@@ -2,6 +2,7 @@ package text
 
 import (
 	"testing"
+	"unicode/utf8"
 )
 
 func TestIsUnfurlingEnabled(t *testing.T) {
@@ -160,3 +161,70 @@ func TestFilterSpecialCharsWithCommas(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkspaceDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{name: "standard", url: "https://acme.slack.com/", want: "acme.slack.com"},
+		{name: "enterprise", url: "https://acme.enterprise.slack.com/", want: "acme.enterprise.slack.com"},
+		{name: "gov", url: "https://agency.slack-gov.com/", want: "agency.slack-gov.com"},
+		{name: "with port and path", url: "https://Acme.Slack.com:443/archives/C123", want: "acme.slack.com"},
+		{name: "no subdomain", url: "https://slack.com/", wantErr: true},
+		{name: "empty label", url: "https://acme..com/", wantErr: true},
+		{name: "empty", url: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WorkspaceDomain(tt.url)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("WorkspaceDomain(%q) = %q, want error", tt.url, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("WorkspaceDomain(%q) unexpected error: %v", tt.url, err)
+			}
+			if got != tt.want {
+				t.Errorf("WorkspaceDomain(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		n    int
+		want string
+	}{
+		{name: "disabled", text: "hello world", n: 0, want: "hello world"},
+		{name: "negative", text: "hello world", n: -1, want: "hello world"},
+		{name: "shorter", text: "hello", n: 10, want: "hello"},
+		{name: "exact", text: "hello", n: 5, want: "hello"},
+		{name: "ascii", text: "hello world", n: 5, want: "hello…"},
+		{name: "cyrillic", text: "привет мир", n: 6, want: "привет…"},
+		{name: "cjk", text: "日本語のテキスト", n: 3, want: "日本語…"},
+		{name: "emoji", text: "🚀🚀🚀 launch", n: 2, want: "🚀🚀…"},
+		{name: "exact multi-byte", text: "ümlaut", n: 6, want: "ümlaut"},
+		{name: "empty", text: "", n: 3, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateText(tt.text, tt.n)
+			if got != tt.want {
+				t.Errorf("TruncateText(%q, %d) = %q, want %q", tt.text, tt.n, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("TruncateText(%q, %d) = %q is not valid UTF-8", tt.text, tt.n, got)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/korotovsky/slack-mcp-server/pkg/requestid"
 	"github.com/korotovsky/slack-mcp-server/pkg/text"
 	utls "github.com/refraction-networking/utls"
 	"go.uber.org/zap"
@@ -70,11 +71,12 @@ func (t *UserAgentTransport) RoundTrip(req *http.Request) (*http.Response, error
 		clonedReq.AddCookie(cookie)
 	}
 
-	t.logger.Debug("Making request", zap.String("url", clonedReq.URL.String()))
+	requestID := zap.String("request_id", requestid.FromContext(req.Context()))
+	t.logger.Debug("Making request", requestID, zap.String("url", clonedReq.URL.String()))
 
 	resp, err := t.roundTripper.RoundTrip(clonedReq)
 	if err != nil {
-		t.logger.Error("Request failed", zap.Error(err))
+		t.logger.Error("Request failed", requestID, zap.Error(err))
 	}
 	return resp, err
 }
@@ -441,6 +443,7 @@ func ProvideHTTPClient(cookies []*http.Cookie, logger *zap.Logger) *http.Client
 	}
 
 	transport = NewUserAgentTransport(transport, userAgent, cookies, logger)
+	transport = NewWarningsTransport(transport)
 
 	client := &http.Client{
 		Transport: transport,
@@ -0,0 +1,104 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// warningCodeRe matches Slack warning codes such as missing_charset. Anything
+// else is dropped, so that recorded warnings can never carry tokens or other
+// response content to the client.
+var warningCodeRe = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+
+type warningsKey struct{}
+
+// Warnings collects the warnings of the Slack responses of one tool call
+type Warnings struct {
+	mu    sync.Mutex
+	codes []string
+	seen  map[string]bool
+}
+
+// WithWarnings returns a context whose Slack responses record their warnings
+// in the returned collector, when made with a WarningsTransport
+func WithWarnings(ctx context.Context) (context.Context, *Warnings) {
+	w := &Warnings{seen: make(map[string]bool)}
+	return context.WithValue(ctx, warningsKey{}, w), w
+}
+
+func warningsFromContext(ctx context.Context) (*Warnings, bool) {
+	w, ok := ctx.Value(warningsKey{}).(*Warnings)
+	return w, ok
+}
+
+// Add records warning codes, ignoring repeated and malformed ones
+func (w *Warnings) Add(codes ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, code := range codes {
+		if !warningCodeRe.MatchString(code) || w.seen[code] {
+			continue
+		}
+		w.seen[code] = true
+		w.codes = append(w.codes, code)
+	}
+}
+
+// List returns the recorded warning codes in the order they were first seen
+func (w *Warnings) List() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string(nil), w.codes...)
+}
+
+// WarningsTransport wraps another RoundTripper to record the warnings of Slack
+// JSON responses in the collector of the request context, see WithWarnings.
+// Requests without a collector are passed through untouched.
+type WarningsTransport struct {
+	roundTripper http.RoundTripper
+}
+
+// NewWarningsTransport creates a new WarningsTransport
+func NewWarningsTransport(roundTripper http.RoundTripper) *WarningsTransport {
+	return &WarningsTransport{roundTripper: roundTripper}
+}
+
+// RoundTrip implements the RoundTripper interface
+func (t *WarningsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.roundTripper.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	w, ok := warningsFromContext(req.Context())
+	if !ok || !strings.Contains(resp.Header.Get("Content-Type"), "application/json") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var meta struct {
+		Warning          string `json:"warning"`
+		ResponseMetadata struct {
+			Warnings []string `json:"warnings"`
+		} `json:"response_metadata"`
+	}
+	if json.Unmarshal(body, &meta) == nil {
+		if meta.Warning != "" {
+			// the top-level field joins several warnings with commas
+			w.Add(strings.Split(meta.Warning, ",")...)
+		}
+		w.Add(meta.ResponseMetadata.Warnings...)
+	}
+	return resp, nil
+}